@@ -0,0 +1,229 @@
+// Package cache provides read-through caches of blogs in front of the blog repository, in Redis or in process memory
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/artnikel/blogapi/internal/model"
+	"github.com/artnikel/blogapi/internal/repository"
+	"github.com/artnikel/blogapi/internal/service"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// blogKeyPrefix is the key of a cached blog, followed by the blog id
+	blogKeyPrefix = "blogapi:blog:"
+	// userBlogsKeyPrefix is the set of the ids of the cached blogs of an author, followed by the user id
+	userBlogsKeyPrefix = "blogapi:userblogs:"
+	// blogVersionKeyPrefix counts the invalidations of a blog, followed by the blog id
+	blogVersionKeyPrefix = "blogapi:blogversion:"
+)
+
+// CachingBlogRepository caches the blogs read by Get in Redis for a TTL and drops them once a write that changes them
+// commits. A read that raced with the drop does not cache what it read.
+// The other methods go straight to the wrapped repository. Redis failures are logged and the read falls back to the
+// wrapped repository, so an unavailable Redis slows requests down but never fails them.
+// With a replica, a read racing a write may cache the replica's stale copy, the TTL bounds how long it is served.
+type CachingBlogRepository struct {
+	service.BlogRepository
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewCachingBlogRepository creates a CachingBlogRepository keeping blogs in Redis for ttl
+func NewCachingBlogRepository(repo service.BlogRepository, client *redis.Client, ttl time.Duration) *CachingBlogRepository {
+	return &CachingBlogRepository{BlogRepository: repo, client: client, ttl: ttl}
+}
+
+func blogKey(id uuid.UUID) string {
+	return blogKeyPrefix + id.String()
+}
+
+func userBlogsKey(userID uuid.UUID) string {
+	return userBlogsKeyPrefix + userID.String()
+}
+
+func blogVersionKey(id string) string {
+	return blogVersionKeyPrefix + id
+}
+
+// Get returns the cached blog, or reads it from the wrapped repository and caches it.
+// Reads that ask for the primary, see repository.WithPrimary, bypass the cache.
+func (r *CachingBlogRepository) Get(ctx context.Context, id uuid.UUID) (*model.Blog, error) {
+	if repository.IsPrimary(ctx) {
+		return r.BlogRepository.Get(ctx, id)
+	}
+	data, err := r.client.Get(ctx, blogKey(id)).Bytes()
+	switch {
+	case err == nil:
+		var blog model.Blog
+		if err := json.Unmarshal(data, &blog); err == nil {
+			return &blog, nil
+		}
+		log.WithField("ID", id).Errorf("json.Unmarshal - %v", err)
+	case !errors.Is(err, redis.Nil):
+		log.WithField("ID", id).Errorf("redis.Get - %v", err)
+	}
+	version, versionErr := r.client.Get(ctx, blogVersionKey(id.String())).Int64()
+	if versionErr != nil && !errors.Is(versionErr, redis.Nil) {
+		log.WithField("ID", id).Errorf("redis.Get - %v", versionErr)
+	}
+	blog, err := r.BlogRepository.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if versionErr == nil || errors.Is(versionErr, redis.Nil) {
+		r.store(ctx, blog, version)
+	}
+	return blog, nil
+}
+
+// store caches the blog and records it in the set of its author's cached blogs, unless the blog was invalidated
+// since its version was read
+func (r *CachingBlogRepository) store(ctx context.Context, blog *model.Blog, version int64) {
+	data, err := json.Marshal(blog)
+	if err != nil {
+		log.WithField("ID", blog.BlogID).Errorf("json.Marshal - %v", err)
+		return
+	}
+	versionKey := blogVersionKey(blog.BlogID.String())
+	err = r.client.Watch(ctx, func(tx *redis.Tx) error {
+		current, err := tx.Get(ctx, versionKey).Int64()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return err
+		}
+		if current != version {
+			return nil
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, blogKey(blog.BlogID), data, r.ttl)
+			pipe.SAdd(ctx, userBlogsKey(blog.UserID), blog.BlogID.String())
+			pipe.Expire(ctx, userBlogsKey(blog.UserID), r.ttl)
+			return nil
+		})
+		return err
+	}, versionKey)
+	if err != nil && !errors.Is(err, redis.TxFailedErr) {
+		log.WithField("ID", blog.BlogID).Errorf("redis.Watch - %v", err)
+	}
+}
+
+// invalidate drops the cached blogs once the transaction of the context commits, see repository.AfterCommit.
+// Outside of a transaction it runs even when the write failed, the write may have been applied before the error.
+func (r *CachingBlogRepository) invalidate(ctx context.Context, ids ...uuid.UUID) {
+	if len(ids) == 0 {
+		return
+	}
+	members := make([]string, len(ids))
+	for i, id := range ids {
+		members[i] = id.String()
+	}
+	repository.AfterCommit(ctx, func() {
+		r.drop(ctx, members)
+	})
+}
+
+// drop deletes the cached blogs with the given ids and bumps their versions, so reads in flight do not cache them again.
+// The versions live for the TTL, longer than any read.
+func (r *CachingBlogRepository) drop(ctx context.Context, ids []string) {
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		keys := make([]string, len(ids))
+		for i, id := range ids {
+			keys[i] = blogKeyPrefix + id
+			pipe.Incr(ctx, blogVersionKey(id))
+			pipe.Expire(ctx, blogVersionKey(id), r.ttl)
+		}
+		pipe.Del(ctx, keys...)
+		return nil
+	})
+	if err != nil {
+		log.WithField("IDs", ids).Errorf("redis.TxPipelined - %v", err)
+	}
+}
+
+// Update updates the blog and drops it from the cache
+func (r *CachingBlogRepository) Update(ctx context.Context, blog *model.Blog) error {
+	err := r.BlogRepository.Update(ctx, blog)
+	r.invalidate(ctx, blog.BlogID)
+	return err
+}
+
+// Delete soft-deletes the blog and drops it from the cache
+func (r *CachingBlogRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	err := r.BlogRepository.Delete(ctx, id)
+	r.invalidate(ctx, id)
+	return err
+}
+
+// DeleteIfUnmodifiedSince soft-deletes the blog unless it was modified after since and drops it from the cache
+func (r *CachingBlogRepository) DeleteIfUnmodifiedSince(ctx context.Context, id uuid.UUID, since time.Time) error {
+	err := r.BlogRepository.DeleteIfUnmodifiedSince(ctx, id, since)
+	r.invalidate(ctx, id)
+	return err
+}
+
+// DeleteMany soft-deletes the blogs and drops them from the cache
+func (r *CachingBlogRepository) DeleteMany(ctx context.Context, ids []uuid.UUID, ownerID uuid.UUID) ([]uuid.UUID, error) {
+	deleted, err := r.BlogRepository.DeleteMany(ctx, ids, ownerID)
+	r.invalidate(ctx, ids...)
+	return deleted, err
+}
+
+// DeleteBlogsByUserID soft-deletes the blogs of the user and drops the cached ones
+func (r *CachingBlogRepository) DeleteBlogsByUserID(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	deleted, err := r.BlogRepository.DeleteBlogsByUserID(ctx, id)
+	repository.AfterCommit(ctx, func() {
+		members, err := r.client.SMembers(ctx, userBlogsKey(id)).Result()
+		if err != nil {
+			log.WithField("UserID", id).Errorf("redis.SMembers - %v", err)
+			return
+		}
+		if len(members) > 0 {
+			r.drop(ctx, members)
+		}
+		if err := r.client.Del(ctx, userBlogsKey(id)).Err(); err != nil {
+			log.WithField("UserID", id).Errorf("redis.Del - %v", err)
+		}
+	})
+	return deleted, err
+}
+
+// HardDelete permanently removes the blog and drops it from the cache
+func (r *CachingBlogRepository) HardDelete(ctx context.Context, id uuid.UUID) error {
+	err := r.BlogRepository.HardDelete(ctx, id)
+	r.invalidate(ctx, id)
+	return err
+}
+
+// Publish publishes the blog and drops it from the cache
+func (r *CachingBlogRepository) Publish(ctx context.Context, id uuid.UUID) error {
+	err := r.BlogRepository.Publish(ctx, id)
+	r.invalidate(ctx, id)
+	return err
+}
+
+// SubmitForReview sends the blog to review and drops it from the cache
+func (r *CachingBlogRepository) SubmitForReview(ctx context.Context, id uuid.UUID) error {
+	err := r.BlogRepository.SubmitForReview(ctx, id)
+	r.invalidate(ctx, id)
+	return err
+}
+
+// Approve publishes the reviewed blog and drops it from the cache
+func (r *CachingBlogRepository) Approve(ctx context.Context, id uuid.UUID) error {
+	err := r.BlogRepository.Approve(ctx, id)
+	r.invalidate(ctx, id)
+	return err
+}
+
+// Reject rejects the reviewed blog and drops it from the cache
+func (r *CachingBlogRepository) Reject(ctx context.Context, id uuid.UUID, reason string) error {
+	err := r.BlogRepository.Reject(ctx, id, reason)
+	r.invalidate(ctx, id)
+	return err
+}
@@ -0,0 +1,174 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/artnikel/blogapi/internal/model"
+	"github.com/artnikel/blogapi/internal/repository"
+	"github.com/artnikel/blogapi/internal/service/mocks"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCache(t *testing.T) (*CachingBlogRepository, *mocks.MockBlogRepository, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+	mockRepo := mocks.NewMockBlogRepository(t)
+	return NewCachingBlogRepository(mockRepo, client, time.Minute), mockRepo, server
+}
+
+func testBlog() *model.Blog {
+	return &model.Blog{
+		BlogID:      uuid.New(),
+		UserID:      uuid.New(),
+		Title:       "cached title",
+		Content:     "cached content",
+		Slug:        "cached-title",
+		ReleaseTime: time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC),
+		Status:      model.BlogStatusPublished,
+		Views:       3,
+	}
+}
+
+func Test_Get_MissThenHit(t *testing.T) {
+	cache, mockRepo, server := newTestCache(t)
+	blog := testBlog()
+	mockRepo.EXPECT().Get(mock.Anything, blog.BlogID).Return(blog, nil).Once()
+
+	first, err := cache.Get(context.Background(), blog.BlogID)
+	require.NoError(t, err)
+	require.Equal(t, blog, first)
+	require.True(t, server.Exists(blogKey(blog.BlogID)))
+	require.Equal(t, time.Minute, server.TTL(blogKey(blog.BlogID)))
+
+	second, err := cache.Get(context.Background(), blog.BlogID)
+	require.NoError(t, err)
+	require.Equal(t, blog, second)
+}
+
+func Test_Get_NotFoundIsNotCached(t *testing.T) {
+	cache, mockRepo, server := newTestCache(t)
+	id := uuid.New()
+	mockRepo.EXPECT().Get(mock.Anything, id).Return(nil, repository.ErrNotFound).Twice()
+
+	for i := 0; i < 2; i++ {
+		_, err := cache.Get(context.Background(), id)
+		require.ErrorIs(t, err, repository.ErrNotFound)
+	}
+	require.False(t, server.Exists(blogKey(id)))
+}
+
+func Test_Get_PrimaryBypassesCache(t *testing.T) {
+	cache, mockRepo, server := newTestCache(t)
+	blog := testBlog()
+	mockRepo.EXPECT().Get(mock.Anything, blog.BlogID).Return(blog, nil).Twice()
+
+	for i := 0; i < 2; i++ {
+		_, err := cache.Get(repository.WithPrimary(context.Background()), blog.BlogID)
+		require.NoError(t, err)
+	}
+	require.False(t, server.Exists(blogKey(blog.BlogID)))
+}
+
+func Test_Get_FallsBackWhenRedisIsDown(t *testing.T) {
+	cache, mockRepo, server := newTestCache(t)
+	blog := testBlog()
+	mockRepo.EXPECT().Get(mock.Anything, blog.BlogID).Return(blog, nil).Once()
+	server.Close()
+
+	got, err := cache.Get(context.Background(), blog.BlogID)
+	require.NoError(t, err)
+	require.Equal(t, blog, got)
+}
+
+func Test_Invalidation(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		name  string
+		write func(cache *CachingBlogRepository, mockRepo *mocks.MockBlogRepository, blog *model.Blog)
+	}{
+		{name: "update", write: func(cache *CachingBlogRepository, mockRepo *mocks.MockBlogRepository, blog *model.Blog) {
+			mockRepo.EXPECT().Update(mock.Anything, blog).Return(nil)
+			require.NoError(t, cache.Update(ctx, blog))
+		}},
+		{name: "delete", write: func(cache *CachingBlogRepository, mockRepo *mocks.MockBlogRepository, blog *model.Blog) {
+			mockRepo.EXPECT().Delete(mock.Anything, blog.BlogID).Return(nil)
+			require.NoError(t, cache.Delete(ctx, blog.BlogID))
+		}},
+		{name: "delete many", write: func(cache *CachingBlogRepository, mockRepo *mocks.MockBlogRepository, blog *model.Blog) {
+			ids := []uuid.UUID{blog.BlogID, uuid.New()}
+			mockRepo.EXPECT().DeleteMany(mock.Anything, ids, uuid.Nil).Return([]uuid.UUID{blog.BlogID}, nil)
+			_, err := cache.DeleteMany(ctx, ids, uuid.Nil)
+			require.NoError(t, err)
+		}},
+		{name: "delete by user", write: func(cache *CachingBlogRepository, mockRepo *mocks.MockBlogRepository, blog *model.Blog) {
+			mockRepo.EXPECT().DeleteBlogsByUserID(mock.Anything, blog.UserID).Return([]uuid.UUID{blog.BlogID}, nil)
+			_, err := cache.DeleteBlogsByUserID(ctx, blog.UserID)
+			require.NoError(t, err)
+		}},
+		{name: "publish", write: func(cache *CachingBlogRepository, mockRepo *mocks.MockBlogRepository, blog *model.Blog) {
+			mockRepo.EXPECT().Publish(mock.Anything, blog.BlogID).Return(nil)
+			require.NoError(t, cache.Publish(ctx, blog.BlogID))
+		}},
+		{name: "reject", write: func(cache *CachingBlogRepository, mockRepo *mocks.MockBlogRepository, blog *model.Blog) {
+			mockRepo.EXPECT().Reject(mock.Anything, blog.BlogID, "spam").Return(nil)
+			require.NoError(t, cache.Reject(ctx, blog.BlogID, "spam"))
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cache, mockRepo, server := newTestCache(t)
+			blog := testBlog()
+			mockRepo.EXPECT().Get(mock.Anything, blog.BlogID).Return(blog, nil).Twice()
+
+			_, err := cache.Get(ctx, blog.BlogID)
+			require.NoError(t, err)
+			require.True(t, server.Exists(blogKey(blog.BlogID)))
+
+			tc.write(cache, mockRepo, blog)
+			require.False(t, server.Exists(blogKey(blog.BlogID)))
+
+			_, err = cache.Get(ctx, blog.BlogID)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_InvalidationWaitsForCommit(t *testing.T) {
+	cache, mockRepo, server := newTestCache(t)
+	blog := testBlog()
+	mockRepo.EXPECT().Update(mock.Anything, blog).Return(nil)
+	mockRepo.EXPECT().Get(mock.Anything, blog.BlogID).Return(blog, nil).Once()
+
+	txCtx, commit := repository.WithAfterCommit(context.Background())
+	require.NoError(t, cache.Update(txCtx, blog))
+	// a read before the commit still sees the old row and caches it
+	_, err := cache.Get(context.Background(), blog.BlogID)
+	require.NoError(t, err)
+	require.True(t, server.Exists(blogKey(blog.BlogID)))
+
+	commit()
+	require.False(t, server.Exists(blogKey(blog.BlogID)))
+}
+
+func Test_Get_ReadRacingWriteIsNotCached(t *testing.T) {
+	ctx := context.Background()
+	cache, mockRepo, server := newTestCache(t)
+	blog := testBlog()
+	mockRepo.EXPECT().Update(mock.Anything, blog).Return(nil)
+	mockRepo.EXPECT().Get(mock.Anything, blog.BlogID).RunAndReturn(func(context.Context, uuid.UUID) (*model.Blog, error) {
+		require.NoError(t, cache.Update(ctx, blog))
+		return blog, nil
+	}).Once()
+
+	_, err := cache.Get(ctx, blog.BlogID)
+	require.NoError(t, err)
+	require.False(t, server.Exists(blogKey(blog.BlogID)))
+}
@@ -0,0 +1,225 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/artnikel/blogapi/internal/model"
+	"github.com/artnikel/blogapi/internal/repository"
+	"github.com/artnikel/blogapi/internal/service"
+	"github.com/google/uuid"
+)
+
+// memoryEntry is a cached blog and the moment it expires
+type memoryEntry struct {
+	blog    model.Blog
+	expires time.Time
+}
+
+// MemoryCacheRepository caches the blogs read by Get in process memory for deployments without Redis.
+// It keeps at most size blogs, evicting the least recently used one, each for at most ttl, and drops blogs on every
+// write that changes them. The cache is per process, so with several instances a write made through one of them
+// reaches the others only when the TTL expires.
+type MemoryCacheRepository struct {
+	service.BlogRepository
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[uuid.UUID]*list.Element
+	// order holds the entries from the most to the least recently used
+	order *list.List
+	// generation changes on every invalidation, a read that raced with one does not cache what it read
+	generation uint64
+	stats      model.CacheStats
+}
+
+// NewMemoryCacheRepository creates a MemoryCacheRepository keeping up to size blogs for ttl
+func NewMemoryCacheRepository(repo service.BlogRepository, size int, ttl time.Duration) *MemoryCacheRepository {
+	return &MemoryCacheRepository{
+		BlogRepository: repo,
+		size:           size,
+		ttl:            ttl,
+		now:            time.Now,
+		entries:        make(map[uuid.UUID]*list.Element, size),
+		order:          list.New(),
+	}
+}
+
+// Stats returns the hit, miss and eviction counters and the number of cached blogs
+func (r *MemoryCacheRepository) Stats() model.CacheStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stats := r.stats
+	stats.Size = r.order.Len()
+	return stats
+}
+
+// Get returns a copy of the cached blog, or reads it from the wrapped repository and caches it.
+// Reads that ask for the primary, see repository.WithPrimary, bypass the cache.
+func (r *MemoryCacheRepository) Get(ctx context.Context, id uuid.UUID) (*model.Blog, error) {
+	if repository.IsPrimary(ctx) {
+		return r.BlogRepository.Get(ctx, id)
+	}
+	blog, generation, ok := r.lookup(id)
+	if ok {
+		return blog, nil
+	}
+	blog, err := r.BlogRepository.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.store(blog, generation)
+	return blog, nil
+}
+
+// lookup returns a copy of the cached blog, or the current generation on a miss
+func (r *MemoryCacheRepository) lookup(id uuid.UUID) (*model.Blog, uint64, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	element, ok := r.entries[id]
+	if ok {
+		entry := element.Value.(*memoryEntry)
+		if r.now().Before(entry.expires) {
+			r.order.MoveToFront(element)
+			r.stats.Hits++
+			blog := entry.blog
+			return &blog, r.generation, true
+		}
+		r.remove(element)
+	}
+	r.stats.Misses++
+	return nil, r.generation, false
+}
+
+// store caches a copy of the blog unless an invalidation happened since the read started, evicting the least
+// recently used blogs beyond the size
+func (r *MemoryCacheRepository) store(blog *model.Blog, generation uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if generation != r.generation {
+		return
+	}
+	entry := &memoryEntry{blog: *blog, expires: r.now().Add(r.ttl)}
+	if element, ok := r.entries[blog.BlogID]; ok {
+		element.Value = entry
+		r.order.MoveToFront(element)
+		return
+	}
+	r.entries[blog.BlogID] = r.order.PushFront(entry)
+	for r.order.Len() > r.size {
+		r.remove(r.order.Back())
+		r.stats.Evictions++
+	}
+}
+
+// remove drops an entry, the caller holds the lock
+func (r *MemoryCacheRepository) remove(element *list.Element) {
+	r.order.Remove(element)
+	delete(r.entries, element.Value.(*memoryEntry).blog.BlogID)
+}
+
+// invalidate drops the cached blogs matching drop once the transaction of the context commits, see repository.AfterCommit
+func (r *MemoryCacheRepository) invalidate(ctx context.Context, drop func(blog *model.Blog) bool) {
+	repository.AfterCommit(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.generation++
+		for element := r.order.Front(); element != nil; {
+			next := element.Next()
+			if drop(&element.Value.(*memoryEntry).blog) {
+				r.remove(element)
+			}
+			element = next
+		}
+	})
+}
+
+// invalidateIDs drops the cached blogs with the given ids once the transaction of the context commits
+func (r *MemoryCacheRepository) invalidateIDs(ctx context.Context, ids ...uuid.UUID) {
+	repository.AfterCommit(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.generation++
+		for _, id := range ids {
+			if element, ok := r.entries[id]; ok {
+				r.remove(element)
+			}
+		}
+	})
+}
+
+// Update updates the blog and drops it from the cache
+func (r *MemoryCacheRepository) Update(ctx context.Context, blog *model.Blog) error {
+	err := r.BlogRepository.Update(ctx, blog)
+	r.invalidateIDs(ctx, blog.BlogID)
+	return err
+}
+
+// Delete soft-deletes the blog and drops it from the cache
+func (r *MemoryCacheRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	err := r.BlogRepository.Delete(ctx, id)
+	r.invalidateIDs(ctx, id)
+	return err
+}
+
+// DeleteIfUnmodifiedSince soft-deletes the blog unless it was modified after since and drops it from the cache
+func (r *MemoryCacheRepository) DeleteIfUnmodifiedSince(ctx context.Context, id uuid.UUID, since time.Time) error {
+	err := r.BlogRepository.DeleteIfUnmodifiedSince(ctx, id, since)
+	r.invalidateIDs(ctx, id)
+	return err
+}
+
+// DeleteMany soft-deletes the blogs and drops them from the cache
+func (r *MemoryCacheRepository) DeleteMany(ctx context.Context, ids []uuid.UUID, ownerID uuid.UUID) ([]uuid.UUID, error) {
+	deleted, err := r.BlogRepository.DeleteMany(ctx, ids, ownerID)
+	r.invalidateIDs(ctx, ids...)
+	return deleted, err
+}
+
+// DeleteBlogsByUserID soft-deletes the blogs of the user and drops the cached ones
+func (r *MemoryCacheRepository) DeleteBlogsByUserID(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	deleted, err := r.BlogRepository.DeleteBlogsByUserID(ctx, id)
+	r.invalidate(ctx, func(blog *model.Blog) bool {
+		return blog.UserID == id
+	})
+	return deleted, err
+}
+
+// HardDelete permanently removes the blog and drops it from the cache
+func (r *MemoryCacheRepository) HardDelete(ctx context.Context, id uuid.UUID) error {
+	err := r.BlogRepository.HardDelete(ctx, id)
+	r.invalidateIDs(ctx, id)
+	return err
+}
+
+// Publish publishes the blog and drops it from the cache
+func (r *MemoryCacheRepository) Publish(ctx context.Context, id uuid.UUID) error {
+	err := r.BlogRepository.Publish(ctx, id)
+	r.invalidateIDs(ctx, id)
+	return err
+}
+
+// SubmitForReview sends the blog to review and drops it from the cache
+func (r *MemoryCacheRepository) SubmitForReview(ctx context.Context, id uuid.UUID) error {
+	err := r.BlogRepository.SubmitForReview(ctx, id)
+	r.invalidateIDs(ctx, id)
+	return err
+}
+
+// Approve publishes the reviewed blog and drops it from the cache
+func (r *MemoryCacheRepository) Approve(ctx context.Context, id uuid.UUID) error {
+	err := r.BlogRepository.Approve(ctx, id)
+	r.invalidateIDs(ctx, id)
+	return err
+}
+
+// Reject rejects the reviewed blog and drops it from the cache
+func (r *MemoryCacheRepository) Reject(ctx context.Context, id uuid.UUID, reason string) error {
+	err := r.BlogRepository.Reject(ctx, id, reason)
+	r.invalidateIDs(ctx, id)
+	return err
+}
@@ -0,0 +1,139 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/artnikel/blogapi/internal/model"
+	"github.com/artnikel/blogapi/internal/repository"
+	"github.com/artnikel/blogapi/internal/service/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMemoryCache(t *testing.T, size int) (*MemoryCacheRepository, *mocks.MockBlogRepository, *time.Time) {
+	mockRepo := mocks.NewMockBlogRepository(t)
+	cache := NewMemoryCacheRepository(mockRepo, size, time.Minute)
+	clock := time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return clock }
+	return cache, mockRepo, &clock
+}
+
+func Test_MemoryCache_Hit(t *testing.T) {
+	cache, mockRepo, _ := newTestMemoryCache(t, 10)
+	blog := testBlog()
+	want := *blog
+	mockRepo.EXPECT().Get(mock.Anything, blog.BlogID).Return(blog, nil).Once()
+
+	for i := 0; i < 3; i++ {
+		got, err := cache.Get(context.Background(), blog.BlogID)
+		require.NoError(t, err)
+		require.Equal(t, &want, got)
+		// callers set views and tags on the blog they get, that must not leak into the cache
+		got.Views = 100
+	}
+	require.Equal(t, model.CacheStats{Hits: 2, Misses: 1, Size: 1}, cache.Stats())
+}
+
+func Test_MemoryCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache, mockRepo, _ := newTestMemoryCache(t, 2)
+	ctx := context.Background()
+	first, second, third := testBlog(), testBlog(), testBlog()
+	mockRepo.EXPECT().Get(mock.Anything, first.BlogID).Return(first, nil).Once()
+	mockRepo.EXPECT().Get(mock.Anything, second.BlogID).Return(second, nil).Twice()
+	mockRepo.EXPECT().Get(mock.Anything, third.BlogID).Return(third, nil).Once()
+
+	for _, id := range []uuid.UUID{first.BlogID, second.BlogID, first.BlogID, third.BlogID, first.BlogID, second.BlogID} {
+		_, err := cache.Get(ctx, id)
+		require.NoError(t, err)
+	}
+	require.Equal(t, model.CacheStats{Hits: 2, Misses: 4, Evictions: 2, Size: 2}, cache.Stats())
+}
+
+func Test_MemoryCache_Expires(t *testing.T) {
+	cache, mockRepo, clock := newTestMemoryCache(t, 10)
+	blog := testBlog()
+	mockRepo.EXPECT().Get(mock.Anything, blog.BlogID).Return(blog, nil).Twice()
+
+	_, err := cache.Get(context.Background(), blog.BlogID)
+	require.NoError(t, err)
+	*clock = clock.Add(59 * time.Second)
+	_, err = cache.Get(context.Background(), blog.BlogID)
+	require.NoError(t, err)
+	*clock = clock.Add(time.Second)
+	_, err = cache.Get(context.Background(), blog.BlogID)
+	require.NoError(t, err)
+	require.Equal(t, model.CacheStats{Hits: 1, Misses: 2, Size: 1}, cache.Stats())
+}
+
+func Test_MemoryCache_Invalidation(t *testing.T) {
+	ctx := context.Background()
+	cache, mockRepo, _ := newTestMemoryCache(t, 10)
+	blog, other := testBlog(), testBlog()
+	mockRepo.EXPECT().Get(mock.Anything, blog.BlogID).Return(blog, nil).Times(3)
+	mockRepo.EXPECT().Get(mock.Anything, other.BlogID).Return(other, nil).Once()
+	mockRepo.EXPECT().Update(mock.Anything, blog).Return(nil)
+	mockRepo.EXPECT().DeleteBlogsByUserID(mock.Anything, blog.UserID).Return([]uuid.UUID{blog.BlogID}, nil)
+
+	_, err := cache.Get(ctx, blog.BlogID)
+	require.NoError(t, err)
+	_, err = cache.Get(ctx, other.BlogID)
+	require.NoError(t, err)
+
+	require.NoError(t, cache.Update(ctx, blog))
+	_, err = cache.Get(ctx, blog.BlogID)
+	require.NoError(t, err)
+
+	_, err = cache.DeleteBlogsByUserID(ctx, blog.UserID)
+	require.NoError(t, err)
+	_, err = cache.Get(ctx, blog.BlogID)
+	require.NoError(t, err)
+	_, err = cache.Get(ctx, other.BlogID)
+	require.NoError(t, err)
+	require.Equal(t, model.CacheStats{Hits: 1, Misses: 4, Size: 2}, cache.Stats())
+}
+
+func Test_MemoryCache_ReadRacingWriteIsNotCached(t *testing.T) {
+	ctx := context.Background()
+	cache, mockRepo, _ := newTestMemoryCache(t, 10)
+	blog := testBlog()
+	reading, written := make(chan struct{}), make(chan struct{})
+	mockRepo.EXPECT().Get(mock.Anything, blog.BlogID).RunAndReturn(func(context.Context, uuid.UUID) (*model.Blog, error) {
+		close(reading)
+		<-written
+		return blog, nil
+	}).Once()
+	mockRepo.EXPECT().Delete(mock.Anything, blog.BlogID).Return(nil)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, _ = cache.Get(ctx, blog.BlogID)
+	}()
+	<-reading
+	require.NoError(t, cache.Delete(ctx, blog.BlogID))
+	close(written)
+	wg.Wait()
+
+	require.Zero(t, cache.Stats().Size)
+}
+
+func Test_MemoryCache_InvalidationWaitsForCommit(t *testing.T) {
+	cache, mockRepo, _ := newTestMemoryCache(t, 10)
+	blog := testBlog()
+	mockRepo.EXPECT().Update(mock.Anything, blog).Return(nil)
+	mockRepo.EXPECT().Get(mock.Anything, blog.BlogID).Return(blog, nil).Once()
+
+	txCtx, commit := repository.WithAfterCommit(context.Background())
+	require.NoError(t, cache.Update(txCtx, blog))
+	_, err := cache.Get(context.Background(), blog.BlogID)
+	require.NoError(t, err)
+	require.Equal(t, 1, cache.Stats().Size)
+
+	commit()
+	require.Zero(t, cache.Stats().Size)
+}
@@ -1,12 +1,56 @@
 // Package config represents structure Config
 package config
 
+import "time"
+
 // Config is a structure of environment variables
 type Config struct {
-	BlogPostgresPath     string `env:"BLOG_POSTGRES_PATH"`
-	BlogTokenSignature   string `env:"BLOG_TOKEN_SIGNATURE"`
-	BlogServerPort       string `env:"BLOG_SERVER_PORT"`
-	BlogPostgresDB       string `env:"BLOG_POSTGRES_DB"`
-	BlogPostgresUser     string `env:"BLOG_POSTGRES_USER"`
-	BlogPostgresPassword string `env:"BLOG_POSTGRES_PASSWORD"`
+	BlogPostgresPath         string        `env:"BLOG_POSTGRES_PATH"`
+	BlogPostgresReplicaPath  string        `env:"BLOG_POSTGRES_REPLICA_PATH"`
+	BlogReadYourWrites       time.Duration `env:"BLOG_READ_YOUR_WRITES"`
+	BlogTokenSignature       string        `env:"BLOG_TOKEN_SIGNATURE"`
+	BlogAccessTokenTTL       time.Duration `env:"BLOG_ACCESS_TOKEN_TTL"`
+	BlogRefreshTokenTTL      time.Duration `env:"BLOG_REFRESH_TOKEN_TTL"`
+	BlogTokenIssuer          string        `env:"BLOG_TOKEN_ISSUER"`
+	BlogTokenAudience        string        `env:"BLOG_TOKEN_AUDIENCE"`
+	BlogRefreshCookie        bool          `env:"BLOG_REFRESH_COOKIE"`
+	BlogServerPort           string        `env:"BLOG_SERVER_PORT"`
+	BlogPostgresHost         string        `env:"BLOG_POSTGRES_HOST"`
+	BlogPostgresPort         int           `env:"BLOG_POSTGRES_PORT"`
+	BlogPostgresDB           string        `env:"BLOG_POSTGRES_DB"`
+	BlogPostgresUser         string        `env:"BLOG_POSTGRES_USER"`
+	BlogPostgresPassword     string        `env:"BLOG_POSTGRES_PASSWORD"`
+	BlogPostgresSSLMode      string        `env:"BLOG_POSTGRES_SSLMODE"`
+	BlogDebugBodyLog         bool          `env:"BLOG_DEBUG_BODY_LOG"`
+	BlogBodyLogMaxBytes      int           `env:"BLOG_BODY_LOG_MAX_BYTES"`
+	BlogBodyLimit            string        `env:"BLOG_BODY_LIMIT"`
+	BlogBulkMaxItems         int           `env:"BLOG_BULK_MAX_ITEMS"`
+	BlogDefaultSort          string        `env:"BLOG_DEFAULT_SORT"`
+	BlogCountEstimateAbove   int           `env:"BLOG_COUNT_ESTIMATE_ABOVE"`
+	BlogCountCacheTTL        time.Duration `env:"BLOG_COUNT_CACHE_TTL"`
+	BlogRedisURL             string        `env:"BLOG_REDIS_URL"`
+	BlogCacheTTL             time.Duration `env:"BLOG_CACHE_TTL"`
+	BlogCacheSize            int           `env:"BLOG_CACHE_SIZE"`
+	BlogLoginLockout         time.Duration `env:"BLOG_LOGIN_LOCKOUT"`
+	BlogBcryptCost           int           `env:"BLOG_BCRYPT_COST"`
+	BlogSignupMode           string        `env:"BLOG_SIGNUP_MODE"`
+	BlogDraftLimit           int           `env:"BLOG_DRAFT_LIMIT"`
+	BlogModeration           bool          `env:"BLOG_MODERATION"`
+	BlogPublicDraftCounts    bool          `env:"BLOG_PUBLIC_DRAFT_COUNTS"`
+	BlogMediaCheck           string        `env:"BLOG_MEDIA_CHECK"`
+	BlogMediaHosts           []string      `env:"BLOG_MEDIA_HOSTS" envSeparator:","`
+	BlogTLSCertFile          string        `env:"BLOG_TLS_CERT_FILE"`
+	BlogTLSKeyFile           string        `env:"BLOG_TLS_KEY_FILE"`
+	BlogTLSMinVersion        string        `env:"BLOG_TLS_MIN_VERSION"`
+	BlogTLSCipherSuites      []string      `env:"BLOG_TLS_CIPHER_SUITES" envSeparator:","`
+	BlogAllowedOrigins       []string      `env:"BLOG_ALLOWED_ORIGINS" envSeparator:","`
+	BlogSMTPAddr             string        `env:"BLOG_SMTP_ADDR"`
+	BlogSMTPFrom             string        `env:"BLOG_SMTP_FROM"`
+	BlogSMTPUsername         string        `env:"BLOG_SMTP_USERNAME"`
+	BlogSMTPPassword         string        `env:"BLOG_SMTP_PASSWORD"`
+	BlogTrustedProxies       []string      `env:"BLOG_TRUSTED_PROXIES" envSeparator:","`
+	BlogRequestIDHeader      string        `env:"BLOG_REQUEST_ID_HEADER"`
+	BlogSlowQuery            time.Duration `env:"BLOG_SLOW_QUERY"`
+	BlogStatementTimeout     time.Duration `env:"BLOG_STATEMENT_TIMEOUT"`
+	BlogPgStatementCacheMode string        `env:"BLOG_PG_STATEMENT_CACHE_MODE"`
 }
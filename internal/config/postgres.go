@@ -0,0 +1,74 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Default address of the database when the discrete connection fields leave it out
+const (
+	defaultPostgresHost = "localhost"
+	defaultPostgresPort = 5432
+)
+
+var postgresSSLModes = map[string]struct{}{
+	"disable":     {},
+	"allow":       {},
+	"prefer":      {},
+	"require":     {},
+	"verify-ca":   {},
+	"verify-full": {},
+}
+
+// PostgresDSN returns the connection string of the primary database.
+// A full DSN in BlogPostgresPath is used as is, otherwise the DSN is assembled from the discrete
+// host, port, user, password, database and sslmode fields. Either way the result is validated.
+func (c *Config) PostgresDSN() (string, error) {
+	dsn := c.BlogPostgresPath
+	if dsn == "" {
+		var err error
+		dsn, err = c.postgresDSNFromFields()
+		if err != nil {
+			return "", err
+		}
+	}
+	if _, err := pgconn.ParseConfig(dsn); err != nil {
+		return "", fmt.Errorf("invalid postgres DSN: %w", err)
+	}
+	return dsn, nil
+}
+
+// postgresDSNFromFields builds a postgres:// URL from the discrete fields, escaping the user and password
+func (c *Config) postgresDSNFromFields() (string, error) {
+	if c.BlogPostgresUser == "" || c.BlogPostgresDB == "" {
+		return "", fmt.Errorf("set BLOG_POSTGRES_PATH or at least BLOG_POSTGRES_USER and BLOG_POSTGRES_DB")
+	}
+	host := c.BlogPostgresHost
+	if host == "" {
+		host = defaultPostgresHost
+	}
+	port := c.BlogPostgresPort
+	if port == 0 {
+		port = defaultPostgresPort
+	}
+	if port < 1 || port > 65535 {
+		return "", fmt.Errorf("invalid postgres port %d", port)
+	}
+	u := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(c.BlogPostgresUser, c.BlogPostgresPassword),
+		Host:   net.JoinHostPort(host, strconv.Itoa(port)),
+		Path:   "/" + c.BlogPostgresDB,
+	}
+	if c.BlogPostgresSSLMode != "" {
+		if _, ok := postgresSSLModes[c.BlogPostgresSSLMode]; !ok {
+			return "", fmt.Errorf("unsupported postgres sslmode %q", c.BlogPostgresSSLMode)
+		}
+		u.RawQuery = url.Values{"sslmode": {c.BlogPostgresSSLMode}}.Encode()
+	}
+	return u.String(), nil
+}
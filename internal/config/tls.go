@@ -0,0 +1,66 @@
+package config
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strings"
+)
+
+// defaultCipherSuites are the forward secret AEAD suites used for TLS 1.2 when none are configured,
+// TLS 1.3 suites are not configurable and always secure
+var defaultCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256,
+}
+
+var tlsVersions = map[string]uint16{
+	"":    tls.VersionTLS12,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// TLSEnabled reports whether a certificate and a key are configured to serve TLS in-process
+func (c *Config) TLSEnabled() bool {
+	return c.BlogTLSCertFile != "" && c.BlogTLSKeyFile != ""
+}
+
+// ValidateTLS refuses a certificate without a key or a key without a certificate,
+// which would otherwise silently serve plain HTTP
+func (c *Config) ValidateTLS() error {
+	if (c.BlogTLSCertFile == "") != (c.BlogTLSKeyFile == "") {
+		return fmt.Errorf("BLOG_TLS_CERT_FILE and BLOG_TLS_KEY_FILE must be set together")
+	}
+	return nil
+}
+
+// TLSConfig builds the server TLS settings without certificates.
+// TLS 1.2 and defaultCipherSuites are used unless overridden, versions below 1.2 and insecure suites are refused.
+func (c *Config) TLSConfig() (*tls.Config, error) {
+	minVersion, ok := tlsVersions[strings.TrimSpace(c.BlogTLSMinVersion)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported TLS min version %q, use 1.2 or 1.3", c.BlogTLSMinVersion)
+	}
+	cipherSuites := defaultCipherSuites
+	if len(c.BlogTLSCipherSuites) > 0 {
+		secure := make(map[string]uint16)
+		for _, suite := range tls.CipherSuites() {
+			secure[suite.Name] = suite.ID
+		}
+		cipherSuites = make([]uint16, 0, len(c.BlogTLSCipherSuites))
+		for _, name := range c.BlogTLSCipherSuites {
+			id, ok := secure[strings.TrimSpace(name)]
+			if !ok {
+				return nil, fmt.Errorf("unknown or insecure TLS cipher suite %q", name)
+			}
+			cipherSuites = append(cipherSuites, id)
+		}
+	}
+	return &tls.Config{
+		MinVersion:   minVersion,
+		CipherSuites: cipherSuites,
+	}, nil
+}
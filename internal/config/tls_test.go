@@ -0,0 +1,43 @@
+package config
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTLSConfig_Defaults(t *testing.T) {
+	cfg := &Config{}
+	tlsCfg, err := cfg.TLSConfig()
+	require.NoError(t, err)
+	require.Equal(t, uint16(tls.VersionTLS12), tlsCfg.MinVersion)
+	require.Equal(t, defaultCipherSuites, tlsCfg.CipherSuites)
+}
+
+func TestTLSConfig_Configured(t *testing.T) {
+	cfg := &Config{
+		BlogTLSMinVersion:   "1.3",
+		BlogTLSCipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384", " TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256"},
+	}
+	tlsCfg, err := cfg.TLSConfig()
+	require.NoError(t, err)
+	require.Equal(t, uint16(tls.VersionTLS13), tlsCfg.MinVersion)
+	require.Equal(t, []uint16{tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384, tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256}, tlsCfg.CipherSuites)
+}
+
+func TestTLSConfig_RefusesInsecureSettings(t *testing.T) {
+	_, err := (&Config{BlogTLSMinVersion: "1.0"}).TLSConfig()
+	require.Error(t, err)
+
+	_, err = (&Config{BlogTLSCipherSuites: []string{"TLS_RSA_WITH_RC4_128_SHA"}}).TLSConfig()
+	require.Error(t, err)
+}
+
+func TestValidateTLS(t *testing.T) {
+	require.NoError(t, (&Config{}).ValidateTLS())
+	require.NoError(t, (&Config{BlogTLSCertFile: "cert.pem", BlogTLSKeyFile: "key.pem"}).ValidateTLS())
+
+	require.Error(t, (&Config{BlogTLSCertFile: "cert.pem"}).ValidateTLS())
+	require.Error(t, (&Config{BlogTLSKeyFile: "key.pem"}).ValidateTLS())
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/artnikel/blogapi/internal/constants"
+)
+
+// AccessTokenTTL returns the configured lifetime of access tokens, or constants.AccessTokenExpiration when unset
+func (c *Config) AccessTokenTTL() time.Duration {
+	if c.BlogAccessTokenTTL > 0 {
+		return c.BlogAccessTokenTTL
+	}
+	return constants.AccessTokenExpiration
+}
+
+// RefreshTokenTTL returns the configured lifetime of refresh tokens, or constants.RefreshTokenExpiration when unset
+func (c *Config) RefreshTokenTTL() time.Duration {
+	if c.BlogRefreshTokenTTL > 0 {
+		return c.BlogRefreshTokenTTL
+	}
+	return constants.RefreshTokenExpiration
+}
+
+// ValidateTokenTTLs refuses negative token lifetimes and refresh tokens that expire no later than access tokens,
+// which would force users to log in again every time their access token expires
+func (c *Config) ValidateTokenTTLs() error {
+	if c.BlogAccessTokenTTL < 0 || c.BlogRefreshTokenTTL < 0 {
+		return fmt.Errorf("token lifetimes must not be negative, got access %s and refresh %s", c.BlogAccessTokenTTL, c.BlogRefreshTokenTTL)
+	}
+	if c.RefreshTokenTTL() <= c.AccessTokenTTL() {
+		return fmt.Errorf("refresh token lifetime %s must exceed the access token lifetime %s", c.RefreshTokenTTL(), c.AccessTokenTTL())
+	}
+	return nil
+}
+
+// TokenIssuer returns the configured iss claim of tokens, or constants.TokenIssuer when unset
+func (c *Config) TokenIssuer() string {
+	if c.BlogTokenIssuer != "" {
+		return c.BlogTokenIssuer
+	}
+	return constants.TokenIssuer
+}
+
+// TokenAudience returns the configured aud claim of tokens, or constants.TokenAudience when unset.
+// Environments sharing a signature but not an audience don't accept each other's tokens.
+func (c *Config) TokenAudience() string {
+	if c.BlogTokenAudience != "" {
+		return c.BlogTokenAudience
+	}
+	return constants.TokenAudience
+}
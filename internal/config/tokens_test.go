@@ -0,0 +1,30 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/artnikel/blogapi/internal/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenTTLs_Defaults(t *testing.T) {
+	cfg := &Config{}
+	require.NoError(t, cfg.ValidateTokenTTLs())
+	require.Equal(t, constants.AccessTokenExpiration, cfg.AccessTokenTTL())
+	require.Equal(t, constants.RefreshTokenExpiration, cfg.RefreshTokenTTL())
+}
+
+func TestTokenTTLs_Configured(t *testing.T) {
+	cfg := &Config{BlogAccessTokenTTL: 5 * time.Minute, BlogRefreshTokenTTL: 24 * time.Hour}
+	require.NoError(t, cfg.ValidateTokenTTLs())
+	require.Equal(t, 5*time.Minute, cfg.AccessTokenTTL())
+	require.Equal(t, 24*time.Hour, cfg.RefreshTokenTTL())
+}
+
+func TestTokenTTLs_RefusesRefreshNotOutlivingAccess(t *testing.T) {
+	require.Error(t, (&Config{BlogAccessTokenTTL: time.Hour, BlogRefreshTokenTTL: time.Hour}).ValidateTokenTTLs())
+	// the default refresh lifetime is shorter than this access lifetime
+	require.Error(t, (&Config{BlogAccessTokenTTL: 100 * time.Hour}).ValidateTokenTTLs())
+	require.Error(t, (&Config{BlogAccessTokenTTL: -time.Minute}).ValidateTokenTTLs())
+}
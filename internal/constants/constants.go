@@ -7,12 +7,114 @@ const (
 	// ServerTimeout — the maximum duration for the server to wait for active connections to finish during shutdown
 	ServerTimeout = 10 * time.Second
 
+	// DBConnectAttempts — the number of times the database connection is tried on startup
+	DBConnectAttempts = 6
+
+	// DBConnectBackoff — the wait before the second connection attempt, doubled after every further failure
+	DBConnectBackoff = time.Second
+
+	// ReplicaCheckInterval — how often the read replica is pinged to decide whether reads may go to it
+	ReplicaCheckInterval = 10 * time.Second
+
+	// ReadYourWritesWindow — the default time the reads of a user stay on the primary after the user's write
+	ReadYourWritesWindow = 5 * time.Second
+
+	// IdempotencyKeyTTL — how long a repeated Idempotency-Key returns the blog created by the first request
+	IdempotencyKeyTTL = 24 * time.Hour
+
+	// IdempotencyKeyMaxLen — the maximum length of an Idempotency-Key header
+	IdempotencyKeyMaxLen = 255
+
 	// AccessTokenExpiration — the lifespan of the Access Token before it expires
 	AccessTokenExpiration = 15 * time.Minute
 
 	// RefreshTokenExpiration — the lifespan of the Refresh Token before it expires
 	RefreshTokenExpiration = 72 * time.Hour
 
-	// BcryptCost — the hashing cost (complexity) for bcrypt when encrypting passwords
+	// RefreshTokenCookie — the name of the HttpOnly cookie carrying the refresh token when cookie delivery is enabled
+	RefreshTokenCookie = "refresh_token"
+
+	// ImpersonationTokenExpiration — the lifespan of the access token an admin gets to act as another user
+	ImpersonationTokenExpiration = 10 * time.Minute
+
+	// TokenIssuer — the issuer written into every token and required from every token, unless configured
+	TokenIssuer = "blogapi"
+	// TokenAudience — the audience written into every token and required from every token, unless configured
+	TokenAudience = "blogapi"
+
+	// PasswordResetExpiration — the lifespan of a password reset token before it expires
+	PasswordResetExpiration = 30 * time.Minute
+
+	// MaxFailedLogins — the number of failed logins in a row after which the account is locked
+	MaxFailedLogins = 5
+
+	// LoginLockoutDuration — the default time an account stays locked after too many failed logins
+	LoginLockoutDuration = 15 * time.Minute
+
+	// BcryptCost — the default hashing cost (complexity) for bcrypt when encrypting passwords
 	BcryptCost = 14
+
+	// BodyLogMaxBytes — the default number of request/response body bytes captured by the debug body logger
+	BodyLogMaxBytes = 4096
+
+	// BodyLimit — the default maximum size of a request body, it fits a full bulk request of blogs with long content
+	BodyLimit = "8M"
+
+	// BlogsDefaultLimit — the number of blogs in a page of the blog list when no limit is given
+	BlogsDefaultLimit = 20
+
+	// BlogsMaxLimit — the maximum number of blogs in a single JSON page of the blog list
+	BlogsMaxLimit = 100
+
+	// CSVMaxRows — the maximum number of blogs returned in a single CSV page
+	CSVMaxRows = 1000
+
+	// CountCacheTTL — how long the total number of published blogs is reused by the blog list
+	CountCacheTTL = 5 * time.Second
+
+	// BlogCacheTTL — how long a blog read by id stays in Redis when the Redis cache is on
+	BlogCacheTTL = time.Minute
+
+	// StreamFlushRows — the number of blogs written between flushes of a streamed blog list
+	StreamFlushRows = 100
+
+	// FeedItems — the number of latest published blogs in the RSS feed
+	FeedItems = 20
+
+	// FeedExcerptLen — the maximum number of characters of blog content in an RSS feed item
+	FeedExcerptLen = 300
+
+	// ReadingWordsPerMinute — the reading speed the reading time of blog content is computed with
+	ReadingWordsPerMinute = 200
+
+	// BulkMaxItems — the default maximum number of items accepted in a single bulk request
+	BulkMaxItems = 100
+
+	// PopularMaxLimit — the maximum number of blogs returned by the popular blogs endpoint
+	PopularMaxLimit = 100
+
+	// TopTagsDefaultLimit — the number of tags returned by the top tags endpoint when no limit is given
+	TopTagsDefaultLimit = 10
+	// TopTagsMaxLimit — the maximum number of tags returned by the top tags endpoint
+	TopTagsMaxLimit = 100
+
+	// RecentViewsLimit — the number of recently viewed blogs kept per user
+	RecentViewsLimit = 20
+
+	// ActivityDefaultLimit — the number of entries in a page of the activity log when no limit is given
+	ActivityDefaultLimit = 20
+	// ActivityMaxLimit — the maximum number of entries in a page of the activity log
+	ActivityMaxLimit = 100
+
+	// AuthRateInterval — the interval at which one more auth request per client IP is allowed
+	AuthRateInterval = 12 * time.Second
+
+	// AuthRateBurst — the number of auth requests a client IP can make in a burst
+	AuthRateBurst = 5
+
+	// RefreshRateInterval — the interval at which one more token refresh per client IP is allowed
+	RefreshRateInterval = 2 * time.Second
+
+	// RefreshRateBurst — the number of token refreshes a client IP can make in a burst
+	RefreshRateBurst = 10
 )
@@ -0,0 +1,15 @@
+// Package docs holds the OpenAPI description of the API and the Swagger UI page that renders it
+package docs
+
+import _ "embed"
+
+// OpenAPI is the OpenAPI 3 description of the v1 routes, keep it in step with the routes when they change
+//
+//go:embed openapi.json
+var OpenAPI []byte
+
+// SwaggerUI is the HTML page of the Swagger UI, it loads the UI from a CDN and the description from swagger.json
+// next to the page
+//
+//go:embed swagger.html
+var SwaggerUI []byte
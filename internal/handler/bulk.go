@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// errTooManyItems is returned when a bulk request carries more items than allowed
+var errTooManyItems = errors.New("too_many_items")
+
+// decodeBulk stream-decodes a JSON array of T from r, one element at a time.
+// It stops as soon as more than maxItems elements are seen, so an oversized array is never fully unmarshaled.
+func decodeBulk[T any](r io.Reader, maxItems int) ([]T, error) {
+	dec := json.NewDecoder(r)
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("error in method dec.Token(): %w", err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return nil, errors.New("expected a JSON array")
+	}
+	items := make([]T, 0)
+	for dec.More() {
+		if len(items) >= maxItems {
+			return nil, errTooManyItems
+		}
+		var item T
+		if err := dec.Decode(&item); err != nil {
+			return nil, fmt.Errorf("error in method dec.Decode(): %w", err)
+		}
+		items = append(items, item)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, fmt.Errorf("error in method dec.Token(): %w", err)
+	}
+	return items, nil
+}
+
+// respondBulkError answers 400 to a bulk request body that decodeBulk refused
+func respondBulkError(c echo.Context, err error) error {
+	if errors.Is(err, errTooManyItems) {
+		return respondError(c, http.StatusBadRequest, codeTooManyItems, "Bulk request has too many items")
+	}
+	return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Failed to decode bulk request")
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+// bulkArray streams a JSON array of n uuids without building it in memory
+func bulkArray(n int) io.Reader {
+	pr, pw := io.Pipe()
+	go func() {
+		_, _ = io.WriteString(pw, "[")
+		for i := 0; i < n; i++ {
+			if i > 0 {
+				_, _ = io.WriteString(pw, ",")
+			}
+			_, _ = fmt.Fprintf(pw, "%q", uuid.New().String())
+		}
+		_, _ = io.WriteString(pw, "]")
+		_ = pw.Close()
+	}()
+	return pr
+}
+
+func Test_decodeBulk_LargeAllowedArray(t *testing.T) {
+	items, err := decodeBulk[uuid.UUID](bulkArray(5000), 5000)
+	require.NoError(t, err)
+	require.Len(t, items, 5000)
+}
+
+func Test_decodeBulk_TooManyItems(t *testing.T) {
+	_, err := decodeBulk[uuid.UUID](bulkArray(11), 10)
+	require.ErrorIs(t, err, errTooManyItems)
+}
+
+func Test_decodeBulk_NotArray(t *testing.T) {
+	_, err := decodeBulk[uuid.UUID](strings.NewReader(`{"id":"x"}`), 10)
+	require.Error(t, err)
+}
+
+func Test_respondBulkError_TooManyItems(t *testing.T) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodPost, "/", http.NoBody), rec)
+
+	_, err := decodeBulk[uuid.UUID](bulkArray(3), 2)
+	require.NoError(t, respondBulkError(c, err))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.JSONEq(t, `{"error":{"code":"too_many_items","message":"Bulk request has too many items"}}`, rec.Body.String())
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/artnikel/blogapi/internal/model"
+	"github.com/artnikel/blogapi/internal/repository"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+// CreateComment processes the POST request to comment a blog
+func (h *Handler) CreateComment(c echo.Context) error {
+	id := c.Param("id")
+	err := h.validate.VarCtx(c.Request().Context(), id, "required,uuid")
+	if err != nil {
+		log.Errorf("validate.VarCtx error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to validate id")
+	}
+	blogID, err := uuid.Parse(id)
+	if err != nil {
+		log.Errorf("uuid.Parse error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to parse id")
+	}
+	userID, ok := c.Get("id").(uuid.UUID)
+	if !ok {
+		return respondError(c, http.StatusUnauthorized, codeUnauthorized, "User ID not found in context")
+	}
+	if visible, err := h.blogVisible(c, blogID); !visible {
+		return err
+	}
+	var newComment model.Comment
+	err = c.Bind(&newComment)
+	if err != nil {
+		log.Errorf("c.Bind error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Filling comment error")
+	}
+	newComment.CommentID = uuid.New()
+	newComment.BlogID = blogID
+	newComment.UserID = userID
+	err = h.validate.StructCtx(c.Request().Context(), newComment)
+	if err != nil {
+		log.Errorf("validate.StructCtx error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Not valid data")
+	}
+	err = h.srvComment.Create(c.Request().Context(), &newComment)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return respondError(c, http.StatusNotFound, codeBlogNotFound, "Cannot find blog with id: "+id)
+		}
+		log.WithField("BlogID", blogID).Errorf("srvComment.Create - %v", err)
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to create comment")
+	}
+	return c.JSON(http.StatusCreated, newComment)
+}
+
+// GetComments processes the GET request to retrieve comments of a blog
+func (h *Handler) GetComments(c echo.Context) error {
+	id := c.Param("id")
+	err := h.validate.VarCtx(c.Request().Context(), id, "required,uuid")
+	if err != nil {
+		log.Errorf("validate.VarCtx error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to validate id")
+	}
+	blogID, err := uuid.Parse(id)
+	if err != nil {
+		log.Errorf("uuid.Parse error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to parse id")
+	}
+	if visible, err := h.blogVisible(c, blogID); !visible {
+		return err
+	}
+
+	limit, err := strconv.Atoi(c.QueryParam("limit"))
+	if err != nil || limit < 1 {
+		limit = 10
+	}
+
+	offset, err := strconv.Atoi(c.QueryParam("offset"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	resp, err := h.srvComment.GetByBlogID(c.Request().Context(), blogID, limit, offset)
+	if err != nil {
+		log.WithField("BlogID", blogID).Errorf("srvComment.GetByBlogID - %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Failed to get comments")
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// blogVisible reports whether the blog exists and the caller may see it, as GetBlog does. If not, it writes
+// the error response, 404 for a deleted blog or someone else's unpublished one, and returns its error.
+func (h *Handler) blogVisible(c echo.Context, blogID uuid.UUID) (bool, error) {
+	notFound := "Cannot find blog with id: " + blogID.String()
+	blog, err := h.srvBlog.Get(c.Request().Context(), blogID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return false, respondError(c, http.StatusNotFound, codeBlogNotFound, notFound)
+		}
+		log.WithField("BlogID", blogID).Errorf("srvBlog.Get - %v", err)
+		return false, respondError(c, http.StatusInternalServerError, codeInternal, "Failed to get blog")
+	}
+	if blog.Status != model.BlogStatusPublished && !canSeeUnpublished(c, blog.UserID) {
+		return false, respondError(c, http.StatusNotFound, codeBlogNotFound, notFound)
+	}
+	return true, nil
+}
+
+// DeleteComment processes the DELETE request to delete a comment by ID
+func (h *Handler) DeleteComment(c echo.Context) error {
+	id := c.Param("id")
+	err := h.validate.VarCtx(c.Request().Context(), id, "required,uuid")
+	if err != nil {
+		log.Errorf("validate.VarCtx error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to validate id")
+	}
+	uuidID, err := uuid.Parse(id)
+	if err != nil {
+		log.Errorf("uuid.Parse error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to parse id")
+	}
+	isAdmin, ok := c.Get("isAdmin").(bool)
+	if !ok || !isAdmin {
+		userID, ok := c.Get("id").(uuid.UUID)
+		if !ok {
+			return respondError(c, http.StatusUnauthorized, codeUnauthorized, "User ID not found in context")
+		}
+		comment, err := h.srvComment.Get(c.Request().Context(), uuidID)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			log.WithField("ID", uuidID).Errorf("srvComment.Get - %v", err)
+			return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Failed to get comment")
+		}
+		if comment == nil || comment.UserID != userID {
+			return respondError(c, http.StatusNotFound, codeCommentNotFound, "Cannot delete comment with id: "+id)
+		}
+	}
+	err = h.srvComment.Delete(c.Request().Context(), uuidID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return respondError(c, http.StatusNotFound, codeCommentNotFound, "Cannot delete comment with id: "+id)
+		}
+		log.WithField("ID", uuidID).Errorf("srvComment.Delete - %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Failed to delete comment")
+	}
+	return c.JSON(http.StatusOK, "Successfully deleted comment: "+id)
+}
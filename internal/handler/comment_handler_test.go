@@ -0,0 +1,271 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/artnikel/blogapi/internal/handler/mocks"
+	"github.com/artnikel/blogapi/internal/model"
+	"github.com/artnikel/blogapi/internal/repository"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+func Test_CreateComment(t *testing.T) {
+	mockBlogService := new(mocks.MockBlogService)
+	mockService := new(mocks.MockCommentService)
+	validate := validator.New()
+	h := NewHandler(mockBlogService, nil, mockService, validate)
+
+	blogID := uuid.New()
+	userID := uuid.New()
+	bodyBytes, err := json.Marshal(map[string]string{"body": "testcomment"})
+	require.NoError(t, err)
+
+	mockBlogService.On("Get", mock.Anything, blogID).
+		Return(&model.Blog{BlogID: blogID, UserID: uuid.New(), Status: model.BlogStatusPublished}, nil)
+
+	mockService.On("Create", mock.Anything, mock.MatchedBy(func(cm *model.Comment) bool {
+		return cm.Body == "testcomment" && cm.BlogID == blogID && cm.UserID == userID && cm.CommentID != uuid.Nil
+	})).Return(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/blog/"+blogID.String()+"/comments", bytes.NewReader(bodyBytes))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(blogID.String())
+	c.Set("id", userID)
+
+	err = h.CreateComment(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	var respComment model.Comment
+	err = json.Unmarshal(rec.Body.Bytes(), &respComment)
+	require.NoError(t, err)
+	require.Equal(t, "testcomment", respComment.Body)
+	require.Equal(t, blogID, respComment.BlogID)
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_CreateComment_BlogNotFound(t *testing.T) {
+	mockBlogService := new(mocks.MockBlogService)
+	mockService := new(mocks.MockCommentService)
+	validate := validator.New()
+	h := NewHandler(mockBlogService, nil, mockService, validate)
+
+	blogID := uuid.New()
+	bodyBytes, err := json.Marshal(map[string]string{"body": "testcomment"})
+	require.NoError(t, err)
+
+	mockBlogService.On("Get", mock.Anything, blogID).
+		Return(&model.Blog{BlogID: blogID, UserID: uuid.New(), Status: model.BlogStatusPublished}, nil)
+
+	mockService.On("Create", mock.Anything, mock.AnythingOfType("*model.Comment")).
+		Return(fmt.Errorf("commentRps.CreateComment - %w", repository.ErrNotFound))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/blog/"+blogID.String()+"/comments", bytes.NewReader(bodyBytes))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(blogID.String())
+	c.Set("id", uuid.New())
+
+	err = h.CreateComment(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_GetComments(t *testing.T) {
+	mockBlogService := new(mocks.MockBlogService)
+	mockService := new(mocks.MockCommentService)
+	validate := validator.New()
+	h := NewHandler(mockBlogService, nil, mockService, validate)
+
+	blogID := uuid.New()
+	mockBlogService.On("Get", mock.Anything, blogID).
+		Return(&model.Blog{BlogID: blogID, UserID: uuid.New(), Status: model.BlogStatusPublished}, nil)
+	resp := &model.CommentListResponse{
+		Comments: []*model.Comment{{CommentID: uuid.New(), BlogID: blogID, Body: "testcomment"}},
+		Count:    3,
+	}
+
+	mockService.On("GetByBlogID", mock.Anything, blogID, 1, 2).Return(resp, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/blog/"+blogID.String()+"/comments?limit=1&offset=2", http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(blogID.String())
+
+	err := h.GetComments(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var respList model.CommentListResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &respList)
+	require.NoError(t, err)
+	require.Equal(t, resp, &respList)
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_CreateComment_HiddenBlog(t *testing.T) {
+	blogID := uuid.New()
+	for name, getBlog := range map[string]func(m *mocks.MockBlogService){
+		"deleted": func(m *mocks.MockBlogService) {
+			m.On("Get", mock.Anything, blogID).Return(nil, fmt.Errorf("blogRps.Get - %w", repository.ErrNotFound))
+		},
+		"someone else's draft": func(m *mocks.MockBlogService) {
+			m.On("Get", mock.Anything, blogID).
+				Return(&model.Blog{BlogID: blogID, UserID: uuid.New(), Status: model.BlogStatusDraft}, nil)
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockBlogService := new(mocks.MockBlogService)
+			mockService := new(mocks.MockCommentService)
+			h := NewHandler(mockBlogService, nil, mockService, validator.New())
+			getBlog(mockBlogService)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/blog/"+blogID.String()+"/comments",
+				bytes.NewReader([]byte(`{"body":"testcomment"}`)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(blogID.String())
+			c.Set("id", uuid.New())
+
+			err := h.CreateComment(c)
+			require.NoError(t, err)
+			requireErrorResponse(t, rec, http.StatusNotFound, codeBlogNotFound)
+			mockService.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			mockBlogService.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_GetComments_HiddenBlog(t *testing.T) {
+	blogID := uuid.New()
+	for name, getBlog := range map[string]func(m *mocks.MockBlogService){
+		"deleted": func(m *mocks.MockBlogService) {
+			m.On("Get", mock.Anything, blogID).Return(nil, fmt.Errorf("blogRps.Get - %w", repository.ErrNotFound))
+		},
+		"someone else's draft": func(m *mocks.MockBlogService) {
+			m.On("Get", mock.Anything, blogID).
+				Return(&model.Blog{BlogID: blogID, UserID: uuid.New(), Status: model.BlogStatusDraft}, nil)
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockBlogService := new(mocks.MockBlogService)
+			mockService := new(mocks.MockCommentService)
+			h := NewHandler(mockBlogService, nil, mockService, validator.New())
+			getBlog(mockBlogService)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/blog/"+blogID.String()+"/comments", http.NoBody)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(blogID.String())
+			c.Set("id", uuid.New())
+
+			err := h.GetComments(c)
+			require.NoError(t, err)
+			requireErrorResponse(t, rec, http.StatusNotFound, codeBlogNotFound)
+			mockService.AssertNotCalled(t, "GetByBlogID", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			mockBlogService.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_DeleteComment_AsAdmin(t *testing.T) {
+	mockService := new(mocks.MockCommentService)
+	validate := validator.New()
+	h := NewHandler(nil, nil, mockService, validate)
+
+	commentID := uuid.New()
+
+	mockService.On("Delete", mock.Anything, commentID).Return(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/comments/"+commentID.String(), http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(commentID.String())
+	c.Set("isAdmin", true)
+
+	err := h.DeleteComment(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_DeleteComment_AsAuthor(t *testing.T) {
+	mockService := new(mocks.MockCommentService)
+	validate := validator.New()
+	h := NewHandler(nil, nil, mockService, validate)
+
+	commentID := uuid.New()
+	userID := uuid.New()
+
+	mockService.On("Get", mock.Anything, commentID).Return(&model.Comment{CommentID: commentID, UserID: userID}, nil)
+	mockService.On("Delete", mock.Anything, commentID).Return(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/comments/"+commentID.String(), http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(commentID.String())
+	c.Set("id", userID)
+
+	err := h.DeleteComment(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "Successfully deleted comment: "+commentID.String())
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_DeleteComment_NotAuthor(t *testing.T) {
+	mockService := new(mocks.MockCommentService)
+	validate := validator.New()
+	h := NewHandler(nil, nil, mockService, validate)
+
+	commentID := uuid.New()
+
+	mockService.On("Get", mock.Anything, commentID).Return(&model.Comment{CommentID: commentID, UserID: uuid.New()}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/comments/"+commentID.String(), http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(commentID.String())
+	c.Set("id", uuid.New())
+
+	err := h.DeleteComment(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"encoding/csv"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/artnikel/blogapi/internal/model"
+	"github.com/labstack/echo/v4"
+)
+
+// MIMETextCSV is the media type of CSV responses
+const MIMETextCSV = "text/csv"
+
+// acceptsCSV reports whether the Accept header prefers CSV over JSON.
+// Media types are ranked by their q-value, ties go to the one the client listed first and q=0 refuses a type.
+func acceptsCSV(accept string) bool {
+	csvQ, jsonQ := 0.0, 0.0
+	csvAt, jsonAt := -1, -1
+	for i, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			q, err = strconv.ParseFloat(value, 64)
+			if err != nil || q < 0 || q > 1 {
+				continue
+			}
+		}
+		switch mediaType {
+		case MIMETextCSV:
+			if q > csvQ {
+				csvQ, csvAt = q, i
+			}
+		case echo.MIMEApplicationJSON, "application/*", "*/*":
+			if q > jsonQ {
+				jsonQ, jsonAt = q, i
+			}
+		}
+	}
+	if csvAt < 0 {
+		return false
+	}
+	return jsonAt < 0 || csvQ > jsonQ || (csvQ == jsonQ && csvAt < jsonAt)
+}
+
+// csvCell keeps a field from being run as a formula by spreadsheets, which treat cells starting with =, +, -, @, a tab
+// or a carriage return as one, by prefixing it with a quote
+func csvCell(field string) string {
+	if field != "" && strings.ContainsRune("=+-@\t\r", rune(field[0])) {
+		return "'" + field
+	}
+	return field
+}
+
+// writeBlogsCSV writes blogs as CSV rows with a header line, quoting fields when needed and escaping formulas, see csvCell
+func writeBlogsCSV(c echo.Context, resp *model.BlogListResponse) error {
+	c.Response().Header().Set(echo.HeaderContentType, MIMETextCSV+"; charset=UTF-8")
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(resp.Count))
+	c.Response().WriteHeader(http.StatusOK)
+
+	w := csv.NewWriter(c.Response())
+	if err := w.Write([]string{"blogid", "userid", "title", "content", "releasetime", "tags"}); err != nil {
+		return err
+	}
+	for _, blog := range resp.Blogs {
+		err := w.Write([]string{
+			blog.BlogID.String(),
+			blog.UserID.String(),
+			csvCell(blog.Title),
+			csvCell(blog.Content),
+			blog.ReleaseTime.Format(time.RFC3339),
+			csvCell(strings.Join(blog.Tags, ";")),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/artnikel/blogapi/internal/model"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_acceptsCSV(t *testing.T) {
+	for _, tc := range []struct {
+		accept string
+		want   bool
+	}{
+		{accept: "", want: false},
+		{accept: "text/csv", want: true},
+		{accept: "text/csv;q=0.5", want: true},
+		{accept: "text/csv, application/json", want: true},
+		{accept: "application/json, text/csv", want: false},
+		{accept: "application/json;q=0.5, text/csv", want: true},
+		{accept: "text/csv;q=0.8, application/json", want: false},
+		{accept: "*/*;q=0.1, text/csv;q=0.9", want: true},
+		{accept: "text/csv;q=0.9, */*", want: false},
+		{accept: "text/csv;q=0", want: false},
+		{accept: "text/csv;q=abc", want: false},
+		{accept: "text/html, text/csv;q=0.2", want: true},
+	} {
+		require.Equal(t, tc.want, acceptsCSV(tc.accept), tc.accept)
+	}
+}
+
+func Test_writeBlogsCSV_EscapesFormulas(t *testing.T) {
+	blog := &model.Blog{
+		BlogID:      uuid.New(),
+		UserID:      uuid.New(),
+		Title:       "=HYPERLINK(\"http://evil.example\")",
+		Content:     "-2+3",
+		ReleaseTime: time.Date(2025, time.May, 1, 10, 0, 0, 0, time.UTC),
+		Tags:        []string{"@go"},
+	}
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/blogs", http.NoBody), rec)
+
+	require.NoError(t, writeBlogsCSV(c, &model.BlogListResponse{Blogs: []*model.Blog{blog}, Count: 1}))
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		blog.BlogID.String(), blog.UserID.String(), "'=HYPERLINK(\"http://evil.example\")", "'-2+3", "2025-05-01T10:00:00Z", "'@go",
+	}, records[1])
+}
@@ -0,0 +1,18 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/artnikel/blogapi/internal/docs"
+	"github.com/labstack/echo/v4"
+)
+
+// GetOpenAPI processes the GET request to retrieve the OpenAPI description of the API
+func (h *Handler) GetOpenAPI(c echo.Context) error {
+	return c.Blob(http.StatusOK, echo.MIMEApplicationJSONCharsetUTF8, docs.OpenAPI)
+}
+
+// GetSwaggerUI processes the GET request to retrieve the Swagger UI page rendering the OpenAPI description
+func (h *Handler) GetSwaggerUI(c echo.Context) error {
+	return c.HTMLBlob(http.StatusOK, docs.SwaggerUI)
+}
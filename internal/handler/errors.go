@@ -0,0 +1,62 @@
+package handler
+
+import (
+	customMiddleware "github.com/artnikel/blogapi/internal/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+// Error codes of the error envelope, clients branch on the code and show or log the message
+const (
+	// codeInvalidRequest is the code of a body or query that cannot be decoded or fails validation
+	codeInvalidRequest = "invalid_request"
+	// codeInvalidID is the code of an id in the path or the query that is not a UUID
+	codeInvalidID = "invalid_id"
+	// codeUnauthorized is the code of requests without the user the JWT middleware sets
+	codeUnauthorized = "unauthorized"
+	// codeAdminRequired is the code of requests of non-admins to admin only actions
+	codeAdminRequired = "admin_required"
+	// codeForbidden is the code of actions the caller may never take, whatever their role
+	codeForbidden = "forbidden"
+	// codeBlogNotFound, codeCommentNotFound and codeUserNotFound are the codes of missing or hidden entities
+	codeBlogNotFound    = "blog_not_found"
+	codeCommentNotFound = "comment_not_found"
+	codeUserNotFound    = "user_not_found"
+	// codeBlogExists is the code of creating a blog with the id of an existing one
+	codeBlogExists = "blog_exists"
+	// codeUsernameTaken is the code of signing up with the username or email of an existing user
+	codeUsernameTaken = "username_taken"
+	// codeIdempotencyKeyReused and codeIdempotencyKeyPending are the codes of idempotency keys that cannot be replayed
+	codeIdempotencyKeyReused  = "idempotency_key_reused"
+	codeIdempotencyKeyPending = "idempotency_key_pending"
+	// codeLastAdmin is the code of demoting the only admin left
+	codeLastAdmin = "last_admin"
+	// codeBlogModified is the code of conditional writes to a blog modified since the given date
+	codeBlogModified = "blog_modified"
+	// codeAccountLocked is the code of logins to an account locked after too many wrong passwords
+	codeAccountLocked = "account_locked"
+	// codeWrongPassword is the code of password changes with a wrong old password
+	codeWrongPassword = "wrong_password"
+	// codeSignupDisabled and codeInvalidInvite are the codes of signups the signup mode refuses
+	codeSignupDisabled = "signup_disabled"
+	codeInvalidInvite  = "invalid_invite"
+	// codeInvalidRefreshToken is the code of refresh tokens that are malformed, expired or revoked
+	codeInvalidRefreshToken = "invalid_refresh_token"
+	// codeInvalidResetToken is the code of password reset tokens that are invalid, expired or used
+	codeInvalidResetToken = "invalid_reset_token"
+	// codeDraftLimitExceeded is the code of creating a blog while keeping as many drafts as the draft limit allows
+	codeDraftLimitExceeded = "draft_limit_exceeded"
+	// codeTooManyItems is the code of bulk requests with more items than allowed
+	codeTooManyItems = "too_many_items"
+	// codeDisallowedMedia is the code of blogs referencing media the media check refuses
+	codeDisallowedMedia = "disallowed_media"
+	// codeNotConfigured is the code of optional features the server runs without
+	codeNotConfigured = "not_configured"
+	// codeInternal is the code of unexpected failures, the request may succeed if retried
+	codeInternal = "internal_error"
+)
+
+// respondError writes the error envelope {"error": {"code": ..., "message": ...}} with the status, the same envelope
+// ErrorHandler renders for the errors of the router and the middlewares
+func respondError(c echo.Context, status int, code, message string) error {
+	return customMiddleware.RespondError(c, status, code, message)
+}
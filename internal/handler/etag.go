@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/artnikel/blogapi/internal/model"
+)
+
+// blogETag returns a weak ETag of the response body of a blog. The view count is left out: it changes on every read,
+// and a client revalidating a blog it already has does not need the latest count.
+func blogETag(blog *model.Blog, body any) (string, error) {
+	views := blog.Views
+	blog.Views = 0
+	data, err := json.Marshal(body)
+	blog.Views = views
+	if err != nil {
+		return "", fmt.Errorf("json.Marshal(): %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return `W/"` + hex.EncodeToString(sum[:16]) + `"`, nil
+}
+
+// etagMatches reports whether the If-None-Match header lists the ETag or is "*", comparing weakly as RFC 9110 asks for
+func etagMatches(ifNoneMatch, etag string) bool {
+	if strings.TrimSpace(ifNoneMatch) == "*" {
+		return true
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
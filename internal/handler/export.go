@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/artnikel/blogapi/internal/model"
+	"github.com/artnikel/blogapi/internal/repository"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+// MIMEApplicationZIP is the media type of ZIP archive responses
+const MIMEApplicationZIP = "application/zip"
+
+// ExportMe processes the GET request of the current user to download all their data as a ZIP archive
+func (h *Handler) ExportMe(c echo.Context) error {
+	userID, ok := c.Get("id").(uuid.UUID)
+	if !ok {
+		return respondError(c, http.StatusUnauthorized, codeUnauthorized, "User ID not found in context")
+	}
+	return h.exportUser(c, userID)
+}
+
+// ExportUser processes the GET request to download all data of a user as a ZIP archive, allowed to the user
+// themselves and admins
+func (h *Handler) ExportUser(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		log.Errorf("uuid.Parse error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to parse id")
+	}
+	if !canSeeUnpublished(c, id) {
+		return respondError(c, http.StatusForbidden, codeAdminRequired, "You can only export your own data unless you are an admin")
+	}
+	return h.exportUser(c, id)
+}
+
+// exportUser streams the ZIP archive of a user: profile.json with the profile but no secrets, every blog as
+// blogs/<id>.json and blogs/<id>.md, drafts included, and every comment of the user in comments.json. The archive is
+// written while the rows are read, so the status is sent before the blogs: an error after it can not change the
+// status, the archive is then left without its central directory, which makes it invalid rather than incomplete.
+func (h *Handler) exportUser(c echo.Context, id uuid.UUID) error {
+	user, err := h.srvUser.GetProfile(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return respondError(c, http.StatusNotFound, codeUserNotFound, "Cannot find user with id: "+id.String())
+		}
+		log.WithField("UserID", id).Errorf("srvUser.GetProfile - %v", err)
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to export user data")
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, MIMEApplicationZIP)
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="export-%s.zip"`, id))
+	res.WriteHeader(http.StatusOK)
+
+	archive := zip.NewWriter(res)
+	if err := h.writeExport(c.Request().Context(), archive, user); err != nil {
+		log.WithField("UserID", id).Errorf("writeExport - %v", err)
+		return nil
+	}
+	if err := archive.Close(); err != nil {
+		log.WithField("UserID", id).Errorf("archive.Close - %v", err)
+	}
+	return nil
+}
+
+// writeExport writes the entries of the export archive of the user, see exportUser
+func (h *Handler) writeExport(ctx context.Context, archive *zip.Writer, user *model.User) error {
+	modified := time.Now().UTC()
+	if err := writeZIPJSON(archive, "profile.json", modified, user); err != nil {
+		return err
+	}
+	err := h.srvBlog.StreamByUserID(ctx, user.ID, func(blog *model.Blog) error {
+		if err := writeZIPJSON(archive, "blogs/"+blog.BlogID.String()+".json", blog.UpdatedAt, blog); err != nil {
+			return err
+		}
+		w, err := archive.CreateHeader(&zip.FileHeader{Name: "blogs/" + blog.BlogID.String() + ".md", Method: zip.Deflate, Modified: blog.UpdatedAt})
+		if err != nil {
+			return fmt.Errorf("archive.CreateHeader - %w", err)
+		}
+		_, err = fmt.Fprintf(w, "# %s\n\n%s\n", blog.Title, blog.Content)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("srvBlog.StreamByUserID - %w", err)
+	}
+
+	w, err := archive.CreateHeader(&zip.FileHeader{Name: "comments.json", Method: zip.Deflate, Modified: modified})
+	if err != nil {
+		return fmt.Errorf("archive.CreateHeader - %w", err)
+	}
+	enc := json.NewEncoder(w)
+	separator := "["
+	err = h.srvComment.StreamByUserID(ctx, user.ID, func(comment *model.Comment) error {
+		if _, err := io.WriteString(w, separator); err != nil {
+			return err
+		}
+		separator = ","
+		return enc.Encode(comment)
+	})
+	if err != nil {
+		return fmt.Errorf("srvComment.StreamByUserID - %w", err)
+	}
+	if separator == "[" {
+		_, err = io.WriteString(w, "[]\n")
+	} else {
+		_, err = io.WriteString(w, "]\n")
+	}
+	return err
+}
+
+// writeZIPJSON adds an entry with the indented JSON of v to the archive
+func writeZIPJSON(archive *zip.Writer, name string, modified time.Time, v any) error {
+	w, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+	if err != nil {
+		return fmt.Errorf("archive.CreateHeader - %w", err)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/artnikel/blogapi/internal/handler/mocks"
+	"github.com/artnikel/blogapi/internal/model"
+	"github.com/artnikel/blogapi/internal/repository"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+// readZIP returns the contents of the entries of the archive by name
+func readZIP(t *testing.T, body []byte) map[string]string {
+	t.Helper()
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	require.NoError(t, err)
+	entries := make(map[string]string, len(archive.File))
+	for _, file := range archive.File {
+		r, err := file.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.NoError(t, r.Close())
+		entries[file.Name] = string(data)
+	}
+	return entries
+}
+
+func Test_ExportMe(t *testing.T) {
+	mockBlogService := new(mocks.MockBlogService)
+	mockUserService := new(mocks.MockUserService)
+	mockCommentService := new(mocks.MockCommentService)
+	h := NewHandler(mockBlogService, mockUserService, mockCommentService, validator.New())
+
+	userID := uuid.New()
+	updated := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+	user := &model.User{ID: userID, Username: "exporter", Email: "exporter@example.com", Password: []byte("hash"), RefreshToken: "secret"}
+	published := &model.Blog{BlogID: uuid.New(), UserID: userID, Title: "Published", Content: "Some *markdown*",
+		Status: model.BlogStatusPublished, Tags: []string{"go"}, UpdatedAt: updated}
+	draft := &model.Blog{BlogID: uuid.New(), UserID: userID, Title: "Draft", Content: "Not yet", Status: model.BlogStatusDraft, UpdatedAt: updated}
+	comment := &model.Comment{CommentID: uuid.New(), BlogID: uuid.New(), UserID: userID, Body: "Nice post", CreatedAt: updated}
+
+	mockUserService.On("GetProfile", mock.Anything, userID).Return(user, nil)
+	mockBlogService.On("StreamByUserID", mock.Anything, userID, mock.Anything).Run(func(args mock.Arguments) {
+		fn := args.Get(2).(func(*model.Blog) error)
+		for _, blog := range []*model.Blog{published, draft} {
+			if err := fn(blog); err != nil {
+				return
+			}
+		}
+	}).Return(nil)
+	mockCommentService.On("StreamByUserID", mock.Anything, userID, mock.Anything).Run(func(args mock.Arguments) {
+		_ = args.Get(2).(func(*model.Comment) error)(comment)
+	}).Return(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/me/export.zip", http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("id", userID)
+
+	require.NoError(t, h.ExportMe(c))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, MIMEApplicationZIP, rec.Header().Get(echo.HeaderContentType))
+	require.Equal(t, fmt.Sprintf(`attachment; filename="export-%s.zip"`, userID), rec.Header().Get(echo.HeaderContentDisposition))
+
+	entries := readZIP(t, rec.Body.Bytes())
+	require.Len(t, entries, 6)
+
+	var profile map[string]any
+	require.NoError(t, json.Unmarshal([]byte(entries["profile.json"]), &profile))
+	require.Equal(t, "exporter", profile["username"])
+	require.Equal(t, "exporter@example.com", profile["email"])
+	require.NotContains(t, entries["profile.json"], "hash")
+	require.NotContains(t, entries["profile.json"], "secret")
+
+	for _, blog := range []*model.Blog{published, draft} {
+		var exported model.Blog
+		require.NoError(t, json.Unmarshal([]byte(entries["blogs/"+blog.BlogID.String()+".json"]), &exported))
+		require.Equal(t, *blog, exported)
+		require.Equal(t, "# "+blog.Title+"\n\n"+blog.Content+"\n", entries["blogs/"+blog.BlogID.String()+".md"])
+	}
+
+	var comments []*model.Comment
+	require.NoError(t, json.Unmarshal([]byte(entries["comments.json"]), &comments))
+	require.Equal(t, []*model.Comment{comment}, comments)
+
+	mockBlogService.AssertExpectations(t)
+	mockUserService.AssertExpectations(t)
+	mockCommentService.AssertExpectations(t)
+}
+
+func Test_ExportUser(t *testing.T) {
+	mockBlogService := new(mocks.MockBlogService)
+	mockUserService := new(mocks.MockUserService)
+	mockCommentService := new(mocks.MockCommentService)
+	h := NewHandler(mockBlogService, mockUserService, mockCommentService, validator.New())
+
+	userID, missing := uuid.New(), uuid.New()
+	mockUserService.On("GetProfile", mock.Anything, userID).Return(&model.User{ID: userID, Username: "exporter"}, nil)
+	mockUserService.On("GetProfile", mock.Anything, missing).Return(nil, fmt.Errorf("userRps.GetUserByID - %w", repository.ErrNotFound))
+	mockBlogService.On("StreamByUserID", mock.Anything, userID, mock.Anything).Return(nil)
+	mockCommentService.On("StreamByUserID", mock.Anything, userID, mock.Anything).Return(nil)
+
+	e := echo.New()
+	export := func(id string, callerID uuid.UUID, admin bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/users/"+id+"/export.zip", http.NoBody)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(id)
+		c.Set("id", callerID)
+		c.Set("isAdmin", admin)
+		require.NoError(t, h.ExportUser(c))
+		return rec
+	}
+
+	rec := export(userID.String(), uuid.New(), false)
+	requireErrorResponse(t, rec, http.StatusForbidden, codeAdminRequired)
+
+	rec = export(missing.String(), uuid.New(), true)
+	requireErrorResponse(t, rec, http.StatusNotFound, codeUserNotFound)
+
+	rec = export("nope", uuid.New(), true)
+	requireErrorResponse(t, rec, http.StatusBadRequest, codeInvalidID)
+
+	for _, admin := range []bool{true, false} {
+		callerID := userID
+		if admin {
+			callerID = uuid.New()
+		}
+		rec = export(userID.String(), callerID, admin)
+		require.Equal(t, http.StatusOK, rec.Code)
+		entries := readZIP(t, rec.Body.Bytes())
+		require.Len(t, entries, 2)
+		require.Contains(t, entries, "profile.json")
+		require.Equal(t, "[]\n", entries["comments.json"])
+	}
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/artnikel/blogapi/internal/constants"
+	"github.com/artnikel/blogapi/internal/model"
+	"github.com/artnikel/blogapi/internal/render"
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+// MIMEApplicationRSS is the media type of RSS feeds
+const MIMEApplicationRSS = "application/rss+xml"
+
+// rss is the root element of an RSS 2.0 document
+type rss struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+	Description string  `xml:"description"`
+}
+
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+// GetFeed processes the GET request to retrieve the latest published blogs as an RSS 2.0 feed
+func (h *Handler) GetFeed(c echo.Context) error {
+	resp, err := h.srvBlog.GetAll(c.Request().Context(), model.BlogFilter{}, constants.FeedItems, 0)
+	if err != nil {
+		log.Errorf("srvBlog.GetAll - %v", err)
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to get feed")
+	}
+	// links point at the API version the feed was requested from
+	base := c.Scheme() + "://" + c.Request().Host + strings.TrimSuffix(c.Request().URL.Path, "/feed.rss")
+	feed := rss{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:         "Blog API",
+			Link:          base + "/blogs",
+			Description:   "The latest published blogs",
+			LastBuildDate: time.Now().UTC().Format(time.RFC1123Z),
+			Items:         make([]rssItem, 0, len(resp.Blogs)),
+		},
+	}
+	for _, blog := range resp.Blogs {
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title:       blog.Title,
+			Link:        base + "/blogs/slug/" + blog.Slug,
+			GUID:        rssGUID{Value: blog.BlogID.String()},
+			PubDate:     blog.ReleaseTime.UTC().Format(time.RFC1123Z),
+			Description: render.Excerpt(blog.Content, constants.FeedExcerptLen),
+		})
+	}
+	body, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		log.Errorf("xml.MarshalIndent - %v", err)
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to get feed")
+	}
+	return c.Blob(http.StatusOK, MIMEApplicationRSS+"; charset=UTF-8", append([]byte(xml.Header), body...))
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/artnikel/blogapi/internal/config"
+	"github.com/artnikel/blogapi/internal/constants"
+	"github.com/artnikel/blogapi/internal/handler/mocks"
+	"github.com/artnikel/blogapi/internal/model"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+func Test_GetFeed(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	h := NewHandler(mockService, nil, nil, validator.New())
+	e := echo.New()
+	RegisterRoutes(e, h, &config.Config{BlogTokenSignature: "secret"})
+
+	released := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	blogs := []*model.Blog{
+		{BlogID: uuid.New(), Title: "Tom & Jerry <3", Slug: "tom-jerry-3", Content: "Cats & mice\n\n<b>forever</b>", ReleaseTime: released},
+		{BlogID: uuid.New(), Title: "Long read", Slug: "long-read", Content: strings.Repeat("word ", 200), ReleaseTime: released},
+	}
+	mockService.On("GetAll", mock.Anything, model.BlogFilter{}, constants.FeedItems, 0).Return(&model.BlogListResponse{Blogs: blogs, Count: 2}, nil)
+
+	// the feed is public, no token is sent
+	req := httptest.NewRequest(http.MethodGet, "/v1/feed.rss", http.NoBody)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, MIMEApplicationRSS+"; charset=UTF-8", rec.Header().Get(echo.HeaderContentType))
+	require.Contains(t, rec.Body.String(), "Tom &amp; Jerry &lt;3")
+
+	var feed rss
+	require.NoError(t, xml.Unmarshal(rec.Body.Bytes(), &feed))
+	require.Equal(t, "2.0", feed.Version)
+	require.Len(t, feed.Channel.Items, 2)
+	require.Equal(t, "Tom & Jerry <3", feed.Channel.Items[0].Title)
+	require.Equal(t, "http://example.com/v1/blogs/slug/tom-jerry-3", feed.Channel.Items[0].Link)
+	require.Equal(t, "Sun, 01 Mar 2026 12:00:00 +0000", feed.Channel.Items[0].PubDate)
+	require.Equal(t, "Cats & mice <b>forever</b>", feed.Channel.Items[0].Description)
+	require.Equal(t, "Long read", feed.Channel.Items[1].Title)
+	require.LessOrEqual(t, len([]rune(feed.Channel.Items[1].Description)), constants.FeedExcerptLen+1)
+
+	mockService.AssertExpectations(t)
+}
@@ -3,26 +3,67 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"time"
 
+	"github.com/artnikel/blogapi/internal/constants"
+	customMiddleware "github.com/artnikel/blogapi/internal/middleware"
 	"github.com/artnikel/blogapi/internal/model"
+	"github.com/artnikel/blogapi/internal/render"
+	"github.com/artnikel/blogapi/internal/repository"
 	"github.com/artnikel/blogapi/internal/service"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	log "github.com/sirupsen/logrus"
 	"gopkg.in/go-playground/validator.v9"
 )
 
+// HeaderIdempotencyKey lets clients retry creating a blog without creating it twice
+const HeaderIdempotencyKey = "Idempotency-Key"
+
+// formatHTML is the value of the format query param asking for the blog content rendered to sanitized HTML
+const formatHTML = "html"
+
 // BlogService is an interface that defines the methods on Blog entity
 type BlogService interface {
-	Create(ctx context.Context, blog *model.Blog) error
+	Create(ctx context.Context, blog *model.Blog, trusted bool) error
 	Get(ctx context.Context, id uuid.UUID) (*model.Blog, error)
+	GetBySlug(ctx context.Context, slug string) (*model.Blog, error)
+	GetWithAuthor(ctx context.Context, id uuid.UUID) (*model.BlogWithAuthor, error)
+	CreateIdempotent(ctx context.Context, blog *model.Blog, key string, trusted bool) (*model.Blog, bool, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 	DeleteBlogsByUserID(ctx context.Context, id uuid.UUID) error
-	Update(ctx context.Context, blog *model.Blog) error
-	GetAll(ctx context.Context, limit, offset int) (*model.BlogListResponse, error)
-	GetByUserID(ctx context.Context, id uuid.UUID) ([]*model.Blog, error)
+	DeleteIfUnmodifiedSince(ctx context.Context, id uuid.UUID, since time.Time) error
+	DeleteMany(ctx context.Context, ids []uuid.UUID, ownerID uuid.UUID) (int, error)
+	Exists(ctx context.Context, ids []uuid.UUID, viewerID uuid.UUID) (map[uuid.UUID]bool, error)
+	Restore(ctx context.Context, id uuid.UUID) error
+	HardDelete(ctx context.Context, id uuid.UUID) error
+	Update(ctx context.Context, blog *model.Blog, trusted bool) error
+	Publish(ctx context.Context, id uuid.UUID, trusted bool) (string, error)
+	Approve(ctx context.Context, id uuid.UUID) error
+	Reject(ctx context.Context, id uuid.UUID, reason string) error
+	GetPendingReview(ctx context.Context, limit, offset int) ([]*model.Blog, error)
+	Count(ctx context.Context) (*model.BlogCountResponse, error)
+	CountByUserID(ctx context.Context, id uuid.UUID, publishedOnly bool) (*model.BlogCountResponse, error)
+	IncrementViews(ctx context.Context, id uuid.UUID) (int, error)
+	GetPopular(ctx context.Context, limit int) ([]*model.Blog, error)
+	AddRecentView(ctx context.Context, userID, blogID uuid.UUID) error
+	GetRecentViews(ctx context.Context, userID uuid.UUID) ([]*model.Blog, error)
+	GetSiblings(ctx context.Context, blog *model.Blog, authorOnly bool) (*model.BlogSiblings, error)
+	GetAll(ctx context.Context, filter model.BlogFilter, limit, offset int) (*model.BlogListResponse, error)
+	StreamAll(ctx context.Context, filter model.BlogFilter, fn func(*model.Blog) error) error
+	StreamByUserID(ctx context.Context, id uuid.UUID, fn func(*model.Blog) error) error
+	GetByUserID(ctx context.Context, id uuid.UUID, publishedOnly bool, limit, offset int) (*model.BlogListResponse, error)
+	IsBlogOwner(ctx context.Context, blogID, userID uuid.UUID) (bool, error)
+	GetByTag(ctx context.Context, tag string, limit, offset int) (*model.BlogListResponse, error)
+	GetTopTags(ctx context.Context, userID uuid.UUID, limit int) ([]*model.TagCount, error)
+	Preview(content string) (*model.ContentPreview, error)
 }
 
 // UserService is an interface that defines the methods on User entity
@@ -31,113 +72,369 @@ type UserService interface {
 	Login(ctx context.Context, user *model.User) (*service.TokenPair, error)
 	Refresh(ctx context.Context, tokenPair service.TokenPair) (service.TokenPair, error)
 	DeleteUserByID(ctx context.Context, id uuid.UUID) error
+	Logout(ctx context.Context, id uuid.UUID, refreshToken string) error
+	LogoutAll(ctx context.Context, id uuid.UUID) error
+	ChangePassword(ctx context.Context, id uuid.UUID, oldPassword, newPassword []byte) error
+	RequestPasswordReset(ctx context.Context, email string) error
+	ConfirmPasswordReset(ctx context.Context, token string, newPassword []byte) error
+	GetProfile(ctx context.Context, id uuid.UUID) (*model.User, error)
+	SetRole(ctx context.Context, id uuid.UUID, admin bool) error
+	GetProfiles(ctx context.Context, ids []uuid.UUID) ([]*model.UserProfile, error)
+	GetActivity(ctx context.Context, id uuid.UUID, limit, offset int) (*model.ActivityListResponse, error)
+	Impersonate(ctx context.Context, adminID, id uuid.UUID) (string, error)
+	SignUpUser(ctx context.Context, user *model.User, invite string) error
+	CreateInvites(ctx context.Context, adminID uuid.UUID, count int) ([]string, error)
+	RevokeTokens(ctx context.Context, adminID, id uuid.UUID) error
+	TokenVersion(ctx context.Context, id uuid.UUID) (int, error)
+}
+
+// CommentService is an interface that defines the methods on Comment entity
+type CommentService interface {
+	Create(ctx context.Context, comment *model.Comment) error
+	Get(ctx context.Context, id uuid.UUID) (*model.Comment, error)
+	GetByBlogID(ctx context.Context, blogID uuid.UUID, limit, offset int) (*model.CommentListResponse, error)
+	StreamByUserID(ctx context.Context, userID uuid.UUID, fn func(*model.Comment) error) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// DiagnosticsService is an interface that defines the methods describing the environment
+type DiagnosticsService interface {
+	Diagnostics(ctx context.Context) (*model.Diagnostics, error)
 }
 
 // Handler is responsible for handling HTTP requests related to entities
 type Handler struct {
-	srvBlog  BlogService
-	srvUser  UserService
-	validate *validator.Validate
+	srvBlog    BlogService
+	srvUser    UserService
+	srvComment CommentService
+	srvDiag    DiagnosticsService
+	validate   *validator.Validate
+	// bulkMaxItems caps the number of items accepted by bulk endpoints
+	bulkMaxItems int
+	// refreshCookieMaxAge is how long browsers keep the refresh token cookie, zero when refresh tokens are sent in bodies
+	refreshCookieMaxAge time.Duration
+	// publicDraftCounts makes the blog counts of a user include their drafts for everyone, not only the user and admins
+	publicDraftCounts bool
 }
 
 // NewHandler creates a new instance of the Handler struct
-func NewHandler(srvBlog BlogService, srvUser UserService, validate *validator.Validate) *Handler {
-	return &Handler{srvBlog: srvBlog, srvUser: srvUser, validate: validate}
+func NewHandler(srvBlog BlogService, srvUser UserService, srvComment CommentService, validate *validator.Validate) *Handler {
+	return &Handler{srvBlog: srvBlog, srvUser: srvUser, srvComment: srvComment, validate: validate, bulkMaxItems: constants.BulkMaxItems}
+}
+
+// SetBulkMaxItems overrides the maximum number of items accepted by bulk endpoints, non-positive values keep the default
+func (h *Handler) SetBulkMaxItems(maxItems int) {
+	if maxItems > 0 {
+		h.bulkMaxItems = maxItems
+	}
+}
+
+// SetRefreshCookie makes Login send the refresh token in an HttpOnly cookie kept by browsers for maxAge
+// instead of the response body
+func (h *Handler) SetRefreshCookie(maxAge time.Duration) {
+	h.refreshCookieMaxAge = maxAge
+}
+
+// SetPublicDraftCounts makes the blog counts of a user include drafts for every caller when public is true,
+// by default only the user themselves and admins see drafts counted
+func (h *Handler) SetPublicDraftCounts(public bool) {
+	h.publicDraftCounts = public
+}
+
+// SetDiagnosticsService enables the admin diagnostics endpoint
+func (h *Handler) SetDiagnosticsService(srvDiag DiagnosticsService) {
+	h.srvDiag = srvDiag
 }
 
-// Create processes the POST request to create a new blog
+// Create processes the POST request to create a new blog. Non-admins keeping as many drafts as the draft limit
+// allows get 403.
 func (h *Handler) Create(c echo.Context) error {
 	var newBlog model.Blog
 	newBlog.BlogID = uuid.New()
 	err := c.Bind(&newBlog)
 	if err != nil {
 		log.Errorf("c.Bind error: %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, "Filling blog error")
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Filling blog error")
 	}
 	userID, ok := c.Get("id").(uuid.UUID)
 	if !ok {
-		return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found in context")
+		return respondError(c, http.StatusUnauthorized, codeUnauthorized, "User ID not found in context")
 	}
 	newBlog.UserID = userID
 	err = h.validate.StructCtx(c.Request().Context(), newBlog)
 	if err != nil {
 		log.Errorf("validate.StructCtx error: %v", err)
-		return c.JSON(http.StatusBadRequest, "Not valid data")
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Not valid data")
 	}
-	err = h.srvBlog.Create(c.Request().Context(), &newBlog)
+	if key := c.Request().Header.Get(HeaderIdempotencyKey); key != "" {
+		return h.createIdempotent(c, &newBlog, key)
+	}
+	isAdmin, _ := c.Get("isAdmin").(bool)
+	err = h.srvBlog.Create(c.Request().Context(), &newBlog, isAdmin)
 	if err != nil {
-		log.WithFields(log.Fields{
-			"Title":   newBlog.Title,
-			"Content": newBlog.Content,
-		}).Errorf("srvBlog.Create - %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create blog")
+		return createBlogError(c, &newBlog, err)
 	}
+	markEditable(c, &newBlog)
 	return c.JSON(http.StatusCreated, newBlog)
 }
 
-// Get processes the GET request to retrieve a blog by ID
+// createIdempotent creates the blog once per idempotency key, a retry of the same request gets the original blog with 200
+func (h *Handler) createIdempotent(c echo.Context, newBlog *model.Blog, key string) error {
+	if len(key) > constants.IdempotencyKeyMaxLen {
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Idempotency key is too long")
+	}
+	isAdmin, _ := c.Get("isAdmin").(bool)
+	blog, replayed, err := h.srvBlog.CreateIdempotent(c.Request().Context(), newBlog, key, isAdmin)
+	switch {
+	case errors.Is(err, service.ErrIdempotencyKeyReused):
+		return respondError(c, http.StatusConflict, codeIdempotencyKeyReused, "Idempotency key was already used for a different blog")
+	case errors.Is(err, service.ErrIdempotencyKeyPending):
+		return respondError(c, http.StatusConflict, codeIdempotencyKeyPending, "The request with this idempotency key has not completed")
+	case err != nil:
+		return createBlogError(c, newBlog, err)
+	}
+	markEditable(c, blog)
+	if replayed {
+		return c.JSON(http.StatusOK, blog)
+	}
+	return c.JSON(http.StatusCreated, blog)
+}
+
+// createBlogError maps an error of creating a blog to the response
+func createBlogError(c echo.Context, newBlog *model.Blog, err error) error {
+	if mediaErr := new(service.MediaError); errors.As(err, &mediaErr) {
+		return disallowedMediaResponse(c, mediaErr)
+	}
+	if errors.Is(err, service.ErrDraftLimitExceeded) {
+		return respondError(c, http.StatusForbidden, codeDraftLimitExceeded, "You have reached the limit of drafts, publish or delete some first")
+	}
+	if errors.Is(err, repository.ErrExist) {
+		log.WithField("ID", newBlog.BlogID).Errorf("srvBlog.Create - %v", err)
+		return respondError(c, http.StatusConflict, codeBlogExists, "Blog with this id already exists")
+	}
+	log.WithFields(log.Fields{
+		"Title":   newBlog.Title,
+		"Content": newBlog.Content,
+	}).Errorf("srvBlog.Create - %v", err)
+	return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to create blog")
+}
+
+// Get processes the GET request to retrieve a blog by ID, with the withAuthor query param set to true
+// the username of the author is included
 func (h *Handler) Get(c echo.Context) error {
 	id := c.Param("id")
 	err := h.validate.VarCtx(c.Request().Context(), id, "required,uuid")
 	if err != nil {
 		log.Errorf("validate.VarCtx error: %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, "Failed to validate id")
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to validate id")
+	}
+	uuidID, err := uuid.Parse(id)
+	if err != nil {
+		log.Errorf("uuid.Parse error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to parse id")
+	}
+	format := c.QueryParam("format")
+	if format != "" && format != formatHTML {
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "format must be html or left out")
+	}
+	renderHTML := format == formatHTML
+	if c.QueryParam("withAuthor") == "true" {
+		blog, err := h.srvBlog.GetWithAuthor(c.Request().Context(), uuidID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return respondError(c, http.StatusNotFound, codeBlogNotFound, "Cannot find blog with id: "+id)
+			}
+			log.WithField("ID", uuidID).Errorf("srvBlog.GetWithAuthor - %v", err)
+			return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to get blog")
+		}
+		return h.respondBlog(c, &blog.Blog, blog, "Cannot find blog with id: "+id, renderHTML)
+	}
+	blog, err := h.srvBlog.Get(c.Request().Context(), uuidID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return respondError(c, http.StatusNotFound, codeBlogNotFound, "Cannot find blog with id: "+id)
+		}
+		log.WithField("ID", uuidID).Errorf("srvBlog.Get - %v", err)
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to get blog")
+	}
+	return h.respondBlog(c, blog, blog, "Cannot find blog with id: "+id, renderHTML)
+}
+
+// GetBySlug processes the GET request to retrieve a blog by its slug
+func (h *Handler) GetBySlug(c echo.Context) error {
+	slug := c.Param("slug")
+	err := h.validate.VarCtx(c.Request().Context(), slug, "required,max=100")
+	if err != nil {
+		log.Errorf("validate.VarCtx error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Failed to validate slug")
+	}
+	blog, err := h.srvBlog.GetBySlug(c.Request().Context(), slug)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return respondError(c, http.StatusNotFound, codeBlogNotFound, "Cannot find blog with slug: "+slug)
+		}
+		log.WithField("Slug", slug).Errorf("srvBlog.GetBySlug - %v", err)
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to get blog")
+	}
+	return h.respondBlog(c, blog, blog, "Cannot find blog with slug: "+slug, false)
+}
+
+// respondBlog hides unpublished blogs from other users, counts the view and remembers it among the recent views of the reader.
+// The response body holds blog, which gets the updated view count and with renderHTML the content rendered to sanitized
+// HTML. A request whose If-None-Match matches the ETag of the
+// body gets 304 without it, the view is counted all the same.
+func (h *Handler) respondBlog(c echo.Context, blog *model.Blog, body any, notFound string, renderHTML bool) error {
+	if blog.Status != model.BlogStatusPublished && !canSeeUnpublished(c, blog.UserID) {
+		return respondError(c, http.StatusNotFound, codeBlogNotFound, notFound)
+	}
+	if renderHTML {
+		contentHTML, err := render.HTML(blog.Content)
+		if err != nil {
+			log.WithField("ID", blog.BlogID).Errorf("render.HTML - %v", err)
+			return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to render blog content")
+		}
+		blog.ContentHTML = contentHTML
+	}
+	// counting views is best-effort, a failed increment must not fail the read
+	views, err := h.srvBlog.IncrementViews(c.Request().Context(), blog.BlogID)
+	if err != nil {
+		log.WithField("ID", blog.BlogID).Errorf("srvBlog.IncrementViews - %v", err)
+	} else {
+		blog.Views = views
+	}
+	if userID, ok := c.Get("id").(uuid.UUID); ok {
+		err = h.srvBlog.AddRecentView(c.Request().Context(), userID, blog.BlogID)
+		if err != nil {
+			log.WithFields(log.Fields{"ID": blog.BlogID, "UserID": userID}).Errorf("srvBlog.AddRecentView - %v", err)
+		}
+	}
+	if !blog.UpdatedAt.IsZero() {
+		c.Response().Header().Set(echo.HeaderLastModified, blog.UpdatedAt.UTC().Format(http.TimeFormat))
+	}
+	markEditable(c, blog)
+	etag, err := blogETag(blog, body)
+	if err != nil {
+		log.WithField("ID", blog.BlogID).Errorf("blogETag - %v", err)
+		return c.JSON(http.StatusOK, body)
+	}
+	c.Response().Header().Set("ETag", etag)
+	if ifNoneMatch := c.Request().Header.Get("If-None-Match"); ifNoneMatch != "" && etagMatches(ifNoneMatch, etag) {
+		return c.NoContent(http.StatusNotModified)
+	}
+	return c.JSON(http.StatusOK, body)
+}
+
+// GetSiblings processes the GET request to retrieve the previous and next published blogs.
+// Neighbours are searched among the author's blogs unless the scope query param is "global".
+func (h *Handler) GetSiblings(c echo.Context) error {
+	id := c.Param("id")
+	err := h.validate.VarCtx(c.Request().Context(), id, "required,uuid")
+	if err != nil {
+		log.Errorf("validate.VarCtx error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to validate id")
 	}
 	uuidID, err := uuid.Parse(id)
 	if err != nil {
 		log.Errorf("uuid.Parse error: %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, "Failed to parse id")
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to parse id")
+	}
+	scope := c.QueryParam("scope")
+	if scope != "" && scope != "author" && scope != "global" {
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Failed to validate scope")
 	}
 	blog, err := h.srvBlog.Get(c.Request().Context(), uuidID)
 	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return respondError(c, http.StatusNotFound, codeBlogNotFound, "Cannot find blog with id: "+id)
+		}
 		log.WithField("ID", uuidID).Errorf("srvBlog.Get - %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, "Failed to get blog")
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to get blog")
+	}
+	if blog.Status != model.BlogStatusPublished && !canSeeUnpublished(c, blog.UserID) {
+		return respondError(c, http.StatusNotFound, codeBlogNotFound, "Cannot find blog with id: "+id)
+	}
+	siblings, err := h.srvBlog.GetSiblings(c.Request().Context(), blog, scope != "global")
+	if err != nil {
+		log.WithField("ID", uuidID).Errorf("srvBlog.GetSiblings - %v", err)
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to get blog siblings")
 	}
-	return c.JSON(http.StatusOK, blog)
+	markEditable(c, siblings.Previous, siblings.Next)
+	return c.JSON(http.StatusOK, siblings)
 }
 
-// Delete processes the DELETE request to delete a blog by ID
+// Delete processes the DELETE request to delete a blog by ID.
+// With an If-Unmodified-Since header the blog is deleted only if it was not modified after that date,
+// otherwise the response is 412. A header that is not a valid HTTP date is ignored as RFC 9110 requires.
 func (h *Handler) Delete(c echo.Context) error {
 	id := c.Param("id")
 	err := h.validate.VarCtx(c.Request().Context(), id, "required,uuid")
 	if err != nil {
 		log.Errorf("validate.VarCtx error: %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, "Failed to validate id")
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to validate id")
 	}
 	uuidID, err := uuid.Parse(id)
 	if err != nil {
 		log.Errorf("uuid.Parse error: %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, "Failed to parse id")
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to parse id")
 	}
 	isAdmin, ok := c.Get("isAdmin").(bool)
 	if ok && isAdmin {
-		err = h.srvBlog.Delete(c.Request().Context(), uuidID)
-		if err != nil {
-			log.WithField("ID", uuidID).Errorf("srvBlog.Delete - %v", err)
-			return echo.NewHTTPError(http.StatusBadRequest, "Failed to delete blog")
-		}
-		return c.JSON(http.StatusOK, "Successfully deleted blog: "+id)
+		return h.deleteBlog(c, uuidID)
 	}
 	userID, ok := c.Get("id").(uuid.UUID)
 	if !ok {
-		return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found in context")
+		return respondError(c, http.StatusUnauthorized, codeUnauthorized, "User ID not found in context")
 	}
-	blogs, err := h.srvBlog.GetByUserID(c.Request().Context(), userID)
+	owner, err := h.srvBlog.IsBlogOwner(c.Request().Context(), uuidID, userID)
 	if err != nil {
-		log.Errorf("srvBlog.GetByUserID - %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, "Failed to get blogs by user id")
+		log.WithField("ID", uuidID).Errorf("srvBlog.IsBlogOwner - %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Failed to get blogs by user id")
 	}
-	for _, blog := range blogs {
-		if uuidID == blog.BlogID {
-			err = h.srvBlog.Delete(c.Request().Context(), uuidID)
-			if err != nil {
-				log.WithField("ID", uuidID).Errorf("srvBlog.Delete - %v", err)
-				return echo.NewHTTPError(http.StatusBadRequest, "Failed to delete blog")
-			}
-			return c.JSON(http.StatusOK, "Successfully deleted blog: "+id)
+	if !owner {
+		return respondError(c, http.StatusNotFound, codeBlogNotFound, "Cannot delete blog with id: "+id)
+	}
+	return h.deleteBlog(c, uuidID)
+}
+
+// deleteBlog deletes the blog the caller may delete, honoring the If-Unmodified-Since header of the request
+func (h *Handler) deleteBlog(c echo.Context, id uuid.UUID) error {
+	since, conditional := ifUnmodifiedSince(c)
+	if !conditional {
+		err := h.srvBlog.Delete(c.Request().Context(), id)
+		if errors.Is(err, repository.ErrNotFound) {
+			return respondError(c, http.StatusNotFound, codeBlogNotFound, "Cannot delete blog with id: "+id.String())
+		}
+		if err != nil {
+			log.WithField("ID", id).Errorf("srvBlog.Delete - %v", err)
+			return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Failed to delete blog")
 		}
+		return c.JSON(http.StatusOK, "Successfully deleted blog: "+id.String())
+	}
+	err := h.srvBlog.DeleteIfUnmodifiedSince(c.Request().Context(), id, since)
+	switch {
+	case errors.Is(err, repository.ErrModified):
+		return respondError(c, http.StatusPreconditionFailed, codeBlogModified, "Blog was modified after "+since.Format(http.TimeFormat))
+	case errors.Is(err, repository.ErrNotFound):
+		return respondError(c, http.StatusNotFound, codeBlogNotFound, "Cannot delete blog with id: "+id.String())
+	case err != nil:
+		log.WithField("ID", id).Errorf("srvBlog.DeleteIfUnmodifiedSince - %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Failed to delete blog")
 	}
-	return c.JSON(http.StatusNotFound, "Cannot delete blog with id: "+id)
+	return c.JSON(http.StatusOK, "Successfully deleted blog: "+id.String())
+}
+
+// ifUnmodifiedSince returns the date of the If-Unmodified-Since header and whether the request has a valid one
+func ifUnmodifiedSince(c echo.Context) (time.Time, bool) {
+	header := c.Request().Header.Get("If-Unmodified-Since")
+	if header == "" {
+		return time.Time{}, false
+	}
+	since, err := http.ParseTime(header)
+	if err != nil {
+		log.WithField("If-Unmodified-Since", header).Errorf("http.ParseTime error: %v", err)
+		return time.Time{}, false
+	}
+	return since, true
 }
 
 // DeleteBlogsByUserID processes the DELETE request to delete all blogs by ID of user
@@ -146,273 +443,1282 @@ func (h *Handler) DeleteBlogsByUserID(c echo.Context) error {
 	err := h.validate.VarCtx(c.Request().Context(), id, "required,uuid")
 	if err != nil {
 		log.Errorf("validate.VarCtx error: %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, "Failed to validate id")
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to validate id")
 	}
 	uuidID, err := uuid.Parse(id)
 	if err != nil {
 		log.Errorf("uuid.Parse error: %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, "Failed to parse id")
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to parse id")
 	}
 	userID, ok := c.Get("id").(uuid.UUID)
 	if !ok {
-		return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found in context")
+		return respondError(c, http.StatusUnauthorized, codeUnauthorized, "User ID not found in context")
 	}
 	if userID != uuidID {
 		isAdmin, ok := c.Get("isAdmin").(bool)
 		if !ok || !isAdmin {
-			return c.JSON(http.StatusForbidden, "You need the admin role to delete someone else's blog")
+			return respondError(c, http.StatusForbidden, codeAdminRequired, "You need the admin role to delete someone else's blog")
 		}
 	}
 	err = h.srvBlog.DeleteBlogsByUserID(c.Request().Context(), userID)
 	if err != nil {
 		log.WithField("ID", userID).Errorf("srvBlog.DeleteBlogsByUserID - %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, "Failed to delete blogs")
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Failed to delete blogs")
 	}
 	return c.JSON(http.StatusOK, "Blogs has been successfully deleted from user id: "+userID.String())
 }
 
+// DeleteMany processes the POST request to soft-delete the blogs of a JSON array of ids and responds with the number deleted.
+// Admins may delete any blog, other users only their own, ids of missing blogs or blogs of other authors are skipped.
+func (h *Handler) DeleteMany(c echo.Context) error {
+	ids, err := decodeBulk[uuid.UUID](c.Request().Body, h.bulkMaxItems)
+	if err != nil {
+		return respondBulkError(c, err)
+	}
+	ownerID := uuid.Nil
+	if isAdmin, ok := c.Get("isAdmin").(bool); !ok || !isAdmin {
+		userID, ok := c.Get("id").(uuid.UUID)
+		if !ok {
+			return respondError(c, http.StatusUnauthorized, codeUnauthorized, "User ID not found in context")
+		}
+		ownerID = userID
+	}
+	deleted, err := h.srvBlog.DeleteMany(c.Request().Context(), ids, ownerID)
+	if err != nil {
+		log.WithField("Count", len(ids)).Errorf("srvBlog.DeleteMany - %v", err)
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to delete blogs")
+	}
+	return c.JSON(http.StatusOK, echo.Map{"deleted": deleted})
+}
+
+// BlogsExist processes the POST request to check which blogs of a JSON array of ids exist, so clients can reconcile
+// their copies without a GET per blog. It responds with a map of every id to whether it exists, unpublished blogs of
+// other authors are reported as missing the same way GET /blog/:id hides them.
+func (h *Handler) BlogsExist(c echo.Context) error {
+	ids, err := decodeBulk[uuid.UUID](c.Request().Body, h.bulkMaxItems)
+	if err != nil {
+		return respondBulkError(c, err)
+	}
+	viewerID := uuid.Nil
+	if isAdmin, ok := c.Get("isAdmin").(bool); !ok || !isAdmin {
+		userID, ok := c.Get("id").(uuid.UUID)
+		if !ok {
+			return respondError(c, http.StatusUnauthorized, codeUnauthorized, "User ID not found in context")
+		}
+		viewerID = userID
+	}
+	exists, err := h.srvBlog.Exists(c.Request().Context(), ids, viewerID)
+	if err != nil {
+		log.WithField("Count", len(ids)).Errorf("srvBlog.Exists - %v", err)
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to check blogs")
+	}
+	return c.JSON(http.StatusOK, exists)
+}
+
 // Update processes the PUT request to update an existing blog
 func (h *Handler) Update(c echo.Context) error {
 	var updBlog model.Blog
 	err := c.Bind(&updBlog)
 	if err != nil {
 		log.Errorf("c.Bind error: %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, "Filling blog error")
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Filling blog error")
 	}
 	err = h.validate.StructCtx(c.Request().Context(), updBlog)
 	if err != nil {
 		log.Errorf("validate.StructCtx error: %v", err)
-		return c.JSON(http.StatusBadRequest, "Not valid data")
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Not valid data")
 	}
 	isAdmin, ok := c.Get("isAdmin").(bool)
 	if ok && isAdmin {
-		err = h.srvBlog.Update(c.Request().Context(), &updBlog)
+		err = h.srvBlog.Update(c.Request().Context(), &updBlog, true)
 		if err != nil {
+			if mediaErr := new(service.MediaError); errors.As(err, &mediaErr) {
+				return disallowedMediaResponse(c, mediaErr)
+			}
 			log.WithFields(log.Fields{
 				"Title":   updBlog.Title,
 				"Content": updBlog.Content,
 			}).Errorf("srvBlog.Update - %v", err)
-			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update blog")
+			return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to update blog")
 		}
+		markEditable(c, &updBlog)
 		return c.JSON(http.StatusOK, updBlog)
 	}
 	userID, ok := c.Get("id").(uuid.UUID)
 	if !ok {
-		return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found in context")
+		return respondError(c, http.StatusUnauthorized, codeUnauthorized, "User ID not found in context")
 	}
-	blogs, err := h.srvBlog.GetByUserID(c.Request().Context(), userID)
+	owner, err := h.srvBlog.IsBlogOwner(c.Request().Context(), updBlog.BlogID, userID)
 	if err != nil {
-		log.Errorf("srvBlog.GetByUserID - %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, "Failed to get blogs by user id")
-	}
-	for _, blog := range blogs {
-		if updBlog.BlogID == blog.BlogID {
-			err = h.srvBlog.Update(c.Request().Context(), &updBlog)
-			if err != nil {
-				log.WithFields(log.Fields{
-					"Title":   updBlog.Title,
-					"Content": updBlog.Content,
-				}).Errorf("srvBlog.Update - %v", err)
-				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update blog")
-			}
-			return c.JSON(http.StatusOK, updBlog)
-		}
-	}
-	return c.JSON(http.StatusNotFound, "Cannot update blog with id: "+updBlog.BlogID.String())
-}
-
-// GetAll processes the GET request to retrieve all blogs
-func (h *Handler) GetAll(c echo.Context) error {
-	limit, err := strconv.Atoi(c.QueryParam("limit"))
-	if err != nil || limit < 1 {
-		limit = 10
+		log.WithField("ID", updBlog.BlogID).Errorf("srvBlog.IsBlogOwner - %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Failed to get blogs by user id")
 	}
-
-	offset, err := strconv.Atoi(c.QueryParam("offset"))
-	if err != nil || offset < 0 {
-		offset = 0
+	if !owner {
+		return respondError(c, http.StatusNotFound, codeBlogNotFound, "Cannot update blog with id: "+updBlog.BlogID.String())
 	}
-
-	resp, err := h.srvBlog.GetAll(c.Request().Context(), limit, offset)
+	err = h.srvBlog.Update(c.Request().Context(), &updBlog, false)
 	if err != nil {
-		log.Errorf("srvBlog.GetAll - %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, "Failed to get all blogs")
+		if mediaErr := new(service.MediaError); errors.As(err, &mediaErr) {
+			return disallowedMediaResponse(c, mediaErr)
+		}
+		log.WithFields(log.Fields{
+			"Title":   updBlog.Title,
+			"Content": updBlog.Content,
+		}).Errorf("srvBlog.Update - %v", err)
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to update blog")
 	}
+	markEditable(c, &updBlog)
+	return c.JSON(http.StatusOK, updBlog)
+}
 
-	return c.JSON(http.StatusOK, resp)
+// disallowedMediaResponse answers 400 with the disallowed media references of a rejected blog as field-level errors
+func disallowedMediaResponse(c echo.Context, mediaErr *service.MediaError) error {
+	return customMiddleware.WriteError(c, http.StatusBadRequest, model.ErrorResponse{Error: model.ErrorBody{
+		Code:      codeDisallowedMedia,
+		Message:   "Blog content references disallowed media",
+		RequestID: customMiddleware.GetRequestID(c),
+		Errors:    mediaErr.Issues,
+	}})
 }
 
-// GetByUserID processes the GET request to retrieve all blogs of a certain user
-func (h *Handler) GetByUserID(c echo.Context) error {
+// Publish processes the POST request to publish a draft blog.
+// When moderation is on, blogs of non-admins are submitted for review and 202 is returned.
+func (h *Handler) Publish(c echo.Context) error {
 	id := c.Param("id")
 	err := h.validate.VarCtx(c.Request().Context(), id, "required,uuid")
 	if err != nil {
 		log.Errorf("validate.VarCtx error: %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, "Failed to validate id")
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to validate id")
 	}
 	uuidID, err := uuid.Parse(id)
 	if err != nil {
 		log.Errorf("uuid.Parse error: %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, "Failed to parse id")
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to parse id")
+	}
+	isAdmin, ok := c.Get("isAdmin").(bool)
+	if !ok || !isAdmin {
+		userID, ok := c.Get("id").(uuid.UUID)
+		if !ok {
+			return respondError(c, http.StatusUnauthorized, codeUnauthorized, "User ID not found in context")
+		}
+		owner, err := h.srvBlog.IsBlogOwner(c.Request().Context(), uuidID, userID)
+		if err != nil {
+			log.WithField("ID", uuidID).Errorf("srvBlog.IsBlogOwner - %v", err)
+			return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Failed to get blogs by user id")
+		}
+		if !owner {
+			return respondError(c, http.StatusNotFound, codeBlogNotFound, "Cannot publish blog with id: "+id)
+		}
 	}
-	blogs, err := h.srvBlog.GetByUserID(c.Request().Context(), uuidID)
+	status, err := h.srvBlog.Publish(c.Request().Context(), uuidID, ok && isAdmin)
 	if err != nil {
-		log.Errorf("srvBlog.GetByUserID - %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, "Failed to get blogs by user id")
+		if errors.Is(err, repository.ErrNotFound) {
+			return respondError(c, http.StatusNotFound, codeBlogNotFound, "Cannot publish blog with id: "+id)
+		}
+		log.WithField("ID", uuidID).Errorf("srvBlog.Publish - %v", err)
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to publish blog")
 	}
-	return c.JSON(http.StatusOK, blogs)
-}
-
-// InputData is a struct for binding login and password
-type InputData struct {
-	Username string `json:"username" form:"username"`
-	Password string `json:"password" form:"password"`
+	if status == model.BlogStatusPendingReview {
+		return c.JSON(http.StatusAccepted, "Blog submitted for review: "+id)
+	}
+	return c.JSON(http.StatusOK, "Successfully published blog: "+id)
 }
 
-// SignUpUser processes the POST request to create a new user
-func (h *Handler) SignUpUser(c echo.Context) error {
-	requestData := &InputData{}
-	err := c.Bind(requestData)
-	if err != nil {
-		log.Errorf("c.Bind error: %v", err)
-		return c.JSON(http.StatusBadRequest, "SignUpUser: Invalid request payload")
+// Restore processes the POST request of an admin to bring back a soft-deleted blog
+func (h *Handler) Restore(c echo.Context) error {
+	isAdmin, ok := c.Get("isAdmin").(bool)
+	if !ok || !isAdmin {
+		return respondError(c, http.StatusForbidden, codeAdminRequired, "You need the admin role to restore blogs")
 	}
-	newUser := &model.User{
-		ID:       uuid.New(),
-		Username: requestData.Username,
-		Password: []byte(requestData.Password),
-		Admin:    false,
+	id := c.Param("id")
+	err := h.validate.VarCtx(c.Request().Context(), id, "required,uuid")
+	if err != nil {
+		log.Errorf("validate.VarCtx error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to validate id")
 	}
-	err = h.validate.StructCtx(c.Request().Context(), newUser)
+	uuidID, err := uuid.Parse(id)
 	if err != nil {
-		log.Errorf("validate.StructCtx error: %v", err)
-		return c.JSON(http.StatusBadRequest, "Not valid data")
+		log.Errorf("uuid.Parse error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to parse id")
 	}
-	err = h.srvUser.SignUp(c.Request().Context(), newUser)
+	err = h.srvBlog.Restore(c.Request().Context(), uuidID)
 	if err != nil {
-		log.WithFields(log.Fields{
-			"Username": newUser.Username,
-			"Password": newUser.Password,
-		}).Errorf("srvUser.SignUp - %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to sign up user")
+		if errors.Is(err, repository.ErrNotFound) {
+			return respondError(c, http.StatusNotFound, codeBlogNotFound, "Cannot find deleted blog with id: "+id)
+		}
+		log.WithField("ID", uuidID).Errorf("srvBlog.Restore - %v", err)
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to restore blog")
 	}
-	return c.JSON(http.StatusCreated, "User created")
+	return c.JSON(http.StatusOK, "Successfully restored blog: "+id)
 }
 
-// SignUpAdmin processes the POST request to create a new admin
-func (h *Handler) SignUpAdmin(c echo.Context) error {
+// HardDelete processes the DELETE request of an admin to permanently remove a blog
+func (h *Handler) HardDelete(c echo.Context) error {
 	isAdmin, ok := c.Get("isAdmin").(bool)
 	if !ok || !isAdmin {
-		return echo.NewHTTPError(http.StatusForbidden, "Admin role not found in context")
+		return respondError(c, http.StatusForbidden, codeAdminRequired, "You need the admin role to permanently delete blogs")
 	}
-	requestData := &InputData{}
-	err := c.Bind(requestData)
+	id := c.Param("id")
+	err := h.validate.VarCtx(c.Request().Context(), id, "required,uuid")
 	if err != nil {
-		log.Errorf("c.Bind error: %v", err)
-		return c.JSON(http.StatusBadRequest, "SignUpUser: Invalid request payload")
-	}
-	newAdmin := &model.User{
-		ID:       uuid.New(),
-		Username: requestData.Username,
-		Password: []byte(requestData.Password),
-		Admin:    true,
+		log.Errorf("validate.VarCtx error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to validate id")
 	}
-	err = h.validate.StructCtx(c.Request().Context(), newAdmin)
+	uuidID, err := uuid.Parse(id)
 	if err != nil {
-		log.Errorf("validate.StructCtx error: %v", err)
-		return c.JSON(http.StatusBadRequest, "Not valid data")
+		log.Errorf("uuid.Parse error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to parse id")
 	}
-	err = h.srvUser.SignUp(c.Request().Context(), newAdmin)
+	err = h.srvBlog.HardDelete(c.Request().Context(), uuidID)
 	if err != nil {
-		log.WithFields(log.Fields{
-			"Username": newAdmin.Username,
-			"Password": newAdmin.Password,
-		}).Errorf("srvUser.SignUpAdmin - %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to sign up admin")
+		if errors.Is(err, repository.ErrNotFound) {
+			return respondError(c, http.StatusNotFound, codeBlogNotFound, "Cannot find blog with id: "+id)
+		}
+		log.WithField("ID", uuidID).Errorf("srvBlog.HardDelete - %v", err)
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to delete blog")
 	}
-	return c.JSON(http.StatusCreated, "Admin created")
+	return c.JSON(http.StatusOK, "Permanently deleted blog: "+id)
 }
 
-// Login processes the POST request to return a token pair based on the user's login fields
-func (h *Handler) Login(c echo.Context) error {
-	requestData := &InputData{}
-	err := c.Bind(requestData)
-	if err != nil {
-		log.Errorf("c.Bind error: %v", err)
-		return c.JSON(http.StatusBadRequest, "SignUpUser: Invalid request payload")
-	}
-	loginedUser := &model.User{
-		Username: requestData.Username,
-		Password: []byte(requestData.Password),
-	}
-	err = h.validate.StructCtx(c.Request().Context(), loginedUser)
-	if err != nil {
-		log.Errorf("validate.StructCtx error: %v", err)
-		return c.JSON(http.StatusBadRequest, "Not valid data")
+// Approve processes the POST request of a moderator to publish a blog waiting for review
+func (h *Handler) Approve(c echo.Context) error {
+	isAdmin, ok := c.Get("isAdmin").(bool)
+	if !ok || !isAdmin {
+		return respondError(c, http.StatusForbidden, codeAdminRequired, "You need the admin role to moderate blogs")
 	}
-	tokenPair, err := h.srvUser.Login(c.Request().Context(), loginedUser)
+	id := c.Param("id")
+	err := h.validate.VarCtx(c.Request().Context(), id, "required,uuid")
 	if err != nil {
-		log.WithFields(log.Fields{
-			"Username": loginedUser.Username,
-			"Password": loginedUser.Password,
-		}).Errorf("srvUser.Login - %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to log in")
+		log.Errorf("validate.VarCtx error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to validate id")
 	}
-	return c.JSON(http.StatusCreated, echo.Map{
-		"Access Token : ":  tokenPair.AccessToken,
-		"Refresh Token : ": tokenPair.RefreshToken,
-	})
-}
-
-// Refresh processes POST request to create new tokens by old tokens
-func (h *Handler) Refresh(c echo.Context) error {
-	bindInfo := struct {
-		AccessToken  string `json:"accesstoken"`
-		RefreshToken string `json:"refreshtoken"`
-	}{}
-	err := c.Bind(&bindInfo)
+	uuidID, err := uuid.Parse(id)
 	if err != nil {
-		log.Errorf("c.Bind error: %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, "Failed to bind tokens")
+		log.Errorf("uuid.Parse error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to parse id")
 	}
-	var tokenPair service.TokenPair
-	tokenPair.AccessToken = bindInfo.AccessToken
-	tokenPair.RefreshToken = bindInfo.RefreshToken
-	tokenPair, err = h.srvUser.Refresh(c.Request().Context(), tokenPair)
+	err = h.srvBlog.Approve(c.Request().Context(), uuidID)
 	if err != nil {
-		log.WithFields(log.Fields{
-			"AccessToken":  tokenPair.AccessToken,
-			"RefreshToken": tokenPair.RefreshToken,
-		}).Errorf("srvUser.Refresh - %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, "Failed to refresh tokens")
+		if errors.Is(err, repository.ErrNotFound) {
+			return respondError(c, http.StatusNotFound, codeBlogNotFound, "Cannot find blog waiting for review with id: "+id)
+		}
+		log.WithField("ID", uuidID).Errorf("srvBlog.Approve - %v", err)
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to approve blog")
 	}
-	return c.JSON(http.StatusOK, echo.Map{
-		"Access Token : ":  tokenPair.AccessToken,
-		"Refresh Token : ": tokenPair.RefreshToken,
-	})
+	return c.JSON(http.StatusOK, "Successfully approved blog: "+id)
 }
 
-// DeleteUserByID processes DELETE request to remove user by its ID
-func (h *Handler) DeleteUserByID(c echo.Context) error {
+// Reject processes the POST request of a moderator to refuse a blog waiting for review with a reason
+func (h *Handler) Reject(c echo.Context) error {
 	isAdmin, ok := c.Get("isAdmin").(bool)
 	if !ok || !isAdmin {
-		return echo.NewHTTPError(http.StatusForbidden, "You need the admin role to delete user")
+		return respondError(c, http.StatusForbidden, codeAdminRequired, "You need the admin role to moderate blogs")
 	}
 	id := c.Param("id")
 	err := h.validate.VarCtx(c.Request().Context(), id, "required,uuid")
 	if err != nil {
 		log.Errorf("validate.VarCtx error: %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, "Failed to validate id")
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to validate id")
 	}
 	uuidID, err := uuid.Parse(id)
 	if err != nil {
 		log.Errorf("uuid.Parse error: %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, "Failed to parse id")
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to parse id")
 	}
-	err = h.srvUser.DeleteUserByID(c.Request().Context(), uuidID)
+	bindInfo := struct {
+		Reason string `json:"reason"`
+	}{}
+	err = c.Bind(&bindInfo)
 	if err != nil {
-		log.WithField("ID", uuidID).Errorf("srvBlog.DeleteUserByID - %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, "Failed to delete user")
+		log.Errorf("c.Bind error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Reject: Invalid request payload")
 	}
-	return c.JSON(http.StatusOK, "User has been successfully deleted: "+uuidID.String())
+	err = h.validate.VarCtx(c.Request().Context(), bindInfo.Reason, "required,max=1000")
+	if err != nil {
+		log.Errorf("validate.VarCtx error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Not valid data")
+	}
+	err = h.srvBlog.Reject(c.Request().Context(), uuidID, bindInfo.Reason)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return respondError(c, http.StatusNotFound, codeBlogNotFound, "Cannot find blog waiting for review with id: "+id)
+		}
+		log.WithField("ID", uuidID).Errorf("srvBlog.Reject - %v", err)
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to reject blog")
+	}
+	return c.JSON(http.StatusOK, "Successfully rejected blog: "+id)
+}
+
+// GetPendingReview processes the GET request of a moderator to retrieve the blogs waiting for review
+func (h *Handler) GetPendingReview(c echo.Context) error {
+	isAdmin, ok := c.Get("isAdmin").(bool)
+	if !ok || !isAdmin {
+		return respondError(c, http.StatusForbidden, codeAdminRequired, "You need the admin role to moderate blogs")
+	}
+	limit, err := strconv.Atoi(c.QueryParam("limit"))
+	if err != nil || limit < 1 {
+		limit = 10
+	}
+
+	offset, err := strconv.Atoi(c.QueryParam("offset"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	blogs, err := h.srvBlog.GetPendingReview(c.Request().Context(), limit, offset)
+	if err != nil {
+		log.Errorf("srvBlog.GetPendingReview - %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Failed to get blogs waiting for review")
+	}
+	markEditable(c, blogs...)
+	return c.JSON(http.StatusOK, blogs)
+}
+
+// canSeeUnpublished reports whether the caller is the owner of the unpublished blogs or an admin
+func canSeeUnpublished(c echo.Context, ownerID uuid.UUID) bool {
+	if isAdmin, ok := c.Get("isAdmin").(bool); ok && isAdmin {
+		return true
+	}
+	userID, ok := c.Get("id").(uuid.UUID)
+	return ok && userID == ownerID
+}
+
+// markEditable sets CanEdit on the blogs the caller may edit and delete, that is their own blogs or any blog for an admin.
+// CanEdit stays false for unauthenticated callers.
+func markEditable(c echo.Context, blogs ...*model.Blog) {
+	for _, blog := range blogs {
+		if blog != nil {
+			blog.CanEdit = canSeeUnpublished(c, blog.UserID)
+		}
+	}
+}
+
+// GetAll processes the GET request to retrieve all blogs
+func (h *Handler) GetAll(c echo.Context) error {
+	limit, err := strconv.Atoi(c.QueryParam("limit"))
+	if err != nil || limit < 1 {
+		limit = constants.BlogsDefaultLimit
+	}
+
+	offset, err := strconv.Atoi(c.QueryParam("offset"))
+	if err != nil {
+		offset = 0
+	}
+	if offset < 0 {
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Offset must not be negative")
+	}
+
+	asCSV := acceptsCSV(c.Request().Header.Get(echo.HeaderAccept))
+	maxLimit := constants.BlogsMaxLimit
+	if asCSV {
+		maxLimit = constants.CSVMaxRows
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	filter, err := parseBlogFilter(c)
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, err.Error())
+	}
+	if c.QueryParam("stream") == "true" {
+		return h.streamAll(c, filter)
+	}
+
+	resp, err := h.srvBlog.GetAll(c.Request().Context(), filter, limit, offset)
+	if err != nil {
+		log.Errorf("srvBlog.GetAll - %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Failed to get all blogs")
+	}
+
+	if asCSV {
+		return writeBlogsCSV(c, resp)
+	}
+	markEditable(c, resp.Blogs...)
+	return c.JSON(http.StatusOK, resp)
+}
+
+// Count processes the GET request to retrieve the number of published blogs, or of the blogs of one user with ?userid=.
+// Drafts of the user are counted only for the user themselves and admins, like in GetByUserID.
+func (h *Handler) Count(c echo.Context) error {
+	id := c.QueryParam("userid")
+	if id == "" {
+		resp, err := h.srvBlog.Count(c.Request().Context())
+		if err != nil {
+			log.Errorf("srvBlog.Count - %v", err)
+			return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to count blogs")
+		}
+		return c.JSON(http.StatusOK, resp)
+	}
+	uuidID, err := uuid.Parse(id)
+	if err != nil {
+		log.Errorf("uuid.Parse error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to parse userid")
+	}
+	publishedOnly := !h.publicDraftCounts && !canSeeUnpublished(c, uuidID)
+	resp, err := h.srvBlog.CountByUserID(c.Request().Context(), uuidID, publishedOnly)
+	if err != nil {
+		log.WithField("UserID", uuidID).Errorf("srvBlog.CountByUserID - %v", err)
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to count blogs")
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// streamAll writes every blog passing the filter as a single JSON array, encoding each row as it is read and flushing
+// every constants.StreamFlushRows blogs, so the list is never held in memory. There is no paging and no count.
+// The status is sent with the first blog, so an error after it can not change the status: the array is then left
+// unterminated, making the body invalid JSON rather than a list that looks complete.
+func (h *Handler) streamAll(c echo.Context, filter model.BlogFilter) error {
+	res := c.Response()
+	enc := json.NewEncoder(res)
+	written := 0
+	err := h.srvBlog.StreamAll(c.Request().Context(), filter, func(blog *model.Blog) error {
+		separator := ","
+		if written == 0 {
+			res.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+			res.WriteHeader(http.StatusOK)
+			separator = "["
+		}
+		if _, err := io.WriteString(res, separator); err != nil {
+			return err
+		}
+		markEditable(c, blog)
+		if err := enc.Encode(blog); err != nil {
+			return err
+		}
+		written++
+		if written%constants.StreamFlushRows == 0 {
+			res.Flush()
+		}
+		return nil
+	})
+	switch {
+	case err != nil && written == 0:
+		log.Errorf("srvBlog.StreamAll - %v", err)
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to get all blogs")
+	case err != nil:
+		log.WithField("Written", written).Errorf("srvBlog.StreamAll - %v", err)
+		return nil
+	case written == 0:
+		return c.JSON(http.StatusOK, []*model.Blog{})
+	}
+	_, err = io.WriteString(res, "]")
+	return err
+}
+
+// parseBlogFilter reads the optional userid, from and to query params of GetAll, the error is the message of the 400
+func parseBlogFilter(c echo.Context) (model.BlogFilter, error) {
+	var filter model.BlogFilter
+	if id := c.QueryParam("userid"); id != "" {
+		userID, err := uuid.Parse(id)
+		if err != nil {
+			return filter, errors.New("Failed to parse userid")
+		}
+		filter.UserID = &userID
+	}
+	var err error
+	if filter.From, err = parseTimeParam(c, "from"); err != nil {
+		return filter, err
+	}
+	if filter.To, err = parseTimeParam(c, "to"); err != nil {
+		return filter, err
+	}
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		return filter, errors.New("from must not be after to")
+	}
+	filter.Sort, err = parseBlogSort(c)
+	return filter, err
+}
+
+// blogSortDefaultDesc is the registry of the fields blog lists may be sorted by, with whether a field is sorted
+// descending when no order is given: the latest blogs first, titles alphabetically
+var blogSortDefaultDesc = map[string]bool{
+	model.SortFieldReleaseTime: true,
+	model.SortFieldTitle:       false,
+}
+
+// parseBlogSort parses the optional sort and order query params, nil keeps the configured order of the list.
+// Without an order the field is sorted in its default direction, an order alone sorts by release time.
+func parseBlogSort(c echo.Context) (*model.BlogSort, error) {
+	field, order := c.QueryParam("sort"), c.QueryParam("order")
+	if field == "" && order == "" {
+		return nil, nil
+	}
+	if field == "" {
+		field = model.SortFieldReleaseTime
+	}
+	desc, ok := blogSortDefaultDesc[field]
+	if !ok {
+		return nil, errors.New("sort must be releasetime or title")
+	}
+	switch order {
+	case "":
+	case "asc":
+		desc = false
+	case "desc":
+		desc = true
+	default:
+		return nil, errors.New("order must be asc or desc")
+	}
+	return &model.BlogSort{Field: field, Desc: desc}, nil
+}
+
+// parseTimeParam parses an optional RFC3339 query param, returning nil when it is absent
+func parseTimeParam(c echo.Context, name string) (*time.Time, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse %s, expected an RFC3339 date", name)
+	}
+	return &t, nil
+}
+
+// GetPopular processes the GET request to retrieve the most viewed blogs
+func (h *Handler) GetPopular(c echo.Context) error {
+	limit, err := strconv.Atoi(c.QueryParam("limit"))
+	if err != nil || limit < 1 {
+		limit = 10
+	}
+	if limit > constants.PopularMaxLimit {
+		limit = constants.PopularMaxLimit
+	}
+
+	blogs, err := h.srvBlog.GetPopular(c.Request().Context(), limit)
+	if err != nil {
+		log.Errorf("srvBlog.GetPopular - %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Failed to get popular blogs")
+	}
+
+	markEditable(c, blogs...)
+	return c.JSON(http.StatusOK, blogs)
+}
+
+// GetTopTags processes the GET request to retrieve the tags a user uses most across their published blogs
+func (h *Handler) GetTopTags(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		log.Errorf("uuid.Parse error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to parse id")
+	}
+	limit, err := strconv.Atoi(c.QueryParam("limit"))
+	if err != nil || limit < 1 {
+		limit = constants.TopTagsDefaultLimit
+	}
+	if limit > constants.TopTagsMaxLimit {
+		limit = constants.TopTagsMaxLimit
+	}
+
+	tags, err := h.srvBlog.GetTopTags(c.Request().Context(), id, limit)
+	if err != nil {
+		log.WithField("UserID", id).Errorf("srvBlog.GetTopTags - %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Failed to get top tags")
+	}
+	return c.JSON(http.StatusOK, tags)
+}
+
+// GetRecent processes the GET request to retrieve the blogs recently viewed by the current user
+func (h *Handler) GetRecent(c echo.Context) error {
+	userID, ok := c.Get("id").(uuid.UUID)
+	if !ok {
+		return respondError(c, http.StatusUnauthorized, codeUnauthorized, "User ID not found in context")
+	}
+	blogs, err := h.srvBlog.GetRecentViews(c.Request().Context(), userID)
+	if err != nil {
+		log.WithField("UserID", userID).Errorf("srvBlog.GetRecentViews - %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Failed to get recently viewed blogs")
+	}
+	markEditable(c, blogs...)
+	return c.JSON(http.StatusOK, blogs)
+}
+
+// GetActivity processes the GET request to retrieve a page of the activity log of the current user, newest first
+func (h *Handler) GetActivity(c echo.Context) error {
+	userID, ok := c.Get("id").(uuid.UUID)
+	if !ok {
+		return respondError(c, http.StatusUnauthorized, codeUnauthorized, "User ID not found in context")
+	}
+	limit, err := strconv.Atoi(c.QueryParam("limit"))
+	if err != nil || limit < 1 {
+		limit = constants.ActivityDefaultLimit
+	}
+	if limit > constants.ActivityMaxLimit {
+		limit = constants.ActivityMaxLimit
+	}
+	offset, err := strconv.Atoi(c.QueryParam("offset"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	resp, err := h.srvUser.GetActivity(c.Request().Context(), userID, limit, offset)
+	if err != nil {
+		log.WithField("UserID", userID).Errorf("srvUser.GetActivity - %v", err)
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to get activity")
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// WhoAmI processes the GET request to return the decoded claims of the bearer token, the signature is never exposed
+func (h *Handler) WhoAmI(c echo.Context) error {
+	claims, ok := c.Get("claims").(jwt.MapClaims)
+	if !ok {
+		return respondError(c, http.StatusUnauthorized, codeUnauthorized, "Token claims not found in context")
+	}
+	id, _ := c.Get("id").(uuid.UUID)
+	isAdmin, _ := c.Get("isAdmin").(bool)
+	resp := model.TokenClaims{ID: id, IsAdmin: isAdmin}
+	if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+		resp.ExpiresAt = exp.UTC()
+	}
+	if iat, err := claims.GetIssuedAt(); err == nil && iat != nil {
+		issuedAt := iat.UTC()
+		resp.IssuedAt = &issuedAt
+	}
+	resp.Issuer, _ = claims.GetIssuer()
+	if adminID, ok := c.Get("impersonatedBy").(uuid.UUID); ok {
+		resp.ImpersonatedBy = &adminID
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetByUserID processes the GET request to retrieve a page of blogs of a certain user,
+// drafts and blogs under review are only listed for the author and admins
+func (h *Handler) GetByUserID(c echo.Context) error {
+	id := c.Param("id")
+	err := h.validate.VarCtx(c.Request().Context(), id, "required,uuid")
+	if err != nil {
+		log.Errorf("validate.VarCtx error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to validate id")
+	}
+	uuidID, err := uuid.Parse(id)
+	if err != nil {
+		log.Errorf("uuid.Parse error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to parse id")
+	}
+	limit, err := strconv.Atoi(c.QueryParam("limit"))
+	if err != nil || limit < 1 {
+		limit = 10
+	}
+	offset, err := strconv.Atoi(c.QueryParam("offset"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	resp, err := h.srvBlog.GetByUserID(c.Request().Context(), uuidID, !canSeeUnpublished(c, uuidID), limit, offset)
+	if err != nil {
+		log.Errorf("srvBlog.GetByUserID - %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Failed to get blogs by user id")
+	}
+	markEditable(c, resp.Blogs...)
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetUserBlogs processes the GET request to retrieve a page of the published blogs of a user, the public
+// counterpart of GetByUserID that lists no drafts whoever asks
+func (h *Handler) GetUserBlogs(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		log.Errorf("uuid.Parse error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to parse id")
+	}
+	if _, err := h.srvUser.GetProfile(c.Request().Context(), id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return respondError(c, http.StatusNotFound, codeUserNotFound, "Cannot find user with id: "+id.String())
+		}
+		log.WithField("UserID", id).Errorf("srvUser.GetProfile - %v", err)
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to get user")
+	}
+	limit, err := strconv.Atoi(c.QueryParam("limit"))
+	if err != nil || limit < 1 {
+		limit = 10
+	}
+	offset, err := strconv.Atoi(c.QueryParam("offset"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	resp, err := h.srvBlog.GetByUserID(c.Request().Context(), id, true, limit, offset)
+	if err != nil {
+		log.WithField("UserID", id).Errorf("srvBlog.GetByUserID - %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Failed to get blogs by user id")
+	}
+	markEditable(c, resp.Blogs...)
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetByTag processes the GET request to retrieve blogs with a certain tag
+func (h *Handler) GetByTag(c echo.Context) error {
+	tag := c.Param("tag")
+	err := h.validate.VarCtx(c.Request().Context(), tag, "required,max=50")
+	if err != nil {
+		log.Errorf("validate.VarCtx error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Failed to validate tag")
+	}
+
+	limit, err := strconv.Atoi(c.QueryParam("limit"))
+	if err != nil || limit < 1 {
+		limit = 10
+	}
+
+	offset, err := strconv.Atoi(c.QueryParam("offset"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	resp, err := h.srvBlog.GetByTag(c.Request().Context(), tag, limit, offset)
+	if err != nil {
+		log.WithField("Tag", tag).Errorf("srvBlog.GetByTag - %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Failed to get blogs by tag")
+	}
+
+	markEditable(c, resp.Blogs...)
+	return c.JSON(http.StatusOK, resp)
+}
+
+// InputData is a struct for binding login and password
+type InputData struct {
+	Username string `json:"username" form:"username"`
+	Email    string `json:"email" form:"email"`
+	Password string `json:"password" form:"password"`
+	// Invite is the invite code needed to sign up while signups are invite-only
+	Invite string `json:"invite" form:"invite"`
+}
+
+// SignUpUser processes the POST request to create a new user
+func (h *Handler) SignUpUser(c echo.Context) error {
+	requestData := &InputData{}
+	err := c.Bind(requestData)
+	if err != nil {
+		log.Errorf("c.Bind error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "SignUpUser: Invalid request payload")
+	}
+	newUser := &model.User{
+		ID:       uuid.New(),
+		Username: requestData.Username,
+		Email:    requestData.Email,
+		Password: []byte(requestData.Password),
+		Admin:    false,
+	}
+	err = h.validate.StructCtx(c.Request().Context(), newUser)
+	if err != nil {
+		log.Errorf("validate.StructCtx error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Not valid data")
+	}
+	err = h.srvUser.SignUpUser(c.Request().Context(), newUser, requestData.Invite)
+	if err != nil {
+		log.WithField("Username", newUser.Username).Errorf("srvUser.SignUpUser - %v", err)
+		return signUpError(c, err, "Failed to sign up user")
+	}
+	return c.JSON(http.StatusCreated, "User created")
+}
+
+// signUpError maps an error of srvUser.SignUp to the response, failed is the message of unexpected errors
+func signUpError(c echo.Context, err error, failed string) error {
+	switch {
+	case errors.Is(err, repository.ErrExist):
+		return respondError(c, http.StatusConflict, codeUsernameTaken, "Username or email is already taken")
+	case errors.Is(err, repository.ErrNil):
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "User data is missing")
+	case errors.Is(err, service.ErrSignupDisabled):
+		return respondError(c, http.StatusForbidden, codeSignupDisabled, "Signups are disabled")
+	case errors.Is(err, service.ErrInvalidInvite):
+		return respondError(c, http.StatusForbidden, codeInvalidInvite, "A valid unused invite code is required to sign up")
+	default:
+		return respondError(c, http.StatusInternalServerError, codeInternal, failed)
+	}
+}
+
+// SignUpAdmin processes the POST request to create a new admin
+func (h *Handler) SignUpAdmin(c echo.Context) error {
+	isAdmin, ok := c.Get("isAdmin").(bool)
+	if !ok || !isAdmin {
+		return respondError(c, http.StatusForbidden, codeAdminRequired, "Admin role not found in context")
+	}
+	requestData := &InputData{}
+	err := c.Bind(requestData)
+	if err != nil {
+		log.Errorf("c.Bind error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "SignUpUser: Invalid request payload")
+	}
+	newAdmin := &model.User{
+		ID:       uuid.New(),
+		Username: requestData.Username,
+		Email:    requestData.Email,
+		Password: []byte(requestData.Password),
+		Admin:    true,
+	}
+	err = h.validate.StructCtx(c.Request().Context(), newAdmin)
+	if err != nil {
+		log.Errorf("validate.StructCtx error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Not valid data")
+	}
+	err = h.srvUser.SignUp(c.Request().Context(), newAdmin)
+	if err != nil {
+		log.WithField("Username", newAdmin.Username).Errorf("srvUser.SignUpAdmin - %v", err)
+		return signUpError(c, err, "Failed to sign up admin")
+	}
+	return c.JSON(http.StatusCreated, "Admin created")
+}
+
+// CreateInvites processes the POST request of an admin to generate single-use invite codes,
+// the optional count in the body defaults to one and is capped like bulk endpoints
+func (h *Handler) CreateInvites(c echo.Context) error {
+	isAdmin, ok := c.Get("isAdmin").(bool)
+	if !ok || !isAdmin {
+		return respondError(c, http.StatusForbidden, codeAdminRequired, "You need the admin role to create invites")
+	}
+	adminID, ok := c.Get("id").(uuid.UUID)
+	if !ok {
+		return respondError(c, http.StatusUnauthorized, codeUnauthorized, "User ID not found in context")
+	}
+	requestData := struct {
+		Count int `json:"count"`
+	}{Count: 1}
+	if c.Request().ContentLength != 0 {
+		if err := c.Bind(&requestData); err != nil {
+			return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Request body must be a JSON object")
+		}
+	}
+	if requestData.Count < 1 || requestData.Count > h.bulkMaxItems {
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "count must be between 1 and "+strconv.Itoa(h.bulkMaxItems))
+	}
+	codes, err := h.srvUser.CreateInvites(c.Request().Context(), adminID, requestData.Count)
+	if err != nil {
+		log.WithField("AdminID", adminID).Errorf("srvUser.CreateInvites - %v", err)
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to create invites")
+	}
+	return c.JSON(http.StatusCreated, echo.Map{"codes": codes})
+}
+
+// Login processes the POST request to return a token pair based on the user's login fields
+func (h *Handler) Login(c echo.Context) error {
+	requestData := &InputData{}
+	err := c.Bind(requestData)
+	if err != nil {
+		log.Errorf("c.Bind error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "SignUpUser: Invalid request payload")
+	}
+	loginedUser := &model.User{
+		Username: requestData.Username,
+		Password: []byte(requestData.Password),
+	}
+	// the username field accepts either a username or an email
+	err = h.validate.VarCtx(c.Request().Context(), loginedUser.Username, "required,max=254")
+	if err != nil {
+		log.Errorf("validate.VarCtx error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Not valid data")
+	}
+	err = h.validate.VarCtx(c.Request().Context(), loginedUser.Password, "required,min=4,max=15")
+	if err != nil {
+		log.Errorf("validate.VarCtx error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Not valid data")
+	}
+	tokenPair, err := h.srvUser.Login(c.Request().Context(), loginedUser)
+	if err != nil {
+		log.WithField("Username", loginedUser.Username).Errorf("srvUser.Login - %v", err)
+		if errors.Is(err, service.ErrAccountLocked) {
+			return respondError(c, http.StatusLocked, codeAccountLocked, "Account is temporarily locked, try again later")
+		}
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to log in")
+	}
+	if h.refreshCookieMaxAge > 0 {
+		h.setRefreshCookie(c, tokenPair.RefreshToken)
+		return c.JSON(http.StatusCreated, echo.Map{
+			"Access Token : ": tokenPair.AccessToken,
+		})
+	}
+	return c.JSON(http.StatusCreated, echo.Map{
+		"Access Token : ":  tokenPair.AccessToken,
+		"Refresh Token : ": tokenPair.RefreshToken,
+	})
+}
+
+// Refresh processes POST request to create new tokens by old tokens.
+// The refresh token is read from the refresh token cookie when the body has none, the new one is then sent in the cookie.
+func (h *Handler) Refresh(c echo.Context) error {
+	bindInfo := struct {
+		AccessToken  string `json:"accesstoken"`
+		RefreshToken string `json:"refreshtoken"`
+	}{}
+	err := c.Bind(&bindInfo)
+	if err != nil {
+		log.Errorf("c.Bind error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Failed to bind tokens")
+	}
+	var tokenPair service.TokenPair
+	tokenPair.AccessToken = bindInfo.AccessToken
+	tokenPair.RefreshToken = bindInfo.RefreshToken
+	fromCookie := false
+	if tokenPair.RefreshToken == "" {
+		tokenPair.RefreshToken, fromCookie = refreshCookie(c)
+	}
+	tokenPair, err = h.srvUser.Refresh(c.Request().Context(), tokenPair)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"AccessToken":  tokenPair.AccessToken,
+			"RefreshToken": tokenPair.RefreshToken,
+		}).Errorf("srvUser.Refresh - %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRefreshToken, "Failed to refresh tokens")
+	}
+	if fromCookie {
+		h.setRefreshCookie(c, tokenPair.RefreshToken)
+		return c.JSON(http.StatusOK, echo.Map{
+			"Access Token : ": tokenPair.AccessToken,
+		})
+	}
+	return c.JSON(http.StatusOK, echo.Map{
+		"Access Token : ":  tokenPair.AccessToken,
+		"Refresh Token : ": tokenPair.RefreshToken,
+	})
+}
+
+// Logout processes POST request to revoke the refresh token of the current device, taken from the body
+// or else from the refresh token cookie, which is then cleared
+func (h *Handler) Logout(c echo.Context) error {
+	userID, ok := c.Get("id").(uuid.UUID)
+	if !ok {
+		return respondError(c, http.StatusUnauthorized, codeUnauthorized, "User ID not found in context")
+	}
+	bindInfo := struct {
+		RefreshToken string `json:"refreshtoken"`
+	}{}
+	err := c.Bind(&bindInfo)
+	if err != nil {
+		log.Errorf("c.Bind error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Failed to bind refresh token")
+	}
+	fromCookie := false
+	if bindInfo.RefreshToken == "" {
+		bindInfo.RefreshToken, fromCookie = refreshCookie(c)
+	}
+	err = h.srvUser.Logout(c.Request().Context(), userID, bindInfo.RefreshToken)
+	if err != nil {
+		log.WithField("ID", userID).Errorf("srvUser.Logout - %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRefreshToken, "Failed to log out")
+	}
+	if fromCookie {
+		clearRefreshCookie(c)
+	}
+	return c.JSON(http.StatusOK, "Successfully logged out")
+}
+
+// LogoutAll processes POST request to revoke refresh tokens of the user on every device
+func (h *Handler) LogoutAll(c echo.Context) error {
+	userID, ok := c.Get("id").(uuid.UUID)
+	if !ok {
+		return respondError(c, http.StatusUnauthorized, codeUnauthorized, "User ID not found in context")
+	}
+	err := h.srvUser.LogoutAll(c.Request().Context(), userID)
+	if err != nil {
+		log.WithField("ID", userID).Errorf("srvUser.LogoutAll - %v", err)
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to log out from all devices")
+	}
+	return c.JSON(http.StatusOK, "Successfully logged out from all devices")
+}
+
+// ChangePassword processes POST request to change the password of the current user
+func (h *Handler) ChangePassword(c echo.Context) error {
+	userID, ok := c.Get("id").(uuid.UUID)
+	if !ok {
+		return respondError(c, http.StatusUnauthorized, codeUnauthorized, "User ID not found in context")
+	}
+	if _, impersonated := c.Get("impersonatedBy").(uuid.UUID); impersonated {
+		return respondError(c, http.StatusForbidden, codeForbidden, "Impersonation tokens cannot change the password")
+	}
+	bindInfo := struct {
+		OldPassword string `json:"oldPassword"`
+		NewPassword string `json:"newPassword"`
+	}{}
+	err := c.Bind(&bindInfo)
+	if err != nil {
+		log.Errorf("c.Bind error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "ChangePassword: Invalid request payload")
+	}
+	err = h.validate.VarCtx(c.Request().Context(), bindInfo.OldPassword, "required")
+	if err != nil {
+		log.Errorf("validate.VarCtx error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Not valid data")
+	}
+	err = h.validate.VarCtx(c.Request().Context(), bindInfo.NewPassword, "required,min=4,max=15")
+	if err != nil {
+		log.Errorf("validate.VarCtx error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Not valid data")
+	}
+	err = h.srvUser.ChangePassword(c.Request().Context(), userID, []byte(bindInfo.OldPassword), []byte(bindInfo.NewPassword))
+	if err != nil {
+		log.WithField("ID", userID).Errorf("srvUser.ChangePassword - %v", err)
+		if errors.Is(err, service.ErrWrongPassword) {
+			return respondError(c, http.StatusForbidden, codeWrongPassword, "Old password is incorrect")
+		}
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to change password")
+	}
+	return c.JSON(http.StatusOK, "Successfully changed password")
+}
+
+// RequestPasswordReset processes POST request to email a password reset token.
+// It responds the same way whether the email is registered or not.
+func (h *Handler) RequestPasswordReset(c echo.Context) error {
+	bindInfo := struct {
+		Email string `json:"email"`
+	}{}
+	err := c.Bind(&bindInfo)
+	if err != nil {
+		log.Errorf("c.Bind error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "RequestPasswordReset: Invalid request payload")
+	}
+	err = h.validate.VarCtx(c.Request().Context(), bindInfo.Email, "required,email,max=254")
+	if err != nil {
+		log.Errorf("validate.VarCtx error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Not valid data")
+	}
+	err = h.srvUser.RequestPasswordReset(c.Request().Context(), bindInfo.Email)
+	if errors.Is(err, service.ErrPasswordResetDisabled) {
+		return respondError(c, http.StatusServiceUnavailable, codeNotConfigured, "Password reset is not configured")
+	}
+	if err != nil {
+		log.Errorf("srvUser.RequestPasswordReset - %v", err)
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to request password reset")
+	}
+	return c.JSON(http.StatusOK, "If the email is registered, a password reset token has been sent to it")
+}
+
+// ConfirmPasswordReset processes POST request to set a new password with a password reset token
+func (h *Handler) ConfirmPasswordReset(c echo.Context) error {
+	bindInfo := struct {
+		Token       string `json:"token"`
+		NewPassword string `json:"newPassword"`
+	}{}
+	err := c.Bind(&bindInfo)
+	if err != nil {
+		log.Errorf("c.Bind error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "ConfirmPasswordReset: Invalid request payload")
+	}
+	err = h.validate.VarCtx(c.Request().Context(), bindInfo.Token, "required")
+	if err != nil {
+		log.Errorf("validate.VarCtx error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Not valid data")
+	}
+	err = h.validate.VarCtx(c.Request().Context(), bindInfo.NewPassword, "required,min=4,max=15")
+	if err != nil {
+		log.Errorf("validate.VarCtx error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Not valid data")
+	}
+	err = h.srvUser.ConfirmPasswordReset(c.Request().Context(), bindInfo.Token, []byte(bindInfo.NewPassword))
+	if err != nil {
+		log.Errorf("srvUser.ConfirmPasswordReset - %v", err)
+		if errors.Is(err, service.ErrInvalidResetToken) || errors.Is(err, service.ErrResetTokenUsed) {
+			return respondError(c, http.StatusBadRequest, codeInvalidResetToken, "Password reset token is invalid, expired or already used")
+		}
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to reset password")
+	}
+	return c.JSON(http.StatusOK, "Successfully reset password")
+}
+
+// GetDiagnostics processes the GET request of an admin to report the database state and the app build info
+func (h *Handler) GetDiagnostics(c echo.Context) error {
+	isAdmin, ok := c.Get("isAdmin").(bool)
+	if !ok || !isAdmin {
+		return respondError(c, http.StatusForbidden, codeAdminRequired, "You need the admin role to see diagnostics")
+	}
+	if h.srvDiag == nil {
+		return respondError(c, http.StatusServiceUnavailable, codeNotConfigured, "Diagnostics are not configured")
+	}
+	diagnostics, err := h.srvDiag.Diagnostics(c.Request().Context())
+	if err != nil {
+		log.Errorf("srvDiag.Diagnostics - %v", err)
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to get diagnostics")
+	}
+	return c.JSON(http.StatusOK, diagnostics)
+}
+
+// GetMe processes the GET request to return the profile of the current user
+func (h *Handler) GetMe(c echo.Context) error {
+	userID, ok := c.Get("id").(uuid.UUID)
+	if !ok {
+		return respondError(c, http.StatusUnauthorized, codeUnauthorized, "User ID not found in context")
+	}
+	user, err := h.srvUser.GetProfile(c.Request().Context(), userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return respondError(c, http.StatusNotFound, codeUserNotFound, "User no longer exists")
+		}
+		log.WithField("UserID", userID).Errorf("srvUser.GetProfile - %v", err)
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to get profile")
+	}
+	return c.JSON(http.StatusOK, user)
+}
+
+// GetProfiles processes the POST request to fetch the public profiles of a list of user ids, unknown ids are omitted
+func (h *Handler) GetProfiles(c echo.Context) error {
+	ids, err := decodeBulk[uuid.UUID](c.Request().Body, h.bulkMaxItems)
+	if err != nil {
+		return respondBulkError(c, err)
+	}
+	profiles, err := h.srvUser.GetProfiles(c.Request().Context(), ids)
+	if err != nil {
+		log.Errorf("srvUser.GetProfiles - %v", err)
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to get user profiles")
+	}
+	return c.JSON(http.StatusOK, profiles)
+}
+
+// SetRole processes the PUT request of an admin to promote another user to admin or demote them
+func (h *Handler) SetRole(c echo.Context) error {
+	isAdmin, ok := c.Get("isAdmin").(bool)
+	if !ok || !isAdmin {
+		return respondError(c, http.StatusForbidden, codeAdminRequired, "You need the admin role to change user roles")
+	}
+	id := c.Param("id")
+	err := h.validate.VarCtx(c.Request().Context(), id, "required,uuid")
+	if err != nil {
+		log.Errorf("validate.VarCtx error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to validate id")
+	}
+	uuidID, err := uuid.Parse(id)
+	if err != nil {
+		log.Errorf("uuid.Parse error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to parse id")
+	}
+	var requestData struct {
+		Admin *bool `json:"admin"`
+	}
+	if err := c.Bind(&requestData); err != nil || requestData.Admin == nil {
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Request body must set admin to true or false")
+	}
+	err = h.srvUser.SetRole(c.Request().Context(), uuidID, *requestData.Admin)
+	if err != nil {
+		if errors.Is(err, service.ErrLastAdmin) {
+			return respondError(c, http.StatusConflict, codeLastAdmin, "Cannot demote the last admin")
+		}
+		if errors.Is(err, repository.ErrNotFound) {
+			return respondError(c, http.StatusNotFound, codeUserNotFound, "Cannot find user with id: "+id)
+		}
+		log.WithField("ID", uuidID).Errorf("srvUser.SetRole - %v", err)
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to change user role")
+	}
+	return c.JSON(http.StatusOK, "Successfully changed role of user: "+id)
+}
+
+// RevokeTokens processes the POST request of an admin to end every session of a user, the action is audited
+func (h *Handler) RevokeTokens(c echo.Context) error {
+	isAdmin, ok := c.Get("isAdmin").(bool)
+	if !ok || !isAdmin {
+		return respondError(c, http.StatusForbidden, codeAdminRequired, "You need the admin role to revoke tokens")
+	}
+	adminID, ok := c.Get("id").(uuid.UUID)
+	if !ok {
+		return respondError(c, http.StatusUnauthorized, codeUnauthorized, "User ID not found in context")
+	}
+	id := c.Param("id")
+	err := h.validate.VarCtx(c.Request().Context(), id, "required,uuid")
+	if err != nil {
+		log.Errorf("validate.VarCtx error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to validate id")
+	}
+	uuidID, err := uuid.Parse(id)
+	if err != nil {
+		log.Errorf("uuid.Parse error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to parse id")
+	}
+	err = h.srvUser.RevokeTokens(c.Request().Context(), adminID, uuidID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return respondError(c, http.StatusNotFound, codeUserNotFound, "Cannot find user with id: "+id)
+		}
+		log.WithFields(log.Fields{"ID": uuidID, "AdminID": adminID}).Errorf("srvUser.RevokeTokens - %v", err)
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to revoke tokens")
+	}
+	return c.JSON(http.StatusOK, "Successfully revoked the tokens of user: "+id)
+}
+
+// Impersonate processes the POST request of an admin to get a short-lived access token acting as another user,
+// the impersonation is recorded in the audit log
+func (h *Handler) Impersonate(c echo.Context) error {
+	isAdmin, ok := c.Get("isAdmin").(bool)
+	if !ok || !isAdmin {
+		return respondError(c, http.StatusForbidden, codeAdminRequired, "You need the admin role to impersonate users")
+	}
+	adminID, ok := c.Get("id").(uuid.UUID)
+	if !ok {
+		return respondError(c, http.StatusUnauthorized, codeUnauthorized, "User ID not found in context")
+	}
+	id := c.Param("id")
+	err := h.validate.VarCtx(c.Request().Context(), id, "required,uuid")
+	if err != nil {
+		log.Errorf("validate.VarCtx error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to validate id")
+	}
+	uuidID, err := uuid.Parse(id)
+	if err != nil {
+		log.Errorf("uuid.Parse error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to parse id")
+	}
+	token, err := h.srvUser.Impersonate(c.Request().Context(), adminID, uuidID)
+	if err != nil {
+		if errors.Is(err, service.ErrImpersonateAdmin) {
+			return respondError(c, http.StatusForbidden, codeForbidden, "Admins cannot be impersonated")
+		}
+		if errors.Is(err, repository.ErrNotFound) {
+			return respondError(c, http.StatusNotFound, codeUserNotFound, "Cannot find user with id: "+id)
+		}
+		log.WithFields(log.Fields{"ID": uuidID, "AdminID": adminID}).Errorf("srvUser.Impersonate - %v", err)
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to impersonate user")
+	}
+	return c.JSON(http.StatusOK, echo.Map{
+		"Access Token : ": token,
+	})
+}
+
+// DeleteUserByID processes DELETE request to remove user by its ID
+func (h *Handler) DeleteUserByID(c echo.Context) error {
+	isAdmin, ok := c.Get("isAdmin").(bool)
+	if !ok || !isAdmin {
+		return respondError(c, http.StatusForbidden, codeAdminRequired, "You need the admin role to delete user")
+	}
+	id := c.Param("id")
+	err := h.validate.VarCtx(c.Request().Context(), id, "required,uuid")
+	if err != nil {
+		log.Errorf("validate.VarCtx error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to validate id")
+	}
+	uuidID, err := uuid.Parse(id)
+	if err != nil {
+		log.Errorf("uuid.Parse error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidID, "Failed to parse id")
+	}
+	err = h.srvUser.DeleteUserByID(c.Request().Context(), uuidID)
+	if err != nil {
+		if errors.Is(err, repository.ErrAdminUser) {
+			return respondError(c, http.StatusForbidden, codeForbidden, "Admins cannot be deleted")
+		}
+		if errors.Is(err, repository.ErrNotFound) {
+			return respondError(c, http.StatusNotFound, codeUserNotFound, "Cannot find user with id: "+id)
+		}
+		log.WithField("ID", uuidID).Errorf("srvUser.DeleteUserByID - %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Failed to delete user")
+	}
+	return c.JSON(http.StatusOK, "User has been successfully deleted: "+uuidID.String())
+}
+
+// DeleteMe processes the DELETE request of a user to delete their own account together with their blogs.
+// Admins cannot delete their account, another admin has to demote them first.
+func (h *Handler) DeleteMe(c echo.Context) error {
+	userID, ok := c.Get("id").(uuid.UUID)
+	if !ok {
+		return respondError(c, http.StatusUnauthorized, codeUnauthorized, "User ID not found in context")
+	}
+	if _, impersonated := c.Get("impersonatedBy").(uuid.UUID); impersonated {
+		return respondError(c, http.StatusForbidden, codeForbidden, "Impersonation tokens cannot delete the account")
+	}
+	err := h.srvUser.DeleteUserByID(c.Request().Context(), userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrAdminUser) {
+			return respondError(c, http.StatusForbidden, codeForbidden, "Admins cannot delete their account")
+		}
+		if errors.Is(err, repository.ErrNotFound) {
+			return respondError(c, http.StatusNotFound, codeUserNotFound, "User no longer exists")
+		}
+		log.WithField("UserID", userID).Errorf("srvUser.DeleteUserByID - %v", err)
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to delete account")
+	}
+	return c.JSON(http.StatusOK, "Your account has been successfully deleted")
 }
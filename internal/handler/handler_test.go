@@ -2,25 +2,47 @@ package handler
 
 import (
 	"bytes"
+	"context"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/artnikel/blogapi/internal/config"
+	"github.com/artnikel/blogapi/internal/constants"
 	"github.com/artnikel/blogapi/internal/handler/mocks"
+	customMiddleware "github.com/artnikel/blogapi/internal/middleware"
 	"github.com/artnikel/blogapi/internal/model"
+	"github.com/artnikel/blogapi/internal/repository"
 	"github.com/artnikel/blogapi/internal/service"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/go-playground/validator.v9"
 )
 
+// requireErrorResponse asserts that rec holds the error envelope with the status and the code and returns its body
+func requireErrorResponse(t *testing.T, rec *httptest.ResponseRecorder, status int, code string) model.ErrorBody {
+	t.Helper()
+	require.Equal(t, status, rec.Code)
+	var resp model.ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, code, resp.Error.Code)
+	return resp.Error
+}
+
 func Test_Create(t *testing.T) {
 	mockService := new(mocks.MockBlogService)
 	validate := validator.New()
-	h := NewHandler(mockService, nil, validate)
+	h := NewHandler(mockService, nil, nil, validate)
 
 	blogInput := model.Blog{
 		BlogID:  uuid.New(),
@@ -41,7 +63,7 @@ func Test_Create(t *testing.T) {
 
 	mockService.On("Create", mock.Anything, mock.MatchedBy(func(b *model.Blog) bool {
 		return b.Title == blogInput.Title && b.Content == blogInput.Content && b.UserID == userID && b.BlogID != uuid.Nil
-	})).Return(nil)
+	}), false).Return(nil)
 
 	err = h.Create(c)
 	require.NoError(t, err)
@@ -57,19 +79,149 @@ func Test_Create(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func Test_Create_Duplicate(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	h := NewHandler(mockService, nil, nil, validator.New())
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/blog", strings.NewReader(`{"title":"testtitle","content":"testcontent"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("id", uuid.New())
+
+	mockService.On("Create", mock.Anything, mock.AnythingOfType("*model.Blog"), false).
+		Return(fmt.Errorf("blogRps.Create - %w", repository.ErrExist))
+
+	err := h.Create(c)
+	require.NoError(t, err)
+	requireErrorResponse(t, rec, http.StatusConflict, codeBlogExists)
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_ErrorEnvelope(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	h := NewHandler(mockService, nil, nil, validator.New())
+	missing := uuid.New()
+	mockService.On("Get", mock.Anything, missing).Return(nil, fmt.Errorf("blogRps.Get - %w", repository.ErrNotFound))
+
+	e := echo.New()
+	t.Run("validation failure", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/blog", strings.NewReader(`{"title":"","content":"testcontent"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("id", uuid.New())
+
+		require.NoError(t, h.Create(c))
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		require.Equal(t, echo.MIMEApplicationJSONCharsetUTF8, rec.Header().Get(echo.HeaderContentType))
+		require.JSONEq(t, `{"error":{"code":"invalid_request","message":"Not valid data"}}`, rec.Body.String())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/blog/"+missing.String(), http.NoBody)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(missing.String())
+
+		require.NoError(t, h.Get(c))
+		require.Equal(t, http.StatusNotFound, rec.Code)
+		require.JSONEq(t, fmt.Sprintf(`{"error":{"code":"blog_not_found","message":"Cannot find blog with id: %s"}}`, missing),
+			rec.Body.String())
+	})
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_Create_IdempotencyKey(t *testing.T) {
+	original := &model.Blog{BlogID: uuid.New(), Title: "testtitle", Content: "testcontent", Status: model.BlogStatusDraft}
+	testCases := []struct {
+		name     string
+		blog     *model.Blog
+		replayed bool
+		err      error
+		status   int
+	}{
+		{"first request", original, false, nil, http.StatusCreated},
+		{"replay", original, true, nil, http.StatusOK},
+		{"different body", nil, false, service.ErrIdempotencyKeyReused, http.StatusConflict},
+		{"first still running", nil, false, service.ErrIdempotencyKeyPending, http.StatusConflict},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.MockBlogService)
+			h := NewHandler(mockService, nil, nil, validator.New())
+			mockService.On("CreateIdempotent", mock.Anything, mock.AnythingOfType("*model.Blog"), "retry-1", false).Return(tc.blog, tc.replayed, tc.err)
+
+			bodyBytes, err := json.Marshal(map[string]string{"title": "testtitle", "content": "testcontent"})
+			require.NoError(t, err)
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/blog", bytes.NewReader(bodyBytes))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set(HeaderIdempotencyKey, "retry-1")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("id", uuid.New())
+
+			err = h.Create(c)
+			require.NoError(t, err)
+			require.Equal(t, tc.status, rec.Code)
+			if tc.blog != nil {
+				var respBlog model.Blog
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &respBlog))
+				require.Equal(t, original.BlogID, respBlog.BlogID)
+			}
+
+			mockService.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_Create_OverLengthFields(t *testing.T) {
+	for name, blogInput := range map[string]map[string]string{
+		"title":   {"title": strings.Repeat("t", 201), "content": "testcontent"},
+		"content": {"title": "testtitle", "content": strings.Repeat("c", 50001)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockService := new(mocks.MockBlogService)
+			h := NewHandler(mockService, nil, nil, validator.New())
+			bodyBytes, err := json.Marshal(blogInput)
+			require.NoError(t, err)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/blog", bytes.NewReader(bodyBytes))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("id", uuid.New())
+
+			require.NoError(t, h.Create(c))
+			require.Equal(t, http.StatusBadRequest, rec.Code)
+			mockService.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
 func Test_Get(t *testing.T) {
 	mockService := new(mocks.MockBlogService)
 	validate := validator.New()
-	h := NewHandler(mockService, nil, validate)
+	h := NewHandler(mockService, nil, nil, validate)
 
 	id := uuid.New()
 	expectedBlog := &model.Blog{
-		BlogID:  id,
-		Title:   "testtitle",
-		Content: "testcontent",
+		BlogID:    id,
+		Title:     "testtitle",
+		Content:   "testcontent",
+		Status:    model.BlogStatusPublished,
+		UpdatedAt: time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC),
 	}
 
 	mockService.On("Get", mock.Anything, id).Return(expectedBlog, nil)
+	mockService.On("IncrementViews", mock.Anything, id).Return(1, nil)
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/blog/"+id.String(), http.NoBody)
@@ -86,14 +238,121 @@ func Test_Get(t *testing.T) {
 	err = json.Unmarshal(rec.Body.Bytes(), &respBlog)
 	require.NoError(t, err)
 	require.Equal(t, expectedBlog, &respBlog)
+	require.Equal(t, "Wed, 01 May 2024 10:00:00 GMT", rec.Header().Get(echo.HeaderLastModified))
 
 	mockService.AssertExpectations(t)
 }
 
+func Test_Get_WithAuthor(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	h := NewHandler(mockService, nil, nil, validator.New())
+
+	id := uuid.New()
+	mockService.On("GetWithAuthor", mock.Anything, id).Return(&model.BlogWithAuthor{
+		Blog:           model.Blog{BlogID: id, Title: "testtitle", Status: model.BlogStatusPublished},
+		AuthorUsername: "testuser",
+	}, nil)
+	mockService.On("IncrementViews", mock.Anything, id).Return(3, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/blog/"+id.String()+"?withAuthor=true", http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(id.String())
+
+	require.NoError(t, h.Get(c))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp model.BlogWithAuthor
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, id, resp.BlogID)
+	require.Equal(t, "testuser", resp.AuthorUsername)
+	require.Equal(t, 3, resp.Views)
+
+	mockService.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	mockService.AssertExpectations(t)
+}
+
+func Test_Get_StatusCodes(t *testing.T) {
+	id := uuid.New()
+	testCases := []struct {
+		name   string
+		param  string
+		err    error
+		status int
+	}{
+		{"malformed id", "not-a-uuid", nil, http.StatusBadRequest},
+		{"not found", id.String(), fmt.Errorf("blogRps.Get - %w", repository.ErrNotFound), http.StatusNotFound},
+		{"internal error", id.String(), errors.New("connection refused"), http.StatusInternalServerError},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.MockBlogService)
+			h := NewHandler(mockService, nil, nil, validator.New())
+			if tc.err != nil {
+				mockService.On("Get", mock.Anything, id).Return(nil, tc.err)
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/blog/"+tc.param, http.NoBody)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.param)
+
+			err := h.Get(c)
+			require.NoError(t, err)
+			require.Equal(t, tc.status, rec.Code)
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_GetBySlug(t *testing.T) {
+	testCases := []struct {
+		name   string
+		blog   *model.Blog
+		err    error
+		status int
+	}{
+		{"published", &model.Blog{BlogID: uuid.New(), Slug: "hello-world", Status: model.BlogStatusPublished}, nil, http.StatusOK},
+		{"draft of another user", &model.Blog{BlogID: uuid.New(), UserID: uuid.New(), Slug: "hello-world", Status: model.BlogStatusDraft}, nil, http.StatusNotFound},
+		{"not found", nil, fmt.Errorf("blogRps.GetBySlug - %w", repository.ErrNotFound), http.StatusNotFound},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.MockBlogService)
+			h := NewHandler(mockService, nil, nil, validator.New())
+			mockService.On("GetBySlug", mock.Anything, "hello-world").Return(tc.blog, tc.err)
+			if tc.status == http.StatusOK {
+				mockService.On("IncrementViews", mock.Anything, tc.blog.BlogID).Return(1, nil)
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/blogs/slug/hello-world", http.NoBody)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("slug")
+			c.SetParamValues("hello-world")
+
+			require.NoError(t, h.GetBySlug(c))
+			require.Equal(t, tc.status, rec.Code)
+			if tc.status == http.StatusOK {
+				var respBlog model.Blog
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &respBlog))
+				require.Equal(t, "hello-world", respBlog.Slug)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
 func Test_Delete_AsAdmin(t *testing.T) {
 	mockService := new(mocks.MockBlogService)
 	validate := validator.New()
-	h := NewHandler(mockService, nil, validate)
+	h := NewHandler(mockService, nil, nil, validate)
 
 	id := uuid.New()
 
@@ -111,24 +370,42 @@ func Test_Delete_AsAdmin(t *testing.T) {
 	require.Equal(t, http.StatusOK, rec.Code)
 	require.Contains(t, rec.Body.String(), "Successfully deleted blog: "+id.String())
 
+	mockService.AssertExpectations(t)
+	mockService.AssertNotCalled(t, "IsBlogOwner", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_Delete_AlreadyDeleted(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	validate := validator.New()
+	h := NewHandler(mockService, nil, nil, validate)
+
+	id := uuid.New()
+
+	mockService.On("Delete", mock.Anything, id).Return(fmt.Errorf("blogRps.Delete - %w", repository.ErrNotFound))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/blog/"+id.String(), http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(id.String())
+	c.Set("isAdmin", true)
+	err := h.Delete(c)
+	require.NoError(t, err)
+	requireErrorResponse(t, rec, http.StatusNotFound, codeBlogNotFound)
+
 	mockService.AssertExpectations(t)
 }
 
 func Test_Delete_AsUserOwnBlog(t *testing.T) {
 	mockService := new(mocks.MockBlogService)
 	validate := validator.New()
-	h := NewHandler(mockService, nil, validate)
+	h := NewHandler(mockService, nil, nil, validate)
 
 	userID := uuid.New()
 	blogID := uuid.New()
 
-	blogs := []*model.Blog{
-		{
-			BlogID: blogID,
-		},
-	}
-
-	mockService.On("GetByUserID", mock.Anything, userID).Return(blogs, nil)
+	mockService.On("IsBlogOwner", mock.Anything, blogID, userID).Return(true, nil)
 	mockService.On("Delete", mock.Anything, blogID).Return(nil)
 
 	e := echo.New()
@@ -147,17 +424,90 @@ func Test_Delete_AsUserOwnBlog(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func Test_Delete_IfUnmodifiedSince(t *testing.T) {
+	userID := uuid.New()
+	since := time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		name    string
+		header  string
+		setup   func(mockService *mocks.MockBlogService, blogID uuid.UUID)
+		status  int
+		message string
+	}{
+		{
+			name:   "unmodified",
+			header: since.Format(http.TimeFormat),
+			setup: func(mockService *mocks.MockBlogService, blogID uuid.UUID) {
+				mockService.On("DeleteIfUnmodifiedSince", mock.Anything, blogID, since).Return(nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "modified after the date",
+			header: since.Format(http.TimeFormat),
+			setup: func(mockService *mocks.MockBlogService, blogID uuid.UUID) {
+				mockService.On("DeleteIfUnmodifiedSince", mock.Anything, blogID, since).
+					Return(fmt.Errorf("blogRps.DeleteIfUnmodifiedSince - %w", repository.ErrModified))
+			},
+			status:  http.StatusPreconditionFailed,
+			message: "Blog was modified after Wed, 01 May 2024 10:00:00 GMT",
+		},
+		{
+			name:   "missing",
+			header: since.Format(http.TimeFormat),
+			setup: func(mockService *mocks.MockBlogService, blogID uuid.UUID) {
+				mockService.On("DeleteIfUnmodifiedSince", mock.Anything, blogID, since).
+					Return(fmt.Errorf("blogRps.DeleteIfUnmodifiedSince - %w", repository.ErrNotFound))
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "invalid date is ignored",
+			header: "yesterday",
+			setup: func(mockService *mocks.MockBlogService, blogID uuid.UUID) {
+				mockService.On("Delete", mock.Anything, blogID).Return(nil)
+			},
+			status: http.StatusOK,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.MockBlogService)
+			h := NewHandler(mockService, nil, nil, validator.New())
+			blogID := uuid.New()
+			mockService.On("IsBlogOwner", mock.Anything, blogID, userID).Return(true, nil)
+			tc.setup(mockService, blogID)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodDelete, "/blog/"+blogID.String(), http.NoBody)
+			req.Header.Set("If-Unmodified-Since", tc.header)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(blogID.String())
+			c.Set("id", userID)
+
+			err := h.Delete(c)
+			require.NoError(t, err)
+			if tc.status == http.StatusPreconditionFailed {
+				body := requireErrorResponse(t, rec, tc.status, codeBlogModified)
+				require.Equal(t, tc.message, body.Message)
+			} else {
+				require.Equal(t, tc.status, rec.Code)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
 func Test_Delete_NotOwner(t *testing.T) {
 	mockService := new(mocks.MockBlogService)
 	validate := validator.New()
-	h := NewHandler(mockService, nil, validate)
+	h := NewHandler(mockService, nil, nil, validate)
 
 	userID := uuid.New()
 	blogID := uuid.New()
 
-	blogs := []*model.Blog{}
-
-	mockService.On("GetByUserID", mock.Anything, userID).Return(blogs, nil)
+	mockService.On("IsBlogOwner", mock.Anything, blogID, userID).Return(false, nil)
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodDelete, "/blog/"+blogID.String(), http.NoBody)
@@ -175,10 +525,35 @@ func Test_Delete_NotOwner(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func Test_Delete_OwnershipCheckFails(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	validate := validator.New()
+	h := NewHandler(mockService, nil, nil, validate)
+
+	userID := uuid.New()
+	blogID := uuid.New()
+
+	mockService.On("IsBlogOwner", mock.Anything, blogID, userID).Return(false, errors.New("connection reset"))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/blog/"+blogID.String(), http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(blogID.String())
+	c.Set("id", userID)
+
+	err := h.Delete(c)
+	require.NoError(t, err)
+	requireErrorResponse(t, rec, http.StatusBadRequest, codeInvalidRequest)
+
+	mockService.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
 func Test_DeleteBlogsByUserID_SameUser(t *testing.T) {
 	mockService := new(mocks.MockBlogService)
 	validate := validator.New()
-	h := NewHandler(mockService, nil, validate)
+	h := NewHandler(mockService, nil, nil, validate)
 
 	userID := uuid.New()
 
@@ -203,7 +578,7 @@ func Test_DeleteBlogsByUserID_SameUser(t *testing.T) {
 func Test_DeleteBlogsByUserID_Forbidden(t *testing.T) {
 	mockService := new(mocks.MockBlogService)
 	validate := validator.New()
-	h := NewHandler(mockService, nil, validate)
+	h := NewHandler(mockService, nil, nil, validate)
 
 	userID := uuid.New()
 	otherUserID := uuid.New()
@@ -228,7 +603,7 @@ func Test_DeleteBlogsByUserID_Forbidden(t *testing.T) {
 func Test_Update_AsAdmin(t *testing.T) {
 	mockService := new(mocks.MockBlogService)
 	validate := validator.New()
-	h := NewHandler(mockService, nil, validate)
+	h := NewHandler(mockService, nil, nil, validate)
 
 	updBlog := model.Blog{
 		BlogID:  uuid.New(),
@@ -239,7 +614,7 @@ func Test_Update_AsAdmin(t *testing.T) {
 	bodyBytes, err := json.Marshal(updBlog)
 	require.NoError(t, err)
 
-	mockService.On("Update", mock.Anything, &updBlog).Return(nil)
+	mockService.On("Update", mock.Anything, &updBlog, true).Return(nil)
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodPut, "/blog", bytes.NewReader(bodyBytes))
@@ -255,6 +630,7 @@ func Test_Update_AsAdmin(t *testing.T) {
 	var respBlog model.Blog
 	err = json.Unmarshal(rec.Body.Bytes(), &respBlog)
 	require.NoError(t, err)
+	updBlog.CanEdit = true
 	require.Equal(t, updBlog, respBlog)
 
 	mockService.AssertExpectations(t)
@@ -263,7 +639,7 @@ func Test_Update_AsAdmin(t *testing.T) {
 func Test_Update_AsUser_OwnBlog(t *testing.T) {
 	mockService := new(mocks.MockBlogService)
 	validate := validator.New()
-	h := NewHandler(mockService, nil, validate)
+	h := NewHandler(mockService, nil, nil, validate)
 
 	userID := uuid.New()
 	updBlog := model.Blog{
@@ -272,17 +648,11 @@ func Test_Update_AsUser_OwnBlog(t *testing.T) {
 		Content: "Updated Content",
 	}
 
-	blogs := []*model.Blog{
-		{
-			BlogID: updBlog.BlogID,
-		},
-	}
-
 	bodyBytes, err := json.Marshal(updBlog)
 	require.NoError(t, err)
 
-	mockService.On("GetByUserID", mock.Anything, userID).Return(blogs, nil)
-	mockService.On("Update", mock.Anything, &updBlog).Return(nil)
+	mockService.On("IsBlogOwner", mock.Anything, updBlog.BlogID, userID).Return(true, nil)
+	mockService.On("Update", mock.Anything, &updBlog, false).Return(nil)
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodPut, "/blog", bytes.NewReader(bodyBytes))
@@ -306,7 +676,7 @@ func Test_Update_AsUser_OwnBlog(t *testing.T) {
 func Test_Update_NotOwner(t *testing.T) {
 	mockService := new(mocks.MockBlogService)
 	validate := validator.New()
-	h := NewHandler(mockService, nil, validate)
+	h := NewHandler(mockService, nil, nil, validate)
 
 	userID := uuid.New()
 	updBlog := model.Blog{
@@ -315,12 +685,10 @@ func Test_Update_NotOwner(t *testing.T) {
 		Content: "Updated Content",
 	}
 
-	blogs := []*model.Blog{}
-
 	bodyBytes, err := json.Marshal(updBlog)
 	require.NoError(t, err)
 
-	mockService.On("GetByUserID", mock.Anything, userID).Return(blogs, nil)
+	mockService.On("IsBlogOwner", mock.Anything, updBlog.BlogID, userID).Return(false, nil)
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodPut, "/blog", bytes.NewReader(bodyBytes))
@@ -340,7 +708,7 @@ func Test_Update_NotOwner(t *testing.T) {
 func Test_GetAll(t *testing.T) {
 	mockService := new(mocks.MockBlogService)
 	validate := validator.New()
-	h := NewHandler(mockService, nil, validate)
+	h := NewHandler(mockService, nil, nil, validate)
 
 	blogs := []*model.Blog{
 		{BlogID: uuid.New(), Title: "Title1", Content: "Content1"},
@@ -352,7 +720,7 @@ func Test_GetAll(t *testing.T) {
 		Count: 2,
 	}
 
-	mockService.On("GetAll", mock.Anything, 10, 0).Return(resp, nil)
+	mockService.On("GetAll", mock.Anything, model.BlogFilter{}, 10, 0).Return(resp, nil)
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/blogs?limit=10&offset=0", http.NoBody)
@@ -374,17 +742,18 @@ func Test_GetAll(t *testing.T) {
 func Test_GetByUserID(t *testing.T) {
 	mockService := new(mocks.MockBlogService)
 	validate := validator.New()
-	h := NewHandler(mockService, nil, validate)
+	h := NewHandler(mockService, nil, nil, validate)
 
 	userID := uuid.New()
-	blogs := []*model.Blog{
-		{BlogID: uuid.New(), Title: "Title1", Content: "Content1", UserID: userID},
+	resp := &model.BlogListResponse{
+		Blogs: []*model.Blog{{BlogID: uuid.New(), Title: "Title1", Content: "Content1", UserID: userID}},
+		Count: 3,
 	}
 
-	mockService.On("GetByUserID", mock.Anything, userID).Return(blogs, nil)
+	mockService.On("GetByUserID", mock.Anything, userID, false, 1, 2).Return(resp, nil)
 
 	e := echo.New()
-	req := httptest.NewRequest(http.MethodGet, "/blogs/user/"+userID.String(), http.NoBody)
+	req := httptest.NewRequest(http.MethodGet, "/blogs/user/"+userID.String()+"?limit=1&offset=2", http.NoBody)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 	c.SetParamNames("id")
@@ -395,21 +764,80 @@ func Test_GetByUserID(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, http.StatusOK, rec.Code)
 
-	var respBlogs []*model.Blog
-	err = json.Unmarshal(rec.Body.Bytes(), &respBlogs)
+	var respBlogList model.BlogListResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &respBlogList)
 	require.NoError(t, err)
-	require.Equal(t, blogs, respBlogs)
+	require.Equal(t, resp, &respBlogList)
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_GetUserBlogs(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	mockUserService := new(mocks.MockUserService)
+	validate := validator.New()
+	h := NewHandler(mockService, mockUserService, nil, validate)
+
+	authorID := uuid.New()
+	resp := &model.BlogListResponse{
+		Blogs: []*model.Blog{{BlogID: uuid.New(), Title: "Title1", UserID: authorID, Status: model.BlogStatusPublished}},
+		Count: 3,
+	}
+
+	mockUserService.On("GetProfile", mock.Anything, authorID).Return(&model.User{ID: authorID, Username: "author"}, nil)
+	mockService.On("GetByUserID", mock.Anything, authorID, true, 1, 2).Return(resp, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/users/"+authorID.String()+"/blogs?limit=1&offset=2", http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(authorID.String())
+	c.Set("id", uuid.New())
+
+	require.NoError(t, h.GetUserBlogs(c))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var respBlogList model.BlogListResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &respBlogList))
+	require.Equal(t, resp, &respBlogList)
+	require.False(t, respBlogList.Blogs[0].CanEdit)
 
 	mockService.AssertExpectations(t)
+	mockUserService.AssertExpectations(t)
+}
+
+func Test_GetUserBlogs_NotFound(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	mockUserService := new(mocks.MockUserService)
+	validate := validator.New()
+	h := NewHandler(mockService, mockUserService, nil, validate)
+
+	missing := uuid.New()
+	mockUserService.On("GetProfile", mock.Anything, missing).Return(nil, fmt.Errorf("userRps.GetByID - %w", repository.ErrNotFound))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/users/"+missing.String()+"/blogs", http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(missing.String())
+
+	require.NoError(t, h.GetUserBlogs(c))
+	requireErrorResponse(t, rec, http.StatusNotFound, codeUserNotFound)
+
+	mockService.AssertNotCalled(t, "GetByUserID", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockUserService.AssertExpectations(t)
 }
 
 func Test_SignUpUser(t *testing.T) {
 	mockService := new(mocks.MockUserService)
 	validate := validator.New()
-	h := NewHandler(nil, mockService, validate)
+	h := NewHandler(nil, mockService, nil, validate)
 
 	inputData := InputData{
 		Username: "testuser",
+		Email:    "testuser@example.com",
 		Password: "password123",
 	}
 	bodyBytes, err := json.Marshal(inputData)
@@ -421,7 +849,7 @@ func Test_SignUpUser(t *testing.T) {
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 
-	mockService.On("SignUp", mock.Anything, mock.AnythingOfType("*model.User")).Return(nil)
+	mockService.On("SignUpUser", mock.Anything, mock.AnythingOfType("*model.User"), "").Return(nil)
 
 	err = h.SignUpUser(c)
 	require.NoError(t, err)
@@ -431,13 +859,50 @@ func Test_SignUpUser(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func Test_SignUpUser_RepositoryErrors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{"duplicate username", fmt.Errorf("rpsUser.SignUp - %w", repository.ErrExist), http.StatusConflict, codeUsernameTaken},
+		{"nil user", fmt.Errorf("rpsUser.SignUp - %w", repository.ErrNil), http.StatusBadRequest, codeInvalidRequest},
+		{"signups disabled", service.ErrSignupDisabled, http.StatusForbidden, codeSignupDisabled},
+		{"invalid invite", service.ErrInvalidInvite, http.StatusForbidden, codeInvalidInvite},
+		{"internal error", errors.New("connection refused"), http.StatusInternalServerError, codeInternal},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.MockUserService)
+			h := NewHandler(nil, mockService, nil, validator.New())
+			mockService.On("SignUpUser", mock.Anything, mock.AnythingOfType("*model.User"), "").Return(tc.err)
+
+			bodyBytes, err := json.Marshal(InputData{Username: "testuser", Email: "testuser@example.com", Password: "password123"})
+			require.NoError(t, err)
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/signup", bytes.NewReader(bodyBytes))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err = h.SignUpUser(c)
+			require.NoError(t, err)
+			requireErrorResponse(t, rec, tc.status, tc.code)
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
 func Test_SignUpAdmin(t *testing.T) {
 	mockService := new(mocks.MockUserService)
 	validate := validator.New()
-	h := NewHandler(nil, mockService, validate)
+	h := NewHandler(nil, mockService, nil, validate)
 
 	inputData := InputData{
 		Username: "adminuser",
+		Email:    "admin@example.com",
 		Password: "adminpass",
 	}
 	bodyBytes, err := json.Marshal(inputData)
@@ -463,7 +928,7 @@ func Test_SignUpAdmin(t *testing.T) {
 func Test_Login(t *testing.T) {
 	mockService := new(mocks.MockUserService)
 	validate := validator.New()
-	h := NewHandler(nil, mockService, validate)
+	h := NewHandler(nil, mockService, nil, validate)
 
 	input := &InputData{
 		Username: "testuser",
@@ -507,7 +972,7 @@ func Test_Login(t *testing.T) {
 func Test_Refresh(t *testing.T) {
 	mockService := new(mocks.MockUserService)
 	validate := validator.New()
-	h := NewHandler(nil, mockService, validate)
+	h := NewHandler(nil, mockService, nil, validate)
 
 	input := struct {
 		AccessToken  string `json:"accesstoken"`
@@ -549,7 +1014,7 @@ func Test_Refresh(t *testing.T) {
 func Test_DeleteUserByID(t *testing.T) {
 	mockService := new(mocks.MockUserService)
 	validate := validator.New()
-	h := NewHandler(nil, mockService, validate)
+	h := NewHandler(nil, mockService, nil, validate)
 
 	userID := uuid.New()
 
@@ -575,7 +1040,7 @@ func Test_DeleteUserByID(t *testing.T) {
 func Test_DeleteUserByID_Forbidden(t *testing.T) {
 	mockService := new(mocks.MockUserService)
 	validate := validator.New()
-	h := NewHandler(nil, mockService, validate)
+	h := NewHandler(nil, mockService, nil, validate)
 	userID := uuid.New()
 
 	e := echo.New()
@@ -588,7 +1053,2013 @@ func Test_DeleteUserByID_Forbidden(t *testing.T) {
 	c.Set("isAdmin", false)
 
 	err := h.DeleteUserByID(c)
-	require.Error(t, err)
+	require.NoError(t, err)
+	requireErrorResponse(t, rec, http.StatusForbidden, codeAdminRequired)
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_Login_RefreshCookie(t *testing.T) {
+	mockService := new(mocks.MockUserService)
+	h := NewHandler(nil, mockService, nil, validator.New())
+	h.SetRefreshCookie(time.Hour)
+
+	bodyBytes, err := json.Marshal(&InputData{Username: "testuser", Password: "testpassword"})
+	require.NoError(t, err)
+	mockService.On("Login", mock.Anything, mock.AnythingOfType("*model.User")).
+		Return(&service.TokenPair{AccessToken: "access-token", RefreshToken: "refresh-token"}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/login", bytes.NewReader(bodyBytes))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	require.NoError(t, h.Login(e.NewContext(req, rec)))
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	var response map[string]string
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.Equal(t, map[string]string{"Access Token : ": "access-token"}, response)
+
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1)
+	require.Equal(t, constants.RefreshTokenCookie, cookies[0].Name)
+	require.Equal(t, "refresh-token", cookies[0].Value)
+	require.Equal(t, 3600, cookies[0].MaxAge)
+	require.True(t, cookies[0].HttpOnly)
+	require.True(t, cookies[0].Secure)
+	require.Equal(t, http.SameSiteStrictMode, cookies[0].SameSite)
+	mockService.AssertExpectations(t)
+}
+
+func Test_Refresh_Cookie(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		body        string
+		wantRefresh string
+		wantCookie  bool
+	}{
+		{name: "token in cookie", body: `{"accesstoken":"oldaccesstoken"}`, wantRefresh: "cookierefreshtoken", wantCookie: true},
+		{name: "token in body", body: `{"accesstoken":"oldaccesstoken","refreshtoken":"bodyrefreshtoken"}`, wantRefresh: "bodyrefreshtoken"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.MockUserService)
+			h := NewHandler(nil, mockService, nil, validator.New())
+			h.SetRefreshCookie(time.Hour)
+			mockService.On("Refresh", mock.Anything, service.TokenPair{AccessToken: "oldaccesstoken", RefreshToken: tc.wantRefresh}).
+				Return(service.TokenPair{AccessToken: "newaccesstoken", RefreshToken: "newrefreshtoken"}, nil)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/refresh", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.AddCookie(&http.Cookie{Name: constants.RefreshTokenCookie, Value: "cookierefreshtoken"})
+			rec := httptest.NewRecorder()
+			require.NoError(t, h.Refresh(e.NewContext(req, rec)))
+			require.Equal(t, http.StatusOK, rec.Code)
+
+			var response map[string]string
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Equal(t, "newaccesstoken", response["Access Token : "])
+			cookies := rec.Result().Cookies()
+			if tc.wantCookie {
+				require.NotContains(t, response, "Refresh Token : ")
+				require.Len(t, cookies, 1)
+				require.Equal(t, "newrefreshtoken", cookies[0].Value)
+				require.True(t, cookies[0].HttpOnly)
+			} else {
+				require.Equal(t, "newrefreshtoken", response["Refresh Token : "])
+				require.Empty(t, cookies)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_Refresh_NoToken(t *testing.T) {
+	mockService := new(mocks.MockUserService)
+	h := NewHandler(nil, mockService, nil, validator.New())
+	mockService.On("Refresh", mock.Anything, service.TokenPair{AccessToken: "oldaccesstoken"}).
+		Return(service.TokenPair{}, errors.New("token is malformed"))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/refresh", strings.NewReader(`{"accesstoken":"oldaccesstoken"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	err := h.Refresh(e.NewContext(req, rec))
+	require.NoError(t, err)
+	requireErrorResponse(t, rec, http.StatusBadRequest, codeInvalidRefreshToken)
+	require.Empty(t, rec.Result().Cookies())
+}
+
+func Test_Logout_Cookie(t *testing.T) {
+	mockService := new(mocks.MockUserService)
+	h := NewHandler(nil, mockService, nil, validator.New())
+	userID := uuid.New()
+	mockService.On("Logout", mock.Anything, userID, "cookierefreshtoken").Return(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/logout", http.NoBody)
+	req.AddCookie(&http.Cookie{Name: constants.RefreshTokenCookie, Value: "cookierefreshtoken"})
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("id", userID)
+	require.NoError(t, h.Logout(c))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1)
+	require.Equal(t, constants.RefreshTokenCookie, cookies[0].Name)
+	require.Empty(t, cookies[0].Value)
+	require.Negative(t, cookies[0].MaxAge)
+	mockService.AssertExpectations(t)
+}
+
+func Test_Logout(t *testing.T) {
+	mockService := new(mocks.MockUserService)
+	validate := validator.New()
+	h := NewHandler(nil, mockService, nil, validate)
+
+	userID := uuid.New()
+	bodyBytes, err := json.Marshal(map[string]string{"refreshtoken": "devicerefreshtoken"})
+	require.NoError(t, err)
+
+	mockService.On("Logout", mock.Anything, userID, "devicerefreshtoken").Return(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/logout", bytes.NewReader(bodyBytes))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("id", userID)
+
+	err = h.Logout(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_LogoutAll(t *testing.T) {
+	mockService := new(mocks.MockUserService)
+	validate := validator.New()
+	h := NewHandler(nil, mockService, nil, validate)
+
+	userID := uuid.New()
+
+	mockService.On("LogoutAll", mock.Anything, userID).Return(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/logout/all", http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("id", userID)
+
+	err := h.LogoutAll(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
 
 	mockService.AssertExpectations(t)
 }
+
+func Test_GetByTag(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	validate := validator.New()
+	h := NewHandler(mockService, nil, nil, validate)
+
+	resp := &model.BlogListResponse{
+		Blogs: []*model.Blog{{BlogID: uuid.New(), Title: "Title1", Content: "Content1", Tags: []string{"go"}}},
+		Count: 1,
+	}
+
+	mockService.On("GetByTag", mock.Anything, "go", 10, 0).Return(resp, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/blogs/tag/go", http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("tag")
+	c.SetParamValues("go")
+
+	err := h.GetByTag(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var respBlogList model.BlogListResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &respBlogList)
+	require.NoError(t, err)
+	require.Equal(t, resp, &respBlogList)
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_GetAll_LimitAndOffset(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	validate := validator.New()
+	h := NewHandler(mockService, nil, nil, validate)
+
+	mockService.On("GetAll", mock.Anything, model.BlogFilter{}, 100, 200).Return(&model.BlogListResponse{}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/blogs?limit=500&offset=200", http.NoBody)
+	rec := httptest.NewRecorder()
+	err := h.GetAll(e.NewContext(req, rec))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/blogs?offset=-1", http.NoBody)
+	rec = httptest.NewRecorder()
+	err = h.GetAll(e.NewContext(req, rec))
+	require.NoError(t, err)
+	requireErrorResponse(t, rec, http.StatusBadRequest, codeInvalidRequest)
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_GetAll_CSV(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	validate := validator.New()
+	h := NewHandler(mockService, nil, nil, validate)
+
+	blog := &model.Blog{
+		BlogID:      uuid.New(),
+		UserID:      uuid.New(),
+		Title:       `Title, with "quotes"`,
+		Content:     "Line one,\nline two",
+		ReleaseTime: time.Date(2025, time.May, 1, 10, 0, 0, 0, time.UTC),
+		Tags:        []string{"go", "csv"},
+	}
+	resp := &model.BlogListResponse{Blogs: []*model.Blog{blog}, Count: 1}
+
+	mockService.On("GetAll", mock.Anything, model.BlogFilter{}, 1000, 0).Return(resp, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/blogs?limit=5000", http.NoBody)
+	req.Header.Set(echo.HeaderAccept, "text/csv")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := h.GetAll(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "text/csv; charset=UTF-8", rec.Header().Get(echo.HeaderContentType))
+
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, [][]string{
+		{"blogid", "userid", "title", "content", "releasetime", "tags"},
+		{blog.BlogID.String(), blog.UserID.String(), blog.Title, blog.Content, "2025-05-01T10:00:00Z", "go;csv"},
+	}, records)
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_GetAll_JSONByDefault(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	validate := validator.New()
+	h := NewHandler(mockService, nil, nil, validate)
+
+	resp := &model.BlogListResponse{Blogs: []*model.Blog{{BlogID: uuid.New(), Title: "Title, 1"}}, Count: 1}
+
+	mockService.On("GetAll", mock.Anything, model.BlogFilter{}, 20, 0).Return(resp, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/blogs", http.NoBody)
+	req.Header.Set(echo.HeaderAccept, "application/json, text/csv")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := h.GetAll(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON)
+
+	var respBlogList model.BlogListResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &respBlogList)
+	require.NoError(t, err)
+	require.Equal(t, resp, &respBlogList)
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_Publish_AsOwner(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	validate := validator.New()
+	h := NewHandler(mockService, nil, nil, validate)
+
+	userID := uuid.New()
+	blogID := uuid.New()
+
+	mockService.On("IsBlogOwner", mock.Anything, blogID, userID).Return(true, nil)
+	mockService.On("Publish", mock.Anything, blogID, false).Return(model.BlogStatusPublished, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/blog/"+blogID.String()+"/publish", http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(blogID.String())
+	c.Set("id", userID)
+
+	err := h.Publish(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "Successfully published blog: "+blogID.String())
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_Publish_NotOwner(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	validate := validator.New()
+	h := NewHandler(mockService, nil, nil, validate)
+
+	userID := uuid.New()
+	blogID := uuid.New()
+
+	mockService.On("IsBlogOwner", mock.Anything, blogID, userID).Return(false, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/blog/"+blogID.String()+"/publish", http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(blogID.String())
+	c.Set("id", userID)
+
+	err := h.Publish(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_Get_DraftHiddenFromOthers(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	validate := validator.New()
+	h := NewHandler(mockService, nil, nil, validate)
+
+	id := uuid.New()
+	ownerID := uuid.New()
+	draft := &model.Blog{BlogID: id, UserID: ownerID, Title: "testtitle", Content: "testcontent", Status: model.BlogStatusDraft}
+
+	mockService.On("Get", mock.Anything, id).Return(draft, nil)
+	mockService.On("IncrementViews", mock.Anything, id).Return(1, nil).Once()
+	mockService.On("AddRecentView", mock.Anything, ownerID, id).Return(nil).Once()
+
+	e := echo.New()
+	for _, tc := range []struct {
+		callerID uuid.UUID
+		status   int
+	}{
+		{callerID: uuid.New(), status: http.StatusNotFound},
+		{callerID: ownerID, status: http.StatusOK},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/blog/"+id.String(), http.NoBody)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(id.String())
+		c.Set("id", tc.callerID)
+
+		err := h.Get(c)
+		require.NoError(t, err)
+		require.Equal(t, tc.status, rec.Code)
+	}
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_GetByUserID_HidesDraftsFromOthers(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	validate := validator.New()
+	h := NewHandler(mockService, nil, nil, validate)
+
+	ownerID := uuid.New()
+	published := &model.Blog{BlogID: uuid.New(), UserID: ownerID, Title: "Published", Status: model.BlogStatusPublished}
+
+	mockService.On("GetByUserID", mock.Anything, ownerID, true, 10, 0).
+		Return(&model.BlogListResponse{Blogs: []*model.Blog{published}, Count: 1}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/blogs/user/"+ownerID.String(), http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(ownerID.String())
+	c.Set("id", uuid.New())
+
+	err := h.GetByUserID(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var respBlogList model.BlogListResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &respBlogList)
+	require.NoError(t, err)
+	require.Equal(t, []*model.Blog{published}, respBlogList.Blogs)
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_Get_ViewsCounterFailureDoesNotFailRead(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	validate := validator.New()
+	h := NewHandler(mockService, nil, nil, validate)
+
+	id := uuid.New()
+	expectedBlog := &model.Blog{BlogID: id, Title: "testtitle", Content: "testcontent", Status: model.BlogStatusPublished, Views: 5}
+
+	mockService.On("Get", mock.Anything, id).Return(expectedBlog, nil)
+	mockService.On("IncrementViews", mock.Anything, id).Return(0, errors.New("counter failed"))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/blog/"+id.String(), http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(id.String())
+
+	err := h.Get(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var respBlog model.Blog
+	err = json.Unmarshal(rec.Body.Bytes(), &respBlog)
+	require.NoError(t, err)
+	require.Equal(t, 5, respBlog.Views)
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_GetPopular(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	validate := validator.New()
+	h := NewHandler(mockService, nil, nil, validate)
+
+	expectedBlogs := []*model.Blog{
+		{BlogID: uuid.New(), Title: "Most read", Views: 10},
+		{BlogID: uuid.New(), Title: "Less read", Views: 3},
+	}
+
+	mockService.On("GetPopular", mock.Anything, constants.PopularMaxLimit).Return(expectedBlogs, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/blogs/popular?limit=100000", http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := h.GetPopular(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var respBlogs []*model.Blog
+	err = json.Unmarshal(rec.Body.Bytes(), &respBlogs)
+	require.NoError(t, err)
+	require.Equal(t, expectedBlogs, respBlogs)
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_GetTopTags(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	validate := validator.New()
+	h := NewHandler(mockService, nil, nil, validate)
+
+	userID := uuid.New()
+	expectedTags := []*model.TagCount{{Tag: "go", Count: 3}, {Tag: "sql", Count: 1}}
+	mockService.On("GetTopTags", mock.Anything, userID, constants.TopTagsDefaultLimit).Return(expectedTags, nil).Once()
+	mockService.On("GetTopTags", mock.Anything, userID, constants.TopTagsMaxLimit).Return(expectedTags[:1], nil).Once()
+
+	e := echo.New()
+	for _, tc := range []struct {
+		query    string
+		expected []*model.TagCount
+	}{
+		{"", expectedTags},
+		{"?limit=100000", expectedTags[:1]},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/users/"+userID.String()+"/top-tags"+tc.query, http.NoBody)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(userID.String())
+
+		require.NoError(t, h.GetTopTags(c))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var respTags []*model.TagCount
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &respTags))
+		require.Equal(t, tc.expected, respTags)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/users/abc/top-tags", http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("abc")
+	require.NoError(t, h.GetTopTags(c))
+	requireErrorResponse(t, rec, http.StatusBadRequest, codeInvalidID)
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_Get_ETag(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	h := NewHandler(mockService, nil, nil, validator.New())
+
+	id := uuid.New()
+	updatedAt := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+	mockService.On("Get", mock.Anything, id).Return(func(context.Context, uuid.UUID) *model.Blog {
+		return &model.Blog{BlogID: id, Title: "testtitle", Content: "testcontent", Status: model.BlogStatusPublished, UpdatedAt: updatedAt}
+	}, nil)
+	mockService.On("IncrementViews", mock.Anything, id).Return(1, nil).Once()
+	mockService.On("IncrementViews", mock.Anything, id).Return(2, nil)
+
+	e := echo.New()
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/blog/"+id.String(), http.NoBody)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(id.String())
+		require.NoError(t, h.Get(c))
+		return rec
+	}
+
+	rec := get("")
+	require.Equal(t, http.StatusOK, rec.Code)
+	etag := rec.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	var blog model.Blog
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &blog))
+	require.Equal(t, 1, blog.Views)
+
+	for _, ifNoneMatch := range []string{etag, strings.TrimPrefix(etag, "W/"), `"stale", ` + etag, "*"} {
+		rec = get(ifNoneMatch)
+		require.Equal(t, http.StatusNotModified, rec.Code, ifNoneMatch)
+		require.Empty(t, rec.Body.String())
+		require.Equal(t, etag, rec.Header().Get("ETag"))
+	}
+
+	rec = get(`W/"stale"`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, etag, rec.Header().Get("ETag"))
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &blog))
+	require.Equal(t, 2, blog.Views)
+
+	updatedAt = updatedAt.Add(time.Minute)
+	rec = get(etag)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NotEqual(t, etag, rec.Header().Get("ETag"))
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_Get_FormatHTML(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	h := NewHandler(mockService, nil, nil, validator.New())
+
+	id := uuid.New()
+	content := "# Title\n\nHello <script>alert('x')</script><img src=\"/a.png\" onerror=\"alert(1)\"> **bold**"
+	mockService.On("Get", mock.Anything, id).Return(func(context.Context, uuid.UUID) *model.Blog {
+		return &model.Blog{BlogID: id, Title: "testtitle", Content: content, Status: model.BlogStatusPublished}
+	}, nil)
+	mockService.On("IncrementViews", mock.Anything, id).Return(1, nil)
+
+	e := echo.New()
+	get := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/blog/"+id.String()+query, http.NoBody)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(id.String())
+		require.NoError(t, h.Get(c))
+		return rec
+	}
+
+	rec := get("?format=html")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var blog model.Blog
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &blog))
+	require.Equal(t, content, blog.Content)
+	require.Contains(t, blog.ContentHTML, "<h1>Title</h1>")
+	require.Contains(t, blog.ContentHTML, "<strong>bold</strong>")
+	require.Contains(t, blog.ContentHTML, `<img src="/a.png">`)
+	require.NotContains(t, blog.ContentHTML, "<script")
+	require.NotContains(t, blog.ContentHTML, "onerror")
+	require.NotContains(t, blog.ContentHTML, "alert")
+
+	rec = get("")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NotContains(t, rec.Body.String(), "content_html")
+
+	rec = get("?format=pdf")
+	requireErrorResponse(t, rec, http.StatusBadRequest, codeInvalidRequest)
+}
+
+func Test_Get_RecordsRecentView(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	validate := validator.New()
+	h := NewHandler(mockService, nil, nil, validate)
+
+	id := uuid.New()
+	userID := uuid.New()
+	expectedBlog := &model.Blog{BlogID: id, Title: "testtitle", Content: "testcontent", Status: model.BlogStatusPublished}
+
+	mockService.On("Get", mock.Anything, id).Return(expectedBlog, nil)
+	mockService.On("IncrementViews", mock.Anything, id).Return(1, nil)
+	mockService.On("AddRecentView", mock.Anything, userID, id).Return(errors.New("recent views failed"))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/blog/"+id.String(), http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(id.String())
+	c.Set("id", userID)
+
+	err := h.Get(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_GetRecent(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	validate := validator.New()
+	h := NewHandler(mockService, nil, nil, validate)
+
+	userID := uuid.New()
+	expectedBlogs := []*model.Blog{
+		{BlogID: uuid.New(), Title: "Viewed last"},
+		{BlogID: uuid.New(), Title: "Viewed first"},
+	}
+
+	mockService.On("GetRecentViews", mock.Anything, userID).Return(expectedBlogs, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/me/recent", http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("id", userID)
+
+	err := h.GetRecent(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var respBlogs []*model.Blog
+	err = json.Unmarshal(rec.Body.Bytes(), &respBlogs)
+	require.NoError(t, err)
+	require.Equal(t, expectedBlogs, respBlogs)
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_GetActivity(t *testing.T) {
+	mockUserService := new(mocks.MockUserService)
+	h := NewHandler(nil, mockUserService, nil, validator.New())
+
+	userID := uuid.New()
+	created := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	expected := &model.ActivityListResponse{
+		Activities: []*model.Activity{
+			{ID: uuid.New(), UserID: userID, Action: model.ActivityBlogUpdated, TargetID: uuid.New(), CreatedAt: created.Add(time.Minute)},
+			{ID: uuid.New(), UserID: userID, Action: model.ActivityBlogCreated, TargetID: uuid.New(), CreatedAt: created},
+		},
+		Count: 2,
+	}
+	// the limit is capped
+	mockUserService.On("GetActivity", mock.Anything, userID, constants.ActivityMaxLimit, 0).Return(expected, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/me/activity?limit=1000&offset=-1", http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("id", userID)
+
+	require.NoError(t, h.GetActivity(c))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp model.ActivityListResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, *expected, resp)
+
+	mockUserService.AssertExpectations(t)
+}
+
+func Test_GetSiblings(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	validate := validator.New()
+	h := NewHandler(mockService, nil, nil, validate)
+
+	id := uuid.New()
+	blog := &model.Blog{BlogID: id, Title: "testtitle", Status: model.BlogStatusPublished}
+	expected := &model.BlogSiblings{Previous: &model.Blog{BlogID: uuid.New(), Title: "Previous"}}
+
+	mockService.On("Get", mock.Anything, id).Return(blog, nil)
+	mockService.On("GetSiblings", mock.Anything, blog, true).Return(expected, nil).Once()
+	mockService.On("GetSiblings", mock.Anything, blog, false).Return(expected, nil).Once()
+
+	e := echo.New()
+	for _, target := range []string{"/blog/" + id.String() + "/siblings", "/blog/" + id.String() + "/siblings?scope=global"} {
+		req := httptest.NewRequest(http.MethodGet, target, http.NoBody)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(id.String())
+
+		err := h.GetSiblings(c)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var resp model.BlogSiblings
+		err = json.Unmarshal(rec.Body.Bytes(), &resp)
+		require.NoError(t, err)
+		require.Equal(t, expected.Previous.BlogID, resp.Previous.BlogID)
+		require.Nil(t, resp.Next)
+	}
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_GetSiblings_InvalidScope(t *testing.T) {
+	h := NewHandler(new(mocks.MockBlogService), nil, nil, validator.New())
+
+	id := uuid.New()
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/blog/"+id.String()+"/siblings?scope=everything", http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(id.String())
+
+	err := h.GetSiblings(c)
+	require.NoError(t, err)
+	requireErrorResponse(t, rec, http.StatusBadRequest, codeInvalidRequest)
+}
+
+func Test_GetSiblings_Errors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		err    error
+		status int
+		code   string
+	}{
+		"not found":   {fmt.Errorf("blogRps.Get - %w", repository.ErrNotFound), http.StatusNotFound, codeBlogNotFound},
+		"unavailable": {fmt.Errorf("blogRps.Get - %w", repository.ErrUnavailable), http.StatusInternalServerError, codeInternal},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockService := new(mocks.MockBlogService)
+			h := NewHandler(mockService, nil, nil, validator.New())
+
+			id := uuid.New()
+			mockService.On("Get", mock.Anything, id).Return(nil, tc.err).Once()
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/blog/"+id.String()+"/siblings", http.NoBody)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(id.String())
+
+			err := h.GetSiblings(c)
+			require.NoError(t, err)
+			requireErrorResponse(t, rec, tc.status, tc.code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_Login_Locked(t *testing.T) {
+	mockService := new(mocks.MockUserService)
+	validate := validator.New()
+	h := NewHandler(nil, mockService, nil, validate)
+
+	bodyBytes, err := json.Marshal(&InputData{Username: "testuser", Password: "testpassword"})
+	require.NoError(t, err)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/login", bytes.NewReader(bodyBytes))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("Login", mock.Anything, mock.AnythingOfType("*model.User")).
+		Return(&service.TokenPair{}, fmt.Errorf("locked: %w", service.ErrAccountLocked))
+
+	err = h.Login(c)
+	require.NoError(t, err)
+	requireErrorResponse(t, rec, http.StatusLocked, codeAccountLocked)
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_ChangePassword(t *testing.T) {
+	mockService := new(mocks.MockUserService)
+	validate := validator.New()
+	h := NewHandler(nil, mockService, nil, validate)
+
+	userID := uuid.New()
+	mockService.On("ChangePassword", mock.Anything, userID, []byte("oldpass"), []byte("newpass")).Return(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/user/password", bytes.NewReader([]byte(`{"oldPassword":"oldpass","newPassword":"newpass"}`)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("id", userID)
+
+	err := h.ChangePassword(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_ChangePassword_InvalidNewPassword(t *testing.T) {
+	mockService := new(mocks.MockUserService)
+	validate := validator.New()
+	h := NewHandler(nil, mockService, nil, validate)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/user/password", bytes.NewReader([]byte(`{"oldPassword":"oldpass","newPassword":"abc"}`)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("id", uuid.New())
+
+	err := h.ChangePassword(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	mockService.AssertNotCalled(t, "ChangePassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_SignUpUser_InvalidEmail(t *testing.T) {
+	mockService := new(mocks.MockUserService)
+	validate := validator.New()
+	h := NewHandler(nil, mockService, nil, validate)
+
+	bodyBytes, err := json.Marshal(InputData{Username: "testuser", Email: "not-an-email", Password: "password123"})
+	require.NoError(t, err)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/signup", bytes.NewReader(bodyBytes))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err = h.SignUpUser(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	mockService.AssertNotCalled(t, "SignUpUser", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_Publish_SubmittedForReview(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	validate := validator.New()
+	h := NewHandler(mockService, nil, nil, validate)
+
+	userID := uuid.New()
+	blogID := uuid.New()
+
+	mockService.On("IsBlogOwner", mock.Anything, blogID, userID).Return(true, nil)
+	mockService.On("Publish", mock.Anything, blogID, false).Return(model.BlogStatusPendingReview, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/blog/"+blogID.String()+"/publish", http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(blogID.String())
+	c.Set("id", userID)
+
+	err := h.Publish(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusAccepted, rec.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_Approve(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	validate := validator.New()
+	h := NewHandler(mockService, nil, nil, validate)
+
+	id := uuid.New()
+	mockService.On("Approve", mock.Anything, id).Return(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/admin/blog/"+id.String()+"/approve", http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(id.String())
+	c.Set("isAdmin", true)
+
+	err := h.Approve(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_Reject(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	validate := validator.New()
+	h := NewHandler(mockService, nil, nil, validate)
+
+	id := uuid.New()
+	mockService.On("Reject", mock.Anything, id, "off-topic").Return(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/admin/blog/"+id.String()+"/reject", bytes.NewReader([]byte(`{"reason":"off-topic"}`)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(id.String())
+	c.Set("isAdmin", true)
+
+	err := h.Reject(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_Reject_NotAdmin(t *testing.T) {
+	h := NewHandler(new(mocks.MockBlogService), nil, nil, validator.New())
+
+	id := uuid.New()
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/admin/blog/"+id.String()+"/reject", bytes.NewReader([]byte(`{"reason":"off-topic"}`)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(id.String())
+	c.Set("isAdmin", false)
+
+	err := h.Reject(c)
+	require.NoError(t, err)
+	requireErrorResponse(t, rec, http.StatusForbidden, codeAdminRequired)
+}
+
+func Test_RequestPasswordReset(t *testing.T) {
+	mockService := new(mocks.MockUserService)
+	validate := validator.New()
+	h := NewHandler(nil, mockService, nil, validate)
+
+	mockService.On("RequestPasswordReset", mock.Anything, "missing@example.com").Return(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/password/reset/request", bytes.NewReader([]byte(`{"email":"missing@example.com"}`)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := h.RequestPasswordReset(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_RequestPasswordReset_NotConfigured(t *testing.T) {
+	mockService := new(mocks.MockUserService)
+	h := NewHandler(nil, mockService, nil, validator.New())
+
+	mockService.On("RequestPasswordReset", mock.Anything, "testuser@example.com").Return(service.ErrPasswordResetDisabled)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/password/reset/request", bytes.NewReader([]byte(`{"email":"testuser@example.com"}`)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := h.RequestPasswordReset(c)
+	require.NoError(t, err)
+	requireErrorResponse(t, rec, http.StatusServiceUnavailable, codeNotConfigured)
+	mockService.AssertExpectations(t)
+}
+
+func Test_ConfirmPasswordReset_UsedToken(t *testing.T) {
+	mockService := new(mocks.MockUserService)
+	validate := validator.New()
+	h := NewHandler(nil, mockService, nil, validate)
+
+	mockService.On("ConfirmPasswordReset", mock.Anything, "reset-token", []byte("newpass")).
+		Return(fmt.Errorf("used: %w", service.ErrResetTokenUsed))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/password/reset/confirm", bytes.NewReader([]byte(`{"token":"reset-token","newPassword":"newpass"}`)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := h.ConfirmPasswordReset(c)
+	require.NoError(t, err)
+	requireErrorResponse(t, rec, http.StatusBadRequest, codeInvalidResetToken)
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_Restore(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	validate := validator.New()
+	h := NewHandler(mockService, nil, nil, validate)
+
+	id := uuid.New()
+	mockService.On("Restore", mock.Anything, id).Return(repository.ErrNotFound).Once()
+	mockService.On("Restore", mock.Anything, id).Return(nil).Once()
+
+	e := echo.New()
+	for _, want := range []int{http.StatusNotFound, http.StatusOK} {
+		req := httptest.NewRequest(http.MethodPost, "/blog/"+id.String()+"/restore", http.NoBody)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(id.String())
+		c.Set("isAdmin", true)
+
+		err := h.Restore(c)
+		require.NoError(t, err)
+		require.Equal(t, want, rec.Code)
+	}
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_HardDelete_NotAdmin(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	validate := validator.New()
+	h := NewHandler(mockService, nil, nil, validate)
+
+	id := uuid.New()
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/admin/blog/"+id.String(), http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(id.String())
+	c.Set("isAdmin", false)
+
+	err := h.HardDelete(c)
+	require.NoError(t, err)
+	requireErrorResponse(t, rec, http.StatusForbidden, codeAdminRequired)
+
+	mockService.AssertNotCalled(t, "HardDelete", mock.Anything, mock.Anything)
+}
+
+func Test_WhoAmI(t *testing.T) {
+	cfg := &config.Config{BlogTokenSignature: "secret"}
+	h := NewHandler(nil, nil, nil, validator.New())
+	id := uuid.New()
+	issuedAt := time.Now().Add(-time.Minute).Truncate(time.Second)
+	expiresAt := issuedAt.Add(constants.AccessTokenExpiration)
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"exp":     expiresAt.Unix(),
+		"iat":     issuedAt.Unix(),
+		"iss":     constants.TokenIssuer,
+		"aud":     constants.TokenAudience,
+		"id":      id,
+		"isAdmin": true,
+	}).SignedString([]byte(cfg.BlogTokenSignature))
+	require.NoError(t, err)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/auth/whoami", http.NoBody)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err = customMiddleware.JWTMiddleware(cfg, nil)(h.WhoAmI)(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NotContains(t, rec.Body.String(), token)
+
+	var claims model.TokenClaims
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &claims))
+	require.Equal(t, id, claims.ID)
+	require.True(t, claims.IsAdmin)
+	require.True(t, expiresAt.Equal(claims.ExpiresAt))
+	require.NotNil(t, claims.IssuedAt)
+	require.True(t, issuedAt.Equal(*claims.IssuedAt))
+	require.Equal(t, constants.TokenIssuer, claims.Issuer)
+}
+
+func Test_WhoAmI_InvalidToken(t *testing.T) {
+	cfg := &config.Config{BlogTokenSignature: "secret"}
+	h := NewHandler(nil, nil, nil, validator.New())
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/auth/whoami", http.NoBody)
+	req.Header.Set("Authorization", "Bearer not.a.token")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := customMiddleware.JWTMiddleware(cfg, nil)(h.WhoAmI)(c)
+	var httpErr *echo.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	require.Equal(t, http.StatusUnauthorized, httpErr.Code)
+}
+
+func Test_Get_ValidationErrorCarriesRequestID(t *testing.T) {
+	h := NewHandler(new(mocks.MockBlogService), nil, nil, validator.New())
+	e := echo.New()
+	e.HTTPErrorHandler = customMiddleware.ErrorHandler(e)
+	e.Pre(customMiddleware.RequestID(echo.HeaderXRequestID))
+	e.GET("/blog/:id", h.Get)
+
+	req := httptest.NewRequest(http.MethodGet, "/blog/not-a-uuid", http.NoBody)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	requestID := rec.Header().Get(echo.HeaderXRequestID)
+	require.NotEmpty(t, requestID)
+	var resp model.ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, requestID, resp.Error.RequestID)
+	require.Equal(t, "Failed to validate id", resp.Error.Message)
+}
+
+func Test_GetProfiles(t *testing.T) {
+	mockUserService := new(mocks.MockUserService)
+	h := NewHandler(nil, mockUserService, nil, validator.New())
+
+	first, second := uuid.New(), uuid.New()
+	mockUserService.On("GetProfiles", mock.Anything, []uuid.UUID{first, second}).
+		Return([]*model.UserProfile{{ID: first, Username: "firstuser"}}, nil)
+
+	e := echo.New()
+	body := fmt.Sprintf(`[%q,%q]`, first, second)
+	req := httptest.NewRequest(http.MethodPost, "/users/profiles", bytes.NewReader([]byte(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := h.GetProfiles(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, fmt.Sprintf(`[{"id":%q,"username":"firstuser"}]`, first), rec.Body.String())
+	for _, field := range []string{"password", "token", "email"} {
+		require.NotContains(t, strings.ToLower(rec.Body.String()), field)
+	}
+
+	mockUserService.AssertExpectations(t)
+}
+
+func Test_GetProfiles_TooManyIDs(t *testing.T) {
+	mockUserService := new(mocks.MockUserService)
+	h := NewHandler(nil, mockUserService, nil, validator.New())
+	h.SetBulkMaxItems(2)
+
+	e := echo.New()
+	body := fmt.Sprintf(`[%q,%q,%q]`, uuid.New(), uuid.New(), uuid.New())
+	req := httptest.NewRequest(http.MethodPost, "/users/profiles", bytes.NewReader([]byte(body)))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := h.GetProfiles(c)
+	require.NoError(t, err)
+	requireErrorResponse(t, rec, http.StatusBadRequest, codeTooManyItems)
+
+	mockUserService.AssertNotCalled(t, "GetProfiles", mock.Anything, mock.Anything)
+}
+
+func Test_GetMe(t *testing.T) {
+	mockUserService := new(mocks.MockUserService)
+	h := NewHandler(nil, mockUserService, nil, validator.New())
+
+	id := uuid.New()
+	createdAt := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	mockUserService.On("GetProfile", mock.Anything, id).Return(&model.User{
+		ID:           id,
+		Username:     "testuser",
+		Email:        "test@example.com",
+		Password:     []byte("$2a$14$secrethash"),
+		RefreshToken: "secretrefresh",
+		CreatedAt:    createdAt,
+	}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/user/me", http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("id", id)
+
+	err := h.GetMe(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, "testuser", body["username"])
+	require.Equal(t, "test@example.com", body["email"])
+	require.Equal(t, false, body["admin"])
+	require.Equal(t, createdAt.Format(time.RFC3339), body["createdat"])
+	require.NotContains(t, body, "password")
+	require.NotContains(t, body, "refreshToken")
+	require.NotContains(t, rec.Body.String(), "secret")
+
+	mockUserService.AssertExpectations(t)
+}
+
+func Test_MethodNotAllowed(t *testing.T) {
+	h := NewHandler(new(mocks.MockBlogService), nil, nil, validator.New())
+	e := echo.New()
+	e.HTTPErrorHandler = customMiddleware.ErrorHandler(e)
+	RegisterRoutes(e, h, &config.Config{BlogTokenSignature: "secret"})
+
+	for _, path := range []string{"/v1/blogs/popular", "/blogs/popular"} {
+		req := httptest.NewRequest(http.MethodDelete, path, http.NoBody)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		require.Equal(t, "OPTIONS, GET", rec.Header().Get(echo.HeaderAllow))
+		var resp model.ErrorResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		require.Equal(t, "method_not_allowed", resp.Error.Code)
+	}
+}
+
+func Test_Create_DisallowedMedia(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	h := NewHandler(mockService, nil, nil, validator.New())
+
+	issues := []model.MediaIssue{{Field: "content", URL: "javascript:alert(1)", Reason: "disallowed scheme javascript"}}
+	mockService.On("Create", mock.Anything, mock.AnythingOfType("*model.Blog"), false).Return(&service.MediaError{Issues: issues})
+
+	e := echo.New()
+	body := `{"title":"testtitle","content":"[x](javascript:alert(1))"}`
+	req := httptest.NewRequest(http.MethodPost, "/blog", bytes.NewReader([]byte(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("id", uuid.New())
+
+	err := h.Create(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	var resp model.ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, "disallowed_media", resp.Error.Code)
+	require.Equal(t, issues, resp.Error.Errors)
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_Create_DraftLimit(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	h := NewHandler(mockService, nil, nil, validator.New())
+	userID, draftID := uuid.New(), uuid.New()
+	mockService.On("Create", mock.Anything, mock.AnythingOfType("*model.Blog"), false).Return(service.ErrDraftLimitExceeded)
+	mockService.On("IsBlogOwner", mock.Anything, draftID, userID).Return(true, nil)
+	mockService.On("Publish", mock.Anything, draftID, false).Return(model.BlogStatusPublished, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/blog", strings.NewReader(`{"title":"testtitle","content":"testcontent"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("id", userID)
+	c.Set("isAdmin", false)
+	require.NoError(t, h.Create(c))
+	requireErrorResponse(t, rec, http.StatusForbidden, codeDraftLimitExceeded)
+
+	req = httptest.NewRequest(http.MethodPost, "/blog/"+draftID.String()+"/publish", http.NoBody)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(draftID.String())
+	c.Set("id", userID)
+	c.Set("isAdmin", false)
+	require.NoError(t, h.Publish(c))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_SignUpUser_FailureDoesNotLogPassword(t *testing.T) {
+	hook := test.NewGlobal()
+	mockService := new(mocks.MockUserService)
+	h := NewHandler(nil, mockService, nil, validator.New())
+
+	password := "secretpass1"
+	bodyBytes, err := json.Marshal(InputData{Username: "testuser", Email: "testuser@example.com", Password: password})
+	require.NoError(t, err)
+	mockService.On("SignUpUser", mock.Anything, mock.AnythingOfType("*model.User"), "").Return(errors.New("connection refused"))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/signup", bytes.NewReader(bodyBytes))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err = h.SignUpUser(c)
+	require.NoError(t, err)
+	requireErrorResponse(t, rec, http.StatusInternalServerError, codeInternal)
+
+	entries := hook.AllEntries()
+	require.NotEmpty(t, entries)
+	for _, entry := range entries {
+		line, err := entry.String()
+		require.NoError(t, err)
+		require.NotContains(t, line, password)
+		require.NotContains(t, line, fmt.Sprint([]byte(password)))
+		require.Equal(t, "testuser", entry.Data["Username"])
+	}
+}
+
+func Test_GetDiagnostics(t *testing.T) {
+	mockDiag := new(mocks.MockDiagnosticsService)
+	h := NewHandler(nil, nil, nil, validator.New())
+	h.SetDiagnosticsService(mockDiag)
+
+	diagnostics := &model.Diagnostics{
+		Database: model.DatabaseDiagnostics{ServerVersion: "16.2", MigrationVersion: 13, Pool: model.PoolStats{TotalConns: 4, MaxConns: 10}},
+		Build:    model.BuildInfo{GoVersion: "go1.24.2", Revision: "abc123"},
+	}
+	mockDiag.On("Diagnostics", mock.Anything).Return(diagnostics, nil).Once()
+
+	e := echo.New()
+	for _, isAdmin := range []bool{false, true} {
+		req := httptest.NewRequest(http.MethodGet, "/admin/diagnostics", http.NoBody)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("isAdmin", isAdmin)
+
+		require.NoError(t, h.GetDiagnostics(c))
+		if !isAdmin {
+			requireErrorResponse(t, rec, http.StatusForbidden, codeAdminRequired)
+			continue
+		}
+		require.Equal(t, http.StatusOK, rec.Code)
+		var resp model.Diagnostics
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		require.Equal(t, *diagnostics, resp)
+	}
+
+	mockDiag.AssertExpectations(t)
+}
+
+func Test_SetRole_LastAdmin(t *testing.T) {
+	mockUserService := new(mocks.MockUserService)
+	h := NewHandler(nil, mockUserService, nil, validator.New())
+
+	id := uuid.New()
+	mockUserService.On("SetRole", mock.Anything, id, false).Return(fmt.Errorf("wrapped: %w", service.ErrLastAdmin))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPut, "/users/"+id.String()+"/role", bytes.NewReader([]byte(`{"admin":false}`)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(id.String())
+	c.Set("isAdmin", true)
+
+	err := h.SetRole(c)
+	require.NoError(t, err)
+	requireErrorResponse(t, rec, http.StatusConflict, codeLastAdmin)
+
+	mockUserService.AssertExpectations(t)
+}
+
+func Test_Impersonate(t *testing.T) {
+	mockUserService := new(mocks.MockUserService)
+	h := NewHandler(nil, mockUserService, nil, validator.New())
+
+	adminID, id := uuid.New(), uuid.New()
+	mockUserService.On("Impersonate", mock.Anything, adminID, id).Return("impersonation-token", nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/admin/impersonate/"+id.String(), http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(id.String())
+	c.Set("id", adminID)
+	c.Set("isAdmin", true)
+
+	require.NoError(t, h.Impersonate(c))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "impersonation-token")
+
+	mockUserService.AssertExpectations(t)
+}
+
+func Test_Impersonate_Forbidden(t *testing.T) {
+	mockUserService := new(mocks.MockUserService)
+	h := NewHandler(nil, mockUserService, nil, validator.New())
+
+	adminID, id := uuid.New(), uuid.New()
+	mockUserService.On("Impersonate", mock.Anything, adminID, id).Return("", fmt.Errorf("wrapped: %w", service.ErrImpersonateAdmin))
+
+	e := echo.New()
+	for _, tc := range []struct {
+		name    string
+		isAdmin bool
+		code    string
+	}{
+		{name: "not an admin", isAdmin: false, code: codeAdminRequired},
+		{name: "target is an admin", isAdmin: true, code: codeForbidden},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/admin/impersonate/"+id.String(), http.NoBody)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(id.String())
+			c.Set("id", adminID)
+			c.Set("isAdmin", tc.isAdmin)
+
+			require.NoError(t, h.Impersonate(c))
+			requireErrorResponse(t, rec, http.StatusForbidden, tc.code)
+		})
+	}
+	mockUserService.AssertNumberOfCalls(t, "Impersonate", 1)
+}
+
+func Test_ImpersonationToken(t *testing.T) {
+	cfg := &config.Config{BlogTokenSignature: "secret"}
+	h := NewHandler(nil, new(mocks.MockUserService), nil, validator.New())
+	adminID, id := uuid.New(), uuid.New()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"exp":             time.Now().Add(constants.ImpersonationTokenExpiration).Unix(),
+		"iat":             time.Now().Unix(),
+		"iss":             constants.TokenIssuer,
+		"aud":             constants.TokenAudience,
+		"id":              id,
+		"isAdmin":         false,
+		"impersonated_by": adminID,
+	}).SignedString([]byte(cfg.BlogTokenSignature))
+	require.NoError(t, err)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/auth/whoami", http.NoBody)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	require.NoError(t, customMiddleware.JWTMiddleware(cfg, nil)(h.WhoAmI)(e.NewContext(req, rec)))
+	var claims model.TokenClaims
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &claims))
+	require.Equal(t, id, claims.ID)
+	require.False(t, claims.IsAdmin)
+	require.NotNil(t, claims.ImpersonatedBy)
+	require.Equal(t, adminID, *claims.ImpersonatedBy)
+
+	req = httptest.NewRequest(http.MethodPost, "/user/password",
+		bytes.NewReader([]byte(`{"oldPassword":"old_password","newPassword":"new_password"}`)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec = httptest.NewRecorder()
+	require.NoError(t, customMiddleware.JWTMiddleware(cfg, nil)(h.ChangePassword)(e.NewContext(req, rec)))
+	requireErrorResponse(t, rec, http.StatusForbidden, codeForbidden)
+}
+
+func Test_DeleteMe(t *testing.T) {
+	mockBlogService := new(mocks.MockBlogService)
+	mockUserService := new(mocks.MockUserService)
+	h := NewHandler(mockBlogService, mockUserService, nil, validator.New())
+
+	userID, adminID := uuid.New(), uuid.New()
+	mockUserService.On("DeleteUserByID", mock.Anything, userID).Return(nil).Once()
+	mockUserService.On("DeleteUserByID", mock.Anything, adminID).Return(fmt.Errorf("wrapped: %w", repository.ErrAdminUser)).Once()
+
+	e := echo.New()
+	for _, tc := range []struct {
+		name   string
+		userID uuid.UUID
+		code   int
+	}{
+		{name: "user", userID: userID, code: http.StatusOK},
+		{name: "admin", userID: adminID, code: http.StatusForbidden},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodDelete, "/user/me", http.NoBody)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("id", tc.userID)
+
+			require.NoError(t, h.DeleteMe(c))
+			require.Equal(t, tc.code, rec.Code)
+		})
+	}
+	// the blogs are deleted with the user in the same transaction by the repository
+	mockBlogService.AssertNotCalled(t, "DeleteBlogsByUserID", mock.Anything, mock.Anything)
+	mockUserService.AssertExpectations(t)
+}
+
+func Test_SignUpUser_WithInvite(t *testing.T) {
+	mockService := new(mocks.MockUserService)
+	h := NewHandler(nil, mockService, nil, validator.New())
+	mockService.On("SignUpUser", mock.Anything, mock.AnythingOfType("*model.User"), "INVITECODE").Return(nil)
+
+	bodyBytes, err := json.Marshal(InputData{Username: "testuser", Email: "testuser@example.com", Password: "password123", Invite: "INVITECODE"})
+	require.NoError(t, err)
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/signup", bytes.NewReader(bodyBytes))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, h.SignUpUser(e.NewContext(req, rec)))
+	require.Equal(t, http.StatusCreated, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+func Test_CreateInvites(t *testing.T) {
+	mockService := new(mocks.MockUserService)
+	h := NewHandler(nil, mockService, nil, validator.New())
+	h.SetBulkMaxItems(5)
+	adminID := uuid.New()
+	mockService.On("CreateInvites", mock.Anything, adminID, 1).Return([]string{"FIRST"}, nil).Once()
+	mockService.On("CreateInvites", mock.Anything, adminID, 2).Return([]string{"FIRST", "SECOND"}, nil).Once()
+
+	e := echo.New()
+	for _, tc := range []struct {
+		name    string
+		body    string
+		isAdmin bool
+		code    int
+		want    []string
+	}{
+		{name: "default count", isAdmin: true, code: http.StatusCreated, want: []string{"FIRST"}},
+		{name: "count", body: `{"count":2}`, isAdmin: true, code: http.StatusCreated, want: []string{"FIRST", "SECOND"}},
+		{name: "count above the cap", body: `{"count":6}`, isAdmin: true, code: http.StatusBadRequest},
+		{name: "zero count", body: `{"count":0}`, isAdmin: true, code: http.StatusBadRequest},
+		{name: "not an admin", isAdmin: false, code: http.StatusForbidden},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/admin/invites", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("id", adminID)
+			c.Set("isAdmin", tc.isAdmin)
+
+			require.NoError(t, h.CreateInvites(c))
+			require.Equal(t, tc.code, rec.Code)
+			if tc.code != http.StatusCreated {
+				return
+			}
+			var resp struct {
+				Codes []string `json:"codes"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			require.Equal(t, tc.want, resp.Codes)
+		})
+	}
+	mockService.AssertExpectations(t)
+}
+
+func Test_RevokeTokens(t *testing.T) {
+	mockUserService := new(mocks.MockUserService)
+	h := NewHandler(nil, mockUserService, nil, validator.New())
+
+	adminID, id, missing := uuid.New(), uuid.New(), uuid.New()
+	mockUserService.On("RevokeTokens", mock.Anything, adminID, id).Return(nil).Once()
+	mockUserService.On("RevokeTokens", mock.Anything, adminID, missing).Return(fmt.Errorf("wrapped: %w", repository.ErrNotFound)).Once()
+
+	e := echo.New()
+	for _, tc := range []struct {
+		name    string
+		target  uuid.UUID
+		isAdmin bool
+		code    int
+	}{
+		{name: "revoked", target: id, isAdmin: true, code: http.StatusOK},
+		{name: "unknown user", target: missing, isAdmin: true, code: http.StatusNotFound},
+		{name: "not an admin", target: id, isAdmin: false, code: http.StatusForbidden},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/admin/users/"+tc.target.String()+"/revoke-tokens", http.NoBody)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.target.String())
+			c.Set("id", adminID)
+			c.Set("isAdmin", tc.isAdmin)
+
+			require.NoError(t, h.RevokeTokens(c))
+			require.Equal(t, tc.code, rec.Code)
+		})
+	}
+	mockUserService.AssertExpectations(t)
+}
+
+func Test_GetAll_CanEdit(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	validate := validator.New()
+	h := NewHandler(mockService, nil, nil, validate)
+
+	ownerID := uuid.New()
+	own := &model.Blog{BlogID: uuid.New(), UserID: ownerID, Title: "Own"}
+	other := &model.Blog{BlogID: uuid.New(), UserID: uuid.New(), Title: "Other"}
+
+	mockService.On("GetAll", mock.Anything, model.BlogFilter{}, 10, 0).Return(&model.BlogListResponse{Blogs: []*model.Blog{own, other}, Count: 2}, nil)
+
+	e := echo.New()
+	for _, tc := range []struct {
+		name     string
+		callerID uuid.UUID
+		isAdmin  bool
+		canEdit  []bool
+	}{
+		{name: "owner", callerID: ownerID, canEdit: []bool{true, false}},
+		{name: "admin", callerID: uuid.New(), isAdmin: true, canEdit: []bool{true, true}},
+		{name: "other user", callerID: uuid.New(), canEdit: []bool{false, false}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/blogs?limit=10&offset=0", http.NoBody)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("id", tc.callerID)
+			c.Set("isAdmin", tc.isAdmin)
+
+			err := h.GetAll(c)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, rec.Code)
+
+			var resp model.BlogListResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			require.Len(t, resp.Blogs, 2)
+			require.Equal(t, tc.canEdit[0], resp.Blogs[0].CanEdit)
+			require.Equal(t, tc.canEdit[1], resp.Blogs[1].CanEdit)
+		})
+	}
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_Get_CanEdit(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	validate := validator.New()
+	h := NewHandler(mockService, nil, nil, validate)
+
+	id := uuid.New()
+	ownerID := uuid.New()
+	blog := &model.Blog{BlogID: id, UserID: ownerID, Title: "testtitle", Content: "testcontent", Status: model.BlogStatusPublished}
+
+	mockService.On("Get", mock.Anything, id).Return(blog, nil)
+	mockService.On("IncrementViews", mock.Anything, id).Return(1, nil)
+	mockService.On("AddRecentView", mock.Anything, mock.Anything, id).Return(nil)
+
+	e := echo.New()
+	for _, tc := range []struct {
+		name     string
+		callerID uuid.UUID
+		isAdmin  bool
+		canEdit  bool
+	}{
+		{name: "owner", callerID: ownerID, canEdit: true},
+		{name: "admin", callerID: uuid.New(), isAdmin: true, canEdit: true},
+		{name: "other user", callerID: uuid.New(), canEdit: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/blog/"+id.String(), http.NoBody)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(id.String())
+			c.Set("id", tc.callerID)
+			c.Set("isAdmin", tc.isAdmin)
+
+			err := h.Get(c)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, rec.Code)
+
+			var respBlog model.Blog
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &respBlog))
+			require.Equal(t, tc.canEdit, respBlog.CanEdit)
+		})
+	}
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_DeleteMany(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	h := NewHandler(mockService, nil, nil, validator.New())
+
+	userID := uuid.New()
+	first, missing := uuid.New(), uuid.New()
+	mockService.On("DeleteMany", mock.Anything, []uuid.UUID{first, missing}, uuid.Nil).Return(1, nil).Once()
+	mockService.On("DeleteMany", mock.Anything, []uuid.UUID{first, missing}, userID).Return(0, nil).Once()
+
+	e := echo.New()
+	for _, tc := range []struct {
+		name    string
+		isAdmin bool
+		body    string
+	}{
+		{name: "admin", isAdmin: true, body: `{"deleted":1}`},
+		{name: "owner", isAdmin: false, body: `{"deleted":0}`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			body := fmt.Sprintf(`[%q,%q]`, first, missing)
+			req := httptest.NewRequest(http.MethodPost, "/blogs/delete", bytes.NewReader([]byte(body)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("id", userID)
+			c.Set("isAdmin", tc.isAdmin)
+
+			err := h.DeleteMany(c)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, rec.Code)
+			require.JSONEq(t, tc.body, rec.Body.String())
+		})
+	}
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_BlogsExist(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	h := NewHandler(mockService, nil, nil, validator.New())
+	h.SetBulkMaxItems(2)
+
+	userID := uuid.New()
+	present, absent := uuid.New(), uuid.New()
+	mockService.On("Exists", mock.Anything, []uuid.UUID{present, absent}, userID).
+		Return(map[uuid.UUID]bool{present: true, absent: false}, nil).Once()
+	mockService.On("Exists", mock.Anything, []uuid.UUID{present, absent}, uuid.Nil).
+		Return(map[uuid.UUID]bool{present: true, absent: false}, nil).Once()
+
+	e := echo.New()
+	for _, tc := range []struct {
+		name    string
+		isAdmin bool
+		body    string
+		status  int
+		code    string
+		want    string
+	}{
+		{name: "user", body: fmt.Sprintf(`[%q,%q]`, present, absent), status: http.StatusOK,
+			want: fmt.Sprintf(`{%q:true,%q:false}`, present, absent)},
+		{name: "admin", isAdmin: true, body: fmt.Sprintf(`[%q,%q]`, present, absent), status: http.StatusOK,
+			want: fmt.Sprintf(`{%q:true,%q:false}`, present, absent)},
+		{name: "too many ids", body: fmt.Sprintf(`[%q,%q,%q]`, present, absent, uuid.New()), status: http.StatusBadRequest, code: codeTooManyItems},
+		{name: "not an id", body: `["not-a-uuid"]`, status: http.StatusBadRequest, code: codeInvalidRequest},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/blogs/exists", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("id", userID)
+			c.Set("isAdmin", tc.isAdmin)
+
+			require.NoError(t, h.BlogsExist(c))
+			if tc.status != http.StatusOK {
+				requireErrorResponse(t, rec, tc.status, tc.code)
+				return
+			}
+			require.Equal(t, http.StatusOK, rec.Code)
+			require.JSONEq(t, tc.want, rec.Body.String())
+		})
+	}
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_Count(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	h := NewHandler(mockService, nil, nil, validator.New())
+
+	callerID, otherID := uuid.New(), uuid.New()
+	mockService.On("Count", mock.Anything).Return(&model.BlogCountResponse{Count: 42}, nil)
+	mockService.On("CountByUserID", mock.Anything, callerID, false).Return(&model.BlogCountResponse{Count: 5}, nil)
+	mockService.On("CountByUserID", mock.Anything, otherID, true).Return(&model.BlogCountResponse{Count: 3}, nil)
+
+	e := echo.New()
+	for _, tc := range []struct {
+		name   string
+		target string
+		body   string
+	}{
+		{name: "all", target: "/blogs/count", body: `{"count":42,"count_is_estimate":false}`},
+		{name: "own blogs with drafts", target: "/blogs/count?userid=" + callerID.String(), body: `{"count":5,"count_is_estimate":false}`},
+		{name: "published blogs of another user", target: "/blogs/count?userid=" + otherID.String(), body: `{"count":3,"count_is_estimate":false}`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.target, http.NoBody)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("id", callerID)
+
+			err := h.Count(c)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, rec.Code)
+			require.JSONEq(t, tc.body, rec.Body.String())
+		})
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/blogs/count?userid=nope", http.NoBody)
+	rec := httptest.NewRecorder()
+	require.NoError(t, h.Count(e.NewContext(req, rec)))
+	requireErrorResponse(t, rec, http.StatusBadRequest, codeInvalidID)
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_Count_PublicDraftCounts(t *testing.T) {
+	ownerID, otherID := uuid.New(), uuid.New()
+	for _, tc := range []struct {
+		name          string
+		public        bool
+		callerID      uuid.UUID
+		publishedOnly bool
+	}{
+		{name: "public count excludes drafts", callerID: otherID, publishedOnly: true},
+		{name: "owner count includes drafts", callerID: ownerID, publishedOnly: false},
+		{name: "public count includes drafts when exposed", public: true, callerID: otherID, publishedOnly: false},
+		{name: "owner count includes drafts when exposed", public: true, callerID: ownerID, publishedOnly: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(mocks.MockBlogService)
+			h := NewHandler(mockService, nil, nil, validator.New())
+			h.SetPublicDraftCounts(tc.public)
+			mockService.On("CountByUserID", mock.Anything, ownerID, tc.publishedOnly).Return(&model.BlogCountResponse{Count: 2}, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/blogs/count?userid="+ownerID.String(), http.NoBody)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+			c.Set("id", tc.callerID)
+
+			require.NoError(t, h.Count(c))
+			require.Equal(t, http.StatusOK, rec.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_GetAll_Filter(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	h := NewHandler(mockService, nil, nil, validator.New())
+
+	userID := uuid.New()
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.February, 1, 12, 0, 0, 0, time.UTC)
+	resp := &model.BlogListResponse{Blogs: []*model.Blog{}}
+	mockService.On("GetAll", mock.Anything, model.BlogFilter{UserID: &userID}, 20, 0).Return(resp, nil).Once()
+	mockService.On("GetAll", mock.Anything, model.BlogFilter{From: &from}, 20, 0).Return(resp, nil).Once()
+	mockService.On("GetAll", mock.Anything, mock.MatchedBy(func(filter model.BlogFilter) bool {
+		return filter.UserID == nil && filter.From == nil && filter.To != nil && filter.To.Equal(to)
+	}), 20, 0).Return(resp, nil).Once()
+	mockService.On("GetAll", mock.Anything, model.BlogFilter{UserID: &userID, From: &from, To: &to}, 20, 0).Return(resp, nil).Once()
+
+	e := echo.New()
+	for _, tc := range []struct {
+		name   string
+		query  string
+		status int
+	}{
+		{name: "author", query: "userid=" + userID.String(), status: http.StatusOK},
+		{name: "from", query: "from=2024-01-01T00:00:00Z", status: http.StatusOK},
+		{name: "to in another zone", query: "to=2024-02-01T15:00:00%2B03:00", status: http.StatusOK},
+		{name: "all filters", query: "userid=" + userID.String() + "&from=2024-01-01T00:00:00Z&to=2024-02-01T12:00:00Z", status: http.StatusOK},
+		{name: "malformed userid", query: "userid=nope", status: http.StatusBadRequest},
+		{name: "malformed from", query: "from=2024-01-01", status: http.StatusBadRequest},
+		{name: "malformed to", query: "to=yesterday", status: http.StatusBadRequest},
+		{name: "from after to", query: "from=2024-02-01T00:00:00Z&to=2024-01-01T00:00:00Z", status: http.StatusBadRequest},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/blogs?"+tc.query, http.NoBody)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			require.NoError(t, h.GetAll(c))
+			if tc.status == http.StatusOK {
+				require.Equal(t, http.StatusOK, rec.Code)
+				return
+			}
+			requireErrorResponse(t, rec, tc.status, codeInvalidRequest)
+		})
+	}
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_GetAll_Sort(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	h := NewHandler(mockService, nil, nil, validator.New())
+	resp := &model.BlogListResponse{Blogs: []*model.Blog{}}
+
+	e := echo.New()
+	for _, tc := range []struct {
+		name   string
+		query  string
+		want   *model.BlogSort
+		status int
+	}{
+		{name: "configured order", query: "", want: nil, status: http.StatusOK},
+		{name: "title defaults ascending", query: "sort=title", want: &model.BlogSort{Field: model.SortFieldTitle}, status: http.StatusOK},
+		{name: "releasetime defaults descending", query: "sort=releasetime", want: &model.BlogSort{Field: model.SortFieldReleaseTime, Desc: true}, status: http.StatusOK},
+		{name: "title descending", query: "sort=title&order=desc", want: &model.BlogSort{Field: model.SortFieldTitle, Desc: true}, status: http.StatusOK},
+		{name: "releasetime ascending", query: "sort=releasetime&order=asc", want: &model.BlogSort{Field: model.SortFieldReleaseTime}, status: http.StatusOK},
+		{name: "order alone sorts by releasetime", query: "order=asc", want: &model.BlogSort{Field: model.SortFieldReleaseTime}, status: http.StatusOK},
+		{name: "unknown field", query: "sort=views", status: http.StatusBadRequest},
+		{name: "unknown order", query: "sort=title&order=up", status: http.StatusBadRequest},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.status == http.StatusOK {
+				mockService.On("GetAll", mock.Anything, model.BlogFilter{Sort: tc.want}, 20, 0).Return(resp, nil).Once()
+			}
+			req := httptest.NewRequest(http.MethodGet, "/blogs?"+tc.query, http.NoBody)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			require.NoError(t, h.GetAll(c))
+			if tc.status == http.StatusOK {
+				require.Equal(t, http.StatusOK, rec.Code)
+				return
+			}
+			requireErrorResponse(t, rec, tc.status, codeInvalidRequest)
+		})
+	}
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_GetAll_Stream(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	h := NewHandler(mockService, nil, nil, validator.New())
+
+	blogs := make([]*model.Blog, 2500)
+	for i := range blogs {
+		blogs[i] = &model.Blog{BlogID: uuid.New(), UserID: uuid.New(), Title: fmt.Sprintf("Title%d", i), Content: "Content", Tags: []string{}}
+	}
+	streamBlogs := func(blogs []*model.Blog) func(args mock.Arguments) {
+		return func(args mock.Arguments) {
+			fn := args.Get(2).(func(*model.Blog) error)
+			for _, blog := range blogs {
+				if err := fn(blog); err != nil {
+					return
+				}
+			}
+		}
+	}
+	mockService.On("StreamAll", mock.Anything, model.BlogFilter{}, mock.Anything).Run(streamBlogs(blogs)).Return(nil).Once()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/blogs?stream=true", http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := h.GetAll(c)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.True(t, rec.Flushed)
+	var streamed []*model.Blog
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &streamed))
+	require.Equal(t, blogs, streamed)
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_GetAll_StreamErrors(t *testing.T) {
+	e := echo.New()
+	blog := &model.Blog{BlogID: uuid.New(), Title: "Title", Content: "Content"}
+
+	t.Run("before the first blog", func(t *testing.T) {
+		mockService := new(mocks.MockBlogService)
+		h := NewHandler(mockService, nil, nil, validator.New())
+		mockService.On("StreamAll", mock.Anything, model.BlogFilter{}, mock.Anything).Return(errors.New("connection refused"))
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/blogs?stream=true", http.NoBody), rec)
+		require.NoError(t, h.GetAll(c))
+		requireErrorResponse(t, rec, http.StatusInternalServerError, codeInternal)
+	})
+
+	t.Run("mid-stream", func(t *testing.T) {
+		mockService := new(mocks.MockBlogService)
+		h := NewHandler(mockService, nil, nil, validator.New())
+		mockService.On("StreamAll", mock.Anything, model.BlogFilter{}, mock.Anything).Run(func(args mock.Arguments) {
+			_ = args.Get(2).(func(*model.Blog) error)(blog)
+		}).Return(errors.New("connection reset"))
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/blogs?stream=true", http.NoBody), rec)
+		require.NoError(t, h.GetAll(c))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.False(t, json.Valid(rec.Body.Bytes()))
+	})
+
+	t.Run("no blogs", func(t *testing.T) {
+		mockService := new(mocks.MockBlogService)
+		h := NewHandler(mockService, nil, nil, validator.New())
+		mockService.On("StreamAll", mock.Anything, model.BlogFilter{}, mock.Anything).Return(nil)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/blogs?stream=true", http.NoBody), rec)
+		require.NoError(t, h.GetAll(c))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.JSONEq(t, `[]`, rec.Body.String())
+	})
+}
@@ -6,6 +6,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/artnikel/blogapi/internal/model"
 	"github.com/google/uuid"
@@ -39,17 +40,224 @@ func (_m *MockBlogService) EXPECT() *MockBlogService_Expecter {
 	return &MockBlogService_Expecter{mock: &_m.Mock}
 }
 
+// AddRecentView provides a mock function for the type MockBlogService
+func (_mock *MockBlogService) AddRecentView(ctx context.Context, userID uuid.UUID, blogID uuid.UUID) error {
+	ret := _mock.Called(ctx, userID, blogID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddRecentView")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID, blogID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockBlogService_AddRecentView_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddRecentView'
+type MockBlogService_AddRecentView_Call struct {
+	*mock.Call
+}
+
+// AddRecentView is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - blogID
+func (_e *MockBlogService_Expecter) AddRecentView(ctx interface{}, userID interface{}, blogID interface{}) *MockBlogService_AddRecentView_Call {
+	return &MockBlogService_AddRecentView_Call{Call: _e.mock.On("AddRecentView", ctx, userID, blogID)}
+}
+
+func (_c *MockBlogService_AddRecentView_Call) Run(run func(ctx context.Context, userID uuid.UUID, blogID uuid.UUID)) *MockBlogService_AddRecentView_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockBlogService_AddRecentView_Call) Return(err error) *MockBlogService_AddRecentView_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockBlogService_AddRecentView_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, blogID uuid.UUID) error) *MockBlogService_AddRecentView_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Approve provides a mock function for the type MockBlogService
+func (_mock *MockBlogService) Approve(ctx context.Context, id uuid.UUID) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Approve")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockBlogService_Approve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Approve'
+type MockBlogService_Approve_Call struct {
+	*mock.Call
+}
+
+// Approve is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockBlogService_Expecter) Approve(ctx interface{}, id interface{}) *MockBlogService_Approve_Call {
+	return &MockBlogService_Approve_Call{Call: _e.mock.On("Approve", ctx, id)}
+}
+
+func (_c *MockBlogService_Approve_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockBlogService_Approve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockBlogService_Approve_Call) Return(err error) *MockBlogService_Approve_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockBlogService_Approve_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *MockBlogService_Approve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Count provides a mock function for the type MockBlogService
+func (_mock *MockBlogService) Count(ctx context.Context) (*model.BlogCountResponse, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 *model.BlogCountResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*model.BlogCountResponse, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *model.BlogCountResponse); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BlogCountResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBlogService_Count_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Count'
+type MockBlogService_Count_Call struct {
+	*mock.Call
+}
+
+// Count is a helper method to define mock.On call
+//   - ctx
+func (_e *MockBlogService_Expecter) Count(ctx interface{}) *MockBlogService_Count_Call {
+	return &MockBlogService_Count_Call{Call: _e.mock.On("Count", ctx)}
+}
+
+func (_c *MockBlogService_Count_Call) Run(run func(ctx context.Context)) *MockBlogService_Count_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockBlogService_Count_Call) Return(blogCountResponse *model.BlogCountResponse, err error) *MockBlogService_Count_Call {
+	_c.Call.Return(blogCountResponse, err)
+	return _c
+}
+
+func (_c *MockBlogService_Count_Call) RunAndReturn(run func(ctx context.Context) (*model.BlogCountResponse, error)) *MockBlogService_Count_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountByUserID provides a mock function for the type MockBlogService
+func (_mock *MockBlogService) CountByUserID(ctx context.Context, id uuid.UUID, publishedOnly bool) (*model.BlogCountResponse, error) {
+	ret := _mock.Called(ctx, id, publishedOnly)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByUserID")
+	}
+
+	var r0 *model.BlogCountResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool) (*model.BlogCountResponse, error)); ok {
+		return returnFunc(ctx, id, publishedOnly)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool) *model.BlogCountResponse); ok {
+		r0 = returnFunc(ctx, id, publishedOnly)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BlogCountResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, bool) error); ok {
+		r1 = returnFunc(ctx, id, publishedOnly)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBlogService_CountByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountByUserID'
+type MockBlogService_CountByUserID_Call struct {
+	*mock.Call
+}
+
+// CountByUserID is a helper method to define mock.On call
+//   - ctx
+//   - id
+//   - publishedOnly
+func (_e *MockBlogService_Expecter) CountByUserID(ctx interface{}, id interface{}, publishedOnly interface{}) *MockBlogService_CountByUserID_Call {
+	return &MockBlogService_CountByUserID_Call{Call: _e.mock.On("CountByUserID", ctx, id, publishedOnly)}
+}
+
+func (_c *MockBlogService_CountByUserID_Call) Run(run func(ctx context.Context, id uuid.UUID, publishedOnly bool)) *MockBlogService_CountByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockBlogService_CountByUserID_Call) Return(blogCountResponse *model.BlogCountResponse, err error) *MockBlogService_CountByUserID_Call {
+	_c.Call.Return(blogCountResponse, err)
+	return _c
+}
+
+func (_c *MockBlogService_CountByUserID_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, publishedOnly bool) (*model.BlogCountResponse, error)) *MockBlogService_CountByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function for the type MockBlogService
-func (_mock *MockBlogService) Create(ctx context.Context, blog *model.Blog) error {
-	ret := _mock.Called(ctx, blog)
+func (_mock *MockBlogService) Create(ctx context.Context, blog *model.Blog, trusted bool) error {
+	ret := _mock.Called(ctx, blog, trusted)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Blog) error); ok {
-		r0 = returnFunc(ctx, blog)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Blog, bool) error); ok {
+		r0 = returnFunc(ctx, blog, trusted)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -64,13 +272,14 @@ type MockBlogService_Create_Call struct {
 // Create is a helper method to define mock.On call
 //   - ctx
 //   - blog
-func (_e *MockBlogService_Expecter) Create(ctx interface{}, blog interface{}) *MockBlogService_Create_Call {
-	return &MockBlogService_Create_Call{Call: _e.mock.On("Create", ctx, blog)}
+//   - trusted
+func (_e *MockBlogService_Expecter) Create(ctx interface{}, blog interface{}, trusted interface{}) *MockBlogService_Create_Call {
+	return &MockBlogService_Create_Call{Call: _e.mock.On("Create", ctx, blog, trusted)}
 }
 
-func (_c *MockBlogService_Create_Call) Run(run func(ctx context.Context, blog *model.Blog)) *MockBlogService_Create_Call {
+func (_c *MockBlogService_Create_Call) Run(run func(ctx context.Context, blog *model.Blog, trusted bool)) *MockBlogService_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*model.Blog))
+		run(args[0].(context.Context), args[1].(*model.Blog), args[2].(bool))
 	})
 	return _c
 }
@@ -80,7 +289,72 @@ func (_c *MockBlogService_Create_Call) Return(err error) *MockBlogService_Create
 	return _c
 }
 
-func (_c *MockBlogService_Create_Call) RunAndReturn(run func(ctx context.Context, blog *model.Blog) error) *MockBlogService_Create_Call {
+func (_c *MockBlogService_Create_Call) RunAndReturn(run func(ctx context.Context, blog *model.Blog, trusted bool) error) *MockBlogService_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateIdempotent provides a mock function for the type MockBlogService
+func (_mock *MockBlogService) CreateIdempotent(ctx context.Context, blog *model.Blog, key string, trusted bool) (*model.Blog, bool, error) {
+	ret := _mock.Called(ctx, blog, key, trusted)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateIdempotent")
+	}
+
+	var r0 *model.Blog
+	var r1 bool
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Blog, string, bool) (*model.Blog, bool, error)); ok {
+		return returnFunc(ctx, blog, key, trusted)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Blog, string, bool) *model.Blog); ok {
+		r0 = returnFunc(ctx, blog, key, trusted)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Blog)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *model.Blog, string, bool) bool); ok {
+		r1 = returnFunc(ctx, blog, key, trusted)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, *model.Blog, string, bool) error); ok {
+		r2 = returnFunc(ctx, blog, key, trusted)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockBlogService_CreateIdempotent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateIdempotent'
+type MockBlogService_CreateIdempotent_Call struct {
+	*mock.Call
+}
+
+// CreateIdempotent is a helper method to define mock.On call
+//   - ctx
+//   - blog
+//   - key
+//   - trusted
+func (_e *MockBlogService_Expecter) CreateIdempotent(ctx interface{}, blog interface{}, key interface{}, trusted interface{}) *MockBlogService_CreateIdempotent_Call {
+	return &MockBlogService_CreateIdempotent_Call{Call: _e.mock.On("CreateIdempotent", ctx, blog, key, trusted)}
+}
+
+func (_c *MockBlogService_CreateIdempotent_Call) Run(run func(ctx context.Context, blog *model.Blog, key string, trusted bool)) *MockBlogService_CreateIdempotent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Blog), args[2].(string), args[3].(bool))
+	})
+	return _c
+}
+
+func (_c *MockBlogService_CreateIdempotent_Call) Return(blog1 *model.Blog, b bool, err error) *MockBlogService_CreateIdempotent_Call {
+	_c.Call.Return(blog1, b, err)
+	return _c
+}
+
+func (_c *MockBlogService_CreateIdempotent_Call) RunAndReturn(run func(ctx context.Context, blog *model.Blog, key string, trusted bool) (*model.Blog, bool, error)) *MockBlogService_CreateIdempotent_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -177,139 +451,185 @@ func (_c *MockBlogService_DeleteBlogsByUserID_Call) RunAndReturn(run func(ctx co
 	return _c
 }
 
-// Get provides a mock function for the type MockBlogService
-func (_mock *MockBlogService) Get(ctx context.Context, id uuid.UUID) (*model.Blog, error) {
-	ret := _mock.Called(ctx, id)
+// DeleteIfUnmodifiedSince provides a mock function for the type MockBlogService
+func (_mock *MockBlogService) DeleteIfUnmodifiedSince(ctx context.Context, id uuid.UUID, since time.Time) error {
+	ret := _mock.Called(ctx, id, since)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Get")
+		panic("no return value specified for DeleteIfUnmodifiedSince")
 	}
 
-	var r0 *model.Blog
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r0 = returnFunc(ctx, id, since)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockBlogService_DeleteIfUnmodifiedSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteIfUnmodifiedSince'
+type MockBlogService_DeleteIfUnmodifiedSince_Call struct {
+	*mock.Call
+}
+
+// DeleteIfUnmodifiedSince is a helper method to define mock.On call
+//   - ctx
+//   - id
+//   - since
+func (_e *MockBlogService_Expecter) DeleteIfUnmodifiedSince(ctx interface{}, id interface{}, since interface{}) *MockBlogService_DeleteIfUnmodifiedSince_Call {
+	return &MockBlogService_DeleteIfUnmodifiedSince_Call{Call: _e.mock.On("DeleteIfUnmodifiedSince", ctx, id, since)}
+}
+
+func (_c *MockBlogService_DeleteIfUnmodifiedSince_Call) Run(run func(ctx context.Context, id uuid.UUID, since time.Time)) *MockBlogService_DeleteIfUnmodifiedSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockBlogService_DeleteIfUnmodifiedSince_Call) Return(err error) *MockBlogService_DeleteIfUnmodifiedSince_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockBlogService_DeleteIfUnmodifiedSince_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, since time.Time) error) *MockBlogService_DeleteIfUnmodifiedSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteMany provides a mock function for the type MockBlogService
+func (_mock *MockBlogService) DeleteMany(ctx context.Context, ids []uuid.UUID, ownerID uuid.UUID) (int, error) {
+	ret := _mock.Called(ctx, ids, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMany")
+	}
+
+	var r0 int
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.Blog, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID, uuid.UUID) (int, error)); ok {
+		return returnFunc(ctx, ids, ownerID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.Blog); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID, uuid.UUID) int); ok {
+		r0 = returnFunc(ctx, ids, ownerID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.Blog)
-		}
+		r0 = ret.Get(0).(int)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []uuid.UUID, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, ids, ownerID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockBlogService_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
-type MockBlogService_Get_Call struct {
+// MockBlogService_DeleteMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMany'
+type MockBlogService_DeleteMany_Call struct {
 	*mock.Call
 }
 
-// Get is a helper method to define mock.On call
+// DeleteMany is a helper method to define mock.On call
 //   - ctx
-//   - id
-func (_e *MockBlogService_Expecter) Get(ctx interface{}, id interface{}) *MockBlogService_Get_Call {
-	return &MockBlogService_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+//   - ids
+//   - ownerID
+func (_e *MockBlogService_Expecter) DeleteMany(ctx interface{}, ids interface{}, ownerID interface{}) *MockBlogService_DeleteMany_Call {
+	return &MockBlogService_DeleteMany_Call{Call: _e.mock.On("DeleteMany", ctx, ids, ownerID)}
 }
 
-func (_c *MockBlogService_Get_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockBlogService_Get_Call {
+func (_c *MockBlogService_DeleteMany_Call) Run(run func(ctx context.Context, ids []uuid.UUID, ownerID uuid.UUID)) *MockBlogService_DeleteMany_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].([]uuid.UUID), args[2].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockBlogService_Get_Call) Return(blog *model.Blog, err error) *MockBlogService_Get_Call {
-	_c.Call.Return(blog, err)
+func (_c *MockBlogService_DeleteMany_Call) Return(n int, err error) *MockBlogService_DeleteMany_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockBlogService_Get_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*model.Blog, error)) *MockBlogService_Get_Call {
+func (_c *MockBlogService_DeleteMany_Call) RunAndReturn(run func(ctx context.Context, ids []uuid.UUID, ownerID uuid.UUID) (int, error)) *MockBlogService_DeleteMany_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAll provides a mock function for the type MockBlogService
-func (_mock *MockBlogService) GetAll(ctx context.Context, limit int, offset int) (*model.BlogListResponse, error) {
-	ret := _mock.Called(ctx, limit, offset)
+// Exists provides a mock function for the type MockBlogService
+func (_mock *MockBlogService) Exists(ctx context.Context, ids []uuid.UUID, viewerID uuid.UUID) (map[uuid.UUID]bool, error) {
+	ret := _mock.Called(ctx, ids, viewerID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAll")
+		panic("no return value specified for Exists")
 	}
 
-	var r0 *model.BlogListResponse
+	var r0 map[uuid.UUID]bool
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) (*model.BlogListResponse, error)); ok {
-		return returnFunc(ctx, limit, offset)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID, uuid.UUID) (map[uuid.UUID]bool, error)); ok {
+		return returnFunc(ctx, ids, viewerID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) *model.BlogListResponse); ok {
-		r0 = returnFunc(ctx, limit, offset)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID, uuid.UUID) map[uuid.UUID]bool); ok {
+		r0 = returnFunc(ctx, ids, viewerID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.BlogListResponse)
+			r0 = ret.Get(0).(map[uuid.UUID]bool)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
-		r1 = returnFunc(ctx, limit, offset)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []uuid.UUID, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, ids, viewerID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockBlogService_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
-type MockBlogService_GetAll_Call struct {
+// MockBlogService_Exists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exists'
+type MockBlogService_Exists_Call struct {
 	*mock.Call
 }
 
-// GetAll is a helper method to define mock.On call
+// Exists is a helper method to define mock.On call
 //   - ctx
-//   - limit
-//   - offset
-func (_e *MockBlogService_Expecter) GetAll(ctx interface{}, limit interface{}, offset interface{}) *MockBlogService_GetAll_Call {
-	return &MockBlogService_GetAll_Call{Call: _e.mock.On("GetAll", ctx, limit, offset)}
+//   - ids
+//   - viewerID
+func (_e *MockBlogService_Expecter) Exists(ctx interface{}, ids interface{}, viewerID interface{}) *MockBlogService_Exists_Call {
+	return &MockBlogService_Exists_Call{Call: _e.mock.On("Exists", ctx, ids, viewerID)}
 }
 
-func (_c *MockBlogService_GetAll_Call) Run(run func(ctx context.Context, limit int, offset int)) *MockBlogService_GetAll_Call {
+func (_c *MockBlogService_Exists_Call) Run(run func(ctx context.Context, ids []uuid.UUID, viewerID uuid.UUID)) *MockBlogService_Exists_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int), args[2].(int))
+		run(args[0].(context.Context), args[1].([]uuid.UUID), args[2].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockBlogService_GetAll_Call) Return(blogListResponse *model.BlogListResponse, err error) *MockBlogService_GetAll_Call {
-	_c.Call.Return(blogListResponse, err)
+func (_c *MockBlogService_Exists_Call) Return(m map[uuid.UUID]bool, err error) *MockBlogService_Exists_Call {
+	_c.Call.Return(m, err)
 	return _c
 }
 
-func (_c *MockBlogService_GetAll_Call) RunAndReturn(run func(ctx context.Context, limit int, offset int) (*model.BlogListResponse, error)) *MockBlogService_GetAll_Call {
+func (_c *MockBlogService_Exists_Call) RunAndReturn(run func(ctx context.Context, ids []uuid.UUID, viewerID uuid.UUID) (map[uuid.UUID]bool, error)) *MockBlogService_Exists_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByUserID provides a mock function for the type MockBlogService
-func (_mock *MockBlogService) GetByUserID(ctx context.Context, id uuid.UUID) ([]*model.Blog, error) {
+// Get provides a mock function for the type MockBlogService
+func (_mock *MockBlogService) Get(ctx context.Context, id uuid.UUID) (*model.Blog, error) {
 	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByUserID")
+		panic("no return value specified for Get")
 	}
 
-	var r0 []*model.Blog
+	var r0 *model.Blog
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*model.Blog, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.Blog, error)); ok {
 		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*model.Blog); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.Blog); ok {
 		r0 = returnFunc(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.Blog)
+			r0 = ret.Get(0).(*model.Blog)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
@@ -320,6 +640,238 @@ func (_mock *MockBlogService) GetByUserID(ctx context.Context, id uuid.UUID) ([]
 	return r0, r1
 }
 
+// MockBlogService_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockBlogService_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockBlogService_Expecter) Get(ctx interface{}, id interface{}) *MockBlogService_Get_Call {
+	return &MockBlogService_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+}
+
+func (_c *MockBlogService_Get_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockBlogService_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockBlogService_Get_Call) Return(blog *model.Blog, err error) *MockBlogService_Get_Call {
+	_c.Call.Return(blog, err)
+	return _c
+}
+
+func (_c *MockBlogService_Get_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*model.Blog, error)) *MockBlogService_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function for the type MockBlogService
+func (_mock *MockBlogService) GetAll(ctx context.Context, filter model.BlogFilter, limit int, offset int) (*model.BlogListResponse, error) {
+	ret := _mock.Called(ctx, filter, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 *model.BlogListResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.BlogFilter, int, int) (*model.BlogListResponse, error)); ok {
+		return returnFunc(ctx, filter, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.BlogFilter, int, int) *model.BlogListResponse); ok {
+		r0 = returnFunc(ctx, filter, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BlogListResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model.BlogFilter, int, int) error); ok {
+		r1 = returnFunc(ctx, filter, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBlogService_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type MockBlogService_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+//   - ctx
+//   - filter
+//   - limit
+//   - offset
+func (_e *MockBlogService_Expecter) GetAll(ctx interface{}, filter interface{}, limit interface{}, offset interface{}) *MockBlogService_GetAll_Call {
+	return &MockBlogService_GetAll_Call{Call: _e.mock.On("GetAll", ctx, filter, limit, offset)}
+}
+
+func (_c *MockBlogService_GetAll_Call) Run(run func(ctx context.Context, filter model.BlogFilter, limit int, offset int)) *MockBlogService_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.BlogFilter), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockBlogService_GetAll_Call) Return(blogListResponse *model.BlogListResponse, err error) *MockBlogService_GetAll_Call {
+	_c.Call.Return(blogListResponse, err)
+	return _c
+}
+
+func (_c *MockBlogService_GetAll_Call) RunAndReturn(run func(ctx context.Context, filter model.BlogFilter, limit int, offset int) (*model.BlogListResponse, error)) *MockBlogService_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBySlug provides a mock function for the type MockBlogService
+func (_mock *MockBlogService) GetBySlug(ctx context.Context, slug string) (*model.Blog, error) {
+	ret := _mock.Called(ctx, slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBySlug")
+	}
+
+	var r0 *model.Blog
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.Blog, error)); ok {
+		return returnFunc(ctx, slug)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.Blog); ok {
+		r0 = returnFunc(ctx, slug)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Blog)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, slug)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBlogService_GetBySlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBySlug'
+type MockBlogService_GetBySlug_Call struct {
+	*mock.Call
+}
+
+// GetBySlug is a helper method to define mock.On call
+//   - ctx
+//   - slug
+func (_e *MockBlogService_Expecter) GetBySlug(ctx interface{}, slug interface{}) *MockBlogService_GetBySlug_Call {
+	return &MockBlogService_GetBySlug_Call{Call: _e.mock.On("GetBySlug", ctx, slug)}
+}
+
+func (_c *MockBlogService_GetBySlug_Call) Run(run func(ctx context.Context, slug string)) *MockBlogService_GetBySlug_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockBlogService_GetBySlug_Call) Return(blog *model.Blog, err error) *MockBlogService_GetBySlug_Call {
+	_c.Call.Return(blog, err)
+	return _c
+}
+
+func (_c *MockBlogService_GetBySlug_Call) RunAndReturn(run func(ctx context.Context, slug string) (*model.Blog, error)) *MockBlogService_GetBySlug_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByTag provides a mock function for the type MockBlogService
+func (_mock *MockBlogService) GetByTag(ctx context.Context, tag string, limit int, offset int) (*model.BlogListResponse, error) {
+	ret := _mock.Called(ctx, tag, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByTag")
+	}
+
+	var r0 *model.BlogListResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, int) (*model.BlogListResponse, error)); ok {
+		return returnFunc(ctx, tag, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, int) *model.BlogListResponse); ok {
+		r0 = returnFunc(ctx, tag, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BlogListResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = returnFunc(ctx, tag, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBlogService_GetByTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByTag'
+type MockBlogService_GetByTag_Call struct {
+	*mock.Call
+}
+
+// GetByTag is a helper method to define mock.On call
+//   - ctx
+//   - tag
+//   - limit
+//   - offset
+func (_e *MockBlogService_Expecter) GetByTag(ctx interface{}, tag interface{}, limit interface{}, offset interface{}) *MockBlogService_GetByTag_Call {
+	return &MockBlogService_GetByTag_Call{Call: _e.mock.On("GetByTag", ctx, tag, limit, offset)}
+}
+
+func (_c *MockBlogService_GetByTag_Call) Run(run func(ctx context.Context, tag string, limit int, offset int)) *MockBlogService_GetByTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockBlogService_GetByTag_Call) Return(blogListResponse *model.BlogListResponse, err error) *MockBlogService_GetByTag_Call {
+	_c.Call.Return(blogListResponse, err)
+	return _c
+}
+
+func (_c *MockBlogService_GetByTag_Call) RunAndReturn(run func(ctx context.Context, tag string, limit int, offset int) (*model.BlogListResponse, error)) *MockBlogService_GetByTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUserID provides a mock function for the type MockBlogService
+func (_mock *MockBlogService) GetByUserID(ctx context.Context, id uuid.UUID, publishedOnly bool, limit int, offset int) (*model.BlogListResponse, error) {
+	ret := _mock.Called(ctx, id, publishedOnly, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUserID")
+	}
+
+	var r0 *model.BlogListResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, int, int) (*model.BlogListResponse, error)); ok {
+		return returnFunc(ctx, id, publishedOnly, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool, int, int) *model.BlogListResponse); ok {
+		r0 = returnFunc(ctx, id, publishedOnly, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BlogListResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, bool, int, int) error); ok {
+		r1 = returnFunc(ctx, id, publishedOnly, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
 // MockBlogService_GetByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUserID'
 type MockBlogService_GetByUserID_Call struct {
 	*mock.Call
@@ -328,38 +880,842 @@ type MockBlogService_GetByUserID_Call struct {
 // GetByUserID is a helper method to define mock.On call
 //   - ctx
 //   - id
-func (_e *MockBlogService_Expecter) GetByUserID(ctx interface{}, id interface{}) *MockBlogService_GetByUserID_Call {
-	return &MockBlogService_GetByUserID_Call{Call: _e.mock.On("GetByUserID", ctx, id)}
+//   - publishedOnly
+//   - limit
+//   - offset
+func (_e *MockBlogService_Expecter) GetByUserID(ctx interface{}, id interface{}, publishedOnly interface{}, limit interface{}, offset interface{}) *MockBlogService_GetByUserID_Call {
+	return &MockBlogService_GetByUserID_Call{Call: _e.mock.On("GetByUserID", ctx, id, publishedOnly, limit, offset)}
 }
 
-func (_c *MockBlogService_GetByUserID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockBlogService_GetByUserID_Call {
+func (_c *MockBlogService_GetByUserID_Call) Run(run func(ctx context.Context, id uuid.UUID, publishedOnly bool, limit int, offset int)) *MockBlogService_GetByUserID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(bool), args[3].(int), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *MockBlogService_GetByUserID_Call) Return(blogListResponse *model.BlogListResponse, err error) *MockBlogService_GetByUserID_Call {
+	_c.Call.Return(blogListResponse, err)
+	return _c
+}
+
+func (_c *MockBlogService_GetByUserID_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, publishedOnly bool, limit int, offset int) (*model.BlogListResponse, error)) *MockBlogService_GetByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingReview provides a mock function for the type MockBlogService
+func (_mock *MockBlogService) GetPendingReview(ctx context.Context, limit int, offset int) ([]*model.Blog, error) {
+	ret := _mock.Called(ctx, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingReview")
+	}
+
+	var r0 []*model.Blog
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) ([]*model.Blog, error)); ok {
+		return returnFunc(ctx, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) []*model.Blog); ok {
+		r0 = returnFunc(ctx, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Blog)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = returnFunc(ctx, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBlogService_GetPendingReview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingReview'
+type MockBlogService_GetPendingReview_Call struct {
+	*mock.Call
+}
+
+// GetPendingReview is a helper method to define mock.On call
+//   - ctx
+//   - limit
+//   - offset
+func (_e *MockBlogService_Expecter) GetPendingReview(ctx interface{}, limit interface{}, offset interface{}) *MockBlogService_GetPendingReview_Call {
+	return &MockBlogService_GetPendingReview_Call{Call: _e.mock.On("GetPendingReview", ctx, limit, offset)}
+}
+
+func (_c *MockBlogService_GetPendingReview_Call) Run(run func(ctx context.Context, limit int, offset int)) *MockBlogService_GetPendingReview_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockBlogService_GetPendingReview_Call) Return(blogs []*model.Blog, err error) *MockBlogService_GetPendingReview_Call {
+	_c.Call.Return(blogs, err)
+	return _c
+}
+
+func (_c *MockBlogService_GetPendingReview_Call) RunAndReturn(run func(ctx context.Context, limit int, offset int) ([]*model.Blog, error)) *MockBlogService_GetPendingReview_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPopular provides a mock function for the type MockBlogService
+func (_mock *MockBlogService) GetPopular(ctx context.Context, limit int) ([]*model.Blog, error) {
+	ret := _mock.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPopular")
+	}
+
+	var r0 []*model.Blog
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]*model.Blog, error)); ok {
+		return returnFunc(ctx, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []*model.Blog); ok {
+		r0 = returnFunc(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Blog)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBlogService_GetPopular_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPopular'
+type MockBlogService_GetPopular_Call struct {
+	*mock.Call
+}
+
+// GetPopular is a helper method to define mock.On call
+//   - ctx
+//   - limit
+func (_e *MockBlogService_Expecter) GetPopular(ctx interface{}, limit interface{}) *MockBlogService_GetPopular_Call {
+	return &MockBlogService_GetPopular_Call{Call: _e.mock.On("GetPopular", ctx, limit)}
+}
+
+func (_c *MockBlogService_GetPopular_Call) Run(run func(ctx context.Context, limit int)) *MockBlogService_GetPopular_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
 	})
 	return _c
 }
 
-func (_c *MockBlogService_GetByUserID_Call) Return(blogs []*model.Blog, err error) *MockBlogService_GetByUserID_Call {
+func (_c *MockBlogService_GetPopular_Call) Return(blogs []*model.Blog, err error) *MockBlogService_GetPopular_Call {
 	_c.Call.Return(blogs, err)
 	return _c
 }
 
-func (_c *MockBlogService_GetByUserID_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) ([]*model.Blog, error)) *MockBlogService_GetByUserID_Call {
+func (_c *MockBlogService_GetPopular_Call) RunAndReturn(run func(ctx context.Context, limit int) ([]*model.Blog, error)) *MockBlogService_GetPopular_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRecentViews provides a mock function for the type MockBlogService
+func (_mock *MockBlogService) GetRecentViews(ctx context.Context, userID uuid.UUID) ([]*model.Blog, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecentViews")
+	}
+
+	var r0 []*model.Blog
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*model.Blog, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*model.Blog); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Blog)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBlogService_GetRecentViews_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRecentViews'
+type MockBlogService_GetRecentViews_Call struct {
+	*mock.Call
+}
+
+// GetRecentViews is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockBlogService_Expecter) GetRecentViews(ctx interface{}, userID interface{}) *MockBlogService_GetRecentViews_Call {
+	return &MockBlogService_GetRecentViews_Call{Call: _e.mock.On("GetRecentViews", ctx, userID)}
+}
+
+func (_c *MockBlogService_GetRecentViews_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockBlogService_GetRecentViews_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockBlogService_GetRecentViews_Call) Return(blogs []*model.Blog, err error) *MockBlogService_GetRecentViews_Call {
+	_c.Call.Return(blogs, err)
+	return _c
+}
+
+func (_c *MockBlogService_GetRecentViews_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) ([]*model.Blog, error)) *MockBlogService_GetRecentViews_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSiblings provides a mock function for the type MockBlogService
+func (_mock *MockBlogService) GetSiblings(ctx context.Context, blog *model.Blog, authorOnly bool) (*model.BlogSiblings, error) {
+	ret := _mock.Called(ctx, blog, authorOnly)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSiblings")
+	}
+
+	var r0 *model.BlogSiblings
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Blog, bool) (*model.BlogSiblings, error)); ok {
+		return returnFunc(ctx, blog, authorOnly)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Blog, bool) *model.BlogSiblings); ok {
+		r0 = returnFunc(ctx, blog, authorOnly)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BlogSiblings)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *model.Blog, bool) error); ok {
+		r1 = returnFunc(ctx, blog, authorOnly)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBlogService_GetSiblings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSiblings'
+type MockBlogService_GetSiblings_Call struct {
+	*mock.Call
+}
+
+// GetSiblings is a helper method to define mock.On call
+//   - ctx
+//   - blog
+//   - authorOnly
+func (_e *MockBlogService_Expecter) GetSiblings(ctx interface{}, blog interface{}, authorOnly interface{}) *MockBlogService_GetSiblings_Call {
+	return &MockBlogService_GetSiblings_Call{Call: _e.mock.On("GetSiblings", ctx, blog, authorOnly)}
+}
+
+func (_c *MockBlogService_GetSiblings_Call) Run(run func(ctx context.Context, blog *model.Blog, authorOnly bool)) *MockBlogService_GetSiblings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Blog), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockBlogService_GetSiblings_Call) Return(blogSiblings *model.BlogSiblings, err error) *MockBlogService_GetSiblings_Call {
+	_c.Call.Return(blogSiblings, err)
+	return _c
+}
+
+func (_c *MockBlogService_GetSiblings_Call) RunAndReturn(run func(ctx context.Context, blog *model.Blog, authorOnly bool) (*model.BlogSiblings, error)) *MockBlogService_GetSiblings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTopTags provides a mock function for the type MockBlogService
+func (_mock *MockBlogService) GetTopTags(ctx context.Context, userID uuid.UUID, limit int) ([]*model.TagCount, error) {
+	ret := _mock.Called(ctx, userID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTopTags")
+	}
+
+	var r0 []*model.TagCount
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) ([]*model.TagCount, error)); ok {
+		return returnFunc(ctx, userID, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) []*model.TagCount); ok {
+		r0 = returnFunc(ctx, userID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.TagCount)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, int) error); ok {
+		r1 = returnFunc(ctx, userID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBlogService_GetTopTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTopTags'
+type MockBlogService_GetTopTags_Call struct {
+	*mock.Call
+}
+
+// GetTopTags is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - limit
+func (_e *MockBlogService_Expecter) GetTopTags(ctx interface{}, userID interface{}, limit interface{}) *MockBlogService_GetTopTags_Call {
+	return &MockBlogService_GetTopTags_Call{Call: _e.mock.On("GetTopTags", ctx, userID, limit)}
+}
+
+func (_c *MockBlogService_GetTopTags_Call) Run(run func(ctx context.Context, userID uuid.UUID, limit int)) *MockBlogService_GetTopTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockBlogService_GetTopTags_Call) Return(tagCounts []*model.TagCount, err error) *MockBlogService_GetTopTags_Call {
+	_c.Call.Return(tagCounts, err)
+	return _c
+}
+
+func (_c *MockBlogService_GetTopTags_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, limit int) ([]*model.TagCount, error)) *MockBlogService_GetTopTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWithAuthor provides a mock function for the type MockBlogService
+func (_mock *MockBlogService) GetWithAuthor(ctx context.Context, id uuid.UUID) (*model.BlogWithAuthor, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWithAuthor")
+	}
+
+	var r0 *model.BlogWithAuthor
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.BlogWithAuthor, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.BlogWithAuthor); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BlogWithAuthor)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBlogService_GetWithAuthor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWithAuthor'
+type MockBlogService_GetWithAuthor_Call struct {
+	*mock.Call
+}
+
+// GetWithAuthor is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockBlogService_Expecter) GetWithAuthor(ctx interface{}, id interface{}) *MockBlogService_GetWithAuthor_Call {
+	return &MockBlogService_GetWithAuthor_Call{Call: _e.mock.On("GetWithAuthor", ctx, id)}
+}
+
+func (_c *MockBlogService_GetWithAuthor_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockBlogService_GetWithAuthor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockBlogService_GetWithAuthor_Call) Return(blogWithAuthor *model.BlogWithAuthor, err error) *MockBlogService_GetWithAuthor_Call {
+	_c.Call.Return(blogWithAuthor, err)
+	return _c
+}
+
+func (_c *MockBlogService_GetWithAuthor_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*model.BlogWithAuthor, error)) *MockBlogService_GetWithAuthor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HardDelete provides a mock function for the type MockBlogService
+func (_mock *MockBlogService) HardDelete(ctx context.Context, id uuid.UUID) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HardDelete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockBlogService_HardDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HardDelete'
+type MockBlogService_HardDelete_Call struct {
+	*mock.Call
+}
+
+// HardDelete is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockBlogService_Expecter) HardDelete(ctx interface{}, id interface{}) *MockBlogService_HardDelete_Call {
+	return &MockBlogService_HardDelete_Call{Call: _e.mock.On("HardDelete", ctx, id)}
+}
+
+func (_c *MockBlogService_HardDelete_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockBlogService_HardDelete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockBlogService_HardDelete_Call) Return(err error) *MockBlogService_HardDelete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockBlogService_HardDelete_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *MockBlogService_HardDelete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IncrementViews provides a mock function for the type MockBlogService
+func (_mock *MockBlogService) IncrementViews(ctx context.Context, id uuid.UUID) (int, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementViews")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) int); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBlogService_IncrementViews_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementViews'
+type MockBlogService_IncrementViews_Call struct {
+	*mock.Call
+}
+
+// IncrementViews is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockBlogService_Expecter) IncrementViews(ctx interface{}, id interface{}) *MockBlogService_IncrementViews_Call {
+	return &MockBlogService_IncrementViews_Call{Call: _e.mock.On("IncrementViews", ctx, id)}
+}
+
+func (_c *MockBlogService_IncrementViews_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockBlogService_IncrementViews_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockBlogService_IncrementViews_Call) Return(n int, err error) *MockBlogService_IncrementViews_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockBlogService_IncrementViews_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (int, error)) *MockBlogService_IncrementViews_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsBlogOwner provides a mock function for the type MockBlogService
+func (_mock *MockBlogService) IsBlogOwner(ctx context.Context, blogID uuid.UUID, userID uuid.UUID) (bool, error) {
+	ret := _mock.Called(ctx, blogID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsBlogOwner")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (bool, error)); ok {
+		return returnFunc(ctx, blogID, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) bool); ok {
+		r0 = returnFunc(ctx, blogID, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, blogID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBlogService_IsBlogOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsBlogOwner'
+type MockBlogService_IsBlogOwner_Call struct {
+	*mock.Call
+}
+
+// IsBlogOwner is a helper method to define mock.On call
+//   - ctx
+//   - blogID
+//   - userID
+func (_e *MockBlogService_Expecter) IsBlogOwner(ctx interface{}, blogID interface{}, userID interface{}) *MockBlogService_IsBlogOwner_Call {
+	return &MockBlogService_IsBlogOwner_Call{Call: _e.mock.On("IsBlogOwner", ctx, blogID, userID)}
+}
+
+func (_c *MockBlogService_IsBlogOwner_Call) Run(run func(ctx context.Context, blogID uuid.UUID, userID uuid.UUID)) *MockBlogService_IsBlogOwner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockBlogService_IsBlogOwner_Call) Return(b bool, err error) *MockBlogService_IsBlogOwner_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockBlogService_IsBlogOwner_Call) RunAndReturn(run func(ctx context.Context, blogID uuid.UUID, userID uuid.UUID) (bool, error)) *MockBlogService_IsBlogOwner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Preview provides a mock function for the type MockBlogService
+func (_mock *MockBlogService) Preview(content string) (*model.ContentPreview, error) {
+	ret := _mock.Called(content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Preview")
+	}
+
+	var r0 *model.ContentPreview
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (*model.ContentPreview, error)); ok {
+		return returnFunc(content)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) *model.ContentPreview); ok {
+		r0 = returnFunc(content)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ContentPreview)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(content)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBlogService_Preview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Preview'
+type MockBlogService_Preview_Call struct {
+	*mock.Call
+}
+
+// Preview is a helper method to define mock.On call
+//   - content
+func (_e *MockBlogService_Expecter) Preview(content interface{}) *MockBlogService_Preview_Call {
+	return &MockBlogService_Preview_Call{Call: _e.mock.On("Preview", content)}
+}
+
+func (_c *MockBlogService_Preview_Call) Run(run func(content string)) *MockBlogService_Preview_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockBlogService_Preview_Call) Return(contentPreview *model.ContentPreview, err error) *MockBlogService_Preview_Call {
+	_c.Call.Return(contentPreview, err)
+	return _c
+}
+
+func (_c *MockBlogService_Preview_Call) RunAndReturn(run func(content string) (*model.ContentPreview, error)) *MockBlogService_Preview_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Publish provides a mock function for the type MockBlogService
+func (_mock *MockBlogService) Publish(ctx context.Context, id uuid.UUID, trusted bool) (string, error) {
+	ret := _mock.Called(ctx, id, trusted)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Publish")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool) (string, error)); ok {
+		return returnFunc(ctx, id, trusted)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool) string); ok {
+		r0 = returnFunc(ctx, id, trusted)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, bool) error); ok {
+		r1 = returnFunc(ctx, id, trusted)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBlogService_Publish_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Publish'
+type MockBlogService_Publish_Call struct {
+	*mock.Call
+}
+
+// Publish is a helper method to define mock.On call
+//   - ctx
+//   - id
+//   - trusted
+func (_e *MockBlogService_Expecter) Publish(ctx interface{}, id interface{}, trusted interface{}) *MockBlogService_Publish_Call {
+	return &MockBlogService_Publish_Call{Call: _e.mock.On("Publish", ctx, id, trusted)}
+}
+
+func (_c *MockBlogService_Publish_Call) Run(run func(ctx context.Context, id uuid.UUID, trusted bool)) *MockBlogService_Publish_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockBlogService_Publish_Call) Return(s string, err error) *MockBlogService_Publish_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockBlogService_Publish_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, trusted bool) (string, error)) *MockBlogService_Publish_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reject provides a mock function for the type MockBlogService
+func (_mock *MockBlogService) Reject(ctx context.Context, id uuid.UUID, reason string) error {
+	ret := _mock.Called(ctx, id, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reject")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = returnFunc(ctx, id, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockBlogService_Reject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reject'
+type MockBlogService_Reject_Call struct {
+	*mock.Call
+}
+
+// Reject is a helper method to define mock.On call
+//   - ctx
+//   - id
+//   - reason
+func (_e *MockBlogService_Expecter) Reject(ctx interface{}, id interface{}, reason interface{}) *MockBlogService_Reject_Call {
+	return &MockBlogService_Reject_Call{Call: _e.mock.On("Reject", ctx, id, reason)}
+}
+
+func (_c *MockBlogService_Reject_Call) Run(run func(ctx context.Context, id uuid.UUID, reason string)) *MockBlogService_Reject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockBlogService_Reject_Call) Return(err error) *MockBlogService_Reject_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockBlogService_Reject_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, reason string) error) *MockBlogService_Reject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function for the type MockBlogService
+func (_mock *MockBlogService) Restore(ctx context.Context, id uuid.UUID) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockBlogService_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type MockBlogService_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockBlogService_Expecter) Restore(ctx interface{}, id interface{}) *MockBlogService_Restore_Call {
+	return &MockBlogService_Restore_Call{Call: _e.mock.On("Restore", ctx, id)}
+}
+
+func (_c *MockBlogService_Restore_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockBlogService_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockBlogService_Restore_Call) Return(err error) *MockBlogService_Restore_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockBlogService_Restore_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *MockBlogService_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StreamAll provides a mock function for the type MockBlogService
+func (_mock *MockBlogService) StreamAll(ctx context.Context, filter model.BlogFilter, fn func(*model.Blog) error) error {
+	ret := _mock.Called(ctx, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamAll")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.BlogFilter, func(*model.Blog) error) error); ok {
+		r0 = returnFunc(ctx, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockBlogService_StreamAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamAll'
+type MockBlogService_StreamAll_Call struct {
+	*mock.Call
+}
+
+// StreamAll is a helper method to define mock.On call
+//   - ctx
+//   - filter
+//   - fn
+func (_e *MockBlogService_Expecter) StreamAll(ctx interface{}, filter interface{}, fn interface{}) *MockBlogService_StreamAll_Call {
+	return &MockBlogService_StreamAll_Call{Call: _e.mock.On("StreamAll", ctx, filter, fn)}
+}
+
+func (_c *MockBlogService_StreamAll_Call) Run(run func(ctx context.Context, filter model.BlogFilter, fn func(*model.Blog) error)) *MockBlogService_StreamAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.BlogFilter), args[2].(func(*model.Blog) error))
+	})
+	return _c
+}
+
+func (_c *MockBlogService_StreamAll_Call) Return(err error) *MockBlogService_StreamAll_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockBlogService_StreamAll_Call) RunAndReturn(run func(ctx context.Context, filter model.BlogFilter, fn func(*model.Blog) error) error) *MockBlogService_StreamAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StreamByUserID provides a mock function for the type MockBlogService
+func (_mock *MockBlogService) StreamByUserID(ctx context.Context, id uuid.UUID, fn func(*model.Blog) error) error {
+	ret := _mock.Called(ctx, id, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamByUserID")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, func(*model.Blog) error) error); ok {
+		r0 = returnFunc(ctx, id, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockBlogService_StreamByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamByUserID'
+type MockBlogService_StreamByUserID_Call struct {
+	*mock.Call
+}
+
+// StreamByUserID is a helper method to define mock.On call
+//   - ctx
+//   - id
+//   - fn
+func (_e *MockBlogService_Expecter) StreamByUserID(ctx interface{}, id interface{}, fn interface{}) *MockBlogService_StreamByUserID_Call {
+	return &MockBlogService_StreamByUserID_Call{Call: _e.mock.On("StreamByUserID", ctx, id, fn)}
+}
+
+func (_c *MockBlogService_StreamByUserID_Call) Run(run func(ctx context.Context, id uuid.UUID, fn func(*model.Blog) error)) *MockBlogService_StreamByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(func(*model.Blog) error))
+	})
+	return _c
+}
+
+func (_c *MockBlogService_StreamByUserID_Call) Return(err error) *MockBlogService_StreamByUserID_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockBlogService_StreamByUserID_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, fn func(*model.Blog) error) error) *MockBlogService_StreamByUserID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Update provides a mock function for the type MockBlogService
-func (_mock *MockBlogService) Update(ctx context.Context, blog *model.Blog) error {
-	ret := _mock.Called(ctx, blog)
+func (_mock *MockBlogService) Update(ctx context.Context, blog *model.Blog, trusted bool) error {
+	ret := _mock.Called(ctx, blog, trusted)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Blog) error); ok {
-		r0 = returnFunc(ctx, blog)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Blog, bool) error); ok {
+		r0 = returnFunc(ctx, blog, trusted)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -374,13 +1730,14 @@ type MockBlogService_Update_Call struct {
 // Update is a helper method to define mock.On call
 //   - ctx
 //   - blog
-func (_e *MockBlogService_Expecter) Update(ctx interface{}, blog interface{}) *MockBlogService_Update_Call {
-	return &MockBlogService_Update_Call{Call: _e.mock.On("Update", ctx, blog)}
+//   - trusted
+func (_e *MockBlogService_Expecter) Update(ctx interface{}, blog interface{}, trusted interface{}) *MockBlogService_Update_Call {
+	return &MockBlogService_Update_Call{Call: _e.mock.On("Update", ctx, blog, trusted)}
 }
 
-func (_c *MockBlogService_Update_Call) Run(run func(ctx context.Context, blog *model.Blog)) *MockBlogService_Update_Call {
+func (_c *MockBlogService_Update_Call) Run(run func(ctx context.Context, blog *model.Blog, trusted bool)) *MockBlogService_Update_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*model.Blog))
+		run(args[0].(context.Context), args[1].(*model.Blog), args[2].(bool))
 	})
 	return _c
 }
@@ -390,7 +1747,7 @@ func (_c *MockBlogService_Update_Call) Return(err error) *MockBlogService_Update
 	return _c
 }
 
-func (_c *MockBlogService_Update_Call) RunAndReturn(run func(ctx context.Context, blog *model.Blog) error) *MockBlogService_Update_Call {
+func (_c *MockBlogService_Update_Call) RunAndReturn(run func(ctx context.Context, blog *model.Blog, trusted bool) error) *MockBlogService_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
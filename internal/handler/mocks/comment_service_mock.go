@@ -0,0 +1,295 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/artnikel/blogapi/internal/model"
+	"github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockCommentService creates a new instance of MockCommentService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCommentService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCommentService {
+	mock := &MockCommentService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockCommentService is an autogenerated mock type for the CommentService type
+type MockCommentService struct {
+	mock.Mock
+}
+
+type MockCommentService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCommentService) EXPECT() *MockCommentService_Expecter {
+	return &MockCommentService_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockCommentService
+func (_mock *MockCommentService) Create(ctx context.Context, comment *model.Comment) error {
+	ret := _mock.Called(ctx, comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Comment) error); ok {
+		r0 = returnFunc(ctx, comment)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCommentService_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockCommentService_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx
+//   - comment
+func (_e *MockCommentService_Expecter) Create(ctx interface{}, comment interface{}) *MockCommentService_Create_Call {
+	return &MockCommentService_Create_Call{Call: _e.mock.On("Create", ctx, comment)}
+}
+
+func (_c *MockCommentService_Create_Call) Run(run func(ctx context.Context, comment *model.Comment)) *MockCommentService_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Comment))
+	})
+	return _c
+}
+
+func (_c *MockCommentService_Create_Call) Return(err error) *MockCommentService_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCommentService_Create_Call) RunAndReturn(run func(ctx context.Context, comment *model.Comment) error) *MockCommentService_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockCommentService
+func (_mock *MockCommentService) Delete(ctx context.Context, id uuid.UUID) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCommentService_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockCommentService_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockCommentService_Expecter) Delete(ctx interface{}, id interface{}) *MockCommentService_Delete_Call {
+	return &MockCommentService_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *MockCommentService_Delete_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockCommentService_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockCommentService_Delete_Call) Return(err error) *MockCommentService_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCommentService_Delete_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *MockCommentService_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockCommentService
+func (_mock *MockCommentService) Get(ctx context.Context, id uuid.UUID) (*model.Comment, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *model.Comment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.Comment, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.Comment); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Comment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCommentService_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockCommentService_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockCommentService_Expecter) Get(ctx interface{}, id interface{}) *MockCommentService_Get_Call {
+	return &MockCommentService_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+}
+
+func (_c *MockCommentService_Get_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockCommentService_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockCommentService_Get_Call) Return(comment *model.Comment, err error) *MockCommentService_Get_Call {
+	_c.Call.Return(comment, err)
+	return _c
+}
+
+func (_c *MockCommentService_Get_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*model.Comment, error)) *MockCommentService_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByBlogID provides a mock function for the type MockCommentService
+func (_mock *MockCommentService) GetByBlogID(ctx context.Context, blogID uuid.UUID, limit int, offset int) (*model.CommentListResponse, error) {
+	ret := _mock.Called(ctx, blogID, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByBlogID")
+	}
+
+	var r0 *model.CommentListResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, int) (*model.CommentListResponse, error)); ok {
+		return returnFunc(ctx, blogID, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, int) *model.CommentListResponse); ok {
+		r0 = returnFunc(ctx, blogID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.CommentListResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, int, int) error); ok {
+		r1 = returnFunc(ctx, blogID, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCommentService_GetByBlogID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByBlogID'
+type MockCommentService_GetByBlogID_Call struct {
+	*mock.Call
+}
+
+// GetByBlogID is a helper method to define mock.On call
+//   - ctx
+//   - blogID
+//   - limit
+//   - offset
+func (_e *MockCommentService_Expecter) GetByBlogID(ctx interface{}, blogID interface{}, limit interface{}, offset interface{}) *MockCommentService_GetByBlogID_Call {
+	return &MockCommentService_GetByBlogID_Call{Call: _e.mock.On("GetByBlogID", ctx, blogID, limit, offset)}
+}
+
+func (_c *MockCommentService_GetByBlogID_Call) Run(run func(ctx context.Context, blogID uuid.UUID, limit int, offset int)) *MockCommentService_GetByBlogID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockCommentService_GetByBlogID_Call) Return(commentListResponse *model.CommentListResponse, err error) *MockCommentService_GetByBlogID_Call {
+	_c.Call.Return(commentListResponse, err)
+	return _c
+}
+
+func (_c *MockCommentService_GetByBlogID_Call) RunAndReturn(run func(ctx context.Context, blogID uuid.UUID, limit int, offset int) (*model.CommentListResponse, error)) *MockCommentService_GetByBlogID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StreamByUserID provides a mock function for the type MockCommentService
+func (_mock *MockCommentService) StreamByUserID(ctx context.Context, userID uuid.UUID, fn func(*model.Comment) error) error {
+	ret := _mock.Called(ctx, userID, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamByUserID")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, func(*model.Comment) error) error); ok {
+		r0 = returnFunc(ctx, userID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCommentService_StreamByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamByUserID'
+type MockCommentService_StreamByUserID_Call struct {
+	*mock.Call
+}
+
+// StreamByUserID is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - fn
+func (_e *MockCommentService_Expecter) StreamByUserID(ctx interface{}, userID interface{}, fn interface{}) *MockCommentService_StreamByUserID_Call {
+	return &MockCommentService_StreamByUserID_Call{Call: _e.mock.On("StreamByUserID", ctx, userID, fn)}
+}
+
+func (_c *MockCommentService_StreamByUserID_Call) Run(run func(ctx context.Context, userID uuid.UUID, fn func(*model.Comment) error)) *MockCommentService_StreamByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(func(*model.Comment) error))
+	})
+	return _c
+}
+
+func (_c *MockCommentService_StreamByUserID_Call) Return(err error) *MockCommentService_StreamByUserID_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCommentService_StreamByUserID_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, fn func(*model.Comment) error) error) *MockCommentService_StreamByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/artnikel/blogapi/internal/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockDiagnosticsService creates a new instance of MockDiagnosticsService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDiagnosticsService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDiagnosticsService {
+	mock := &MockDiagnosticsService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDiagnosticsService is an autogenerated mock type for the DiagnosticsService type
+type MockDiagnosticsService struct {
+	mock.Mock
+}
+
+type MockDiagnosticsService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDiagnosticsService) EXPECT() *MockDiagnosticsService_Expecter {
+	return &MockDiagnosticsService_Expecter{mock: &_m.Mock}
+}
+
+// Diagnostics provides a mock function for the type MockDiagnosticsService
+func (_mock *MockDiagnosticsService) Diagnostics(ctx context.Context) (*model.Diagnostics, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Diagnostics")
+	}
+
+	var r0 *model.Diagnostics
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*model.Diagnostics, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *model.Diagnostics); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Diagnostics)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDiagnosticsService_Diagnostics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Diagnostics'
+type MockDiagnosticsService_Diagnostics_Call struct {
+	*mock.Call
+}
+
+// Diagnostics is a helper method to define mock.On call
+//   - ctx
+func (_e *MockDiagnosticsService_Expecter) Diagnostics(ctx interface{}) *MockDiagnosticsService_Diagnostics_Call {
+	return &MockDiagnosticsService_Diagnostics_Call{Call: _e.mock.On("Diagnostics", ctx)}
+}
+
+func (_c *MockDiagnosticsService_Diagnostics_Call) Run(run func(ctx context.Context)) *MockDiagnosticsService_Diagnostics_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockDiagnosticsService_Diagnostics_Call) Return(diagnostics *model.Diagnostics, err error) *MockDiagnosticsService_Diagnostics_Call {
+	_c.Call.Return(diagnostics, err)
+	return _c
+}
+
+func (_c *MockDiagnosticsService_Diagnostics_Call) RunAndReturn(run func(ctx context.Context) (*model.Diagnostics, error)) *MockDiagnosticsService_Diagnostics_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -40,6 +40,159 @@ func (_m *MockUserService) EXPECT() *MockUserService_Expecter {
 	return &MockUserService_Expecter{mock: &_m.Mock}
 }
 
+// ChangePassword provides a mock function for the type MockUserService
+func (_mock *MockUserService) ChangePassword(ctx context.Context, id uuid.UUID, oldPassword []byte, newPassword []byte) error {
+	ret := _mock.Called(ctx, id, oldPassword, newPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangePassword")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, []byte, []byte) error); ok {
+		r0 = returnFunc(ctx, id, oldPassword, newPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserService_ChangePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangePassword'
+type MockUserService_ChangePassword_Call struct {
+	*mock.Call
+}
+
+// ChangePassword is a helper method to define mock.On call
+//   - ctx
+//   - id
+//   - oldPassword
+//   - newPassword
+func (_e *MockUserService_Expecter) ChangePassword(ctx interface{}, id interface{}, oldPassword interface{}, newPassword interface{}) *MockUserService_ChangePassword_Call {
+	return &MockUserService_ChangePassword_Call{Call: _e.mock.On("ChangePassword", ctx, id, oldPassword, newPassword)}
+}
+
+func (_c *MockUserService_ChangePassword_Call) Run(run func(ctx context.Context, id uuid.UUID, oldPassword []byte, newPassword []byte)) *MockUserService_ChangePassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].([]byte), args[3].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockUserService_ChangePassword_Call) Return(err error) *MockUserService_ChangePassword_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserService_ChangePassword_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, oldPassword []byte, newPassword []byte) error) *MockUserService_ChangePassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ConfirmPasswordReset provides a mock function for the type MockUserService
+func (_mock *MockUserService) ConfirmPasswordReset(ctx context.Context, token string, newPassword []byte) error {
+	ret := _mock.Called(ctx, token, newPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmPasswordReset")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []byte) error); ok {
+		r0 = returnFunc(ctx, token, newPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserService_ConfirmPasswordReset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmPasswordReset'
+type MockUserService_ConfirmPasswordReset_Call struct {
+	*mock.Call
+}
+
+// ConfirmPasswordReset is a helper method to define mock.On call
+//   - ctx
+//   - token
+//   - newPassword
+func (_e *MockUserService_Expecter) ConfirmPasswordReset(ctx interface{}, token interface{}, newPassword interface{}) *MockUserService_ConfirmPasswordReset_Call {
+	return &MockUserService_ConfirmPasswordReset_Call{Call: _e.mock.On("ConfirmPasswordReset", ctx, token, newPassword)}
+}
+
+func (_c *MockUserService_ConfirmPasswordReset_Call) Run(run func(ctx context.Context, token string, newPassword []byte)) *MockUserService_ConfirmPasswordReset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockUserService_ConfirmPasswordReset_Call) Return(err error) *MockUserService_ConfirmPasswordReset_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserService_ConfirmPasswordReset_Call) RunAndReturn(run func(ctx context.Context, token string, newPassword []byte) error) *MockUserService_ConfirmPasswordReset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateInvites provides a mock function for the type MockUserService
+func (_mock *MockUserService) CreateInvites(ctx context.Context, adminID uuid.UUID, count int) ([]string, error) {
+	ret := _mock.Called(ctx, adminID, count)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateInvites")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) ([]string, error)); ok {
+		return returnFunc(ctx, adminID, count)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) []string); ok {
+		r0 = returnFunc(ctx, adminID, count)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, int) error); ok {
+		r1 = returnFunc(ctx, adminID, count)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_CreateInvites_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateInvites'
+type MockUserService_CreateInvites_Call struct {
+	*mock.Call
+}
+
+// CreateInvites is a helper method to define mock.On call
+//   - ctx
+//   - adminID
+//   - count
+func (_e *MockUserService_Expecter) CreateInvites(ctx interface{}, adminID interface{}, count interface{}) *MockUserService_CreateInvites_Call {
+	return &MockUserService_CreateInvites_Call{Call: _e.mock.On("CreateInvites", ctx, adminID, count)}
+}
+
+func (_c *MockUserService_CreateInvites_Call) Run(run func(ctx context.Context, adminID uuid.UUID, count int)) *MockUserService_CreateInvites_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockUserService_CreateInvites_Call) Return(ss []string, err error) *MockUserService_CreateInvites_Call {
+	_c.Call.Return(ss, err)
+	return _c
+}
+
+func (_c *MockUserService_CreateInvites_Call) RunAndReturn(run func(ctx context.Context, adminID uuid.UUID, count int) ([]string, error)) *MockUserService_CreateInvites_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteUserByID provides a mock function for the type MockUserService
 func (_mock *MockUserService) DeleteUserByID(ctx context.Context, id uuid.UUID) error {
 	ret := _mock.Called(ctx, id)
@@ -86,6 +239,235 @@ func (_c *MockUserService_DeleteUserByID_Call) RunAndReturn(run func(ctx context
 	return _c
 }
 
+// GetActivity provides a mock function for the type MockUserService
+func (_mock *MockUserService) GetActivity(ctx context.Context, id uuid.UUID, limit int, offset int) (*model.ActivityListResponse, error) {
+	ret := _mock.Called(ctx, id, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActivity")
+	}
+
+	var r0 *model.ActivityListResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, int) (*model.ActivityListResponse, error)); ok {
+		return returnFunc(ctx, id, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, int) *model.ActivityListResponse); ok {
+		r0 = returnFunc(ctx, id, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ActivityListResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, int, int) error); ok {
+		r1 = returnFunc(ctx, id, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_GetActivity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActivity'
+type MockUserService_GetActivity_Call struct {
+	*mock.Call
+}
+
+// GetActivity is a helper method to define mock.On call
+//   - ctx
+//   - id
+//   - limit
+//   - offset
+func (_e *MockUserService_Expecter) GetActivity(ctx interface{}, id interface{}, limit interface{}, offset interface{}) *MockUserService_GetActivity_Call {
+	return &MockUserService_GetActivity_Call{Call: _e.mock.On("GetActivity", ctx, id, limit, offset)}
+}
+
+func (_c *MockUserService_GetActivity_Call) Run(run func(ctx context.Context, id uuid.UUID, limit int, offset int)) *MockUserService_GetActivity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockUserService_GetActivity_Call) Return(activityListResponse *model.ActivityListResponse, err error) *MockUserService_GetActivity_Call {
+	_c.Call.Return(activityListResponse, err)
+	return _c
+}
+
+func (_c *MockUserService_GetActivity_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, limit int, offset int) (*model.ActivityListResponse, error)) *MockUserService_GetActivity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProfile provides a mock function for the type MockUserService
+func (_mock *MockUserService) GetProfile(ctx context.Context, id uuid.UUID) (*model.User, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProfile")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.User, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.User); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_GetProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProfile'
+type MockUserService_GetProfile_Call struct {
+	*mock.Call
+}
+
+// GetProfile is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockUserService_Expecter) GetProfile(ctx interface{}, id interface{}) *MockUserService_GetProfile_Call {
+	return &MockUserService_GetProfile_Call{Call: _e.mock.On("GetProfile", ctx, id)}
+}
+
+func (_c *MockUserService_GetProfile_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockUserService_GetProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockUserService_GetProfile_Call) Return(user *model.User, err error) *MockUserService_GetProfile_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserService_GetProfile_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*model.User, error)) *MockUserService_GetProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProfiles provides a mock function for the type MockUserService
+func (_mock *MockUserService) GetProfiles(ctx context.Context, ids []uuid.UUID) ([]*model.UserProfile, error) {
+	ret := _mock.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProfiles")
+	}
+
+	var r0 []*model.UserProfile
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID) ([]*model.UserProfile, error)); ok {
+		return returnFunc(ctx, ids)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID) []*model.UserProfile); ok {
+		r0 = returnFunc(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.UserProfile)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_GetProfiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProfiles'
+type MockUserService_GetProfiles_Call struct {
+	*mock.Call
+}
+
+// GetProfiles is a helper method to define mock.On call
+//   - ctx
+//   - ids
+func (_e *MockUserService_Expecter) GetProfiles(ctx interface{}, ids interface{}) *MockUserService_GetProfiles_Call {
+	return &MockUserService_GetProfiles_Call{Call: _e.mock.On("GetProfiles", ctx, ids)}
+}
+
+func (_c *MockUserService_GetProfiles_Call) Run(run func(ctx context.Context, ids []uuid.UUID)) *MockUserService_GetProfiles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockUserService_GetProfiles_Call) Return(userProfiles []*model.UserProfile, err error) *MockUserService_GetProfiles_Call {
+	_c.Call.Return(userProfiles, err)
+	return _c
+}
+
+func (_c *MockUserService_GetProfiles_Call) RunAndReturn(run func(ctx context.Context, ids []uuid.UUID) ([]*model.UserProfile, error)) *MockUserService_GetProfiles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Impersonate provides a mock function for the type MockUserService
+func (_mock *MockUserService) Impersonate(ctx context.Context, adminID uuid.UUID, id uuid.UUID) (string, error) {
+	ret := _mock.Called(ctx, adminID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Impersonate")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (string, error)); ok {
+		return returnFunc(ctx, adminID, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) string); ok {
+		r0 = returnFunc(ctx, adminID, id)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, adminID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_Impersonate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Impersonate'
+type MockUserService_Impersonate_Call struct {
+	*mock.Call
+}
+
+// Impersonate is a helper method to define mock.On call
+//   - ctx
+//   - adminID
+//   - id
+func (_e *MockUserService_Expecter) Impersonate(ctx interface{}, adminID interface{}, id interface{}) *MockUserService_Impersonate_Call {
+	return &MockUserService_Impersonate_Call{Call: _e.mock.On("Impersonate", ctx, adminID, id)}
+}
+
+func (_c *MockUserService_Impersonate_Call) Run(run func(ctx context.Context, adminID uuid.UUID, id uuid.UUID)) *MockUserService_Impersonate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockUserService_Impersonate_Call) Return(s string, err error) *MockUserService_Impersonate_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockUserService_Impersonate_Call) RunAndReturn(run func(ctx context.Context, adminID uuid.UUID, id uuid.UUID) (string, error)) *MockUserService_Impersonate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Login provides a mock function for the type MockUserService
 func (_mock *MockUserService) Login(ctx context.Context, user *model.User) (*service.TokenPair, error) {
 	ret := _mock.Called(ctx, user)
@@ -143,6 +525,99 @@ func (_c *MockUserService_Login_Call) RunAndReturn(run func(ctx context.Context,
 	return _c
 }
 
+// Logout provides a mock function for the type MockUserService
+func (_mock *MockUserService) Logout(ctx context.Context, id uuid.UUID, refreshToken string) error {
+	ret := _mock.Called(ctx, id, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = returnFunc(ctx, id, refreshToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserService_Logout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Logout'
+type MockUserService_Logout_Call struct {
+	*mock.Call
+}
+
+// Logout is a helper method to define mock.On call
+//   - ctx
+//   - id
+//   - refreshToken
+func (_e *MockUserService_Expecter) Logout(ctx interface{}, id interface{}, refreshToken interface{}) *MockUserService_Logout_Call {
+	return &MockUserService_Logout_Call{Call: _e.mock.On("Logout", ctx, id, refreshToken)}
+}
+
+func (_c *MockUserService_Logout_Call) Run(run func(ctx context.Context, id uuid.UUID, refreshToken string)) *MockUserService_Logout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockUserService_Logout_Call) Return(err error) *MockUserService_Logout_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserService_Logout_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, refreshToken string) error) *MockUserService_Logout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LogoutAll provides a mock function for the type MockUserService
+func (_mock *MockUserService) LogoutAll(ctx context.Context, id uuid.UUID) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LogoutAll")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserService_LogoutAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogoutAll'
+type MockUserService_LogoutAll_Call struct {
+	*mock.Call
+}
+
+// LogoutAll is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockUserService_Expecter) LogoutAll(ctx interface{}, id interface{}) *MockUserService_LogoutAll_Call {
+	return &MockUserService_LogoutAll_Call{Call: _e.mock.On("LogoutAll", ctx, id)}
+}
+
+func (_c *MockUserService_LogoutAll_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockUserService_LogoutAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockUserService_LogoutAll_Call) Return(err error) *MockUserService_LogoutAll_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserService_LogoutAll_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *MockUserService_LogoutAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Refresh provides a mock function for the type MockUserService
 func (_mock *MockUserService) Refresh(ctx context.Context, tokenPair service.TokenPair) (service.TokenPair, error) {
 	ret := _mock.Called(ctx, tokenPair)
@@ -198,6 +673,146 @@ func (_c *MockUserService_Refresh_Call) RunAndReturn(run func(ctx context.Contex
 	return _c
 }
 
+// RequestPasswordReset provides a mock function for the type MockUserService
+func (_mock *MockUserService) RequestPasswordReset(ctx context.Context, email string) error {
+	ret := _mock.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestPasswordReset")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserService_RequestPasswordReset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestPasswordReset'
+type MockUserService_RequestPasswordReset_Call struct {
+	*mock.Call
+}
+
+// RequestPasswordReset is a helper method to define mock.On call
+//   - ctx
+//   - email
+func (_e *MockUserService_Expecter) RequestPasswordReset(ctx interface{}, email interface{}) *MockUserService_RequestPasswordReset_Call {
+	return &MockUserService_RequestPasswordReset_Call{Call: _e.mock.On("RequestPasswordReset", ctx, email)}
+}
+
+func (_c *MockUserService_RequestPasswordReset_Call) Run(run func(ctx context.Context, email string)) *MockUserService_RequestPasswordReset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockUserService_RequestPasswordReset_Call) Return(err error) *MockUserService_RequestPasswordReset_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserService_RequestPasswordReset_Call) RunAndReturn(run func(ctx context.Context, email string) error) *MockUserService_RequestPasswordReset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeTokens provides a mock function for the type MockUserService
+func (_mock *MockUserService) RevokeTokens(ctx context.Context, adminID uuid.UUID, id uuid.UUID) error {
+	ret := _mock.Called(ctx, adminID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeTokens")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, adminID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserService_RevokeTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeTokens'
+type MockUserService_RevokeTokens_Call struct {
+	*mock.Call
+}
+
+// RevokeTokens is a helper method to define mock.On call
+//   - ctx
+//   - adminID
+//   - id
+func (_e *MockUserService_Expecter) RevokeTokens(ctx interface{}, adminID interface{}, id interface{}) *MockUserService_RevokeTokens_Call {
+	return &MockUserService_RevokeTokens_Call{Call: _e.mock.On("RevokeTokens", ctx, adminID, id)}
+}
+
+func (_c *MockUserService_RevokeTokens_Call) Run(run func(ctx context.Context, adminID uuid.UUID, id uuid.UUID)) *MockUserService_RevokeTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockUserService_RevokeTokens_Call) Return(err error) *MockUserService_RevokeTokens_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserService_RevokeTokens_Call) RunAndReturn(run func(ctx context.Context, adminID uuid.UUID, id uuid.UUID) error) *MockUserService_RevokeTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetRole provides a mock function for the type MockUserService
+func (_mock *MockUserService) SetRole(ctx context.Context, id uuid.UUID, admin bool) error {
+	ret := _mock.Called(ctx, id, admin)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRole")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool) error); ok {
+		r0 = returnFunc(ctx, id, admin)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserService_SetRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetRole'
+type MockUserService_SetRole_Call struct {
+	*mock.Call
+}
+
+// SetRole is a helper method to define mock.On call
+//   - ctx
+//   - id
+//   - admin
+func (_e *MockUserService_Expecter) SetRole(ctx interface{}, id interface{}, admin interface{}) *MockUserService_SetRole_Call {
+	return &MockUserService_SetRole_Call{Call: _e.mock.On("SetRole", ctx, id, admin)}
+}
+
+func (_c *MockUserService_SetRole_Call) Run(run func(ctx context.Context, id uuid.UUID, admin bool)) *MockUserService_SetRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockUserService_SetRole_Call) Return(err error) *MockUserService_SetRole_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserService_SetRole_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, admin bool) error) *MockUserService_SetRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SignUp provides a mock function for the type MockUserService
 func (_mock *MockUserService) SignUp(ctx context.Context, user *model.User) error {
 	ret := _mock.Called(ctx, user)
@@ -243,3 +858,105 @@ func (_c *MockUserService_SignUp_Call) RunAndReturn(run func(ctx context.Context
 	_c.Call.Return(run)
 	return _c
 }
+
+// SignUpUser provides a mock function for the type MockUserService
+func (_mock *MockUserService) SignUpUser(ctx context.Context, user *model.User, invite string) error {
+	ret := _mock.Called(ctx, user, invite)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignUpUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.User, string) error); ok {
+		r0 = returnFunc(ctx, user, invite)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserService_SignUpUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SignUpUser'
+type MockUserService_SignUpUser_Call struct {
+	*mock.Call
+}
+
+// SignUpUser is a helper method to define mock.On call
+//   - ctx
+//   - user
+//   - invite
+func (_e *MockUserService_Expecter) SignUpUser(ctx interface{}, user interface{}, invite interface{}) *MockUserService_SignUpUser_Call {
+	return &MockUserService_SignUpUser_Call{Call: _e.mock.On("SignUpUser", ctx, user, invite)}
+}
+
+func (_c *MockUserService_SignUpUser_Call) Run(run func(ctx context.Context, user *model.User, invite string)) *MockUserService_SignUpUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.User), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockUserService_SignUpUser_Call) Return(err error) *MockUserService_SignUpUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserService_SignUpUser_Call) RunAndReturn(run func(ctx context.Context, user *model.User, invite string) error) *MockUserService_SignUpUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TokenVersion provides a mock function for the type MockUserService
+func (_mock *MockUserService) TokenVersion(ctx context.Context, id uuid.UUID) (int, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TokenVersion")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) int); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_TokenVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TokenVersion'
+type MockUserService_TokenVersion_Call struct {
+	*mock.Call
+}
+
+// TokenVersion is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockUserService_Expecter) TokenVersion(ctx interface{}, id interface{}) *MockUserService_TokenVersion_Call {
+	return &MockUserService_TokenVersion_Call{Call: _e.mock.On("TokenVersion", ctx, id)}
+}
+
+func (_c *MockUserService_TokenVersion_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockUserService_TokenVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockUserService_TokenVersion_Call) Return(n int, err error) *MockUserService_TokenVersion_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockUserService_TokenVersion_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (int, error)) *MockUserService_TokenVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
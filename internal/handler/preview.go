@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+// PreviewContent processes the POST request to render blog content the way it is shown to readers, so editors can
+// show a live preview. It returns the sanitized HTML, the excerpt, word count and reading time and the media
+// warnings of the content without creating a blog.
+func (h *Handler) PreviewContent(c echo.Context) error {
+	var bindInfo struct {
+		Content string `json:"content"`
+	}
+	if err := c.Bind(&bindInfo); err != nil {
+		log.Errorf("c.Bind error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Request body must be a JSON object with the content")
+	}
+	err := h.validate.VarCtx(c.Request().Context(), bindInfo.Content, "required,max=50000")
+	if err != nil {
+		log.Errorf("validate.VarCtx error: %v", err)
+		return respondError(c, http.StatusBadRequest, codeInvalidRequest, "Content must be set and at most 50000 characters")
+	}
+	preview, err := h.srvBlog.Preview(bindInfo.Content)
+	if err != nil {
+		log.Errorf("srvBlog.Preview - %v", err)
+		return respondError(c, http.StatusInternalServerError, codeInternal, "Failed to preview content")
+	}
+	return c.JSON(http.StatusOK, preview)
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/artnikel/blogapi/internal/config"
+	"github.com/artnikel/blogapi/internal/model"
+	"github.com/artnikel/blogapi/internal/service"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+func Test_PreviewContent(t *testing.T) {
+	// the preview saves nothing, the real service runs without a repository
+	h := NewHandler(service.NewBlogService(nil), nil, nil, validator.New())
+	cfg := &config.Config{BlogTokenSignature: "secret"}
+	e := echo.New()
+	RegisterRoutes(e, h, cfg)
+	token := testToken(t, cfg, uuid.New(), false)
+
+	preview := func(body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/content/preview", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if token != "" {
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := preview(`{"content": "# Hello\n\nSome **bold** words<script>alert(1)</script>"}`, token)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var got model.ContentPreview
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	require.Contains(t, got.HTML, "<h1")
+	require.Contains(t, got.HTML, "<strong>bold</strong>")
+	require.NotContains(t, got.HTML, "<script")
+	require.NotContains(t, got.HTML, "alert(1)")
+	require.Equal(t, "Hello Some bold words", got.Excerpt)
+	require.Equal(t, 4, got.WordCount)
+	require.Equal(t, 1, got.ReadingTime)
+
+	require.Equal(t, http.StatusBadRequest, preview(`{"content": ""}`, token).Code)
+	require.Equal(t, http.StatusUnauthorized, preview(`{"content": "text"}`, "").Code)
+}
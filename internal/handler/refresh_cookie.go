@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/artnikel/blogapi/internal/constants"
+	"github.com/labstack/echo/v4"
+)
+
+// newRefreshCookie builds the refresh token cookie. It is HttpOnly so scripts cannot read it, Secure so it is only
+// sent over HTTPS and SameSite=Strict so other sites cannot make the browser send it. The path is the root because
+// the refresh and logout routes are served both with and without the version prefix.
+func newRefreshCookie(value string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     constants.RefreshTokenCookie,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteStrictMode,
+	}
+}
+
+// setRefreshCookie sends the refresh token in the refresh token cookie
+func (h *Handler) setRefreshCookie(c echo.Context, refreshToken string) {
+	maxAge := int(h.refreshCookieMaxAge.Seconds())
+	if maxAge <= 0 {
+		maxAge = int(constants.RefreshTokenExpiration.Seconds())
+	}
+	c.SetCookie(newRefreshCookie(refreshToken, maxAge))
+}
+
+// clearRefreshCookie tells the browser to drop the refresh token cookie
+func clearRefreshCookie(c echo.Context) {
+	c.SetCookie(newRefreshCookie("", -1))
+}
+
+// refreshCookie returns the refresh token of the request cookie and whether there was one
+func refreshCookie(c echo.Context) (string, bool) {
+	cookie, err := c.Cookie(constants.RefreshTokenCookie)
+	if err != nil || cookie.Value == "" {
+		return "", false
+	}
+	return cookie.Value, true
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/artnikel/blogapi/internal/config"
+	"github.com/artnikel/blogapi/internal/constants"
+	customMiddleware "github.com/artnikel/blogapi/internal/middleware"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/time/rate"
+)
+
+// route describes a single endpoint of an API version
+type route struct {
+	method     string
+	path       string
+	handler    echo.HandlerFunc
+	middleware []echo.MiddlewareFunc
+}
+
+// RegisterRoutes registers every API version and the unauthenticated API description on the echo instance.
+// The v1 routes are also served without a prefix as deprecated aliases until the legacy sunset date.
+func RegisterRoutes(e *echo.Echo, h *Handler, cfg *config.Config) {
+	e.GET("/swagger.json", h.GetOpenAPI)
+	e.GET("/swagger", h.GetSwaggerUI)
+	v1 := e.Group("/v1")
+	legacy := customMiddleware.Deprecated(legacyRoutesSunset(), "Unversioned routes are deprecated, use the /v1 prefix")
+	for _, r := range h.v1Routes(cfg) {
+		v1.Add(r.method, r.path, r.handler, r.middleware...)
+		e.Add(r.method, r.path, r.handler, append([]echo.MiddlewareFunc{legacy}, r.middleware...)...)
+	}
+}
+
+// v1Routes returns the routes of the first API version
+func (h *Handler) v1Routes(cfg *config.Config) []route {
+	jwt := customMiddleware.JWTMiddleware(cfg, h.srvUser)
+	// each family of auth routes has its own buckets, so routine refreshes do not use up the login attempts of an IP
+	loginLimit := customMiddleware.RateLimit(rate.Every(constants.AuthRateInterval), constants.AuthRateBurst)
+	resetLimit := customMiddleware.RateLimit(rate.Every(constants.AuthRateInterval), constants.AuthRateBurst)
+	refreshLimit := customMiddleware.RateLimit(rate.Every(constants.RefreshRateInterval), constants.RefreshRateBurst)
+	return []route{
+		{http.MethodPost, "/blog", h.Create, []echo.MiddlewareFunc{jwt}},
+		{http.MethodGet, "/blog/:id", h.Get, []echo.MiddlewareFunc{jwt}},
+		{http.MethodGet, "/blogs/slug/:slug", h.GetBySlug, []echo.MiddlewareFunc{jwt}},
+		{http.MethodGet, "/blog/:id/siblings", h.GetSiblings, []echo.MiddlewareFunc{jwt}},
+		{http.MethodDelete, "/blog/:id", h.Delete, []echo.MiddlewareFunc{jwt}},
+		{http.MethodDelete, "/blogs/user/:id", h.DeleteBlogsByUserID, []echo.MiddlewareFunc{jwt}},
+		{http.MethodPost, "/blogs/delete", h.DeleteMany, []echo.MiddlewareFunc{jwt}},
+		{http.MethodPost, "/blogs/exists", h.BlogsExist, []echo.MiddlewareFunc{jwt}},
+		{http.MethodPut, "/blog", h.Update, []echo.MiddlewareFunc{jwt}},
+		{http.MethodPost, "/blog/:id/publish", h.Publish, []echo.MiddlewareFunc{jwt}},
+		{http.MethodPost, "/blog/:id/restore", h.Restore, []echo.MiddlewareFunc{jwt}},
+		{http.MethodDelete, "/admin/blog/:id", h.HardDelete, []echo.MiddlewareFunc{jwt}},
+		{http.MethodGet, "/admin/diagnostics", h.GetDiagnostics, []echo.MiddlewareFunc{jwt}},
+		{http.MethodGet, "/admin/blogs/pending", h.GetPendingReview, []echo.MiddlewareFunc{jwt}},
+		{http.MethodPost, "/admin/blog/:id/approve", h.Approve, []echo.MiddlewareFunc{jwt}},
+		{http.MethodPost, "/admin/blog/:id/reject", h.Reject, []echo.MiddlewareFunc{jwt}},
+		{http.MethodGet, "/blogs", h.GetAll, []echo.MiddlewareFunc{jwt}},
+		{http.MethodGet, "/blogs/count", h.Count, []echo.MiddlewareFunc{jwt}},
+		{http.MethodGet, "/blogs/popular", h.GetPopular, []echo.MiddlewareFunc{jwt}},
+		{http.MethodGet, "/blogs/user/:id", h.GetByUserID, []echo.MiddlewareFunc{jwt}},
+		{http.MethodGet, "/users/:id/blogs", h.GetUserBlogs, []echo.MiddlewareFunc{jwt}},
+		{http.MethodGet, "/blogs/tag/:tag", h.GetByTag, []echo.MiddlewareFunc{jwt}},
+		{http.MethodGet, "/users/:id/top-tags", h.GetTopTags, []echo.MiddlewareFunc{jwt}},
+		{http.MethodGet, "/feed.rss", h.GetFeed, nil},
+		{http.MethodPost, "/content/preview", h.PreviewContent, []echo.MiddlewareFunc{jwt}},
+
+		{http.MethodGet, "/me/recent", h.GetRecent, []echo.MiddlewareFunc{jwt}},
+		{http.MethodGet, "/me/activity", h.GetActivity, []echo.MiddlewareFunc{jwt}},
+		{http.MethodGet, "/me/export.zip", h.ExportMe, []echo.MiddlewareFunc{jwt}},
+		{http.MethodGet, "/users/:id/export.zip", h.ExportUser, []echo.MiddlewareFunc{jwt}},
+		{http.MethodGet, "/auth/whoami", h.WhoAmI, []echo.MiddlewareFunc{jwt}},
+
+		{http.MethodPost, "/blog/:id/comments", h.CreateComment, []echo.MiddlewareFunc{jwt}},
+		{http.MethodGet, "/blog/:id/comments", h.GetComments, []echo.MiddlewareFunc{jwt}},
+		{http.MethodDelete, "/comments/:id", h.DeleteComment, []echo.MiddlewareFunc{jwt}},
+
+		{http.MethodPost, "/signup", h.SignUpUser, []echo.MiddlewareFunc{loginLimit}},
+		{http.MethodPost, "/signupadmin", h.SignUpAdmin, []echo.MiddlewareFunc{jwt}},
+		{http.MethodPost, "/admin/invites", h.CreateInvites, []echo.MiddlewareFunc{jwt}},
+		{http.MethodPost, "/login", h.Login, []echo.MiddlewareFunc{loginLimit}},
+		{http.MethodPost, "/refresh", h.Refresh, []echo.MiddlewareFunc{refreshLimit}},
+		{http.MethodPost, "/logout", h.Logout, []echo.MiddlewareFunc{jwt}},
+		{http.MethodPost, "/logout/all", h.LogoutAll, []echo.MiddlewareFunc{jwt}},
+		{http.MethodPost, "/user/password", h.ChangePassword, []echo.MiddlewareFunc{jwt}},
+		{http.MethodPost, "/password/reset/request", h.RequestPasswordReset, []echo.MiddlewareFunc{resetLimit}},
+		{http.MethodPost, "/password/reset/confirm", h.ConfirmPasswordReset, []echo.MiddlewareFunc{resetLimit}},
+		{http.MethodGet, "/user/me", h.GetMe, []echo.MiddlewareFunc{jwt}},
+		{http.MethodDelete, "/user/me", h.DeleteMe, []echo.MiddlewareFunc{jwt}},
+		{http.MethodPut, "/users/:id/role", h.SetRole, []echo.MiddlewareFunc{jwt}},
+		{http.MethodPost, "/admin/impersonate/:id", h.Impersonate, []echo.MiddlewareFunc{jwt}},
+		{http.MethodPost, "/admin/users/:id/revoke-tokens", h.RevokeTokens, []echo.MiddlewareFunc{jwt}},
+		{http.MethodPost, "/users/profiles", h.GetProfiles, []echo.MiddlewareFunc{jwt}},
+		{http.MethodDelete, "/user/:id", h.DeleteUserByID, []echo.MiddlewareFunc{jwt}},
+	}
+}
+
+// legacyRoutesSunset is the date after which the unprefixed aliases may be removed
+func legacyRoutesSunset() time.Time {
+	return time.Date(2027, time.June, 30, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/artnikel/blogapi/internal/config"
+	"github.com/artnikel/blogapi/internal/constants"
+	"github.com/artnikel/blogapi/internal/handler/mocks"
+	"github.com/artnikel/blogapi/internal/model"
+	"github.com/artnikel/blogapi/internal/service"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+func testToken(t *testing.T, cfg *config.Config, id uuid.UUID, isAdmin bool) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"exp":     time.Now().Add(time.Minute).Unix(),
+		"id":      id,
+		"isAdmin": isAdmin,
+		"iss":     constants.TokenIssuer,
+		"aud":     constants.TokenAudience,
+	})
+	tokenString, err := token.SignedString([]byte(cfg.BlogTokenSignature))
+	require.NoError(t, err)
+	return tokenString
+}
+
+func Test_RegisterRoutes_VersionedAndLegacy(t *testing.T) {
+	mockService := new(mocks.MockBlogService)
+	validate := validator.New()
+	h := NewHandler(mockService, nil, nil, validate)
+	cfg := &config.Config{BlogTokenSignature: "secret"}
+
+	e := echo.New()
+	RegisterRoutes(e, h, cfg)
+
+	resp := &model.BlogListResponse{Blogs: []*model.Blog{}, Count: 0}
+	mockService.On("GetAll", mock.Anything, model.BlogFilter{}, 20, 0).Return(resp, nil)
+	token := testToken(t, cfg, uuid.New(), false)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/blogs", http.NoBody)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Empty(t, rec.Header().Get("Deprecation"))
+
+	req = httptest.NewRequest(http.MethodGet, "/blogs", http.NoBody)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "true", rec.Header().Get("Deprecation"))
+	require.NotEmpty(t, rec.Header().Get("Sunset"))
+
+	mockService.AssertExpectations(t)
+}
+
+func Test_RegisterRoutes_SeparateAuthLimits(t *testing.T) {
+	mockUserService := new(mocks.MockUserService)
+	h := NewHandler(nil, mockUserService, nil, validator.New())
+	cfg := &config.Config{BlogTokenSignature: "secret"}
+	e := echo.New()
+	RegisterRoutes(e, h, cfg)
+	mockUserService.On("Refresh", mock.Anything, service.TokenPair{}).Return(service.TokenPair{}, errors.New("invalid refresh token"))
+	post := func(path string) int {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader("{}"))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	for i := 0; i < constants.AuthRateBurst; i++ {
+		require.Equal(t, http.StatusBadRequest, post("/v1/login"))
+	}
+	require.Equal(t, http.StatusTooManyRequests, post("/v1/login"))
+	require.Equal(t, http.StatusTooManyRequests, post("/v1/signup"))
+
+	// the used up login attempts leave refreshes alone
+	require.Equal(t, http.StatusBadRequest, post("/v1/refresh"))
+	mockUserService.AssertExpectations(t)
+}
+
+func Test_RegisterRoutes_OpenAPI(t *testing.T) {
+	h := NewHandler(nil, nil, nil, validator.New())
+	cfg := &config.Config{BlogTokenSignature: "secret"}
+	e := echo.New()
+	RegisterRoutes(e, h, cfg)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/swagger.json", http.NoBody))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var spec struct {
+		OpenAPI string `json:"openapi"`
+		Info    struct {
+			Title   string `json:"title"`
+			Version string `json:"version"`
+		} `json:"info"`
+		Paths      map[string]map[string]json.RawMessage `json:"paths"`
+		Components struct {
+			SecuritySchemes map[string]struct {
+				Type   string `json:"type"`
+				Scheme string `json:"scheme"`
+			} `json:"securitySchemes"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &spec))
+	require.True(t, strings.HasPrefix(spec.OpenAPI, "3."))
+	require.NotEmpty(t, spec.Info.Title)
+	require.NotEmpty(t, spec.Info.Version)
+	require.Equal(t, "bearer", spec.Components.SecuritySchemes["bearerAuth"].Scheme)
+
+	// every route is documented, with the path params of echo written the OpenAPI way
+	param := regexp.MustCompile(`:(\w+)`)
+	for _, r := range h.v1Routes(cfg) {
+		path := param.ReplaceAllString(r.path, "{$1}")
+		require.Contains(t, spec.Paths[path], strings.ToLower(r.method), "%s %s is not documented", r.method, r.path)
+	}
+
+	// every reference points at a component of the document
+	var document map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &document))
+	for _, ref := range regexp.MustCompile(`"\$ref":\s*"#/([^"]+)"`).FindAllStringSubmatch(rec.Body.String(), -1) {
+		var node any = document
+		for _, key := range strings.Split(ref[1], "/") {
+			object, ok := node.(map[string]any)
+			require.True(t, ok, "reference %s does not resolve", ref[1])
+			node, ok = object[key]
+			require.True(t, ok, "reference %s does not resolve", ref[1])
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/swagger", http.NoBody))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMETextHTML)
+	require.Contains(t, rec.Body.String(), `url: "swagger.json"`)
+}
@@ -0,0 +1,70 @@
+// Package mailer provides sending of emails to users
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Mailer is an interface that defines sending of a single email
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// LogMailer is a Mailer that logs that an email would be sent instead of delivering it, meant for local development.
+// The body is never logged, it may hold secrets such as password reset tokens.
+type LogMailer struct{}
+
+// Send logs the recipient and the subject of the email
+func (LogMailer) Send(_ context.Context, to, subject, _ string) error {
+	log.WithFields(log.Fields{
+		"to":      to,
+		"subject": subject,
+	}).Info("email")
+	return nil
+}
+
+// SMTPMailer is a Mailer that delivers plain text emails through an SMTP server
+type SMTPMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewSMTPMailer creates an SMTPMailer sending from the given address through the server at addr, a host:port.
+// Without a username the server is used without authentication.
+func NewSMTPMailer(addr, from, username, password string) (*SMTPMailer, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SMTP address %q: %w", addr, err)
+	}
+	if from == "" {
+		return nil, fmt.Errorf("the sender address of emails is not set")
+	}
+	m := &SMTPMailer{addr: addr, from: from}
+	if username != "" {
+		m.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return m, nil
+}
+
+// Send delivers the email, smtp.SendMail upgrades the connection with STARTTLS when the server supports it
+func (m *SMTPMailer) Send(_ context.Context, to, subject, body string) error {
+	if strings.ContainsAny(to+subject, "\r\n") {
+		return fmt.Errorf("email headers must not contain line breaks")
+	}
+	msg := "From: " + m.from + "\r\n" +
+		"To: " + to + "\r\n" +
+		"Subject: " + subject + "\r\n" +
+		"Content-Type: text/plain; charset=UTF-8\r\n" +
+		"\r\n" + body + "\r\n"
+	if err := smtp.SendMail(m.addr, m.auth, m.from, []string{to}, []byte(msg)); err != nil {
+		return fmt.Errorf("smtp.SendMail - %w", err)
+	}
+	return nil
+}
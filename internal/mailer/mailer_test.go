@@ -0,0 +1,41 @@
+package mailer
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogMailer_DoesNotLogBody(t *testing.T) {
+	hook := test.NewGlobal()
+	defer hook.Reset()
+
+	require.NoError(t, LogMailer{}.Send(context.Background(), "user@example.com", "Password reset", "secret-reset-token"))
+
+	entry := hook.LastEntry()
+	require.NotNil(t, entry)
+	require.Equal(t, log.InfoLevel, entry.Level)
+	require.Equal(t, "user@example.com", entry.Data["to"])
+	require.Equal(t, "Password reset", entry.Data["subject"])
+	for _, value := range entry.Data {
+		require.NotContains(t, value, "secret-reset-token")
+	}
+	require.NotContains(t, entry.Message, "secret-reset-token")
+}
+
+func TestNewSMTPMailer(t *testing.T) {
+	_, err := NewSMTPMailer("smtp.example.com", "blog@example.com", "", "")
+	require.Error(t, err, "the port is required")
+	_, err = NewSMTPMailer("smtp.example.com:587", "", "", "")
+	require.Error(t, err, "the sender is required")
+
+	m, err := NewSMTPMailer("smtp.example.com:587", "blog@example.com", "blog", "secret")
+	require.NoError(t, err)
+	require.NotNil(t, m.auth)
+
+	err = m.Send(context.Background(), "user@example.com\r\nBcc: other@example.com", "Password reset", "body")
+	require.Error(t, err)
+}
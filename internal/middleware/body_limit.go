@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/artnikel/blogapi/internal/constants"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/labstack/gommon/bytes"
+)
+
+// BodyLimit rejects requests whose body is larger than limit, e.g. "2M", with 413.
+// An empty limit falls back to constants.BodyLimit, a malformed one is an error instead of the panic of echo.
+func BodyLimit(limit string) (echo.MiddlewareFunc, error) {
+	if limit == "" {
+		limit = constants.BodyLimit
+	}
+	size, err := bytes.Parse(limit)
+	if err != nil {
+		return nil, fmt.Errorf("invalid body limit %q: %w", limit, err)
+	}
+	if size <= 0 {
+		return nil, fmt.Errorf("body limit %q must be positive", limit)
+	}
+	return middleware.BodyLimit(limit), nil
+}
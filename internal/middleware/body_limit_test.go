@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BodyLimit(t *testing.T) {
+	limit, err := BodyLimit("1KiB")
+	require.NoError(t, err)
+	e := echo.New()
+	e.Use(limit)
+	e.POST("/blog", func(c echo.Context) error {
+		return c.NoContent(http.StatusCreated)
+	})
+
+	post := func(size int) int {
+		req := httptest.NewRequest(http.MethodPost, "/blog", strings.NewReader(strings.Repeat("a", size)))
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	require.Equal(t, http.StatusCreated, post(1024))
+	require.Equal(t, http.StatusRequestEntityTooLarge, post(1025))
+}
+
+func Test_BodyLimit_Invalid(t *testing.T) {
+	_, err := BodyLimit("lots")
+	require.Error(t, err)
+	_, err = BodyLimit("0")
+	require.Error(t, err)
+
+	_, err = BodyLimit("")
+	require.NoError(t, err)
+}
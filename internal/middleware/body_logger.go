@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/artnikel/blogapi/internal/constants"
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveFieldPattern matches quoted JSON fields and form values whose names mention a password or a token
+var sensitiveFieldPattern = regexp.MustCompile(`(?i)("[^"]*(?:password|token)[^"]*"\s*:\s*)"(?:[^"\\]|\\.)*"?|([\w-]*(?:password|token)[\w-]*=)[^&\s]*`)
+
+// BodyLogger is a debugging middleware that logs request and response bodies.
+// Bodies are captured up to maxBytes and marked as truncated beyond that, while the rest of the stream is passed through untouched.
+// Fields whose names contain "password" or "token" are always redacted.
+func BodyLogger(maxBytes int) echo.MiddlewareFunc {
+	if maxBytes <= 0 {
+		maxBytes = constants.BodyLogMaxBytes
+	}
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			var reqBody []byte
+			reqTruncated := false
+			if req.Body != nil && req.Body != http.NoBody {
+				head, err := io.ReadAll(io.LimitReader(req.Body, int64(maxBytes)+1))
+				if err != nil {
+					return echo.NewHTTPError(http.StatusBadRequest, "Failed to read request body")
+				}
+				req.Body = &replayBody{Reader: io.MultiReader(bytes.NewReader(head), req.Body), Closer: req.Body}
+				reqBody, reqTruncated = capBody(head, maxBytes)
+			}
+
+			capture := &capturingWriter{ResponseWriter: c.Response().Writer, limit: maxBytes}
+			c.Response().Writer = capture
+
+			err := next(c)
+
+			log.WithFields(log.Fields{
+				"method":             req.Method,
+				"path":               req.URL.Path,
+				"status":             c.Response().Status,
+				"request_body":       RedactBody(reqBody),
+				"request_truncated":  reqTruncated,
+				"response_body":      RedactBody(capture.buf.Bytes()),
+				"response_truncated": capture.truncated,
+			}).Info("http body dump")
+			return err
+		}
+	}
+}
+
+// RedactBody hides the values of password and token fields in a JSON or form encoded body
+func RedactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err == nil {
+		redactJSON(decoded)
+		if out, err := json.Marshal(decoded); err == nil {
+			return string(out)
+		}
+	}
+	return sensitiveFieldPattern.ReplaceAllStringFunc(string(body), func(match string) string {
+		parts := sensitiveFieldPattern.FindStringSubmatch(match)
+		if parts[1] != "" {
+			return parts[1] + `"` + redacted + `"`
+		}
+		return parts[2] + redacted
+	})
+}
+
+func redactJSON(v interface{}) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for key, field := range val {
+			if isSensitiveField(key) {
+				val[key] = redacted
+				continue
+			}
+			redactJSON(field)
+		}
+	case []interface{}:
+		for _, item := range val {
+			redactJSON(item)
+		}
+	}
+}
+
+func isSensitiveField(name string) bool {
+	lower := strings.ToLower(name)
+	return strings.Contains(lower, "password") || strings.Contains(lower, "token")
+}
+
+func capBody(body []byte, limit int) ([]byte, bool) {
+	if len(body) > limit {
+		return body[:limit], true
+	}
+	return body, false
+}
+
+// replayBody re-serves the already captured head of a request body followed by the unread remainder
+type replayBody struct {
+	io.Reader
+	io.Closer
+}
+
+// capturingWriter copies at most limit bytes of the response into a buffer while writing everything through
+type capturingWriter struct {
+	http.ResponseWriter
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (w *capturingWriter) Write(b []byte) (int, error) {
+	if room := w.limit - w.buf.Len(); room > 0 {
+		if len(b) > room {
+			w.buf.Write(b[:room])
+			w.truncated = true
+		} else {
+			w.buf.Write(b)
+		}
+	} else if len(b) > 0 {
+		w.truncated = true
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *capturingWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *capturingWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if hijacker, ok := w.ResponseWriter.(http.Hijacker); ok {
+		return hijacker.Hijack()
+	}
+	return nil, nil, errors.New("response writer does not support hijacking")
+}
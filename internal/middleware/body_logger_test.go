@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BodyLogger_LogsBodies(t *testing.T) {
+	hook := test.NewGlobal()
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/blog", strings.NewReader(`{"title":"testtitle"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	h := BodyLogger(1024)(func(c echo.Context) error {
+		var body map[string]string
+		require.NoError(t, c.Bind(&body))
+		require.Equal(t, "testtitle", body["title"])
+		return c.JSON(http.StatusCreated, body)
+	})
+	require.NoError(t, h(c))
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	entry := hook.LastEntry()
+	require.NotNil(t, entry)
+	require.Equal(t, `{"title":"testtitle"}`, entry.Data["request_body"])
+	require.Equal(t, `{"title":"testtitle"}`, entry.Data["response_body"])
+	require.Equal(t, false, entry.Data["request_truncated"])
+	require.Equal(t, false, entry.Data["response_truncated"])
+}
+
+func Test_BodyLogger_RedactsAuthEndpoints(t *testing.T) {
+	hook := test.NewGlobal()
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(`{"username":"testuser","password":"secretpass"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	h := BodyLogger(1024)(func(c echo.Context) error {
+		return c.JSON(http.StatusCreated, echo.Map{
+			"Access Token : ":  "secretaccess",
+			"Refresh Token : ": "secretrefresh",
+		})
+	})
+	require.NoError(t, h(c))
+	require.Contains(t, rec.Body.String(), "secretaccess")
+
+	entry := hook.LastEntry()
+	require.NotNil(t, entry)
+	reqBody := entry.Data["request_body"].(string)
+	respBody := entry.Data["response_body"].(string)
+	require.Contains(t, reqBody, "testuser")
+	require.NotContains(t, reqBody, "secretpass")
+	require.NotContains(t, respBody, "secretaccess")
+	require.NotContains(t, respBody, "secretrefresh")
+	require.Contains(t, respBody, redacted)
+}
+
+func Test_BodyLogger_TruncatesBeyondCap(t *testing.T) {
+	hook := test.NewGlobal()
+	e := echo.New()
+	body := strings.Repeat("a", 100)
+	req := httptest.NewRequest(http.MethodPost, "/blog", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	h := BodyLogger(10)(func(c echo.Context) error {
+		read, err := io.ReadAll(c.Request().Body)
+		require.NoError(t, err)
+		require.Equal(t, body, string(read))
+		return c.String(http.StatusOK, string(read))
+	})
+	require.NoError(t, h(c))
+	require.Equal(t, body, rec.Body.String())
+
+	entry := hook.LastEntry()
+	require.NotNil(t, entry)
+	require.Equal(t, strings.Repeat("a", 10), entry.Data["request_body"])
+	require.Equal(t, strings.Repeat("a", 10), entry.Data["response_body"])
+	require.Equal(t, true, entry.Data["request_truncated"])
+	require.Equal(t, true, entry.Data["response_truncated"])
+}
+
+func Test_RedactBody_Truncated(t *testing.T) {
+	redactedBody := RedactBody([]byte(`{"username":"testuser","password":"secre`))
+	require.NotContains(t, redactedBody, "secre")
+	require.Contains(t, redactedBody, "testuser")
+
+	redactedForm := RedactBody([]byte("username=testuser&password=secretpass"))
+	require.Equal(t, "username=testuser&password="+redacted, redactedForm)
+}
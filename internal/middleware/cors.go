@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// corsMaxAge is the number of seconds browsers may cache a preflight response
+const corsMaxAge = 600
+
+// CORS allows browsers on the listed origins to call the API and answers preflight requests.
+// Origins are matched exactly, requests from other origins get no Access-Control-Allow-Origin header.
+func CORS(allowedOrigins []string) echo.MiddlewareFunc {
+	allowed := make(map[string]struct{}, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		origin = strings.TrimRight(strings.TrimSpace(origin), "/")
+		if origin != "" {
+			allowed[origin] = struct{}{}
+		}
+	}
+	return middleware.CORSWithConfig(middleware.CORSConfig{
+		AllowOriginFunc: func(origin string) (bool, error) {
+			_, ok := allowed[origin]
+			return ok, nil
+		},
+		AllowMethods:  []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodOptions},
+		AllowHeaders:  []string{echo.HeaderAuthorization, echo.HeaderContentType, echo.HeaderAccept, "Idempotency-Key", "If-None-Match"},
+		ExposeHeaders: []string{echo.HeaderRetryAfter, "ETag"},
+		MaxAge:        corsMaxAge,
+	})
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CORS_Preflight(t *testing.T) {
+	e := echo.New()
+	e.Use(CORS([]string{"https://blog.example.com", " https://admin.example.com/ "}))
+	e.POST("/blog", func(c echo.Context) error {
+		return c.NoContent(http.StatusCreated)
+	})
+
+	preflight := func(origin string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodOptions, "/blog", http.NoBody)
+		req.Header.Set(echo.HeaderOrigin, origin)
+		req.Header.Set(echo.HeaderAccessControlRequestMethod, http.MethodPost)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := preflight("https://admin.example.com")
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	require.Equal(t, "https://admin.example.com", rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+	require.Contains(t, rec.Header().Get(echo.HeaderAccessControlAllowMethods), http.MethodPost)
+	require.Contains(t, rec.Header().Get(echo.HeaderAccessControlAllowHeaders), echo.HeaderAuthorization)
+
+	rec = preflight("https://evil.example.com")
+	require.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+	require.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowMethods))
+
+	req := httptest.NewRequest(http.MethodPost, "/blog", http.NoBody)
+	req.Header.Set(echo.HeaderOrigin, "https://blog.example.com")
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusCreated, rec.Code)
+	require.Equal(t, "https://blog.example.com", rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Deprecated is a per-route middleware that marks the route as deprecated.
+// It sets the Deprecation and Sunset headers (RFC 8594) and a Warning header with the given message.
+func Deprecated(sunset time.Time, message string) echo.MiddlewareFunc {
+	sunsetHeader := sunset.UTC().Format(http.TimeFormat)
+	warningHeader := fmt.Sprintf("299 - %q", message)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Response().Header()
+			header.Set("Deprecation", "true")
+			header.Set("Sunset", sunsetHeader)
+			header.Set("Warning", warningHeader)
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Deprecated(t *testing.T) {
+	e := echo.New()
+	sunset := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	e.GET("/legacy", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, "legacy")
+	}, Deprecated(sunset, "Use /current instead"))
+	e.GET("/current", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, "current")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/legacy", http.NoBody)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "true", rec.Header().Get("Deprecation"))
+	require.Equal(t, "Tue, 01 Jan 2030 00:00:00 GMT", rec.Header().Get("Sunset"))
+	require.Equal(t, `299 - "Use /current instead"`, rec.Header().Get("Warning"))
+
+	req = httptest.NewRequest(http.MethodGet, "/current", http.NoBody)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Empty(t, rec.Header().Get("Deprecation"))
+	require.Empty(t, rec.Header().Get("Sunset"))
+}
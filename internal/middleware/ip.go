@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// IPExtractor returns how c.RealIP finds the client IP, which the rate limiter keys on.
+// Without trusted proxies the IP of the connection is used and X-Forwarded-For and X-Real-IP are ignored, since any
+// client could send them. With trusted proxies, given as CIDRs, X-Forwarded-For is followed back through them only.
+func IPExtractor(trustedProxies []string) (echo.IPExtractor, error) {
+	var ranges []echo.TrustOption
+	for _, proxy := range trustedProxies {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		_, ipRange, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+		}
+		ranges = append(ranges, echo.TrustIPRange(ipRange))
+	}
+	if len(ranges) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+	// echo trusts loopback, link-local and private addresses by default, only the configured proxies are trusted here
+	options := append([]echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}, ranges...)
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
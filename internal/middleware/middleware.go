@@ -2,19 +2,41 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
-	"time"
 
 	"github.com/artnikel/blogapi/internal/config"
+	"github.com/artnikel/blogapi/internal/requestuser"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
-// JWTMiddleware is a middleware function that checks the validity of the JWT token in the request header
-func JWTMiddleware(cfg *config.Config) echo.MiddlewareFunc {
+var (
+	// ErrExpired is returned by ValidateToken for a token past its exp claim
+	ErrExpired = errors.New("token is expired")
+	// ErrInvalidClaims is returned for a token whose claims are missing or have unexpected types
+	ErrInvalidClaims = errors.New("token has invalid claims")
+	// ErrTokenRevoked is returned by TokenVersions for a user whose tokens are all revoked, e.g. a deleted one
+	ErrTokenRevoked = errors.New("token is revoked")
+)
+
+// TokenVersions looks up the current token version of users, access tokens carry the version they were issued
+// with in the ver claim and stop working once the version of their user is bumped
+type TokenVersions interface {
+	TokenVersion(ctx context.Context, id uuid.UUID) (int, error)
+}
+
+// validSigningMethods are the algorithms tokens may be signed with, "none" is never accepted
+var validSigningMethods = []string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodHS384.Alg(), jwt.SigningMethodHS512.Alg()}
+
+// JWTMiddleware is a middleware function that checks the validity of the JWT token in the request header.
+// The ver claim of the token, 0 for tokens issued before versioning, must match the current version from versions,
+// which costs a lookup per request. A nil versions skips the check.
+func JWTMiddleware(cfg *config.Config, versions TokenVersions) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			authHeader := c.Request().Header.Get("Authorization")
@@ -26,30 +48,53 @@ func JWTMiddleware(cfg *config.Config) echo.MiddlewareFunc {
 			if tokenString == "" {
 				return echo.NewHTTPError(http.StatusUnauthorized, "Invalid authorization header format")
 			}
-			token, err := ValidateToken(tokenString, cfg.BlogTokenSignature)
-			if err != nil || !token.Valid {
+			token, err := ValidateToken(tokenString, cfg)
+			if errors.Is(err, ErrExpired) {
+				return echo.NewHTTPError(http.StatusUnauthorized, "Token is expired")
+			}
+			if err != nil {
 				return echo.NewHTTPError(http.StatusUnauthorized, "Invalid token")
 			}
-			if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-				exp := claims["exp"].(float64)
-				if exp < float64(time.Now().Unix()) {
-					return echo.NewHTTPError(http.StatusUnauthorized, "Token is expired")
+			claims := token.Claims.(jwt.MapClaims)
+			// password reset tokens carry a purpose and refresh tokens the jti of their session, neither is an access token
+			if _, ok := claims["purpose"]; ok {
+				return echo.NewHTTPError(http.StatusUnauthorized, "Token cannot be used for authorization")
+			}
+			if _, ok := claims["jti"]; ok {
+				return echo.NewHTTPError(http.StatusUnauthorized, "Token cannot be used for authorization")
+			}
+			id, isAdmin, err := TokenUser(token)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, "Invalid token claims")
+			}
+			if versions != nil {
+				version, err := TokenVersion(token)
+				if err != nil {
+					return echo.NewHTTPError(http.StatusUnauthorized, "Invalid token claims")
 				}
-				idStr, ok := claims["id"].(string)
-				if !ok {
-					return echo.NewHTTPError(http.StatusUnauthorized, "Invalid user ID format")
+				current, err := versions.TokenVersion(c.Request().Context(), id)
+				if errors.Is(err, ErrTokenRevoked) {
+					return echo.NewHTTPError(http.StatusUnauthorized, "Token is revoked")
 				}
-				id, err := uuid.Parse(idStr)
 				if err != nil {
-					return echo.NewHTTPError(http.StatusUnauthorized, "Invalid UUID format")
+					return echo.NewHTTPError(http.StatusServiceUnavailable, "Failed to check token").SetInternal(err)
 				}
-				isAdmin, ok := claims["isAdmin"].(bool)
-				if !ok {
-					return echo.NewHTTPError(http.StatusUnauthorized, "Invalid isAdmin format")
+				if version != current {
+					return echo.NewHTTPError(http.StatusUnauthorized, "Token is revoked")
 				}
-				c.Set("id", id)
-				c.Set("isAdmin", isAdmin)
 			}
+			if by, ok := claims["impersonated_by"]; ok {
+				adminStr, _ := by.(string)
+				adminID, err := uuid.Parse(adminStr)
+				if err != nil {
+					return echo.NewHTTPError(http.StatusUnauthorized, "Invalid token claims")
+				}
+				c.Set("impersonatedBy", adminID)
+			}
+			c.Set("id", id)
+			c.Set("isAdmin", isAdmin)
+			c.Set("claims", claims)
+			c.SetRequest(c.Request().WithContext(requestuser.WithID(c.Request().Context(), id)))
 			return next(c)
 		}
 	}
@@ -63,16 +108,76 @@ func extractTokenFromHeader(authHeader string) string {
 	return parts[1]
 }
 
-// ValidateToken validates a JWT token and returns the claims if valid, otherwise an error.
-func ValidateToken(tokenString, secretKey string) (*jwt.Token, error) {
+// ValidateToken validates a JWT token signed with the token signature of cfg and returns it if valid, otherwise an error.
+// Only HMAC signed tokens with a numeric exp, a string id claim and the issuer and audience of cfg are valid,
+// the returned token always has jwt.MapClaims.
+// A token past its expiry returns ErrExpired, a token with missing, mistyped or mismatched claims returns ErrInvalidClaims.
+func ValidateToken(tokenString string, cfg *config.Config) (*jwt.Token, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if token.Method == jwt.SigningMethodNone {
+			return nil, fmt.Errorf("unsigned tokens are not accepted")
+		}
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
-		return []byte(secretKey), nil
-	})
+		return []byte(cfg.BlogTokenSignature), nil
+	}, jwt.WithValidMethods(validSigningMethods), jwt.WithExpirationRequired(),
+		jwt.WithIssuer(cfg.TokenIssuer()), jwt.WithAudience(cfg.TokenAudience()))
+	if errors.Is(err, jwt.ErrTokenExpired) {
+		return nil, ErrExpired
+	}
+	if errors.Is(err, jwt.ErrTokenRequiredClaimMissing) || errors.Is(err, jwt.ErrTokenInvalidClaims) {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidClaims, err)
+	}
 	if err != nil {
 		return nil, err
 	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, ErrInvalidClaims
+	}
+	if _, ok := claims["exp"].(float64); !ok {
+		return nil, fmt.Errorf("%w: exp is not a number", ErrInvalidClaims)
+	}
+	if _, ok := claims["id"].(string); !ok {
+		return nil, fmt.Errorf("%w: id is not a string", ErrInvalidClaims)
+	}
 	return token, nil
 }
+
+// TokenUser returns the user ID and the admin flag of a token returned by ValidateToken,
+// or ErrInvalidClaims if the id isn't a UUID or the isAdmin claim is missing or not a boolean
+func TokenUser(token *jwt.Token) (id uuid.UUID, isAdmin bool, err error) {
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return uuid.Nil, false, ErrInvalidClaims
+	}
+	idStr, _ := claims["id"].(string)
+	id, err = uuid.Parse(idStr)
+	if err != nil {
+		return uuid.Nil, false, fmt.Errorf("%w: id is not a UUID", ErrInvalidClaims)
+	}
+	isAdmin, ok = claims["isAdmin"].(bool)
+	if !ok {
+		return uuid.Nil, false, fmt.Errorf("%w: isAdmin is not a boolean", ErrInvalidClaims)
+	}
+	return id, isAdmin, nil
+}
+
+// TokenVersion returns the ver claim of a token returned by ValidateToken, 0 if it has none,
+// or ErrInvalidClaims if it isn't a whole number
+func TokenVersion(token *jwt.Token) (int, error) {
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return 0, ErrInvalidClaims
+	}
+	ver, ok := claims["ver"]
+	if !ok {
+		return 0, nil
+	}
+	version, ok := ver.(float64)
+	if !ok || version != float64(int(version)) {
+		return 0, fmt.Errorf("%w: ver is not a whole number", ErrInvalidClaims)
+	}
+	return int(version), nil
+}
@@ -0,0 +1,230 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/artnikel/blogapi/internal/config"
+	"github.com/artnikel/blogapi/internal/constants"
+	"github.com/artnikel/blogapi/internal/requestuser"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+// issued adds the default issuer and audience every valid token carries to the claims
+func issued(claims jwt.MapClaims) jwt.MapClaims {
+	claims["iss"] = constants.TokenIssuer
+	claims["aud"] = constants.TokenAudience
+	return claims
+}
+
+func Test_JWTMiddleware_RejectsPurposeTokens(t *testing.T) {
+	cfg := &config.Config{BlogTokenSignature: "secret"}
+	sign := func(claims jwt.MapClaims) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, issued(claims)).SignedString([]byte(cfg.BlogTokenSignature))
+		require.NoError(t, err)
+		return token
+	}
+	e := echo.New()
+	e.GET("/", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, JWTMiddleware(cfg, nil))
+
+	for _, tc := range []struct {
+		claims jwt.MapClaims
+		status int
+	}{
+		{jwt.MapClaims{"exp": time.Now().Add(time.Minute).Unix(), "id": uuid.NewString(), "isAdmin": false}, http.StatusOK},
+		{jwt.MapClaims{"exp": time.Now().Add(time.Minute).Unix(), "id": uuid.NewString(), "isAdmin": false, "purpose": "reset"}, http.StatusUnauthorized},
+		{jwt.MapClaims{"exp": time.Now().Add(time.Minute).Unix(), "id": uuid.NewString(), "isAdmin": false, "jti": uuid.NewString()}, http.StatusUnauthorized},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+sign(tc.claims))
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		require.Equal(t, tc.status, rec.Code)
+	}
+}
+
+func Test_JWTMiddleware_RejectsMalformedTokens(t *testing.T) {
+	cfg := &config.Config{BlogTokenSignature: "secret"}
+	sign := func(method jwt.SigningMethod, key interface{}, claims jwt.MapClaims) string {
+		token, err := jwt.NewWithClaims(method, issued(claims)).SignedString(key)
+		require.NoError(t, err)
+		return token
+	}
+	e := echo.New()
+	e.GET("/", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, JWTMiddleware(cfg, nil))
+
+	exp := time.Now().Add(time.Minute).Unix()
+	for name, token := range map[string]string{
+		"malformed":       "not.a.jwt",
+		"none algorithm":  sign(jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, jwt.MapClaims{"exp": exp, "id": uuid.NewString(), "isAdmin": true}),
+		"missing isAdmin": sign(jwt.SigningMethodHS256, []byte(cfg.BlogTokenSignature), jwt.MapClaims{"exp": exp, "id": uuid.NewString()}),
+		"isAdmin string":  sign(jwt.SigningMethodHS256, []byte(cfg.BlogTokenSignature), jwt.MapClaims{"exp": exp, "id": uuid.NewString(), "isAdmin": "true"}),
+		"missing exp":     sign(jwt.SigningMethodHS256, []byte(cfg.BlogTokenSignature), jwt.MapClaims{"id": uuid.NewString(), "isAdmin": false}),
+		"numeric id":      sign(jwt.SigningMethodHS256, []byte(cfg.BlogTokenSignature), jwt.MapClaims{"exp": exp, "id": 42, "isAdmin": false}),
+		"expired":         sign(jwt.SigningMethodHS256, []byte(cfg.BlogTokenSignature), jwt.MapClaims{"exp": time.Now().Add(-time.Minute).Unix(), "id": uuid.NewString(), "isAdmin": false}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+			rec := httptest.NewRecorder()
+			require.NotPanics(t, func() { e.ServeHTTP(rec, req) })
+			require.Equal(t, http.StatusUnauthorized, rec.Code)
+		})
+	}
+}
+
+func Test_ValidateToken_TypedErrors(t *testing.T) {
+	cfg := &config.Config{BlogTokenSignature: "secret"}
+	sign := func(claims jwt.MapClaims) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, issued(claims)).SignedString([]byte(cfg.BlogTokenSignature))
+		require.NoError(t, err)
+		return token
+	}
+
+	_, err := ValidateToken(sign(jwt.MapClaims{"exp": time.Now().Add(-time.Minute).Unix(), "id": uuid.NewString()}), cfg)
+	require.ErrorIs(t, err, ErrExpired)
+
+	_, err = ValidateToken(sign(jwt.MapClaims{"id": uuid.NewString()}), cfg)
+	require.ErrorIs(t, err, ErrInvalidClaims)
+
+	_, err = ValidateToken(sign(jwt.MapClaims{"exp": time.Now().Add(time.Minute).Unix(), "id": true}), cfg)
+	require.ErrorIs(t, err, ErrInvalidClaims)
+
+	token, err := ValidateToken(sign(jwt.MapClaims{"exp": time.Now().Add(time.Minute).Unix(), "id": uuid.NewString()}), cfg)
+	require.NoError(t, err)
+	_, _, err = TokenUser(token)
+	require.ErrorIs(t, err, ErrInvalidClaims)
+}
+
+func Test_JWTMiddleware_PutsUserIntoRequestContext(t *testing.T) {
+	cfg := &config.Config{BlogTokenSignature: "secret"}
+	userID := uuid.New()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, issued(jwt.MapClaims{
+		"exp": time.Now().Add(time.Minute).Unix(), "id": userID.String(), "isAdmin": false,
+	})).SignedString([]byte(cfg.BlogTokenSignature))
+	require.NoError(t, err)
+
+	var fromContext uuid.UUID
+	e := echo.New()
+	e.GET("/", func(c echo.Context) error {
+		fromContext, _ = requestuser.FromContext(c.Request().Context())
+		return c.NoContent(http.StatusOK)
+	}, JWTMiddleware(cfg, nil))
+
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, userID, fromContext)
+}
+
+func Test_JWTMiddleware_RejectsForeignIssuerAndAudience(t *testing.T) {
+	cfg := &config.Config{BlogTokenSignature: "secret", BlogTokenIssuer: "blogapi-prod", BlogTokenAudience: "blogapi-prod"}
+	e := echo.New()
+	e.GET("/", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, JWTMiddleware(cfg, nil))
+
+	exp := time.Now().Add(time.Minute).Unix()
+	for _, tc := range []struct {
+		name   string
+		claims jwt.MapClaims
+		status int
+	}{
+		{"matching", jwt.MapClaims{"iss": "blogapi-prod", "aud": "blogapi-prod"}, http.StatusOK},
+		{"audience in a list", jwt.MapClaims{"iss": "blogapi-prod", "aud": []string{"other", "blogapi-prod"}}, http.StatusOK},
+		{"other audience", jwt.MapClaims{"iss": "blogapi-prod", "aud": "blogapi-staging"}, http.StatusUnauthorized},
+		{"other issuer", jwt.MapClaims{"iss": "blogapi-staging", "aud": "blogapi-prod"}, http.StatusUnauthorized},
+		{"no audience", jwt.MapClaims{"iss": "blogapi-prod"}, http.StatusUnauthorized},
+		{"no issuer", jwt.MapClaims{"aud": "blogapi-prod"}, http.StatusUnauthorized},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.claims["exp"], tc.claims["id"], tc.claims["isAdmin"] = exp, uuid.NewString(), false
+			token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, tc.claims).SignedString([]byte(cfg.BlogTokenSignature))
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			require.Equal(t, tc.status, rec.Code)
+		})
+	}
+
+	_, err := ValidateToken(func() string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"exp": exp, "id": uuid.NewString(), "iss": "blogapi-prod", "aud": "blogapi-staging",
+		}).SignedString([]byte(cfg.BlogTokenSignature))
+		require.NoError(t, err)
+		return token
+	}(), cfg)
+	require.ErrorIs(t, err, ErrInvalidClaims)
+}
+
+// tokenVersions is a TokenVersions serving the versions of a map, users missing from it are revoked
+type tokenVersions map[uuid.UUID]int
+
+func (v tokenVersions) TokenVersion(_ context.Context, id uuid.UUID) (int, error) {
+	version, ok := v[id]
+	if !ok {
+		return 0, ErrTokenRevoked
+	}
+	return version, nil
+}
+
+func Test_JWTMiddleware_ChecksTokenVersion(t *testing.T) {
+	cfg := &config.Config{BlogTokenSignature: "secret"}
+	userID := uuid.New()
+	versions := tokenVersions{userID: 1}
+	e := echo.New()
+	e.GET("/", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, JWTMiddleware(cfg, versions))
+
+	exp := time.Now().Add(time.Minute).Unix()
+	for _, tc := range []struct {
+		name   string
+		claims jwt.MapClaims
+		status int
+	}{
+		{"current version", jwt.MapClaims{"id": userID.String(), "ver": 1}, http.StatusOK},
+		{"older version", jwt.MapClaims{"id": userID.String(), "ver": 0}, http.StatusUnauthorized},
+		{"issued before versioning", jwt.MapClaims{"id": userID.String()}, http.StatusUnauthorized},
+		{"fractional version", jwt.MapClaims{"id": userID.String(), "ver": 1.5}, http.StatusUnauthorized},
+		{"deleted user", jwt.MapClaims{"id": uuid.NewString(), "ver": 1}, http.StatusUnauthorized},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.claims["exp"], tc.claims["isAdmin"] = exp, false
+			token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, issued(tc.claims)).SignedString([]byte(cfg.BlogTokenSignature))
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			require.Equal(t, tc.status, rec.Code)
+		})
+	}
+
+	versions[userID] = 2
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, issued(jwt.MapClaims{
+		"exp": exp, "id": userID.String(), "isAdmin": false, "ver": 1,
+	})).SignedString([]byte(cfg.BlogTokenSignature))
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusUnauthorized, rec.Code, "bumping the version revokes tokens issued before")
+}
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/artnikel/blogapi/internal/model"
+	"github.com/labstack/echo/v4"
+)
+
+// MIMEApplicationProblemJSON is the media type of RFC 7807 problem details
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// problemTypeBase prefixes the error code of a response to form the type URI of its problem details
+const problemTypeBase = "urn:blogapi:problem:"
+
+// wantsProblem reports whether the Accept header prefers problem details over the default error envelope,
+// the first listed media type the API knows decides
+func wantsProblem(accept string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case MIMEApplicationProblemJSON:
+			return true
+		case echo.MIMEApplicationJSON, "*/*":
+			return false
+		}
+	}
+	return false
+}
+
+// problemType maps an error code to the type URI of the problem, errors without a code are only described by
+// their status, which RFC 7807 spells about:blank
+func problemType(code string) string {
+	if code == "" {
+		return "about:blank"
+	}
+	return problemTypeBase + strings.ReplaceAll(code, "_", "-")
+}
+
+// WriteError writes the error envelope with the status, or its RFC 7807 problem details when the client asks for
+// application/problem+json in the Accept header. An envelope without a code gets the snake-cased status text as its
+// code, e.g. bad_request, so clients always have one to branch on.
+func WriteError(c echo.Context, status int, resp model.ErrorResponse) error {
+	if c.Request().Method == http.MethodHead {
+		return c.NoContent(status)
+	}
+	body := resp.Error
+	if !wantsProblem(c.Request().Header.Get(echo.HeaderAccept)) {
+		if resp.Error.Code == "" {
+			resp.Error.Code = statusCode(status)
+		}
+		return c.JSON(status, resp)
+	}
+	problem := model.Problem{
+		Type:      problemType(body.Code),
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    fmt.Sprint(body.Message),
+		Instance:  c.Request().URL.RequestURI(),
+		Code:      body.Code,
+		Error:     body.Debug,
+		RequestID: body.RequestID,
+		Errors:    body.Errors,
+	}
+	data, err := json.Marshal(problem)
+	if err != nil {
+		return fmt.Errorf("json.Marshal - %w", err)
+	}
+	return c.Blob(status, MIMEApplicationProblemJSON, data)
+}
+
+// statusCode derives the error code of a status from its text, e.g. too_many_requests for 429
+func statusCode(status int) string {
+	text := http.StatusText(status)
+	if text == "" {
+		return "error"
+	}
+	return strings.ReplaceAll(strings.ToLower(text), " ", "_")
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/artnikel/blogapi/internal/model"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ErrorHandler_ProblemJSON(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = ErrorHandler(e)
+	e.Pre(RequestID(""))
+	e.GET("/blog/:id", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusBadRequest, "Failed to validate id")
+	})
+
+	for _, tc := range []struct {
+		name     string
+		path     string
+		status   int
+		typ      string
+		detail   string
+		code     string
+		instance string
+	}{
+		{name: "bad request", path: "/blog/nope?withAuthor=true", status: http.StatusBadRequest, typ: "about:blank",
+			detail: "Failed to validate id", instance: "/blog/nope?withAuthor=true"},
+		{name: "not found", path: "/no/such/route", status: http.StatusNotFound, typ: "urn:blogapi:problem:route-not-found",
+			detail: http.StatusText(http.StatusNotFound), code: "route_not_found", instance: "/no/such/route"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.path, http.NoBody)
+			req.Header.Set(echo.HeaderAccept, "application/problem+json, application/json;q=0.5")
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.status, rec.Code)
+			require.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+			var problem model.Problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+			require.Equal(t, model.Problem{
+				Type:      tc.typ,
+				Title:     http.StatusText(tc.status),
+				Status:    tc.status,
+				Detail:    tc.detail,
+				Instance:  tc.instance,
+				Code:      tc.code,
+				RequestID: rec.Header().Get(echo.HeaderXRequestID),
+			}, problem)
+		})
+	}
+
+	// the envelope stays the default
+	req := httptest.NewRequest(http.MethodGet, "/blog/nope", http.NoBody)
+	req.Header.Set(echo.HeaderAccept, "application/json, application/problem+json")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON)
+	var resp model.ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, model.ErrorBody{Code: "bad_request", Message: "Failed to validate id", RequestID: rec.Header().Get(echo.HeaderXRequestID)}, resp.Error)
+}
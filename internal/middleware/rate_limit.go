@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// rateLimitCleanupInterval is how often idle client entries are swept
+	rateLimitCleanupInterval = time.Minute
+	// rateLimitIdleTTL is how long a client may stay idle before its entry is dropped
+	rateLimitIdleTTL = 10 * time.Minute
+)
+
+// clientLimiter is the token bucket of a single client
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// ipRateLimiter keeps a token bucket per client IP
+type ipRateLimiter struct {
+	mu        sync.Mutex
+	clients   map[string]*clientLimiter
+	limit     rate.Limit
+	burst     int
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// RateLimit is a token-bucket middleware keyed by client IP.
+// Requests over the limit get 429 with a Retry-After header, idle clients are forgotten periodically.
+func RateLimit(limit rate.Limit, burst int) echo.MiddlewareFunc {
+	return newIPRateLimiter(limit, burst, time.Now).middleware
+}
+
+func newIPRateLimiter(limit rate.Limit, burst int, now func() time.Time) *ipRateLimiter {
+	return &ipRateLimiter{
+		clients:   make(map[string]*clientLimiter),
+		limit:     limit,
+		burst:     burst,
+		lastSweep: now(),
+		now:       now,
+	}
+}
+
+func (l *ipRateLimiter) middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		delay, ok := l.reserve(c.RealIP())
+		if !ok {
+			c.Response().Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+			return echo.NewHTTPError(http.StatusTooManyRequests, "Too many requests")
+		}
+		return next(c)
+	}
+}
+
+// reserve takes a token for the client, returning how long to wait when none is available
+func (l *ipRateLimiter) reserve(ip string) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Sub(l.lastSweep) >= rateLimitCleanupInterval {
+		l.sweep(now)
+	}
+	client, ok := l.clients[ip]
+	if !ok {
+		client = &clientLimiter{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.clients[ip] = client
+	}
+	client.lastSeen = now
+
+	reservation := client.limiter.ReserveN(now, 1)
+	if !reservation.OK() {
+		return rateLimitIdleTTL, false
+	}
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return delay, false
+	}
+	return 0, true
+}
+
+// sweep drops the clients that have been idle longer than rateLimitIdleTTL
+func (l *ipRateLimiter) sweep(now time.Time) {
+	for ip, client := range l.clients {
+		if now.Sub(client.lastSeen) > rateLimitIdleTTL {
+			delete(l.clients, ip)
+		}
+	}
+	l.lastSweep = now
+}
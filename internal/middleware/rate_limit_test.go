@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func Test_RateLimit_RejectsOverBurst(t *testing.T) {
+	burst := 3
+	e := echo.New()
+	e.POST("/login", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, RateLimit(rate.Every(time.Minute), burst))
+
+	send := func(ip string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/login", http.NoBody)
+		req.RemoteAddr = ip + ":1234"
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for i := 0; i < burst; i++ {
+		require.Equal(t, http.StatusOK, send("10.0.0.1").Code)
+	}
+	rec := send("10.0.0.1")
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	require.NotEmpty(t, rec.Header().Get("Retry-After"))
+
+	require.Equal(t, http.StatusOK, send("10.0.0.2").Code)
+}
+
+func Test_RateLimit_IgnoresSpoofedForwardedFor(t *testing.T) {
+	extractor, err := IPExtractor(nil)
+	require.NoError(t, err)
+	e := echo.New()
+	e.IPExtractor = extractor
+	e.POST("/login", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, RateLimit(rate.Every(time.Minute), 1))
+
+	send := func(forwardedFor string) int {
+		req := httptest.NewRequest(http.MethodPost, "/login", http.NoBody)
+		req.RemoteAddr = "203.0.113.7:1234"
+		req.Header.Set(echo.HeaderXForwardedFor, forwardedFor)
+		req.Header.Set(echo.HeaderXRealIP, forwardedFor)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	require.Equal(t, http.StatusOK, send("10.0.0.1"))
+	require.Equal(t, http.StatusTooManyRequests, send("10.0.0.2"), "a new X-Forwarded-For must not reset the limit")
+}
+
+func Test_IPExtractor_TrustedProxies(t *testing.T) {
+	extractor, err := IPExtractor([]string{"10.0.0.0/8"})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/login", http.NoBody)
+	req.RemoteAddr = "10.1.2.3:1234"
+	req.Header.Set(echo.HeaderXForwardedFor, "198.51.100.1, 203.0.113.7")
+	require.Equal(t, "203.0.113.7", extractor(req), "the client is the address the trusted proxy saw")
+
+	// a client outside the trusted proxies is taken as is, whatever it claims to forward for
+	req.RemoteAddr = "192.168.1.1:1234"
+	require.Equal(t, "192.168.1.1", extractor(req))
+
+	_, err = IPExtractor([]string{"not-a-cidr"})
+	require.Error(t, err)
+}
+
+func Test_ipRateLimiter_SweepsIdleClients(t *testing.T) {
+	now := time.Now()
+	l := newIPRateLimiter(rate.Every(time.Minute), 1, func() time.Time { return now })
+
+	_, ok := l.reserve("10.0.0.1")
+	require.True(t, ok)
+	_, ok = l.reserve("10.0.0.1")
+	require.False(t, ok)
+
+	now = now.Add(rateLimitIdleTTL + rateLimitCleanupInterval)
+	_, ok = l.reserve("10.0.0.2")
+	require.True(t, ok)
+	require.Len(t, l.clients, 1)
+	require.Contains(t, l.clients, "10.0.0.2")
+}
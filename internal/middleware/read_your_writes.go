@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// WriteRecorder remembers that a user has just written data
+type WriteRecorder interface {
+	MarkWrite(userID uuid.UUID)
+}
+
+// ReadYourWrites records every successful POST, PUT, PATCH or DELETE of an authenticated user,
+// so the user's following reads can be served from the primary instead of a lagging replica
+func ReadYourWrites(recorder WriteRecorder) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			err := next(c)
+			switch c.Request().Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				return err
+			}
+			if err != nil || c.Response().Status >= http.StatusBadRequest {
+				return err
+			}
+			if userID, ok := c.Get("id").(uuid.UUID); ok {
+				recorder.MarkWrite(userID)
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+type writeRecorderFunc func(uuid.UUID)
+
+func (f writeRecorderFunc) MarkWrite(userID uuid.UUID) {
+	f(userID)
+}
+
+func Test_ReadYourWrites(t *testing.T) {
+	userID := uuid.New()
+	var marked []uuid.UUID
+	e := echo.New()
+	e.Use(ReadYourWrites(writeRecorderFunc(func(id uuid.UUID) {
+		marked = append(marked, id)
+	})))
+	setUser := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("id", userID)
+			return next(c)
+		}
+	}
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+	e.GET("/blog", ok, setUser)
+	e.POST("/blog", ok, setUser)
+	e.PUT("/blog", func(c echo.Context) error { return c.NoContent(http.StatusBadRequest) }, setUser)
+	e.DELETE("/blog", func(c echo.Context) error { return echo.ErrForbidden }, setUser)
+	e.POST("/login", ok)
+
+	for _, tc := range []struct {
+		method, path string
+		marked       int
+	}{
+		{http.MethodGet, "/blog", 0},
+		{http.MethodPut, "/blog", 0},
+		{http.MethodDelete, "/blog", 0},
+		{http.MethodPost, "/login", 0},
+		{http.MethodPost, "/blog", 1},
+	} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tc.method, tc.path, http.NoBody))
+		require.Len(t, marked, tc.marked, tc.method+" "+tc.path)
+	}
+	require.Equal(t, userID, marked[0])
+}
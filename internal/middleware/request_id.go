@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/artnikel/blogapi/internal/model"
+	"github.com/artnikel/blogapi/internal/requestid"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	log "github.com/sirupsen/logrus"
+)
+
+// requestIDKey is the context key the request id is stored under
+const requestIDKey = "requestID"
+
+// Error codes of requests rejected by the router
+const (
+	// codeRouteNotFound is the error code of requests to paths that match no route
+	codeRouteNotFound = "route_not_found"
+	// codeMethodNotAllowed is the error code of requests to known paths with an unsupported method
+	codeMethodNotAllowed = "method_not_allowed"
+	// codeRequestCancelled is the error code of requests that failed because their context was cancelled or timed out
+	codeRequestCancelled = "request_cancelled"
+)
+
+// messageRequestCancelled is the message of requests that failed because their context was cancelled or timed out
+const messageRequestCancelled = "Request was cancelled or timed out"
+
+// RequestID reuses the request id sent by the client in the given header or generates a new one.
+// The id is echoed back in the same header and kept in the echo context for error responses,
+// as well as in the request context for the logs of the lower layers.
+func RequestID(header string) echo.MiddlewareFunc {
+	if header == "" {
+		header = echo.HeaderXRequestID
+	}
+	return middleware.RequestIDWithConfig(middleware.RequestIDConfig{
+		TargetHeader: header,
+		RequestIDHandler: func(c echo.Context, id string) {
+			c.Set(requestIDKey, id)
+			c.SetRequest(c.Request().WithContext(requestid.WithID(c.Request().Context(), id)))
+		},
+	})
+}
+
+// GetRequestID returns the id of the current request, or an empty string when RequestID is not in use
+func GetRequestID(c echo.Context) string {
+	id, _ := c.Get(requestIDKey).(string)
+	return id
+}
+
+// ErrorHandler renders every error as an envelope carrying the request id, so users can quote it in support tickets,
+// or as problem details for clients asking for them, see WriteError.
+// When the context of the request was cancelled or timed out, the error most likely came from the database call
+// that was cut short, so whatever status the handler chose is replaced with 503.
+func ErrorHandler(e *echo.Echo) echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		if c.Response().Committed {
+			return
+		}
+		// the router returns these errors itself, for a 405 echo has already set the Allow header
+		code := ""
+		switch {
+		case errors.Is(err, echo.ErrNotFound):
+			code = codeRouteNotFound
+		case errors.Is(err, echo.ErrMethodNotAllowed):
+			code = codeMethodNotAllowed
+		}
+		he, ok := err.(*echo.HTTPError)
+		if !ok {
+			he = echo.NewHTTPError(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		} else if internal, ok := he.Internal.(*echo.HTTPError); ok {
+			he = internal
+		}
+		if code == "" && isCancelled(c.Request().Context(), err) {
+			code = codeRequestCancelled
+			he = echo.NewHTTPError(http.StatusServiceUnavailable, messageRequestCancelled)
+		}
+		resp := model.ErrorResponse{Error: model.ErrorBody{Code: code, Message: he.Message, RequestID: GetRequestID(c)}}
+		if e.Debug {
+			resp.Error.Debug = err.Error()
+		}
+		if err := WriteError(c, he.Code, resp); err != nil {
+			log.Errorf("ErrorHandler - %v", err)
+		}
+	}
+}
+
+// RespondError writes the error envelope of a failure a handler answers itself, with the request id.
+// Like ErrorHandler it answers 503 instead when the context of the request is done.
+func RespondError(c echo.Context, status int, code string, message interface{}) error {
+	if c.Request().Context().Err() != nil {
+		status, code, message = http.StatusServiceUnavailable, codeRequestCancelled, messageRequestCancelled
+	}
+	return WriteError(c, status, model.ErrorResponse{Error: model.ErrorBody{Code: code, Message: message, RequestID: GetRequestID(c)}})
+}
+
+// isCancelled reports whether the request failed because its context is done
+func isCancelled(ctx context.Context, err error) bool {
+	return ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
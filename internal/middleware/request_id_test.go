@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/artnikel/blogapi/internal/model"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RequestID_PropagatesIntoErrors(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = ErrorHandler(e)
+	e.Pre(RequestID("X-Correlation-ID"))
+	e.GET("/fail", func(c echo.Context) error {
+		return errors.New("database is down")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/fail", http.NoBody)
+	req.Header.Set("X-Correlation-ID", "client-id")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+	require.Equal(t, "client-id", rec.Header().Get("X-Correlation-ID"))
+	var resp model.ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, "client-id", resp.Error.RequestID)
+	require.Equal(t, "internal_server_error", resp.Error.Code)
+	require.Equal(t, http.StatusText(http.StatusInternalServerError), resp.Error.Message)
+	require.Empty(t, resp.Error.Debug)
+
+	req = httptest.NewRequest(http.MethodGet, "/missing", http.NoBody)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	generated := rec.Header().Get("X-Correlation-ID")
+	require.NotEmpty(t, generated)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, generated, resp.Error.RequestID)
+}
+
+func Test_ErrorHandler_UnknownRoute(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = ErrorHandler(e)
+	e.Pre(RequestID(""))
+	e.GET("/blogs", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/no/such/route", http.NoBody)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	var resp model.ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, "route_not_found", resp.Error.Code)
+	require.Equal(t, http.StatusText(http.StatusNotFound), resp.Error.Message)
+	require.Equal(t, rec.Header().Get(echo.HeaderXRequestID), resp.Error.RequestID)
+}
+
+func Test_ErrorHandler_CancelledRequest(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = ErrorHandler(e)
+	e.GET("/cancelled", func(c echo.Context) error {
+		// handlers answer with their usual status, unaware of why the database call failed
+		return echo.NewHTTPError(http.StatusBadRequest, "Failed to get blog")
+	})
+	e.GET("/timeout", func(c echo.Context) error {
+		return fmt.Errorf("srvBlog.Get - %w", context.DeadlineExceeded)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/cancelled", http.NoBody).WithContext(ctx),
+		httptest.NewRequest(http.MethodGet, "/timeout", http.NoBody),
+	} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		var resp model.ErrorResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		require.Equal(t, "request_cancelled", resp.Error.Code)
+	}
+}
+
+func Test_RespondError(t *testing.T) {
+	e := echo.New()
+	e.Pre(RequestID(""))
+	e.GET("/blog", func(c echo.Context) error {
+		return RespondError(c, http.StatusNotFound, "blog_not_found", "Cannot find blog")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/blog", http.NoBody)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.JSONEq(t, fmt.Sprintf(`{"error":{"code":"blog_not_found","message":"Cannot find blog","request_id":%q}}`,
+		rec.Header().Get(echo.HeaderXRequestID)), rec.Body.String())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req.WithContext(ctx))
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	var resp model.ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, "request_cancelled", resp.Error.Code)
+}
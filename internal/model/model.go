@@ -7,26 +7,349 @@ import (
 	"github.com/google/uuid"
 )
 
+// Blog statuses
+const (
+	// BlogStatusDraft is a blog visible only to its owner and admins
+	BlogStatusDraft = "draft"
+	// BlogStatusPublished is a blog visible to everyone
+	BlogStatusPublished = "published"
+	// BlogStatusPendingReview is a blog waiting for a moderator before it gets published
+	BlogStatusPendingReview = "pending_review"
+	// BlogStatusRejected is a blog a moderator refused to publish, the reason is kept in ModerationReason
+	BlogStatusRejected = "rejected"
+)
+
+// Audit actions
+const (
+	// AuditActionImpersonate is an admin getting a token acting as another user
+	AuditActionImpersonate = "impersonate"
+	// AuditActionRevokeTokens is an admin revoking every session of a user
+	AuditActionRevokeTokens = "revoke_tokens"
+)
+
+// Activity actions, the target of a blog action is the blog, of a comment action the comment and of a user action the user
+const (
+	// ActivityBlogCreated is a user creating a blog
+	ActivityBlogCreated = "blog_created"
+	// ActivityBlogUpdated is a user editing a blog
+	ActivityBlogUpdated = "blog_updated"
+	// ActivityBlogDeleted is a user deleting a blog
+	ActivityBlogDeleted = "blog_deleted"
+	// ActivityBlogPublished is a user publishing a blog
+	ActivityBlogPublished = "blog_published"
+	// ActivityBlogRestored is a user restoring a deleted blog
+	ActivityBlogRestored = "blog_restored"
+	// ActivityCommentCreated is a user commenting on a blog
+	ActivityCommentCreated = "comment_created"
+	// ActivityCommentDeleted is a user deleting a comment
+	ActivityCommentDeleted = "comment_deleted"
+	// ActivityUserDeleted is a user deleting an account, their own or another one as an admin
+	ActivityUserDeleted = "user_deleted"
+)
+
 // Blog entity
 type Blog struct {
-	BlogID      uuid.UUID `json:"blogid,omitempty" validate:"required"`
-	UserID      uuid.UUID `json:"userid,omitempty"`
-	Title       string    `json:"title" validate:"required"`
-	Content     string    `json:"content" validate:"required"`
+	BlogID  uuid.UUID `json:"blogid,omitempty" validate:"required"`
+	UserID  uuid.UUID `json:"userid,omitempty"`
+	Title   string    `json:"title" validate:"required,max=200"`
+	Content string    `json:"content" validate:"required,max=50000"`
+	// Slug is generated from the title on every create and update, a value sent by the client is ignored
+	Slug        string    `json:"slug"`
 	ReleaseTime time.Time `json:"releasetime"`
+	Status      string    `json:"status"`
+	// ModerationReason is the reason a moderator gave for rejecting the blog, shown only to its author
+	ModerationReason string   `json:"moderationreason,omitempty"`
+	Views            int      `json:"views"`
+	Tags             []string `json:"tags" validate:"dive,max=50"`
+	// UpdatedAt is when the content or the status of the blog last changed, it is read only with single blogs
+	UpdatedAt time.Time `json:"updatedat,omitzero"`
+	// Warnings are the disallowed media references of a saved blog, they are returned once and never stored
+	Warnings []MediaIssue `json:"warnings,omitempty"`
+	// CanEdit tells the caller whether they may edit and delete the blog, it is computed per request and never stored
+	CanEdit bool `json:"can_edit"`
+	// ContentHTML is the content rendered from Markdown and sanitized, it is set only when a reader asks for HTML
+	ContentHTML string `json:"content_html,omitempty"`
+}
+
+// BlogWithAuthor is a blog with the username of its author, the username is empty when the author was deleted
+type BlogWithAuthor struct {
+	Blog
+	AuthorUsername string `json:"authorusername"`
+}
+
+// ContentPreview is struct for blog content rendered to sanitized HTML with its derived metadata, nothing is saved
+type ContentPreview struct {
+	// HTML is the content rendered from Markdown and sanitized
+	HTML      string `json:"html"`
+	Excerpt   string `json:"excerpt"`
+	WordCount int    `json:"word_count"`
+	// ReadingTime is in minutes, rounded up
+	ReadingTime int `json:"reading_time"`
+	// Warnings are the disallowed media references, with the media check set to reject saving the content would fail
+	Warnings []MediaIssue `json:"warnings,omitempty"`
+}
+
+// MediaIssue is a disallowed image or link URL found in a field of a blog
+type MediaIssue struct {
+	Field  string `json:"field"`
+	URL    string `json:"url"`
+	Reason string `json:"reason"`
 }
 
 // User entity
 type User struct {
 	ID           uuid.UUID `json:"id"`
 	Username     string    `json:"username" validate:"required,min=4,max=15"`
-	Password     []byte    `json:"password" validate:"required,min=4,max=15"`
-	RefreshToken string    `json:"refreshToken"`
-	Admin        bool      `json:"-"`
+	Email        string    `json:"email" validate:"required,email,max=254"`
+	Password     []byte    `json:"-" validate:"required,min=4,max=15"`
+	RefreshToken string    `json:"-"`
+	Admin        bool      `json:"admin"`
+	TokenVersion int       `json:"-"`
+	CreatedAt    time.Time `json:"createdat"`
+}
+
+// UserProfile is the public part of a user that is safe to show to anyone
+type UserProfile struct {
+	ID       uuid.UUID `json:"id"`
+	Username string    `json:"username"`
+}
+
+// Comment entity
+type Comment struct {
+	CommentID uuid.UUID `json:"commentid"`
+	BlogID    uuid.UUID `json:"blogid"`
+	UserID    uuid.UUID `json:"userid"`
+	Body      string    `json:"body" validate:"required,max=5000"`
+	CreatedAt time.Time `json:"createdat"`
+}
+
+// RefreshToken entity is a hashed refresh token issued to one of the user's devices
+type RefreshToken struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"userid"`
+	Token     string    `json:"-"`
+	ExpiresAt time.Time `json:"expiresat"`
+	CreatedAt time.Time `json:"createdat"`
+}
+
+// PasswordReset entity is a hashed single-use password reset token
+type PasswordReset struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"userid"`
+	Token     string     `json:"-"`
+	ExpiresAt time.Time  `json:"expiresat"`
+	UsedAt    *time.Time `json:"usedat"`
+	CreatedAt time.Time  `json:"createdat"`
+}
+
+// Invite entity is a hashed single-use code letting someone sign up while signups are invite-only
+type Invite struct {
+	ID        uuid.UUID  `json:"id"`
+	Code      string     `json:"-"`
+	CreatedBy uuid.UUID  `json:"createdby"`
+	UsedBy    *uuid.UUID `json:"usedby"`
+	UsedAt    *time.Time `json:"usedat"`
+	CreatedAt time.Time  `json:"createdat"`
+}
+
+// AuditEntry entity is a privileged action recorded in the audit log
+type AuditEntry struct {
+	ID       uuid.UUID `json:"id"`
+	Action   string    `json:"action"`
+	ActorID  uuid.UUID `json:"actorid"`
+	TargetID uuid.UUID `json:"targetid"`
+	// CreatedAt is set by the database
+	CreatedAt time.Time `json:"createdat"`
+}
+
+// Activity entity is a write a user made, listed in their activity log
+type Activity struct {
+	ID       uuid.UUID `json:"id"`
+	UserID   uuid.UUID `json:"userid"`
+	Action   string    `json:"action"`
+	TargetID uuid.UUID `json:"targetid"`
+	// CreatedAt is set by the database
+	CreatedAt time.Time `json:"createdat"`
 }
 
-// BlogListResponse is struct for pagination
+// ActivityListResponse is struct for activity log pagination
+type ActivityListResponse struct {
+	Activities []*Activity `json:"activities"`
+	Count      int         `json:"count"`
+}
+
+// IdempotencyKey remembers which blog was created by a request carrying a client supplied Idempotency-Key
+type IdempotencyKey struct {
+	UserID      uuid.UUID `json:"userid"`
+	Key         string    `json:"key"`
+	RequestHash string    `json:"-"`
+	BlogID      uuid.UUID `json:"blogid"`
+	CreatedAt   time.Time `json:"createdat"`
+}
+
+// BlogListResponse is struct for pagination, Page is 1-based and TotalPages is 0 when there are no blogs
 type BlogListResponse struct {
-	Blogs []*Blog `json:"blogs"`
-	Count int     `json:"count"`
+	Blogs      []*Blog `json:"blogs"`
+	Count      int     `json:"count"`
+	Limit      int     `json:"limit"`
+	Offset     int     `json:"offset"`
+	Page       int     `json:"page"`
+	TotalPages int     `json:"totalpages"`
+	// CountIsEstimate is set when Count comes from the table statistics instead of an exact COUNT
+	CountIsEstimate bool `json:"count_is_estimate"`
+}
+
+// BlogFilter narrows a list of published blogs to an author and a release time range, nil fields do not filter
+type BlogFilter struct {
+	UserID *uuid.UUID
+	// From and To bound the release time, both ends included
+	From *time.Time
+	To   *time.Time
+	// Sort overrides the configured order of the list without narrowing it, nil keeps the configured order
+	Sort *BlogSort
+}
+
+// Blog list sort fields clients may pick
+const (
+	// SortFieldReleaseTime orders blogs by release time
+	SortFieldReleaseTime = "releasetime"
+	// SortFieldTitle orders blogs by title
+	SortFieldTitle = "title"
+)
+
+// BlogSort is an order of a blog list picked by the client, ties are broken by blog id in the same direction
+type BlogSort struct {
+	Field string
+	Desc  bool
+}
+
+// IsZero reports whether the filter lets every published blog through, the sort doesn't matter
+func (f BlogFilter) IsZero() bool {
+	return f.UserID == nil && f.From == nil && f.To == nil
+}
+
+// TagCount is a tag with the number of blogs carrying it
+type TagCount struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
+// BlogCountResponse is the total number of blogs, without any page of them
+type BlogCountResponse struct {
+	Count int `json:"count"`
+	// CountIsEstimate is set when Count comes from the table statistics instead of an exact COUNT
+	CountIsEstimate bool `json:"count_is_estimate"`
+}
+
+// CommentListResponse is struct for comments pagination
+type CommentListResponse struct {
+	Comments []*Comment `json:"comments"`
+	Count    int        `json:"count"`
+}
+
+// ErrorResponse is struct for the error envelope of every failed request, {"error": {"code": ..., "message": ...}}
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+// ErrorBody is struct for the error of a failed request, clients branch on the code, the message is for humans
+type ErrorBody struct {
+	Code    string      `json:"code"`
+	Message interface{} `json:"message"`
+	// Debug is the underlying error, only set when the server runs in debug mode
+	Debug     string `json:"debug,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+	// Errors are the field-level problems of a rejected blog
+	Errors []MediaIssue `json:"errors,omitempty"`
+}
+
+// Problem is an error in the RFC 7807 problem details format, served to clients that accept application/problem+json.
+// The code, request id and field-level errors of ErrorBody are kept as extension members.
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      string       `json:"code,omitempty"`
+	Error     string       `json:"error,omitempty"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []MediaIssue `json:"errors,omitempty"`
+}
+
+// TokenClaims is struct for the decoded claims of an access token
+type TokenClaims struct {
+	ID        uuid.UUID  `json:"id"`
+	IsAdmin   bool       `json:"isAdmin"`
+	ExpiresAt time.Time  `json:"exp"`
+	IssuedAt  *time.Time `json:"iat,omitempty"`
+	Issuer    string     `json:"iss,omitempty"`
+	// ImpersonatedBy is the admin acting as the user, set only on impersonation tokens
+	ImpersonatedBy *uuid.UUID `json:"impersonated_by,omitempty"`
+}
+
+// Diagnostics is struct for the state of an environment reported to admins
+type Diagnostics struct {
+	Database DatabaseDiagnostics `json:"database"`
+	Build    BuildInfo           `json:"build"`
+	// Cache is the state of the in-memory blog cache, nil when it is off
+	Cache *CacheStats `json:"cache,omitempty"`
+}
+
+// CacheStats is struct for the counters of the in-memory blog cache
+type CacheStats struct {
+	Hits      int64 `json:"hits"`
+	Misses    int64 `json:"misses"`
+	Evictions int64 `json:"evictions"`
+	Size      int   `json:"size"`
+}
+
+// DatabaseDiagnostics is struct for the Postgres server and connection pool state
+type DatabaseDiagnostics struct {
+	ServerVersion    string    `json:"serverversion"`
+	MigrationVersion int       `json:"migrationversion"`
+	Pool             PoolStats `json:"pool"`
+	// Queries are the duration histograms by operation, empty when the pool has no query tracer
+	Queries map[string]QueryStats `json:"queries,omitempty"`
+}
+
+// PoolStats is struct for a snapshot of the connection pool statistics
+type PoolStats struct {
+	TotalConns           int32  `json:"totalconns"`
+	AcquiredConns        int32  `json:"acquiredconns"`
+	IdleConns            int32  `json:"idleconns"`
+	MaxConns             int32  `json:"maxconns"`
+	AcquireCount         int64  `json:"acquirecount"`
+	EmptyAcquireCount    int64  `json:"emptyacquirecount"`
+	CanceledAcquireCount int64  `json:"canceledacquirecount"`
+	AcquireDuration      string `json:"acquireduration"`
+}
+
+// QueryStats is struct for the duration histogram of one kind of query
+type QueryStats struct {
+	Count   int64            `json:"count"`
+	Errors  int64            `json:"errors"`
+	TotalMs float64          `json:"totalms"`
+	Buckets []DurationBucket `json:"buckets"`
+}
+
+// DurationBucket is struct for the number of queries that took at most LessOrEqualMs milliseconds
+type DurationBucket struct {
+	LessOrEqualMs int64 `json:"le"`
+	Count         int64 `json:"count"`
+}
+
+// BuildInfo is struct for the version of the running binary
+type BuildInfo struct {
+	GoVersion string `json:"goversion"`
+	Version   string `json:"version,omitempty"`
+	Revision  string `json:"revision,omitempty"`
+	BuildTime string `json:"buildtime,omitempty"`
+	Modified  bool   `json:"modified"`
+}
+
+// BlogSiblings is struct for the previous and next published blogs around a blog
+type BlogSiblings struct {
+	Previous *Blog `json:"previous"`
+	Next     *Blog `json:"next"`
 }
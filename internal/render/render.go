@@ -0,0 +1,71 @@
+// Package render turns blog content written in Markdown, with inline HTML allowed, into sanitized HTML
+// and derives the excerpt, word count and reading time of it
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/artnikel/blogapi/internal/constants"
+	"github.com/artnikel/blogapi/internal/model"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	goldmarkhtml "github.com/yuin/goldmark/renderer/html"
+)
+
+var (
+	// markdown keeps inline HTML in the output, it is sanitized afterwards
+	markdown = goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		goldmark.WithRendererOptions(goldmarkhtml.WithUnsafe()),
+	)
+	// sanitizer allows the formatting, links and images of user generated content and drops scripts,
+	// event handlers and dangerous URL schemes
+	sanitizer = bluemonday.UGCPolicy()
+	// tag matches a tag of sanitized HTML, it is replaced by a space so the words of adjacent blocks stay apart
+	tag = regexp.MustCompile(`<[^>]*>`)
+)
+
+// HTML renders the content to sanitized HTML
+func HTML(content string) (string, error) {
+	var rendered bytes.Buffer
+	if err := markdown.Convert([]byte(content), &rendered); err != nil {
+		return "", fmt.Errorf("markdown.Convert - %w", err)
+	}
+	return string(sanitizer.SanitizeBytes(rendered.Bytes())), nil
+}
+
+// Preview renders the content to sanitized HTML and computes its excerpt, word count and reading time
+func Preview(content string) (*model.ContentPreview, error) {
+	safe, err := HTML(content)
+	if err != nil {
+		return nil, err
+	}
+	text := html.UnescapeString(tag.ReplaceAllString(safe, " "))
+	words := len(strings.Fields(text))
+	return &model.ContentPreview{
+		HTML:        safe,
+		Excerpt:     Excerpt(text, constants.FeedExcerptLen),
+		WordCount:   words,
+		ReadingTime: ReadingTime(words),
+	}, nil
+}
+
+// ReadingTime returns the minutes needed to read the given number of words, rounded up
+func ReadingTime(words int) int {
+	return (words + constants.ReadingWordsPerMinute - 1) / constants.ReadingWordsPerMinute
+}
+
+// Excerpt collapses the whitespace of the text and cuts it to at most maxLen characters
+func Excerpt(text string, maxLen int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	runes := []rune(text)
+	if len(runes) <= maxLen {
+		return text
+	}
+	return strings.TrimSpace(string(runes[:maxLen])) + "…"
+}
@@ -0,0 +1,60 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/artnikel/blogapi/internal/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Preview_RendersMarkdown(t *testing.T) {
+	preview, err := Preview("# Title\n\nSome **bold** text with a [link](https://example.com).\n\n- one\n- two\n")
+	require.NoError(t, err)
+	require.Contains(t, preview.HTML, "<h1>Title</h1>")
+	require.Contains(t, preview.HTML, "<strong>bold</strong>")
+	require.Contains(t, preview.HTML, `<a href="https://example.com" rel="nofollow">link</a>`)
+	require.Contains(t, preview.HTML, "<li>one</li>")
+	require.Equal(t, "Title Some bold text with a link . one two", preview.Excerpt)
+	require.Equal(t, 10, preview.WordCount)
+	require.Equal(t, 1, preview.ReadingTime)
+}
+
+func Test_Preview_Sanitizes(t *testing.T) {
+	preview, err := Preview("Hello<script>alert('x')</script> <img src=\"/a.png\" onerror=\"alert(1)\"> " +
+		"[click](javascript:alert(1)) <a href=\"javascript:alert(1)\">raw</a> <b>kept</b>")
+	require.NoError(t, err)
+	require.NotContains(t, preview.HTML, "<script")
+	require.NotContains(t, preview.HTML, "alert")
+	require.NotContains(t, preview.HTML, "onerror")
+	require.NotContains(t, preview.HTML, "javascript:")
+	require.Contains(t, preview.HTML, `<img src="/a.png">`)
+	require.Contains(t, preview.HTML, "<b>kept</b>")
+	require.NotContains(t, preview.Excerpt, "alert")
+}
+
+func Test_HTML(t *testing.T) {
+	rendered, err := HTML("Some *text*<script>alert('x')</script>")
+	require.NoError(t, err)
+	require.Equal(t, "<p>Some <em>text</em></p>\n", rendered)
+}
+
+func Test_Preview_Metadata(t *testing.T) {
+	content := strings.Repeat("word ", constants.ReadingWordsPerMinute+1)
+	preview, err := Preview(content)
+	require.NoError(t, err)
+	require.Equal(t, constants.ReadingWordsPerMinute+1, preview.WordCount)
+	require.Equal(t, 2, preview.ReadingTime)
+	require.LessOrEqual(t, len([]rune(preview.Excerpt)), constants.FeedExcerptLen+1)
+	require.True(t, strings.HasSuffix(preview.Excerpt, "…"))
+
+	empty, err := Preview("   ")
+	require.NoError(t, err)
+	require.Zero(t, empty.WordCount)
+	require.Zero(t, empty.ReadingTime)
+	require.Empty(t, empty.Excerpt)
+
+	escaped, err := Preview("Fish &amp; chips")
+	require.NoError(t, err)
+	require.Equal(t, "Fish & chips", escaped.Excerpt)
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/artnikel/blogapi/internal/model"
+	"github.com/google/uuid"
+)
+
+// AddActivity inserts a new row into the activity log of a user
+func (p *PgRepository) AddActivity(ctx context.Context, activity *model.Activity) error {
+	if activity == nil {
+		return ErrNil
+	}
+	_, err := p.writer(ctx).Exec(ctx, "INSERT INTO user_activity (id, userid, action, targetid) VALUES ($1, $2, $3, $4)",
+		activity.ID, activity.UserID, activity.Action, activity.TargetID)
+	if err != nil {
+		return fmt.Errorf("error in method p.pool.Exec(): %w", classify(err))
+	}
+	return nil
+}
+
+// CountActivity returns the number of entries in the activity log of a user
+func (p *PgRepository) CountActivity(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	err := p.reader(ctx).QueryRow(ctx, "SELECT COUNT(*) FROM user_activity WHERE userid = $1", userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("error in method p.pool.QueryRow(): %w", classify(err))
+	}
+	return count, nil
+}
+
+// GetActivity retrieves a page of the activity log of a user, newest first
+func (p *PgRepository) GetActivity(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*model.Activity, error) {
+	rows, err := p.reader(ctx).Query(ctx, `SELECT id, userid, action, targetid, createdat FROM user_activity
+		WHERE userid = $1 ORDER BY createdat DESC, id DESC LIMIT $2 OFFSET $3`, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("error in p.pool.Query(): %w", classify(err))
+	}
+	defer rows.Close()
+
+	activities := []*model.Activity{}
+	for rows.Next() {
+		var activity model.Activity
+		if err := rows.Scan(&activity.ID, &activity.UserID, &activity.Action, &activity.TargetID, &activity.CreatedAt); err != nil {
+			return nil, fmt.Errorf("error in rows.Scan(): %w", err)
+		}
+		activities = append(activities, &activity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", classify(err))
+	}
+	return activities, nil
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/artnikel/blogapi/internal/model"
+)
+
+// AddAuditEntry inserts a new row into the audit log, it joins the transaction of InTx
+func (p *PgRepository) AddAuditEntry(ctx context.Context, entry *model.AuditEntry) error {
+	if entry == nil {
+		return ErrNil
+	}
+	_, err := p.writer(ctx).Exec(ctx, "INSERT INTO audit_log (id, action, actorid, targetid) VALUES ($1, $2, $3, $4)",
+		entry.ID, entry.Action, entry.ActorID, entry.TargetID)
+	if err != nil {
+		return fmt.Errorf("error in method p.pool.Exec(): %w", classify(err))
+	}
+	return nil
+}
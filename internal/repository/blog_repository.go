@@ -3,31 +3,174 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync/atomic"
+	"time"
 
 	"github.com/artnikel/blogapi/internal/model"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// Blog list sort orders, every order ends with blogid so pages stay stable when release times collide
+const (
+	// SortNewest lists the latest released blogs first
+	SortNewest = "newest"
+	// SortOldest lists the earliest released blogs first
+	SortOldest = "oldest"
+)
+
+var blogSortOrders = map[string]string{
+	SortNewest: "releasetime DESC, blogid DESC",
+	SortOldest: "releasetime ASC, blogid ASC",
+}
+
+// blogSortColumns maps the sort fields clients may pick to their columns
+var blogSortColumns = map[string]string{
+	model.SortFieldReleaseTime: "releasetime",
+	model.SortFieldTitle:       "title",
+}
+
 // PgRepository represents the PostgreSQL repository implementation
 type PgRepository struct {
-	pool *pgxpool.Pool
+	pool           *pgxpool.Pool
+	replica        ReadPool
+	replicaHealthy atomic.Bool
+	sticky         *StickyPrimary
+	blogOrder      string
 }
 
 // NewPgRepository creates and returns a new instance of PgRepository, using the provided pgxpool.Pool
 func NewPgRepository(pool *pgxpool.Pool) *PgRepository {
 	return &PgRepository{
-		pool: pool,
+		pool:      pool,
+		blogOrder: blogSortOrders[SortNewest],
+	}
+}
+
+// SetDefaultSort changes the order in which blog lists are returned, an empty sort keeps the current one
+func (p *PgRepository) SetDefaultSort(sort string) error {
+	if sort == "" {
+		return nil
 	}
+	order, ok := blogSortOrders[sort]
+	if !ok {
+		return fmt.Errorf("unknown blog sort %q", sort)
+	}
+	p.blogOrder = order
+	return nil
 }
 
-// Create creates a new blog record in the db
+// blogListOrder returns the ORDER BY clause of a blog list, the sort of the filter or the configured default order.
+// A sort by an unknown field keeps the default order, the handlers accept only known fields.
+func (p *PgRepository) blogListOrder(sort *model.BlogSort) string {
+	if sort == nil {
+		return p.blogOrder
+	}
+	column, ok := blogSortColumns[sort.Field]
+	if !ok {
+		return p.blogOrder
+	}
+	direction := "ASC"
+	if sort.Desc {
+		direction = "DESC"
+	}
+	return column + " " + direction + ", blogid " + direction
+}
+
+// Create creates a new blog record in the db and sets its slug, generated from the title.
+// It joins the transaction of InTx.
 func (p *PgRepository) Create(ctx context.Context, blog *model.Blog) error {
-	_, err := p.pool.Exec(ctx, "INSERT INTO blog (blogid, userid, title, content) VALUES ($1, $2, $3, $4)",
-		blog.BlogID, blog.UserID, blog.Title, blog.Content)
+	return p.writeWithSlug(ctx, blog, func(db execer, slug string) error {
+		_, err := db.Exec(ctx, "INSERT INTO blog (blogid, userid, title, content, slug, status) VALUES ($1, $2, $3, $4, $5, $6)",
+			blog.BlogID, blog.UserID, blog.Title, blog.Content, slug, blog.Status)
+		return err
+	})
+}
+
+// writeWithSlug runs write with a free slug for the title of the blog and stores the slug in the blog.
+// Another blog with the same title may take the slug between the lookup and the write, then the write is retried,
+// inside the transaction of InTx each attempt runs in a savepoint. Any other unique violation, a blog with the same id,
+// returns ErrExist.
+func (p *PgRepository) writeWithSlug(ctx context.Context, blog *model.Blog, write func(db execer, slug string) error) error {
+	base := slugify(blog.Title)
+	for attempt := 1; ; attempt++ {
+		slug, err := p.freeSlug(ctx, base, blog.BlogID)
+		if err != nil {
+			return err
+		}
+		err = p.savepoint(ctx, func(db execer) error {
+			return write(db, slug)
+		})
+		if isSlugConflict(err) {
+			if attempt < slugAttempts {
+				continue
+			}
+			return fmt.Errorf("slug %q is still taken after %d attempts: %w", slug, attempt, ErrConflict)
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return ErrExist
+		}
+		if err != nil {
+			return fmt.Errorf("error in method p.pool.Exec(): %w", classify(err))
+		}
+		blog.Slug = slug
+		return nil
+	}
+}
+
+// CreateBatchCopy inserts the blogs with a single COPY, which is much faster than one INSERT per blog for large imports.
+// COPY fails as a whole without telling which row broke it, so on failure the blogs are inserted one by one
+// in a transaction instead and the error names the first failing blog. Either all blogs are inserted or none.
+func (p *PgRepository) CreateBatchCopy(ctx context.Context, blogs []*model.Blog) error {
+	if len(blogs) == 0 {
+		return nil
+	}
+	_, err := p.pool.CopyFrom(ctx, pgx.Identifier{"blog"}, []string{"blogid", "userid", "title", "content", "slug", "status"},
+		pgx.CopyFromSlice(len(blogs), func(i int) ([]any, error) {
+			return []any{blogs[i].BlogID, blogs[i].UserID, blogs[i].Title, blogs[i].Content, batchSlug(blogs[i]), blogs[i].Status}, nil
+		}))
+	if err == nil {
+		for _, blog := range blogs {
+			blog.Slug = batchSlug(blog)
+		}
+		return nil
+	}
+	if ctx.Err() != nil {
+		return fmt.Errorf("error in method p.pool.CopyFrom(): %w", classify(err))
+	}
+	return p.createBatchByRow(ctx, blogs)
+}
+
+// createBatchByRow inserts the blogs one by one in a transaction, returning which blog failed
+func (p *PgRepository) createBatchByRow(ctx context.Context, blogs []*model.Blog) error {
+	tx, err := p.pool.Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("error in method p.pool.Exec(): %w", err)
+		return fmt.Errorf("error in method p.pool.Begin(): %w", classify(err))
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	for i, blog := range blogs {
+		_, err := tx.Exec(ctx, "INSERT INTO blog (blogid, userid, title, content, slug, status) VALUES ($1, $2, $3, $4, $5, $6)",
+			blog.BlogID, blog.UserID, blog.Title, blog.Content, batchSlug(blog), blog.Status)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+				err = ErrExist
+			}
+			return fmt.Errorf("blog %d (%s): %w", i, blog.BlogID, err)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error in method tx.Commit(): %w", classify(err))
+	}
+	for _, blog := range blogs {
+		blog.Slug = batchSlug(blog)
 	}
 	return nil
 }
@@ -35,93 +178,643 @@ func (p *PgRepository) Create(ctx context.Context, blog *model.Blog) error {
 // Get retrieves a blog record from the db based on the provided ID
 func (p *PgRepository) Get(ctx context.Context, id uuid.UUID) (*model.Blog, error) {
 	var blog model.Blog
-	err := p.pool.QueryRow(ctx, "SELECT blogid, userid, title, content, releasetime FROM blog WHERE blogid = $1", id).
-		Scan(&blog.BlogID, &blog.UserID, &blog.Title, &blog.Content, &blog.ReleaseTime)
+	err := p.reader(ctx).QueryRow(ctx, `SELECT blogid, userid, title, content, slug, releasetime, status, COALESCE(moderation_reason, ''), views,
+		updated_at FROM blog WHERE blogid = $1 AND deleted_at IS NULL`, id).
+		Scan(&blog.BlogID, &blog.UserID, &blog.Title, &blog.Content, &blog.Slug, &blog.ReleaseTime, &blog.Status, &blog.ModerationReason, &blog.Views,
+			&blog.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error in method p.pool.QuerryRow(): %w", classify(err))
+	}
+	return &blog, nil
+}
+
+// GetWithAuthor retrieves a blog record together with the username of its author
+func (p *PgRepository) GetWithAuthor(ctx context.Context, id uuid.UUID) (*model.BlogWithAuthor, error) {
+	var blog model.BlogWithAuthor
+	err := p.reader(ctx).QueryRow(ctx, `SELECT b.blogid, b.userid, b.title, b.content, b.slug, b.releasetime, b.status,
+		COALESCE(b.moderation_reason, ''), b.views, b.updated_at, COALESCE(u.username, '')
+		FROM blog b LEFT JOIN users u ON u.id = b.userid WHERE b.blogid = $1 AND b.deleted_at IS NULL`, id).
+		Scan(&blog.BlogID, &blog.UserID, &blog.Title, &blog.Content, &blog.Slug, &blog.ReleaseTime, &blog.Status, &blog.ModerationReason,
+			&blog.Views, &blog.UpdatedAt, &blog.AuthorUsername)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error in method p.pool.QueryRow(): %w", classify(err))
+	}
+	return &blog, nil
+}
+
+// GetBySlug retrieves a blog record from the db based on its slug
+func (p *PgRepository) GetBySlug(ctx context.Context, slug string) (*model.Blog, error) {
+	var blog model.Blog
+	err := p.reader(ctx).QueryRow(ctx, `SELECT blogid, userid, title, content, slug, releasetime, status, COALESCE(moderation_reason, ''), views,
+		updated_at FROM blog WHERE slug = $1 AND deleted_at IS NULL`, slug).
+		Scan(&blog.BlogID, &blog.UserID, &blog.Title, &blog.Content, &blog.Slug, &blog.ReleaseTime, &blog.Status, &blog.ModerationReason, &blog.Views,
+			&blog.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
 	if err != nil {
-		return nil, fmt.Errorf("error in method p.pool.QuerryRow(): %w", err)
+		return nil, fmt.Errorf("error in method p.pool.QuerryRow(): %w", classify(err))
 	}
 	return &blog, nil
 }
 
-// Delete removes a blog record from the db based on the provided ID
+// Delete soft-deletes a blog record based on the provided ID, it can be brought back with Restore.
+// It returns ErrNotFound if there is no such blog or it is already deleted, and joins the transaction of InTx.
 func (p *PgRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	_, err := p.pool.Exec(ctx, "DELETE FROM blog WHERE blogid = $1", id)
+	result, err := p.writer(ctx).Exec(ctx, "UPDATE blog SET deleted_at = NOW() WHERE blogid = $1 AND deleted_at IS NULL", id)
 	if err != nil {
-		return fmt.Errorf("error in method p.pool.Exec(): %w", err)
+		return fmt.Errorf("error in method p.pool.Exec(): %w", classify(err))
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
 	}
 	return nil
 }
 
-// DeleteBlogsByUserID removes blog records from the db based on the user ID
-func (p *PgRepository) DeleteBlogsByUserID(ctx context.Context, id uuid.UUID) error {
-	_, err := p.pool.Exec(ctx, "DELETE FROM blog WHERE userid = $1", id)
+// DeleteIfUnmodifiedSince soft-deletes a blog unless it was modified after since, compared at the second precision
+// of HTTP dates. The check and the delete are one statement, so a concurrent update cannot slip in between.
+// It returns ErrNotFound if there is no such blog and ErrModified if it was modified, and joins the transaction of InTx.
+func (p *PgRepository) DeleteIfUnmodifiedSince(ctx context.Context, id uuid.UUID, since time.Time) error {
+	var modified bool
+	err := p.writer(ctx).QueryRow(ctx, `WITH target AS (
+			SELECT blogid, date_trunc('second', updated_at) > $2 AS modified FROM blog
+			WHERE blogid = $1 AND deleted_at IS NULL FOR UPDATE
+		), deleted AS (
+			UPDATE blog SET deleted_at = NOW() FROM target WHERE blog.blogid = target.blogid AND NOT target.modified
+		)
+		SELECT modified FROM target`, id, since).Scan(&modified)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrNotFound
+	}
 	if err != nil {
-		return fmt.Errorf("error in method p.pool.Exec(): %w", err)
+		return fmt.Errorf("error in method p.pool.QueryRow(): %w", classify(err))
+	}
+	if modified {
+		return ErrModified
 	}
 	return nil
 }
 
-// Update updates a blog record in the db
+// DeleteBlogsByUserID soft-deletes blog records based on the user ID and returns the ids of the deleted ones,
+// it joins the transaction of InTx
+func (p *PgRepository) DeleteBlogsByUserID(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	return p.deleteReturning(ctx, "UPDATE blog SET deleted_at = NOW() WHERE userid = $1 AND deleted_at IS NULL RETURNING blogid", id)
+}
+
+// DeleteMany soft-deletes the blogs with the given ids and returns the ids of the deleted ones, ids of missing or
+// already deleted blogs are skipped. Unless ownerID is uuid.Nil only the blogs of that author are deleted.
+// It joins the transaction of InTx.
+func (p *PgRepository) DeleteMany(ctx context.Context, ids []uuid.UUID, ownerID uuid.UUID) ([]uuid.UUID, error) {
+	query := "UPDATE blog SET deleted_at = NOW() WHERE blogid = ANY($1) AND deleted_at IS NULL"
+	args := []any{ids}
+	if ownerID != uuid.Nil {
+		query += " AND userid = $2"
+		args = append(args, ownerID)
+	}
+	return p.deleteReturning(ctx, query+" RETURNING blogid", args...)
+}
+
+// deleteReturning runs a soft-delete returning the ids of the deleted blogs
+func (p *PgRepository) deleteReturning(ctx context.Context, query string, args ...any) ([]uuid.UUID, error) {
+	rows, err := p.writer(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error in p.pool.Query(): %w", classify(err))
+	}
+	defer rows.Close()
+	var deleted []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("error in rows.Scan(): %w", err)
+		}
+		deleted = append(deleted, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", classify(err))
+	}
+	return deleted, nil
+}
+
+// ExistingIDs returns which of the given ids belong to blogs that are not deleted. Unless viewerID is uuid.Nil,
+// unpublished blogs count only when the viewer is their author.
+func (p *PgRepository) ExistingIDs(ctx context.Context, ids []uuid.UUID, viewerID uuid.UUID) ([]uuid.UUID, error) {
+	query := "SELECT blogid FROM blog WHERE blogid = ANY($1) AND deleted_at IS NULL"
+	args := []any{ids}
+	if viewerID != uuid.Nil {
+		query += " AND (status = $2 OR userid = $3)"
+		args = append(args, model.BlogStatusPublished, viewerID)
+	}
+	rows, err := p.reader(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error in p.pool.Query(): %w", classify(err))
+	}
+	defer rows.Close()
+
+	existing := make([]uuid.UUID, 0, len(ids))
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("error in rows.Scan(): %w", err)
+		}
+		existing = append(existing, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", classify(err))
+	}
+	return existing, nil
+}
+
+// Restore brings back a soft-deleted blog, returning ErrNotFound if there is no such deleted blog.
+// It joins the transaction of InTx.
+func (p *PgRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	result, err := p.writer(ctx).Exec(ctx, "UPDATE blog SET deleted_at = NULL, updated_at = NOW() WHERE blogid = $1 AND deleted_at IS NOT NULL", id)
+	if err != nil {
+		return fmt.Errorf("error in method p.pool.Exec(): %w", classify(err))
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// HardDelete permanently removes a blog and its tags, whether it was soft-deleted or not
+func (p *PgRepository) HardDelete(ctx context.Context, id uuid.UUID) error {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error in method p.pool.Begin(): %w", classify(err))
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	_, err = tx.Exec(ctx, "DELETE FROM blog_tags WHERE blogid = $1", id)
+	if err != nil {
+		return fmt.Errorf("error in method tx.Exec(): %w", classify(err))
+	}
+	result, err := tx.Exec(ctx, "DELETE FROM blog WHERE blogid = $1", id)
+	if err != nil {
+		return fmt.Errorf("error in method tx.Exec(): %w", classify(err))
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error in method tx.Commit(): %w", classify(err))
+	}
+	return nil
+}
+
+// Update updates a blog record in the db, it joins the transaction of InTx
 func (p *PgRepository) Update(ctx context.Context, blog *model.Blog) error {
-	_, err := p.pool.Exec(ctx, "UPDATE blog SET title = $1, content = $2 WHERE blogid = $3", blog.Title, blog.Content, blog.BlogID)
+	return p.writeWithSlug(ctx, blog, func(db execer, slug string) error {
+		_, err := db.Exec(ctx, "UPDATE blog SET title = $1, content = $2, slug = $3, updated_at = NOW() WHERE blogid = $4 AND deleted_at IS NULL",
+			blog.Title, blog.Content, slug, blog.BlogID)
+		return err
+	})
+}
+
+// Publish marks a blog as published and sets its release time to now, returning ErrNotFound if there is no such
+// unpublished blog. Publishing a blog again would move it to the top of every list, so published blogs are left alone.
+// It joins the transaction of InTx.
+func (p *PgRepository) Publish(ctx context.Context, id uuid.UUID) error {
+	result, err := p.writer(ctx).Exec(ctx, "UPDATE blog SET status = $1, releasetime = NOW(), updated_at = NOW() WHERE blogid = $2 AND status <> $1 AND deleted_at IS NULL",
+		model.BlogStatusPublished, id)
 	if err != nil {
-		return fmt.Errorf("error in method p.pool.Exec(): %w", err)
+		return fmt.Errorf("error in method p.pool.Exec(): %w", classify(err))
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
 	}
 	return nil
 }
 
-// Count returns count of blogs
-func (p *PgRepository) Count(ctx context.Context) (int, error) {
+// SubmitForReview puts a blog into the moderation queue, clearing the reason of a previous rejection.
+// It joins the transaction of InTx.
+func (p *PgRepository) SubmitForReview(ctx context.Context, id uuid.UUID) error {
+	result, err := p.writer(ctx).Exec(ctx, "UPDATE blog SET status = $1, moderation_reason = NULL, updated_at = NOW() WHERE blogid = $2 AND deleted_at IS NULL", model.BlogStatusPendingReview, id)
+	if err != nil {
+		return fmt.Errorf("error in method p.pool.Exec(): %w", classify(err))
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Approve publishes a blog waiting for review, returning ErrNotFound if there is no such blog in the queue
+func (p *PgRepository) Approve(ctx context.Context, id uuid.UUID) error {
+	result, err := p.pool.Exec(ctx, `UPDATE blog SET status = $1, releasetime = NOW(), moderation_reason = NULL, updated_at = NOW()
+		WHERE blogid = $2 AND status = $3 AND deleted_at IS NULL`, model.BlogStatusPublished, id, model.BlogStatusPendingReview)
+	if err != nil {
+		return fmt.Errorf("error in method p.pool.Exec(): %w", classify(err))
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Reject refuses a blog waiting for review with the given reason, returning ErrNotFound if there is no such blog in the queue
+func (p *PgRepository) Reject(ctx context.Context, id uuid.UUID, reason string) error {
+	result, err := p.pool.Exec(ctx, "UPDATE blog SET status = $1, moderation_reason = $2, updated_at = NOW() WHERE blogid = $3 AND status = $4 AND deleted_at IS NULL",
+		model.BlogStatusRejected, reason, id, model.BlogStatusPendingReview)
+	if err != nil {
+		return fmt.Errorf("error in method p.pool.Exec(): %w", classify(err))
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// GetPendingReview retrieves the blogs waiting for review, oldest first
+func (p *PgRepository) GetPendingReview(ctx context.Context, limit, offset int) ([]*model.Blog, error) {
+	query := `SELECT blogid, userid, title, content, slug, releasetime, status, views FROM blog WHERE status = $1 AND deleted_at IS NULL
+		ORDER BY releasetime, blogid LIMIT $2 OFFSET $3`
+
+	rows, err := p.pool.Query(ctx, query, model.BlogStatusPendingReview, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("error in p.pool.Query(): %w", classify(err))
+	}
+	defer rows.Close()
+
+	blogs := []*model.Blog{}
+	for rows.Next() {
+		var blog model.Blog
+		if err := rows.Scan(&blog.BlogID, &blog.UserID, &blog.Title, &blog.Content, &blog.Slug, &blog.ReleaseTime, &blog.Status, &blog.Views); err != nil {
+			return nil, fmt.Errorf("error in rows.Scan(): %w", err)
+		}
+		blogs = append(blogs, &blog)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", classify(err))
+	}
+	return blogs, nil
+}
+
+// IncrementViews increases the view counter of a blog by one and returns the new value
+func (p *PgRepository) IncrementViews(ctx context.Context, id uuid.UUID) (int, error) {
+	var views int
+	err := p.pool.QueryRow(ctx, "UPDATE blog SET views = views + 1 WHERE blogid = $1 AND deleted_at IS NULL RETURNING views", id).Scan(&views)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ErrNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("error in method p.pool.QueryRow(): %w", classify(err))
+	}
+	return views, nil
+}
+
+// GetPopular retrieves the most viewed published blogs
+func (p *PgRepository) GetPopular(ctx context.Context, limit int) ([]*model.Blog, error) {
+	query := `SELECT blogid, userid, title, content, slug, releasetime, status, views FROM blog WHERE status = $1 AND deleted_at IS NULL
+		ORDER BY views DESC, releasetime DESC, blogid DESC LIMIT $2`
+
+	rows, err := p.reader(ctx).Query(ctx, query, model.BlogStatusPublished, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error in p.pool.Query(): %w", classify(err))
+	}
+	defer rows.Close()
+
+	blogs := []*model.Blog{}
+	for rows.Next() {
+		var blog model.Blog
+		if err := rows.Scan(&blog.BlogID, &blog.UserID, &blog.Title, &blog.Content, &blog.Slug, &blog.ReleaseTime, &blog.Status, &blog.Views); err != nil {
+			return nil, fmt.Errorf("error in rows.Scan(): %w", err)
+		}
+		blogs = append(blogs, &blog)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", classify(err))
+	}
+	return blogs, nil
+}
+
+// GetSiblings retrieves the published blogs released right before and right after the given one.
+// With authorOnly the search is limited to blogs of the same author, a missing neighbour is returned as nil.
+func (p *PgRepository) GetSiblings(ctx context.Context, blog *model.Blog, authorOnly bool) (*model.BlogSiblings, error) {
+	filter := "status = $1 AND deleted_at IS NULL"
+	args := []interface{}{model.BlogStatusPublished, blog.ReleaseTime, blog.BlogID}
+	if authorOnly {
+		filter += " AND userid = $4"
+		args = append(args, blog.UserID)
+	}
+	prev, err := p.getAdjacent(ctx, `SELECT blogid, userid, title, content, slug, releasetime, status, views FROM blog
+		WHERE `+filter+` AND (releasetime, blogid) < ($2, $3) ORDER BY releasetime DESC, blogid DESC LIMIT 1`, args...)
+	if err != nil {
+		return nil, err
+	}
+	next, err := p.getAdjacent(ctx, `SELECT blogid, userid, title, content, slug, releasetime, status, views FROM blog
+		WHERE `+filter+` AND (releasetime, blogid) > ($2, $3) ORDER BY releasetime, blogid LIMIT 1`, args...)
+	if err != nil {
+		return nil, err
+	}
+	return &model.BlogSiblings{Previous: prev, Next: next}, nil
+}
+
+func (p *PgRepository) getAdjacent(ctx context.Context, query string, args ...interface{}) (*model.Blog, error) {
+	var blog model.Blog
+	err := p.reader(ctx).QueryRow(ctx, query, args...).
+		Scan(&blog.BlogID, &blog.UserID, &blog.Title, &blog.Content, &blog.Slug, &blog.ReleaseTime, &blog.Status, &blog.Views)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error in method p.pool.QueryRow(): %w", classify(err))
+	}
+	return &blog, nil
+}
+
+// blogFilterClause are the predicates of a model.BlogFilter on the parameters starting at $2, a NULL parameter disables its predicate
+const blogFilterClause = `($2::uuid IS NULL OR userid = $2) AND ($3::timestamp IS NULL OR releasetime >= $3) AND ($4::timestamp IS NULL OR releasetime <= $4)`
+
+// blogFilterArgs are the $2, $3 and $4 parameters of blogFilterClause.
+// releasetime has no time zone and is written in UTC, so the bounds are compared in UTC too.
+func blogFilterArgs(filter model.BlogFilter) []any {
+	var from, to *time.Time
+	if filter.From != nil {
+		utc := filter.From.UTC()
+		from = &utc
+	}
+	if filter.To != nil {
+		utc := filter.To.UTC()
+		to = &utc
+	}
+	return []any{filter.UserID, from, to}
+}
+
+// Count returns count of published blogs that pass the filter
+func (p *PgRepository) Count(ctx context.Context, filter model.BlogFilter) (int, error) {
 	var count int
-	err := p.pool.QueryRow(ctx, "SELECT COUNT(*) FROM blog").Scan(&count)
+	args := append([]any{model.BlogStatusPublished}, blogFilterArgs(filter)...)
+	err := p.reader(ctx).QueryRow(ctx, "SELECT COUNT(*) FROM blog WHERE status = $1 AND deleted_at IS NULL AND "+blogFilterClause, args...).Scan(&count)
 	if err != nil {
-		return 0, fmt.Errorf("error in Count: %w", err)
+		return 0, fmt.Errorf("error in Count: %w", classify(err))
 	}
 	return count, nil
 }
 
-// GetAll retrieves all blogs records from the db
-func (p *PgRepository) GetAll(ctx context.Context, limit, offset int) ([]*model.Blog, error) {
-	query := `SELECT blogid, userid, title, content, releasetime FROM blog ORDER BY releasetime DESC LIMIT $1 OFFSET $2`
+// EstimateCount returns the planner's estimate of the number of rows in the blog table without scanning it.
+// The estimate includes drafts and deleted blogs and is only as fresh as the last ANALYZE, -1 means the table was never analyzed.
+func (p *PgRepository) EstimateCount(ctx context.Context) (int, error) {
+	var count int
+	err := p.reader(ctx).QueryRow(ctx, "SELECT reltuples::bigint FROM pg_class WHERE oid = 'blog'::regclass").Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("error in EstimateCount: %w", classify(err))
+	}
+	return count, nil
+}
+
+// GetAll retrieves a page of the published blogs records that pass the filter from the db
+func (p *PgRepository) GetAll(ctx context.Context, filter model.BlogFilter, limit, offset int) ([]*model.Blog, error) {
+	query := `SELECT blogid, userid, title, content, slug, releasetime, status, views FROM blog WHERE status = $1 AND deleted_at IS NULL
+		AND ` + blogFilterClause + ` ORDER BY ` + p.blogListOrder(filter.Sort) + ` LIMIT $5 OFFSET $6`
 
-	rows, err := p.pool.Query(ctx, query, limit, offset)
+	args := append([]any{model.BlogStatusPublished}, blogFilterArgs(filter)...)
+	rows, err := p.reader(ctx).Query(ctx, query, append(args, limit, offset)...)
 	if err != nil {
-		return nil, fmt.Errorf("error in p.pool.Query(): %w", err)
+		return nil, fmt.Errorf("error in p.pool.Query(): %w", classify(err))
 	}
 	defer rows.Close()
 
-	var blogs []*model.Blog
+	blogs := []*model.Blog{}
 	for rows.Next() {
 		var blog model.Blog
-		if err := rows.Scan(&blog.BlogID, &blog.UserID, &blog.Title, &blog.Content, &blog.ReleaseTime); err != nil {
+		if err := rows.Scan(&blog.BlogID, &blog.UserID, &blog.Title, &blog.Content, &blog.Slug, &blog.ReleaseTime, &blog.Status, &blog.Views); err != nil {
 			return nil, fmt.Errorf("error in rows.Scan(): %w", err)
 		}
 		blogs = append(blogs, &blog)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating rows: %w", err)
+		return nil, fmt.Errorf("error iterating rows: %w", classify(err))
 	}
 	return blogs, nil
 }
 
-// GetByUserID retrieves all blogs from the db of a certain user
-func (p *PgRepository) GetByUserID(ctx context.Context, id uuid.UUID) ([]*model.Blog, error) {
-	var blogs []*model.Blog
-	rows, err := p.pool.Query(ctx, "SELECT userid, blogid, title, content, releasetime FROM blog WHERE userid = $1", id)
+// StreamAll calls fn with every published blog that passes the filter, in list order, reading the rows one at a time
+// instead of collecting them. It stops at the first error of fn and returns it.
+func (p *PgRepository) StreamAll(ctx context.Context, filter model.BlogFilter, fn func(*model.Blog) error) error {
+	query := `SELECT blogid, userid, title, content, slug, releasetime, status, views FROM blog WHERE status = $1 AND deleted_at IS NULL
+		AND ` + blogFilterClause + ` ORDER BY ` + p.blogListOrder(filter.Sort)
+
+	args := append([]any{model.BlogStatusPublished}, blogFilterArgs(filter)...)
+	rows, err := p.reader(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("error in p.pool.Query(): %w", classify(err))
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var blog model.Blog
+		if err := rows.Scan(&blog.BlogID, &blog.UserID, &blog.Title, &blog.Content, &blog.Slug, &blog.ReleaseTime, &blog.Status, &blog.Views); err != nil {
+			return fmt.Errorf("error in rows.Scan(): %w", err)
+		}
+		if err := fn(&blog); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating rows: %w", classify(err))
+	}
+	return nil
+}
+
+// StreamByUserID calls fn with every blog of a certain user, drafts included, oldest first with its tags, reading the
+// rows one at a time instead of collecting them. It stops at the first error of fn and returns it.
+func (p *PgRepository) StreamByUserID(ctx context.Context, id uuid.UUID, fn func(*model.Blog) error) error {
+	rows, err := p.reader(ctx).Query(ctx, `SELECT b.blogid, b.userid, b.title, b.content, b.slug, b.releasetime, b.status,
+		COALESCE(b.moderation_reason, ''), b.views, b.updated_at,
+		COALESCE((SELECT array_agg(t.tag ORDER BY t.tag) FROM blog_tags t WHERE t.blogid = b.blogid), '{}')
+		FROM blog b WHERE b.userid = $1 AND b.deleted_at IS NULL ORDER BY b.releasetime, b.blogid`, id)
+	if err != nil {
+		return fmt.Errorf("error in p.pool.Query(): %w", classify(err))
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var blog model.Blog
+		if err := rows.Scan(&blog.BlogID, &blog.UserID, &blog.Title, &blog.Content, &blog.Slug, &blog.ReleaseTime, &blog.Status,
+			&blog.ModerationReason, &blog.Views, &blog.UpdatedAt, &blog.Tags); err != nil {
+			return fmt.Errorf("error in rows.Scan(): %w", err)
+		}
+		if err := fn(&blog); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating rows: %w", classify(err))
+	}
+	return nil
+}
+
+// CountByUserID returns the number of blogs of a certain user, only the published ones with publishedOnly
+func (p *PgRepository) CountByUserID(ctx context.Context, id uuid.UUID, publishedOnly bool) (int, error) {
+	var count int
+	err := p.reader(ctx).QueryRow(ctx, "SELECT COUNT(*) FROM blog WHERE userid = $1 AND deleted_at IS NULL AND (NOT $2 OR status = $3)",
+		id, publishedOnly, model.BlogStatusPublished).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("error in method p.pool.QueryRow(): %w", classify(err))
+	}
+	return count, nil
+}
+
+// CountDraftsByUserID returns the number of drafts of a certain user. It reads from the primary so that a draft the
+// user just created is counted.
+func (p *PgRepository) CountDraftsByUserID(ctx context.Context, id uuid.UUID) (int, error) {
+	var count int
+	err := p.pool.QueryRow(ctx, "SELECT COUNT(*) FROM blog WHERE userid = $1 AND deleted_at IS NULL AND status = $2",
+		id, model.BlogStatusDraft).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("error in method p.pool.QueryRow(): %w", classify(err))
+	}
+	return count, nil
+}
+
+// GetByUserID retrieves a page of blogs from the db of a certain user, including drafts unless publishedOnly is set
+func (p *PgRepository) GetByUserID(ctx context.Context, id uuid.UUID, publishedOnly bool, limit, offset int) ([]*model.Blog, error) {
+	blogs := []*model.Blog{}
+	rows, err := p.reader(ctx).Query(ctx, `SELECT userid, blogid, title, content, slug, releasetime, status, COALESCE(moderation_reason, ''), views
+		FROM blog WHERE userid = $1 AND deleted_at IS NULL AND (NOT $2 OR status = $3) ORDER BY `+p.blogOrder+` LIMIT $4 OFFSET $5`,
+		id, publishedOnly, model.BlogStatusPublished, limit, offset)
 	if err != nil {
-		return nil, fmt.Errorf("error in method p.pool.QuerryRow(): %w", err)
+		return nil, fmt.Errorf("error in method p.pool.QuerryRow(): %w", classify(err))
 	}
 	defer rows.Close()
 	for rows.Next() {
 		var blog model.Blog
-		err := rows.Scan(&blog.UserID, &blog.BlogID, &blog.Title, &blog.Content, &blog.ReleaseTime)
+		err := rows.Scan(&blog.UserID, &blog.BlogID, &blog.Title, &blog.Content, &blog.Slug, &blog.ReleaseTime, &blog.Status, &blog.ModerationReason, &blog.Views)
 		if err != nil {
 			return nil, fmt.Errorf("error in method rows.Scan(): %w", err)
 		}
 		blogs = append(blogs, &blog)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating rows: %w", err)
+		return nil, fmt.Errorf("error iterating rows: %w", classify(err))
+	}
+	return blogs, nil
+}
+
+// IsBlogOwner reports whether the blog exists and belongs to the user.
+// It reads from the primary because it guards writes, a blog created a moment ago must be found.
+func (p *PgRepository) IsBlogOwner(ctx context.Context, blogID, userID uuid.UUID) (bool, error) {
+	var owner bool
+	err := p.pool.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM blog WHERE blogid = $1 AND userid = $2 AND deleted_at IS NULL)",
+		blogID, userID).Scan(&owner)
+	if err != nil {
+		return false, fmt.Errorf("error in method p.pool.QueryRow(): %w", classify(err))
+	}
+	return owner, nil
+}
+
+// AddTags attaches tags to a blog, skipping the ones it already has. It joins the transaction of InTx.
+func (p *PgRepository) AddTags(ctx context.Context, blogID uuid.UUID, tags []string) error {
+	_, err := p.writer(ctx).Exec(ctx, "INSERT INTO blog_tags (blogid, tag) SELECT $1, unnest($2::varchar[]) ON CONFLICT DO NOTHING", blogID, tags)
+	if err != nil {
+		return fmt.Errorf("error in method p.pool.Exec(): %w", classify(err))
+	}
+	return nil
+}
+
+// ReplaceTags replaces all tags of a blog with the given ones in a single transaction, it joins the transaction of InTx
+func (p *PgRepository) ReplaceTags(ctx context.Context, blogID uuid.UUID, tags []string) error {
+	return p.InTx(ctx, func(ctx context.Context) error {
+		db := p.writer(ctx)
+		_, err := db.Exec(ctx, "DELETE FROM blog_tags WHERE blogid = $1", blogID)
+		if err != nil {
+			return fmt.Errorf("error in method tx.Exec(): %w", classify(err))
+		}
+		_, err = db.Exec(ctx, "INSERT INTO blog_tags (blogid, tag) SELECT $1, unnest($2::varchar[]) ON CONFLICT DO NOTHING", blogID, tags)
+		if err != nil {
+			return fmt.Errorf("error in method tx.Exec(): %w", classify(err))
+		}
+		return nil
+	})
+}
+
+// GetTags retrieves the tags of a blog in alphabetical order
+func (p *PgRepository) GetTags(ctx context.Context, blogID uuid.UUID) ([]string, error) {
+	rows, err := p.reader(ctx).Query(ctx, "SELECT tag FROM blog_tags WHERE blogid = $1 ORDER BY tag", blogID)
+	if err != nil {
+		return nil, fmt.Errorf("error in method p.pool.Query(): %w", classify(err))
+	}
+	defer rows.Close()
+	tags := []string{}
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, fmt.Errorf("error in method rows.Scan(): %w", err)
+		}
+		tags = append(tags, tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", classify(err))
+	}
+	return tags, nil
+}
+
+// CountByTag returns count of published blogs with the given tag
+func (p *PgRepository) CountByTag(ctx context.Context, tag string) (int, error) {
+	var count int
+	err := p.reader(ctx).QueryRow(ctx, `SELECT COUNT(*) FROM blog_tags t JOIN blog b ON b.blogid = t.blogid
+		WHERE t.tag = $1 AND b.status = $2 AND b.deleted_at IS NULL`, tag, model.BlogStatusPublished).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("error in CountByTag: %w", classify(err))
+	}
+	return count, nil
+}
+
+// GetTopTagsByUserID retrieves the tags of the published blogs of a user with the number of blogs carrying each,
+// most used first, ties in alphabetical order
+func (p *PgRepository) GetTopTagsByUserID(ctx context.Context, id uuid.UUID, limit int) ([]*model.TagCount, error) {
+	rows, err := p.reader(ctx).Query(ctx, `SELECT t.tag, COUNT(*) AS uses FROM blog_tags t JOIN blog b ON b.blogid = t.blogid
+		WHERE b.userid = $1 AND b.status = $2 AND b.deleted_at IS NULL
+		GROUP BY t.tag ORDER BY uses DESC, t.tag LIMIT $3`, id, model.BlogStatusPublished, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error in p.pool.Query(): %w", classify(err))
+	}
+	defer rows.Close()
+
+	tags := []*model.TagCount{}
+	for rows.Next() {
+		var tag model.TagCount
+		if err := rows.Scan(&tag.Tag, &tag.Count); err != nil {
+			return nil, fmt.Errorf("error in rows.Scan(): %w", err)
+		}
+		tags = append(tags, &tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", classify(err))
+	}
+	return tags, nil
+}
+
+// GetByTag retrieves published blogs with the given tag from the db
+func (p *PgRepository) GetByTag(ctx context.Context, tag string, limit, offset int) ([]*model.Blog, error) {
+	query := `SELECT b.blogid, b.userid, b.title, b.content, b.slug, b.releasetime, b.status, b.views FROM blog b
+		JOIN blog_tags t ON t.blogid = b.blogid
+		WHERE t.tag = $1 AND b.status = $2 AND b.deleted_at IS NULL ORDER BY ` + p.blogOrder + ` LIMIT $3 OFFSET $4`
+
+	rows, err := p.reader(ctx).Query(ctx, query, tag, model.BlogStatusPublished, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("error in p.pool.Query(): %w", classify(err))
+	}
+	defer rows.Close()
+
+	blogs := []*model.Blog{}
+	for rows.Next() {
+		var blog model.Blog
+		if err := rows.Scan(&blog.BlogID, &blog.UserID, &blog.Title, &blog.Content, &blog.Slug, &blog.ReleaseTime, &blog.Status, &blog.Views); err != nil {
+			return nil, fmt.Errorf("error in rows.Scan(): %w", err)
+		}
+		blogs = append(blogs, &blog)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", classify(err))
 	}
 	return blogs, nil
 }
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/artnikel/blogapi/internal/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Postgres SQLSTATE codes of constraint violations
+// CreateComment creates a new comment record in the db, returning ErrNotFound if the blog doesn't exist.
+// It joins the transaction of InTx.
+func (p *PgRepository) CreateComment(ctx context.Context, comment *model.Comment) error {
+	if comment == nil {
+		return ErrNil
+	}
+	err := p.writer(ctx).QueryRow(ctx, "INSERT INTO comments (commentid, blogid, userid, body) VALUES ($1, $2, $3, $4) RETURNING createdat",
+		comment.CommentID, comment.BlogID, comment.UserID, comment.Body).Scan(&comment.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+			return fmt.Errorf("blog %s: %w", comment.BlogID, ErrNotFound)
+		}
+		return fmt.Errorf("error in method p.pool.QueryRow(): %w", classify(err))
+	}
+	return nil
+}
+
+// GetComment retrieves a comment record from the db based on the provided ID
+func (p *PgRepository) GetComment(ctx context.Context, id uuid.UUID) (*model.Comment, error) {
+	var comment model.Comment
+	err := p.pool.QueryRow(ctx, "SELECT commentid, blogid, userid, body, createdat FROM comments WHERE commentid = $1", id).
+		Scan(&comment.CommentID, &comment.BlogID, &comment.UserID, &comment.Body, &comment.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("error in method p.pool.QueryRow(): %w", classify(err))
+	}
+	return &comment, nil
+}
+
+// CountCommentsByBlogID returns count of comments of a certain blog
+func (p *PgRepository) CountCommentsByBlogID(ctx context.Context, blogID uuid.UUID) (int, error) {
+	var count int
+	err := p.reader(ctx).QueryRow(ctx, "SELECT COUNT(*) FROM comments WHERE blogid = $1", blogID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("error in CountCommentsByBlogID: %w", classify(err))
+	}
+	return count, nil
+}
+
+// GetCommentsByBlogID retrieves comments of a certain blog from the db, oldest first
+func (p *PgRepository) GetCommentsByBlogID(ctx context.Context, blogID uuid.UUID, limit, offset int) ([]*model.Comment, error) {
+	query := `SELECT commentid, blogid, userid, body, createdat FROM comments WHERE blogid = $1
+		ORDER BY createdat, commentid LIMIT $2 OFFSET $3`
+
+	rows, err := p.reader(ctx).Query(ctx, query, blogID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("error in p.pool.Query(): %w", classify(err))
+	}
+	defer rows.Close()
+
+	var comments []*model.Comment
+	for rows.Next() {
+		var comment model.Comment
+		if err := rows.Scan(&comment.CommentID, &comment.BlogID, &comment.UserID, &comment.Body, &comment.CreatedAt); err != nil {
+			return nil, fmt.Errorf("error in rows.Scan(): %w", err)
+		}
+		comments = append(comments, &comment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", classify(err))
+	}
+	return comments, nil
+}
+
+// StreamCommentsByUserID calls fn with every comment a certain user wrote, oldest first, reading the rows one at a time
+// instead of collecting them. It stops at the first error of fn and returns it.
+func (p *PgRepository) StreamCommentsByUserID(ctx context.Context, userID uuid.UUID, fn func(*model.Comment) error) error {
+	rows, err := p.reader(ctx).Query(ctx, `SELECT commentid, blogid, userid, body, createdat FROM comments WHERE userid = $1
+		ORDER BY createdat, commentid`, userID)
+	if err != nil {
+		return fmt.Errorf("error in p.pool.Query(): %w", classify(err))
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var comment model.Comment
+		if err := rows.Scan(&comment.CommentID, &comment.BlogID, &comment.UserID, &comment.Body, &comment.CreatedAt); err != nil {
+			return fmt.Errorf("error in rows.Scan(): %w", err)
+		}
+		if err := fn(&comment); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating rows: %w", classify(err))
+	}
+	return nil
+}
+
+// DeleteComment removes a comment record from the db based on the provided ID, it joins the transaction of InTx
+func (p *PgRepository) DeleteComment(ctx context.Context, id uuid.UUID) error {
+	result, err := p.writer(ctx).Exec(ctx, "DELETE FROM comments WHERE commentid = $1", id)
+	if err != nil {
+		return fmt.Errorf("error in method p.pool.Exec(): %w", classify(err))
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// statementCacheModes maps the names of BLOG_PG_STATEMENT_CACHE_MODE to pgx query exec modes,
+// the names are the ones pgx accepts in the default_query_exec_mode connection string parameter
+var statementCacheModes = map[string]pgx.QueryExecMode{
+	"cache_statement": pgx.QueryExecModeCacheStatement,
+	"cache_describe":  pgx.QueryExecModeCacheDescribe,
+	"describe_exec":   pgx.QueryExecModeDescribeExec,
+	"exec":            pgx.QueryExecModeExec,
+	"simple_protocol": pgx.QueryExecModeSimpleProtocol,
+}
+
+// PoolOptions are the optional settings applied to every connection of a pool
+type PoolOptions struct {
+	// Tracer is attached to every connection when non-nil
+	Tracer pgx.QueryTracer
+	// StatementCacheMode sets how queries are executed, one of the keys of statementCacheModes.
+	// Empty keeps the pgx default of caching prepared statements, or the mode set in the dsn.
+	StatementCacheMode string
+	// StatementTimeout makes the server cancel statements running longer than this, non-positive leaves the server default
+	StatementTimeout time.Duration
+}
+
+// dialFunc opens a pool and checks that the database answers
+type dialFunc func(ctx context.Context) (*pgxpool.Pool, error)
+
+// Connect opens a pool to the database at dsn and pings it, retrying up to attempts times.
+// The wait between attempts starts at backoff and doubles after every failure.
+func Connect(ctx context.Context, dsn string, attempts int, backoff time.Duration, opts PoolOptions) (*pgxpool.Pool, error) {
+	conf, err := poolConfig(dsn, opts)
+	if err != nil {
+		return nil, err
+	}
+	return connectWithRetry(ctx, attempts, backoff, func(ctx context.Context) (*pgxpool.Pool, error) {
+		pool, err := pgxpool.NewWithConfig(ctx, conf)
+		if err != nil {
+			return nil, fmt.Errorf("error in method pgxpool.NewWithConfig(): %w", err)
+		}
+		if err := pool.Ping(ctx); err != nil {
+			pool.Close()
+			return nil, fmt.Errorf("error in method pool.Ping(): %w", classify(err))
+		}
+		return pool, nil
+	})
+}
+
+// Open creates a pool to the database at dsn without waiting for the database to answer,
+// connections are made on first use. It is meant for optional databases such as the read replica.
+func Open(ctx context.Context, dsn string, opts PoolOptions) (*pgxpool.Pool, error) {
+	conf, err := poolConfig(dsn, opts)
+	if err != nil {
+		return nil, err
+	}
+	pool, err := pgxpool.NewWithConfig(ctx, conf)
+	if err != nil {
+		return nil, fmt.Errorf("error in method pgxpool.NewWithConfig(): %w", err)
+	}
+	return pool, nil
+}
+
+// poolConfig parses dsn and applies the options to it
+func poolConfig(dsn string, opts PoolOptions) (*pgxpool.Config, error) {
+	conf, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("error in method pgxpool.ParseConfig(): %w", err)
+	}
+	if opts.Tracer != nil {
+		conf.ConnConfig.Tracer = opts.Tracer
+	}
+	if opts.StatementCacheMode != "" {
+		mode, ok := statementCacheModes[opts.StatementCacheMode]
+		if !ok {
+			return nil, fmt.Errorf("unknown statement cache mode %q", opts.StatementCacheMode)
+		}
+		conf.ConnConfig.DefaultQueryExecMode = mode
+	}
+	if opts.StatementTimeout > 0 {
+		// the timeout is applied by the server, so it also stops queries whose Go context never ends
+		timeout := strconv.FormatInt(opts.StatementTimeout.Milliseconds(), 10)
+		conf.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+			if _, err := conn.Exec(ctx, "SELECT set_config('statement_timeout', $1, false)", timeout); err != nil {
+				return fmt.Errorf("error in method conn.Exec(): %w", classify(err))
+			}
+			return nil
+		}
+	}
+	return conf, nil
+}
+
+func connectWithRetry(ctx context.Context, attempts int, backoff time.Duration, dial dialFunc) (*pgxpool.Pool, error) {
+	if attempts < 1 {
+		attempts = 1
+	}
+	var err error
+	for attempt := 1; ; attempt++ {
+		var pool *pgxpool.Pool
+		pool, err = dial(ctx)
+		if err == nil {
+			return pool, nil
+		}
+		if attempt == attempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("gave up connecting after %d attempts: %w", attempt, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	return nil, fmt.Errorf("gave up connecting after %d attempts: %w", attempts, classify(err))
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/artnikel/blogapi/internal/model"
+)
+
+// ServerVersion returns the version of the Postgres server
+func (p *PgRepository) ServerVersion(ctx context.Context) (string, error) {
+	var version string
+	err := p.pool.QueryRow(ctx, "SHOW server_version").Scan(&version)
+	if err != nil {
+		return "", fmt.Errorf("error in method p.pool.QueryRow(): %w", classify(err))
+	}
+	return version, nil
+}
+
+// MigrationVersion returns the latest applied migration version, 0 if none was applied
+func (p *PgRepository) MigrationVersion(ctx context.Context) (int, error) {
+	var version int
+	err := p.pool.QueryRow(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("error in method p.pool.QueryRow(): %w", classify(err))
+	}
+	return version, nil
+}
+
+// QueryStats returns the duration histograms by operation recorded by the QueryTracer of the pool, nil without one
+func (p *PgRepository) QueryStats() map[string]model.QueryStats {
+	tracer, ok := p.pool.Config().ConnConfig.Tracer.(*QueryTracer)
+	if !ok {
+		return nil
+	}
+	return tracer.Stats()
+}
+
+// PoolStats returns a snapshot of the connection pool statistics
+func (p *PgRepository) PoolStats() model.PoolStats {
+	stat := p.pool.Stat()
+	return model.PoolStats{
+		TotalConns:           stat.TotalConns(),
+		AcquiredConns:        stat.AcquiredConns(),
+		IdleConns:            stat.IdleConns(),
+		MaxConns:             stat.MaxConns(),
+		AcquireCount:         stat.AcquireCount(),
+		EmptyAcquireCount:    stat.EmptyAcquireCount(),
+		CanceledAcquireCount: stat.CanceledAcquireCount(),
+		AcquireDuration:      stat.AcquireDuration().String(),
+	}
+}
@@ -0,0 +1,85 @@
+// Package repository errors.go contains custom errors
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ErrNil means that u've given nil entity for a create method
+var ErrNil = errors.New("entity that u've given is nil")
+
+// ErrExist means that an entity with the same unique key, e.g. the username, already exists
+var ErrExist = errors.New("entity already exists")
+
+// ErrNotFound means that the requested entity doesn't exist
+var ErrNotFound = errors.New("entity not found")
+
+// ErrAdminUser means that the user is an admin, admins cannot be deleted
+var ErrAdminUser = errors.New("user is an admin")
+
+// ErrLastAdmin means that the user is the only admin left, demoting them would leave no one able to manage roles
+var ErrLastAdmin = errors.New("user is the last admin")
+
+// ErrConflict means that the write collided with a concurrent one and may succeed if retried
+var ErrConflict = errors.New("conflicting concurrent write")
+
+// ErrModified means that the entity was modified after the time a conditional write required it unmodified since
+var ErrModified = errors.New("entity modified since the given time")
+
+// ErrUnavailable means that the database could not be reached
+var ErrUnavailable = errors.New("database unavailable")
+
+// PostgreSQL error codes the repository reacts to
+const (
+	// foreignKeyViolation is raised when a referenced row doesn't exist
+	foreignKeyViolation = "23503"
+	// uniqueViolation is raised when a row with the same unique key already exists
+	uniqueViolation = "23505"
+	// serializationFailure is raised when a transaction could not be serialized with a concurrent one
+	serializationFailure = "40001"
+	// deadlockDetected is raised when two transactions wait for each other's locks
+	deadlockDetected = "40P01"
+	// lockNotAvailable is raised when a NOWAIT lock is held by another transaction
+	lockNotAvailable = "55P03"
+	// tooManyConnections is raised when the server has no free connection slots
+	tooManyConnections = "53300"
+	// adminShutdown, crashShutdown and cannotConnectNow are raised while the server is shutting down or starting up
+	adminShutdown    = "57P01"
+	crashShutdown    = "57P02"
+	cannotConnectNow = "57P03"
+)
+
+// classify tags a database error with ErrUnavailable or ErrConflict when it is one, so callers can tell
+// an unreachable database and a retryable write conflict from other failures with errors.Is.
+// Errors caused by the cancelled or expired context of the caller are returned as they are, so errors.Is still
+// finds context.Canceled or context.DeadlineExceeded and the database is not blamed for them.
+func classify(err error) error {
+	if errors.Is(err, ErrUnavailable) || errors.Is(err, ErrConflict) ||
+		errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case pgErr.Code == serializationFailure || pgErr.Code == deadlockDetected || pgErr.Code == lockNotAvailable:
+			return fmt.Errorf("%w: %w", ErrConflict, err)
+		case pgErr.Code == tooManyConnections || pgErr.Code == adminShutdown || pgErr.Code == crashShutdown ||
+			pgErr.Code == cannotConnectNow || strings.HasPrefix(pgErr.Code, "08"):
+			return fmt.Errorf("%w: %w", ErrUnavailable, err)
+		}
+		return err
+	}
+	// other timeouts are left alone too, they usually come from the deadline of the caller rather than a broken connection
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+	if errors.As(err, &connectErr) || (errors.As(err, &netErr) && !netErr.Timeout()) {
+		return fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+	return err
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/artnikel/blogapi/internal/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ClaimIdempotencyKey stores the key for the blog about to be created, replacing a stored key older than ttl.
+// It returns nil if the key was claimed, or the key stored by an earlier request that is still within ttl.
+func (p *PgRepository) ClaimIdempotencyKey(ctx context.Context, key *model.IdempotencyKey, ttl time.Duration) (*model.IdempotencyKey, error) {
+	if key == nil {
+		return nil, ErrNil
+	}
+	result, err := p.pool.Exec(ctx, `INSERT INTO idempotency_keys (userid, key, requesthash, blogid) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (userid, key) DO UPDATE SET requesthash = EXCLUDED.requesthash, blogid = EXCLUDED.blogid, createdat = NOW()
+		WHERE idempotency_keys.createdat < NOW() - make_interval(secs => $5)`,
+		key.UserID, key.Key, key.RequestHash, key.BlogID, ttl.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("error in method p.pool.Exec(): %w", classify(err))
+	}
+	if result.RowsAffected() == 1 {
+		return nil, nil
+	}
+	var stored model.IdempotencyKey
+	err = p.pool.QueryRow(ctx, "SELECT userid, key, requesthash, blogid, createdat FROM idempotency_keys WHERE userid = $1 AND key = $2",
+		key.UserID, key.Key).Scan(&stored.UserID, &stored.Key, &stored.RequestHash, &stored.BlogID, &stored.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// released by the earlier request between the insert and the select, the client may retry
+		return nil, fmt.Errorf("idempotency key %q: %w", key.Key, ErrConflict)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error in method p.pool.QueryRow(): %w", classify(err))
+	}
+	return &stored, nil
+}
+
+// DeleteIdempotencyKey releases a claimed key, so a request that failed may be retried with the same key
+func (p *PgRepository) DeleteIdempotencyKey(ctx context.Context, userID uuid.UUID, key string) error {
+	_, err := p.pool.Exec(ctx, "DELETE FROM idempotency_keys WHERE userid = $1 AND key = $2", userID, key)
+	if err != nil {
+		return fmt.Errorf("error in method p.pool.Exec(): %w", classify(err))
+	}
+	return nil
+}
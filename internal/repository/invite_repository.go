@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/artnikel/blogapi/internal/model"
+	"github.com/google/uuid"
+)
+
+// AddInvite inserts a new invite code row
+func (p *PgRepository) AddInvite(ctx context.Context, invite *model.Invite) error {
+	if invite == nil {
+		return ErrNil
+	}
+	_, err := p.pool.Exec(ctx, "INSERT INTO invites (id, code, createdby) VALUES ($1, $2, $3)",
+		invite.ID, invite.Code, invite.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("error in method p.pool.Exec(): %w", classify(err))
+	}
+	return nil
+}
+
+// UseInvite marks the unused invite with the given code as used by the user, it joins the transaction of InTx.
+// An unknown or already used code returns ErrNotFound.
+func (p *PgRepository) UseInvite(ctx context.Context, code string, userID uuid.UUID) error {
+	result, err := p.writer(ctx).Exec(ctx, "UPDATE invites SET usedby = $2, usedat = NOW() WHERE code = $1 AND usedat IS NULL",
+		code, userID)
+	if err != nil {
+		return fmt.Errorf("error in method p.pool.Exec(): %w", classify(err))
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationName matches the V<version>__<description>.sql file names of migrations
+var migrationName = regexp.MustCompile(`^V(\d+)__(\w+)\.sql$`)
+
+// migrationLockID serializes Migrate between app instances starting at the same time
+const migrationLockID = 7346201
+
+// migration is a single versioned SQL file
+type migration struct {
+	version     int
+	description string
+	sql         string
+}
+
+// Migrate applies the embedded migrations that are not recorded in schema_migrations yet, in version order.
+// Every migration runs in its own transaction together with its schema_migrations row, so reruns are no-ops.
+// A database previously migrated by Flyway has its history imported instead of being migrated again.
+func Migrate(ctx context.Context, pool *pgxpool.Pool) error {
+	migrations, err := loadMigrations(migrationFiles)
+	if err != nil {
+		return err
+	}
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("error in method pool.Acquire(): %w", classify(err))
+	}
+	defer conn.Release()
+	// waiting for the lock and building indexes may take longer than the statement timeout of the pool
+	var statementTimeout string
+	if err := conn.QueryRow(ctx, "SHOW statement_timeout").Scan(&statementTimeout); err != nil {
+		return fmt.Errorf("error in method conn.QueryRow(): %w", classify(err))
+	}
+	if _, err := conn.Exec(ctx, "SET statement_timeout = 0"); err != nil {
+		return fmt.Errorf("error in method conn.Exec(): %w", classify(err))
+	}
+	defer func() {
+		_, _ = conn.Exec(context.Background(), "SELECT set_config('statement_timeout', $1, false)", statementTimeout)
+	}()
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return fmt.Errorf("error in method conn.Exec(): %w", classify(err))
+	}
+	defer func() {
+		_, _ = conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockID)
+	}()
+
+	_, err = conn.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version integer PRIMARY KEY,
+		description VARCHAR NOT NULL,
+		appliedat timestamp DEFAULT NOW()
+	)`)
+	if err != nil {
+		return fmt.Errorf("error in method conn.Exec(): %w", classify(err))
+	}
+	_, err = conn.Exec(ctx, `DO $$ BEGIN
+		IF to_regclass('flyway_schema_history') IS NOT NULL THEN
+			INSERT INTO schema_migrations (version, description)
+			SELECT version::integer, description FROM flyway_schema_history WHERE success AND version IS NOT NULL
+			ON CONFLICT (version) DO NOTHING;
+		END IF;
+	END $$`)
+	if err != nil {
+		return fmt.Errorf("error in method conn.Exec(): %w", classify(err))
+	}
+
+	for _, m := range migrations {
+		tx, err := conn.Begin(ctx)
+		if err != nil {
+			return fmt.Errorf("error in method conn.Begin(): %w", classify(err))
+		}
+		result, err := tx.Exec(ctx, "INSERT INTO schema_migrations (version, description) VALUES ($1, $2) ON CONFLICT (version) DO NOTHING",
+			m.version, m.description)
+		if err != nil {
+			_ = tx.Rollback(ctx)
+			return fmt.Errorf("error in method tx.Exec(): %w", classify(err))
+		}
+		if result.RowsAffected() == 0 {
+			_ = tx.Rollback(ctx)
+			continue
+		}
+		if _, err := tx.Exec(ctx, m.sql); err != nil {
+			_ = tx.Rollback(ctx)
+			return fmt.Errorf("migration V%d %s: %w", m.version, m.description, err)
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return fmt.Errorf("error in method tx.Commit(): %w", classify(err))
+		}
+	}
+	return nil
+}
+
+// loadMigrations reads the migration files sorted by version and rejects unexpected names and duplicate versions
+func loadMigrations(fsys fs.FS) ([]migration, error) {
+	names, err := fs.Glob(fsys, "migrations/*.sql")
+	if err != nil {
+		return nil, fmt.Errorf("error in method fs.Glob(): %w", err)
+	}
+	migrations := make([]migration, 0, len(names))
+	seen := make(map[int]string, len(names))
+	for _, name := range names {
+		parts := migrationName.FindStringSubmatch(path.Base(name))
+		if parts == nil {
+			return nil, fmt.Errorf("unexpected migration file name %q", name)
+		}
+		version, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("error in method strconv.Atoi(): %w", err)
+		}
+		if other, ok := seen[version]; ok {
+			return nil, fmt.Errorf("migrations %q and %q share version %d", other, name, version)
+		}
+		seen[version] = name
+		sql, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("error in method fs.ReadFile(): %w", err)
+		}
+		migrations = append(migrations, migration{version: version, description: parts[2], sql: string(sql)})
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].version < migrations[j].version
+	})
+	return migrations, nil
+}
@@ -0,0 +1 @@
+ALTER TABLE blog ADD COLUMN moderation_reason VARCHAR(1000);
@@ -0,0 +1,11 @@
+CREATE TABLE password_resets (
+	id uuid,
+	userid uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	token VARCHAR NOT NULL,
+	expiresat timestamp NOT NULL,
+	usedat timestamp,
+	createdat timestamp DEFAULT NOW(),
+	primary key (id)
+);
+
+CREATE INDEX password_resets_userid_idx ON password_resets (userid);
@@ -0,0 +1 @@
+ALTER TABLE blog ADD COLUMN deleted_at timestamp;
@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN createdat timestamp DEFAULT NOW();
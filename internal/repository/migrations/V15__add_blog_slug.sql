@@ -0,0 +1,9 @@
+ALTER TABLE blog ADD COLUMN slug VARCHAR;
+
+UPDATE blog SET slug = CONCAT_WS('-',
+	NULLIF(TRIM(BOTH '-' FROM REGEXP_REPLACE(LOWER(title), '[^[:alnum:]]+', '-', 'g')), ''),
+	LEFT(blogid::text, 8));
+
+ALTER TABLE blog ALTER COLUMN slug SET NOT NULL;
+
+CREATE UNIQUE INDEX blog_slug_idx ON blog (slug);
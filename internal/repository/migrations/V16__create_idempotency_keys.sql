@@ -0,0 +1,8 @@
+CREATE TABLE idempotency_keys (
+	userid uuid NOT NULL,
+	key VARCHAR(255) NOT NULL,
+	requesthash VARCHAR NOT NULL,
+	blogid uuid NOT NULL,
+	createdat timestamp DEFAULT NOW(),
+	primary key (userid, key)
+);
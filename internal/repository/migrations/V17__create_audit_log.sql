@@ -0,0 +1,11 @@
+CREATE TABLE audit_log (
+	id uuid,
+	action VARCHAR NOT NULL,
+	actorid uuid NOT NULL,
+	targetid uuid,
+	createdat timestamp DEFAULT NOW(),
+	primary key (id)
+);
+
+CREATE INDEX audit_log_actorid_idx ON audit_log (actorid);
+CREATE INDEX audit_log_targetid_idx ON audit_log (targetid);
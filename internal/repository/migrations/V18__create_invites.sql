@@ -0,0 +1,11 @@
+CREATE TABLE invites (
+	id uuid,
+	code VARCHAR NOT NULL,
+	createdby uuid NOT NULL,
+	usedby uuid,
+	usedat timestamp,
+	createdat timestamp DEFAULT NOW(),
+	primary key (id)
+);
+
+CREATE UNIQUE INDEX invites_code_idx ON invites (code);
@@ -0,0 +1,3 @@
+CREATE INDEX blog_status_releasetime_blogid_idx ON blog (status, releasetime DESC, blogid DESC) WHERE deleted_at IS NULL;
+
+DROP INDEX IF EXISTS blog_status_releasetime_idx;
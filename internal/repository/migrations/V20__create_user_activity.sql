@@ -0,0 +1,10 @@
+CREATE TABLE user_activity (
+	id uuid,
+	userid uuid NOT NULL,
+	action VARCHAR NOT NULL,
+	targetid uuid NOT NULL,
+	createdat timestamp NOT NULL DEFAULT clock_timestamp(),
+	primary key (id)
+);
+
+CREATE INDEX user_activity_userid_createdat_idx ON user_activity (userid, createdat DESC, id DESC);
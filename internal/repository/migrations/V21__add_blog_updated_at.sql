@@ -0,0 +1,3 @@
+ALTER TABLE blog ADD COLUMN updated_at timestamptz NOT NULL DEFAULT NOW();
+
+UPDATE blog SET updated_at = releasetime WHERE releasetime IS NOT NULL;
@@ -0,0 +1,12 @@
+CREATE TABLE refresh_tokens (
+	id uuid,
+	userid uuid NOT NULL,
+	token VARCHAR NOT NULL,
+	expiresat timestamp NOT NULL,
+	createdat timestamp DEFAULT NOW(),
+	primary key (id)
+);
+
+CREATE INDEX refresh_tokens_userid_idx ON refresh_tokens (userid);
+
+ALTER TABLE users DROP COLUMN refreshToken;
@@ -0,0 +1,7 @@
+CREATE TABLE blog_tags (
+	blogid uuid NOT NULL,
+	tag VARCHAR(50) NOT NULL,
+	primary key (blogid, tag)
+);
+
+CREATE INDEX blog_tags_tag_idx ON blog_tags (tag);
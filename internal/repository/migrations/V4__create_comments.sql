@@ -0,0 +1,10 @@
+CREATE TABLE comments (
+	commentid uuid,
+	blogid uuid NOT NULL REFERENCES blog (blogid) ON DELETE CASCADE,
+	userid uuid NOT NULL,
+	body VARCHAR NOT NULL,
+	createdat timestamp DEFAULT NOW(),
+	primary key (commentid)
+);
+
+CREATE INDEX comments_blogid_createdat_idx ON comments (blogid, createdat);
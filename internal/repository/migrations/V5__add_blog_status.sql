@@ -0,0 +1,4 @@
+ALTER TABLE blog ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'published';
+ALTER TABLE blog ALTER COLUMN status SET DEFAULT 'draft';
+
+CREATE INDEX blog_status_releasetime_idx ON blog (status, releasetime DESC);
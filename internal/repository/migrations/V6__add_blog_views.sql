@@ -0,0 +1,3 @@
+ALTER TABLE blog ADD COLUMN views INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX blog_status_views_idx ON blog (status, views DESC);
@@ -0,0 +1,8 @@
+CREATE TABLE recent_views (
+	userid uuid NOT NULL,
+	blogid uuid NOT NULL REFERENCES blog (blogid) ON DELETE CASCADE,
+	viewedat timestamp NOT NULL DEFAULT NOW(),
+	primary key (userid, blogid)
+);
+
+CREATE INDEX recent_views_userid_viewedat_idx ON recent_views (userid, viewedat DESC);
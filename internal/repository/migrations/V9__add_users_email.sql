@@ -0,0 +1,3 @@
+ALTER TABLE users ADD COLUMN email VARCHAR(254);
+
+CREATE UNIQUE INDEX users_email_idx ON users (email);
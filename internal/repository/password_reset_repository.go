@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/artnikel/blogapi/internal/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// AddPasswordReset inserts a new password reset token row
+func (p *PgRepository) AddPasswordReset(ctx context.Context, reset *model.PasswordReset) error {
+	if reset == nil {
+		return ErrNil
+	}
+	_, err := p.pool.Exec(ctx, "INSERT INTO password_resets (id, userid, token, expiresat) VALUES ($1, $2, $3, $4)",
+		reset.ID, reset.UserID, reset.Token, reset.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("error in method p.pool.Exec(): %w", classify(err))
+	}
+	return nil
+}
+
+// GetPasswordReset retrieves a password reset token row by its ID
+func (p *PgRepository) GetPasswordReset(ctx context.Context, id uuid.UUID) (*model.PasswordReset, error) {
+	var reset model.PasswordReset
+	err := p.pool.QueryRow(ctx, "SELECT id, userid, token, expiresat, usedat, createdat FROM password_resets WHERE id = $1", id).
+		Scan(&reset.ID, &reset.UserID, &reset.Token, &reset.ExpiresAt, &reset.UsedAt, &reset.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error in method p.pool.QueryRow(): %w", classify(err))
+	}
+	return &reset, nil
+}
+
+// UsePasswordReset marks a password reset token as used, returning ErrNotFound if it doesn't exist or was already used
+func (p *PgRepository) UsePasswordReset(ctx context.Context, id uuid.UUID) error {
+	result, err := p.pool.Exec(ctx, "UPDATE password_resets SET usedat = NOW() WHERE id = $1 AND usedat IS NULL", id)
+	if err != nil {
+		return fmt.Errorf("error in method p.pool.Exec(): %w", classify(err))
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
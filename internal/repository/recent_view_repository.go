@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/artnikel/blogapi/internal/model"
+	"github.com/google/uuid"
+)
+
+// AddRecentView moves a blog to the top of the user's recently viewed list and prunes the list to keep entries
+func (p *PgRepository) AddRecentView(ctx context.Context, userID, blogID uuid.UUID, keep int) error {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error in method p.pool.Begin(): %w", classify(err))
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	_, err = tx.Exec(ctx, `INSERT INTO recent_views (userid, blogid, viewedat) VALUES ($1, $2, clock_timestamp())
+		ON CONFLICT (userid, blogid) DO UPDATE SET viewedat = EXCLUDED.viewedat`, userID, blogID)
+	if err != nil {
+		return fmt.Errorf("error in method tx.Exec(): %w", classify(err))
+	}
+	_, err = tx.Exec(ctx, `DELETE FROM recent_views WHERE userid = $1 AND blogid NOT IN
+		(SELECT blogid FROM recent_views WHERE userid = $1 ORDER BY viewedat DESC, blogid DESC LIMIT $2)`, userID, keep)
+	if err != nil {
+		return fmt.Errorf("error in method tx.Exec(): %w", classify(err))
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error in method tx.Commit(): %w", classify(err))
+	}
+	return nil
+}
+
+// GetRecentViews retrieves the blogs recently viewed by a user, newest first
+func (p *PgRepository) GetRecentViews(ctx context.Context, userID uuid.UUID) ([]*model.Blog, error) {
+	query := `SELECT b.blogid, b.userid, b.title, b.content, b.slug, b.releasetime, b.status, b.views FROM recent_views r
+		JOIN blog b ON b.blogid = r.blogid
+		WHERE r.userid = $1 AND b.deleted_at IS NULL ORDER BY r.viewedat DESC, r.blogid DESC`
+
+	rows, err := p.reader(ctx).Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error in p.pool.Query(): %w", classify(err))
+	}
+	defer rows.Close()
+
+	blogs := []*model.Blog{}
+	for rows.Next() {
+		var blog model.Blog
+		if err := rows.Scan(&blog.BlogID, &blog.UserID, &blog.Title, &blog.Content, &blog.Slug, &blog.ReleaseTime, &blog.Status, &blog.Views); err != nil {
+			return nil, fmt.Errorf("error in rows.Scan(): %w", err)
+		}
+		blogs = append(blogs, &blog)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", classify(err))
+	}
+	return blogs, nil
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/artnikel/blogapi/internal/requestuser"
+	"github.com/jackc/pgx/v5"
+	log "github.com/sirupsen/logrus"
+)
+
+// replicaPingTimeout bounds a single health check of the read replica
+const replicaPingTimeout = 2 * time.Second
+
+// ReadPool is the part of a pool that read-only queries need, *pgxpool.Pool implements it
+type ReadPool interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Ping(ctx context.Context) error
+}
+
+type primaryKey struct{}
+
+// WithPrimary returns a context whose reads go to the primary even when a replica is set.
+// Use it to read data right after writing it, the replica may lag behind the primary.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// IsPrimary reports whether the context asks for reads from the primary, see WithPrimary
+func IsPrimary(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryKey{}).(bool)
+	return primary
+}
+
+// SetReplica routes blog, comment and profile reads to the replica, a nil replica sends them back to the primary.
+// User, token and moderation lookups that decide on writes always stay on the primary.
+func (p *PgRepository) SetReplica(replica ReadPool) {
+	p.replica = replica
+	p.replicaHealthy.Store(replica != nil)
+}
+
+// SetStickyPrimary keeps the reads of users who wrote recently on the primary, see StickyPrimary
+func (p *PgRepository) SetStickyPrimary(sticky *StickyPrimary) {
+	p.sticky = sticky
+}
+
+// CheckReplica pings the replica right away and then every interval until ctx is done.
+// Reads fall back to the primary while the replica doesn't answer and return to it once it does.
+func (p *PgRepository) CheckReplica(ctx context.Context, interval time.Duration) {
+	p.checkReplica(ctx)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.checkReplica(ctx)
+		}
+	}
+}
+
+// checkReplica pings the replica once and records whether reads may go to it
+func (p *PgRepository) checkReplica(ctx context.Context) {
+	if p.replica == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, replicaPingTimeout)
+	defer cancel()
+	err := p.replica.Ping(ctx)
+	healthy := err == nil
+	if p.replicaHealthy.Swap(healthy) == healthy {
+		return
+	}
+	if healthy {
+		log.Info("read replica is back, routing reads to it")
+	} else {
+		log.WithField("error", err.Error()).Warn("read replica is unhealthy, routing reads to the primary")
+	}
+}
+
+// reader returns the pool read-only queries of ctx should run on
+func (p *PgRepository) reader(ctx context.Context) ReadPool {
+	if p.replica == nil || !p.replicaHealthy.Load() {
+		return p.pool
+	}
+	if IsPrimary(ctx) {
+		return p.pool
+	}
+	if userID, ok := requestuser.FromContext(ctx); ok && p.sticky != nil && p.sticky.Active(userID) {
+		return p.pool
+	}
+	return p.replica
+}
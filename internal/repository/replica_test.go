@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/artnikel/blogapi/internal/model"
+	"github.com/artnikel/blogapi/internal/requestuser"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+var errReplicaHit = errors.New("replica hit")
+
+// fakeReplica counts the queries sent to it and fails every one of them with errReplicaHit
+type fakeReplica struct {
+	queries int
+	pingErr error
+}
+
+func (f *fakeReplica) Query(context.Context, string, ...any) (pgx.Rows, error) {
+	f.queries++
+	return nil, errReplicaHit
+}
+
+func (f *fakeReplica) QueryRow(context.Context, string, ...any) pgx.Row {
+	f.queries++
+	return fakeRow{}
+}
+
+func (f *fakeReplica) Ping(context.Context) error {
+	return f.pingErr
+}
+
+type fakeRow struct{}
+
+func (fakeRow) Scan(...any) error {
+	return errReplicaHit
+}
+
+func newReplicaRepository() (*PgRepository, *fakeReplica) {
+	replica := &fakeReplica{}
+	repo := NewPgRepository(pgRepo.pool)
+	repo.SetReplica(replica)
+	return repo, replica
+}
+
+func Test_Replica_ReadsGoToReplica(t *testing.T) {
+	repo, replica := newReplicaRepository()
+
+	_, err := repo.Get(context.Background(), uuid.New())
+	require.ErrorIs(t, err, errReplicaHit)
+	_, err = repo.GetAll(context.Background(), model.BlogFilter{}, 10, 0)
+	require.ErrorIs(t, err, errReplicaHit)
+	require.Equal(t, 2, replica.queries)
+}
+
+func Test_Replica_WritesGoToPrimary(t *testing.T) {
+	repo, replica := newReplicaRepository()
+	blog := model.Blog{BlogID: uuid.New(), UserID: uuid.New(), Title: "replicatitle", Content: "replicacontent", Status: model.BlogStatusPublished}
+
+	require.NoError(t, repo.Create(context.Background(), &blog))
+	require.NoError(t, repo.Delete(context.Background(), blog.BlogID))
+	require.Zero(t, replica.queries)
+}
+
+func Test_Replica_WithPrimary(t *testing.T) {
+	repo, replica := newReplicaRepository()
+
+	_, err := repo.Get(WithPrimary(context.Background()), uuid.New())
+	require.ErrorIs(t, err, ErrNotFound)
+	require.Zero(t, replica.queries)
+}
+
+func Test_Replica_FallsBackWhenUnhealthy(t *testing.T) {
+	repo, replica := newReplicaRepository()
+	replica.pingErr = errors.New("connection refused")
+
+	repo.checkReplica(context.Background())
+	_, err := repo.Get(context.Background(), uuid.New())
+	require.ErrorIs(t, err, ErrNotFound)
+	require.Zero(t, replica.queries)
+
+	replica.pingErr = nil
+	repo.checkReplica(context.Background())
+	_, err = repo.Get(context.Background(), uuid.New())
+	require.ErrorIs(t, err, errReplicaHit)
+}
+
+func Test_Replica_Unconfigured(t *testing.T) {
+	repo := NewPgRepository(pgRepo.pool)
+	repo.SetReplica(nil)
+
+	_, err := repo.Get(context.Background(), uuid.New())
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func Test_Replica_ReadYourWrites(t *testing.T) {
+	repo, replica := newReplicaRepository()
+	sticky := NewStickyPrimary(5 * time.Second)
+	clock := time.Now()
+	sticky.now = func() time.Time { return clock }
+	repo.SetStickyPrimary(sticky)
+
+	writer := uuid.New()
+	ctx := requestuser.WithID(context.Background(), writer)
+	sticky.MarkWrite(writer)
+
+	_, err := repo.Get(ctx, uuid.New())
+	require.ErrorIs(t, err, ErrNotFound)
+	require.Zero(t, replica.queries)
+
+	_, err = repo.Get(requestuser.WithID(context.Background(), uuid.New()), uuid.New())
+	require.ErrorIs(t, err, errReplicaHit)
+
+	clock = clock.Add(5 * time.Second)
+	_, err = repo.Get(ctx, uuid.New())
+	require.ErrorIs(t, err, errReplicaHit)
+}
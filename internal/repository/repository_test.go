@@ -1,11 +1,16 @@
 package repository
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	"github.com/artnikel/blogapi/internal/config"
@@ -37,11 +42,16 @@ func SetupPostgres() (*pgxpool.Pool, func(), error) {
 		return nil, nil, fmt.Errorf("could not start resource: %w", err)
 	}
 
-	dbURL := fmt.Sprintf("postgres://%s:%s@localhost:%s/%s",
-		cfg.BlogPostgresUser,
-		cfg.BlogPostgresPassword,
-		resource.GetPort("5432"),
-		cfg.BlogPostgresDB)
+	cfg.BlogPostgresPath = ""
+	cfg.BlogPostgresHost = "localhost"
+	cfg.BlogPostgresPort, err = strconv.Atoi(resource.GetPort("5432/tcp"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse container port: %w", err)
+	}
+	dbURL, err := cfg.PostgresDSN()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build dbURL: %w", err)
+	}
 	conf, err := pgxpool.ParseConfig(dbURL)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to parse dbURL: %w", err)
@@ -64,6 +74,11 @@ func TestMain(m *testing.M) {
 		cleanupPgx()
 		os.Exit(1)
 	}
+	if err := Migrate(context.Background(), dbpool); err != nil {
+		fmt.Println("Could not migrate the database: ", err)
+		cleanupPgx()
+		os.Exit(1)
+	}
 	pgRepo = NewPgRepository(dbpool)
 	exitCode := m.Run()
 	cleanupPgx()
@@ -103,7 +118,7 @@ func Test_CreateBlog_Duplicate(t *testing.T) {
 	require.NoError(t, err)
 
 	err = pgRepo.Create(ctx, &testBlog)
-	require.Error(t, err)
+	require.ErrorIs(t, err, ErrExist)
 }
 
 func Test_CreateBlog_ContextTimeout(t *testing.T) {
@@ -115,15 +130,45 @@ func Test_CreateBlog_ContextTimeout(t *testing.T) {
 	require.True(t, errors.Is(err, context.DeadlineExceeded))
 }
 
+func Test_CancelledContext(t *testing.T) {
+	blog := testBlog
+	blog.BlogID = uuid.New()
+	require.NoError(t, pgRepo.Create(context.Background(), &blog))
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+	for _, tc := range []struct {
+		ctx  context.Context
+		want error
+	}{
+		{cancelled, context.Canceled},
+		{expired, context.DeadlineExceeded},
+	} {
+		_, err := pgRepo.Get(tc.ctx, blog.BlogID)
+		require.ErrorIs(t, err, tc.want)
+		require.NotErrorIs(t, err, ErrUnavailable)
+
+		_, err = pgRepo.GetAll(tc.ctx, model.BlogFilter{}, 10, 0)
+		require.ErrorIs(t, err, tc.want)
+		require.NotErrorIs(t, err, ErrUnavailable)
+
+		err = pgRepo.Update(tc.ctx, &blog)
+		require.ErrorIs(t, err, tc.want)
+		require.NotErrorIs(t, err, ErrNotFound)
+	}
+}
+
 func Test_GetBlog_NotFound(t *testing.T) {
 	_, err := pgRepo.Get(context.Background(), uuid.New())
-	require.Error(t, err)
+	require.ErrorIs(t, err, ErrNotFound)
 }
 
 func Test_Count(t *testing.T) {
 	ctx := context.Background()
 
-	initialCount, err := pgRepo.Count(ctx)
+	initialCount, err := pgRepo.Count(ctx, model.BlogFilter{})
 	require.NoError(t, err)
 
 	testBlog1 := model.Blog{
@@ -145,18 +190,79 @@ func Test_Count(t *testing.T) {
 	err = pgRepo.Create(ctx, &testBlog2)
 	require.NoError(t, err)
 
-	finalCount, err := pgRepo.Count(ctx)
+	require.NoError(t, pgRepo.Publish(ctx, testBlog1.BlogID))
+	require.NoError(t, pgRepo.Publish(ctx, testBlog2.BlogID))
+
+	finalCount, err := pgRepo.Count(ctx, model.BlogFilter{})
 	require.NoError(t, err)
 	require.Equal(t, initialCount+2, finalCount)
 }
 
+func Test_CountDraftsByUserID(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	blogs := make([]model.Blog, 4)
+	for i := range blogs {
+		blogs[i] = model.Blog{BlogID: uuid.New(), UserID: userID, Title: "Draft", Content: "Content", Status: model.BlogStatusDraft}
+		require.NoError(t, pgRepo.Create(ctx, &blogs[i]))
+	}
+	require.NoError(t, pgRepo.Create(ctx, &model.Blog{BlogID: uuid.New(), UserID: uuid.New(), Title: "Draft", Content: "Content",
+		Status: model.BlogStatusDraft}))
+	require.NoError(t, pgRepo.Publish(ctx, blogs[0].BlogID))
+	require.NoError(t, pgRepo.Delete(ctx, blogs[1].BlogID))
+
+	drafts, err := pgRepo.CountDraftsByUserID(ctx, userID)
+	require.NoError(t, err)
+	require.Equal(t, 2, drafts)
+}
+
+func Test_ClaimIdempotencyKey(t *testing.T) {
+	ctx := context.Background()
+	key := &model.IdempotencyKey{UserID: uuid.New(), Key: "retry-" + uuid.NewString(), RequestHash: "hash", BlogID: uuid.New()}
+
+	stored, err := pgRepo.ClaimIdempotencyKey(ctx, key, time.Hour)
+	require.NoError(t, err)
+	require.Nil(t, stored)
+
+	retry := *key
+	retry.BlogID = uuid.New()
+	stored, err = pgRepo.ClaimIdempotencyKey(ctx, &retry, time.Hour)
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	require.Equal(t, key.BlogID, stored.BlogID)
+
+	// an expired key is claimed again
+	_, err = pgRepo.pool.Exec(ctx, "UPDATE idempotency_keys SET createdat = NOW() - INTERVAL '2 hours' WHERE key = $1", key.Key)
+	require.NoError(t, err)
+	stored, err = pgRepo.ClaimIdempotencyKey(ctx, &retry, time.Hour)
+	require.NoError(t, err)
+	require.Nil(t, stored)
+
+	require.NoError(t, pgRepo.DeleteIdempotencyKey(ctx, key.UserID, key.Key))
+	stored, err = pgRepo.ClaimIdempotencyKey(ctx, key, time.Hour)
+	require.NoError(t, err)
+	require.Nil(t, stored)
+}
+
+func Test_EstimateCount(t *testing.T) {
+	ctx := context.Background()
+	blogs := newBatch(20)
+	require.NoError(t, pgRepo.CreateBatchCopy(ctx, blogs))
+	_, err := pgRepo.pool.Exec(ctx, "ANALYZE blog")
+	require.NoError(t, err)
+
+	estimate, err := pgRepo.EstimateCount(ctx)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, estimate, len(blogs))
+}
+
 func Test_GetAllBlogs(t *testing.T) {
 	const (
 		limit  = 10
 		offset = 0
 	)
 	ctx := context.Background()
-	firstblogs, err := pgRepo.GetAll(ctx, limit, offset)
+	firstblogs, err := pgRepo.GetAll(ctx, model.BlogFilter{}, limit, offset)
 	require.NoError(t, err)
 
 	testBlog1 := model.Blog{
@@ -174,12 +280,121 @@ func Test_GetAllBlogs(t *testing.T) {
 
 	_ = pgRepo.Create(ctx, &testBlog1)
 	_ = pgRepo.Create(ctx, &testBlog2)
+	_ = pgRepo.Publish(ctx, testBlog1.BlogID)
+	_ = pgRepo.Publish(ctx, testBlog2.BlogID)
 
-	blogs, err := pgRepo.GetAll(ctx, limit, offset)
+	blogs, err := pgRepo.GetAll(ctx, model.BlogFilter{}, limit, offset)
 	require.NoError(t, err)
 	require.Equal(t, len(blogs), len(firstblogs)+2)
 }
 
+func Test_EmptyPagesAreNotNil(t *testing.T) {
+	ctx := context.Background()
+	blogs, err := pgRepo.GetAll(ctx, model.BlogFilter{}, 10, 1_000_000)
+	require.NoError(t, err)
+	require.NotNil(t, blogs)
+	require.Empty(t, blogs)
+
+	blogs, err = pgRepo.GetByUserID(ctx, uuid.New(), false, 10, 0)
+	require.NoError(t, err)
+	require.NotNil(t, blogs)
+	require.Empty(t, blogs)
+}
+
+func Test_GetAll_Order(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	base := time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+	// inserted out of order, the last two share a release time and are ordered by blogid
+	var blogs []model.Blog
+	for _, hours := range []int{3, 1, 4, 2, 0, 0} {
+		blog := model.Blog{BlogID: uuid.New(), UserID: userID, Title: "Ordered", Content: "Ordered content", Status: model.BlogStatusPublished}
+		require.NoError(t, pgRepo.Create(ctx, &blog))
+		blog.ReleaseTime = base.Add(time.Duration(hours) * time.Hour)
+		_, err := pgRepo.pool.Exec(ctx, "UPDATE blog SET releasetime = $1 WHERE blogid = $2", blog.ReleaseTime, blog.BlogID)
+		require.NoError(t, err)
+		blogs = append(blogs, blog)
+	}
+	sort.Slice(blogs, func(i, j int) bool {
+		if !blogs[i].ReleaseTime.Equal(blogs[j].ReleaseTime) {
+			return blogs[i].ReleaseTime.After(blogs[j].ReleaseTime)
+		}
+		return bytes.Compare(blogs[i].BlogID[:], blogs[j].BlogID[:]) > 0
+	})
+	want := make([]uuid.UUID, len(blogs))
+	for i, blog := range blogs {
+		want[i] = blog.BlogID
+	}
+
+	filter := model.BlogFilter{UserID: &userID}
+	for run := 0; run < 3; run++ {
+		var got []uuid.UUID
+		for offset := 0; offset < len(want); offset += 4 {
+			page, err := pgRepo.GetAll(ctx, filter, 4, offset)
+			require.NoError(t, err)
+			for _, blog := range page {
+				got = append(got, blog.BlogID)
+			}
+		}
+		require.Equal(t, want, got)
+	}
+
+	oldest := NewPgRepository(pgRepo.pool)
+	require.NoError(t, oldest.SetDefaultSort(SortOldest))
+	page, err := oldest.GetAll(ctx, filter, len(want), 0)
+	require.NoError(t, err)
+	for i, blog := range page {
+		require.Equal(t, want[len(want)-1-i], blog.BlogID)
+	}
+}
+
+func Test_GetAll_Filter(t *testing.T) {
+	ctx := context.Background()
+	date := func(month time.Month) *time.Time {
+		d := time.Date(2020, month, 1, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+	first, second := uuid.New(), uuid.New()
+	for _, seed := range []struct {
+		userID uuid.UUID
+		month  time.Month
+	}{{first, time.January}, {first, time.June}, {second, time.March}} {
+		blog := model.Blog{BlogID: uuid.New(), UserID: seed.userID, Title: "Filtered", Content: "Filtered content", Status: model.BlogStatusPublished}
+		require.NoError(t, pgRepo.Create(ctx, &blog))
+		_, err := pgRepo.pool.Exec(ctx, "UPDATE blog SET releasetime = $1 WHERE blogid = $2", *date(seed.month), blog.BlogID)
+		require.NoError(t, err)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		filter model.BlogFilter
+		count  int
+	}{
+		{name: "author", filter: model.BlogFilter{UserID: &first}, count: 2},
+		{name: "range", filter: model.BlogFilter{From: date(time.February), To: date(time.December)}, count: 2},
+		{name: "author from", filter: model.BlogFilter{UserID: &first, From: date(time.February)}, count: 1},
+		{name: "author to, inclusive", filter: model.BlogFilter{UserID: &first, To: date(time.January)}, count: 1},
+		{name: "author and range", filter: model.BlogFilter{UserID: &second, From: date(time.February), To: date(time.April)}, count: 1},
+		{name: "nothing in range", filter: model.BlogFilter{UserID: &second, From: date(time.April)}, count: 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			blogs, err := pgRepo.GetAll(ctx, tc.filter, 10, 0)
+			require.NoError(t, err)
+			require.Len(t, blogs, tc.count)
+			count, err := pgRepo.Count(ctx, tc.filter)
+			require.NoError(t, err)
+			require.Equal(t, tc.count, count)
+			streamed := 0
+			err = pgRepo.StreamAll(ctx, tc.filter, func(*model.Blog) error {
+				streamed++
+				return nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, tc.count, streamed)
+		})
+	}
+}
+
 func Test_UpdateBlog(t *testing.T) {
 	ctx := context.Background()
 	testBlog.BlogID = uuid.New()
@@ -196,6 +411,94 @@ func Test_UpdateBlog(t *testing.T) {
 	require.Equal(t, "Updated Content", updatedBlog.Content)
 }
 
+func Test_GetWithAuthor(t *testing.T) {
+	ctx := context.Background()
+	author := model.User{ID: uuid.New(), Username: "author" + uuid.NewString()[:8], Password: []byte("password")}
+	require.NoError(t, pgRepo.SignUp(ctx, &author))
+	blog := model.Blog{BlogID: uuid.New(), UserID: author.ID, Title: "With author", Content: "content"}
+	require.NoError(t, pgRepo.Create(ctx, &blog))
+
+	stored, err := pgRepo.GetWithAuthor(ctx, blog.BlogID)
+	require.NoError(t, err)
+	require.Equal(t, blog.BlogID, stored.BlogID)
+	require.Equal(t, blog.Title, stored.Title)
+	require.Equal(t, author.Username, stored.AuthorUsername)
+
+	// the author of this blog doesn't exist anymore
+	orphan := model.Blog{BlogID: uuid.New(), UserID: uuid.New(), Title: "Orphan", Content: "content"}
+	require.NoError(t, pgRepo.Create(ctx, &orphan))
+	stored, err = pgRepo.GetWithAuthor(ctx, orphan.BlogID)
+	require.NoError(t, err)
+	require.Equal(t, orphan.BlogID, stored.BlogID)
+	require.Empty(t, stored.AuthorUsername)
+
+	_, err = pgRepo.GetWithAuthor(ctx, uuid.New())
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func Test_Slugify(t *testing.T) {
+	tests := map[string]string{
+		"Hello, World!":            "hello-world",
+		"  Go 1.24 -- released  ":  "go-1-24-released",
+		"Crème brûlée à la carte":  "creme-brulee-a-la-carte",
+		"Привет, мир":              "привет-мир",
+		"Йога для всех":            "йога-для-всех",
+		"日本語のブログ":                  "日本語のブログ",
+		"!!!":                      "blog",
+		"":                         "blog",
+		strings.Repeat("ab ", 100): strings.TrimSuffix(strings.Repeat("ab-", 27), "-"),
+	}
+	for title, slug := range tests {
+		require.Equal(t, slug, slugify(title), title)
+	}
+}
+
+func Test_NextSlug(t *testing.T) {
+	require.Equal(t, "go", nextSlug("go", map[string]struct{}{}))
+	require.Equal(t, "go-2", nextSlug("go", map[string]struct{}{"go": {}}))
+	require.Equal(t, "go-4", nextSlug("go", map[string]struct{}{"go": {}, "go-2": {}, "go-3": {}, "go-5": {}}))
+}
+
+func Test_Slug_Collision(t *testing.T) {
+	ctx := context.Background()
+	title := "Slug collision " + uuid.NewString()[:8]
+	first := model.Blog{BlogID: uuid.New(), UserID: uuid.New(), Title: title, Content: "content"}
+	second := model.Blog{BlogID: uuid.New(), UserID: uuid.New(), Title: strings.ToUpper(title) + "!", Content: "content"}
+	third := model.Blog{BlogID: uuid.New(), UserID: uuid.New(), Title: title, Content: "content"}
+	require.NoError(t, pgRepo.Create(ctx, &first))
+	require.NoError(t, pgRepo.Create(ctx, &second))
+	require.NoError(t, pgRepo.Create(ctx, &third))
+
+	require.Equal(t, slugify(title), first.Slug)
+	require.Equal(t, first.Slug+"-2", second.Slug)
+	require.Equal(t, first.Slug+"-3", third.Slug)
+
+	// editing the content keeps the slug, a new title frees the old one
+	second.Content = "edited"
+	require.NoError(t, pgRepo.Update(ctx, &second))
+	require.Equal(t, first.Slug+"-2", second.Slug)
+	first.Title = "Renamed " + title
+	require.NoError(t, pgRepo.Update(ctx, &first))
+	require.Equal(t, slugify(first.Title), first.Slug)
+	fourth := model.Blog{BlogID: uuid.New(), UserID: uuid.New(), Title: title, Content: "content"}
+	require.NoError(t, pgRepo.Create(ctx, &fourth))
+	require.Equal(t, slugify(title), fourth.Slug)
+}
+
+func Test_GetBySlug(t *testing.T) {
+	ctx := context.Background()
+	blog := model.Blog{BlogID: uuid.New(), UserID: uuid.New(), Title: "Find me by slug " + uuid.NewString()[:8], Content: "content"}
+	require.NoError(t, pgRepo.Create(ctx, &blog))
+
+	stored, err := pgRepo.GetBySlug(ctx, blog.Slug)
+	require.NoError(t, err)
+	require.Equal(t, blog.BlogID, stored.BlogID)
+	require.Equal(t, blog.Slug, stored.Slug)
+
+	_, err = pgRepo.GetBySlug(ctx, "no-such-slug-"+uuid.NewString())
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
 func Test_DeleteBlog(t *testing.T) {
 	ctx := context.Background()
 	testBlog.BlogID = uuid.New()
@@ -207,6 +510,40 @@ func Test_DeleteBlog(t *testing.T) {
 
 	_, err = pgRepo.Get(ctx, testBlog.BlogID)
 	require.Error(t, err)
+
+	err = pgRepo.Delete(ctx, testBlog.BlogID)
+	require.ErrorIs(t, err, ErrNotFound)
+	err = pgRepo.Delete(ctx, uuid.New())
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func Test_DeleteIfUnmodifiedSince(t *testing.T) {
+	ctx := context.Background()
+	blog := model.Blog{BlogID: uuid.New(), UserID: uuid.New(), Title: "conditional", Content: "conditional content", Status: model.BlogStatusPublished}
+	require.NoError(t, pgRepo.Create(ctx, &blog))
+	created, err := pgRepo.Get(ctx, blog.BlogID)
+	require.NoError(t, err)
+	require.False(t, created.UpdatedAt.IsZero())
+	seen := created.UpdatedAt.Truncate(time.Second)
+
+	time.Sleep(time.Second)
+	blog.Content = "changed content"
+	require.NoError(t, pgRepo.Update(ctx, &blog))
+	updated, err := pgRepo.Get(ctx, blog.BlogID)
+	require.NoError(t, err)
+	require.True(t, updated.UpdatedAt.After(created.UpdatedAt))
+
+	err = pgRepo.DeleteIfUnmodifiedSince(ctx, blog.BlogID, seen)
+	require.ErrorIs(t, err, ErrModified)
+	_, err = pgRepo.Get(ctx, blog.BlogID)
+	require.NoError(t, err)
+
+	require.NoError(t, pgRepo.DeleteIfUnmodifiedSince(ctx, blog.BlogID, updated.UpdatedAt.Truncate(time.Second)))
+	_, err = pgRepo.Get(ctx, blog.BlogID)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	err = pgRepo.DeleteIfUnmodifiedSince(ctx, blog.BlogID, time.Now())
+	require.ErrorIs(t, err, ErrNotFound)
 }
 
 func Test_DeleteBlogsByUserID(t *testing.T) {
@@ -215,19 +552,113 @@ func Test_DeleteBlogsByUserID(t *testing.T) {
 
 	_ = pgRepo.Create(ctx, &testBlog)
 
-	err := pgRepo.DeleteBlogsByUserID(ctx, testBlog.UserID)
+	deleted, err := pgRepo.DeleteBlogsByUserID(ctx, testBlog.UserID)
 	require.NoError(t, err)
+	require.Contains(t, deleted, testBlog.BlogID)
 
 	_, err = pgRepo.Get(ctx, testBlog.BlogID)
 	require.Error(t, err)
 }
 
+func Test_DeleteMany(t *testing.T) {
+	ctx := context.Background()
+	ownerID := uuid.New()
+	own := model.Blog{BlogID: uuid.New(), UserID: ownerID, Title: "bulk own", Content: "bulk content", Status: model.BlogStatusPublished}
+	other := model.Blog{BlogID: uuid.New(), UserID: uuid.New(), Title: "bulk other", Content: "bulk content", Status: model.BlogStatusPublished}
+	require.NoError(t, pgRepo.Create(ctx, &own))
+	require.NoError(t, pgRepo.Create(ctx, &other))
+
+	deleted, err := pgRepo.DeleteMany(ctx, []uuid.UUID{own.BlogID, other.BlogID, uuid.New()}, ownerID)
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{own.BlogID}, deleted)
+	_, err = pgRepo.Get(ctx, own.BlogID)
+	require.Error(t, err)
+	_, err = pgRepo.Get(ctx, other.BlogID)
+	require.NoError(t, err)
+
+	deleted, err = pgRepo.DeleteMany(ctx, []uuid.UUID{own.BlogID, other.BlogID, uuid.New()}, uuid.Nil)
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{other.BlogID}, deleted)
+	_, err = pgRepo.Get(ctx, other.BlogID)
+	require.Error(t, err)
+}
+
+func Test_ExistingIDs(t *testing.T) {
+	ctx := context.Background()
+	authorID := uuid.New()
+	published := model.Blog{BlogID: uuid.New(), UserID: authorID, Title: "exists published", Content: "exists content", Status: model.BlogStatusPublished}
+	draft := model.Blog{BlogID: uuid.New(), UserID: authorID, Title: "exists draft", Content: "exists content", Status: model.BlogStatusDraft}
+	deleted := model.Blog{BlogID: uuid.New(), UserID: authorID, Title: "exists deleted", Content: "exists content", Status: model.BlogStatusPublished}
+	for _, blog := range []*model.Blog{&published, &draft, &deleted} {
+		require.NoError(t, pgRepo.Create(ctx, blog))
+	}
+	require.NoError(t, pgRepo.Delete(ctx, deleted.BlogID))
+	ids := []uuid.UUID{published.BlogID, draft.BlogID, deleted.BlogID, uuid.New()}
+
+	existing, err := pgRepo.ExistingIDs(ctx, ids, uuid.Nil)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []uuid.UUID{published.BlogID, draft.BlogID}, existing)
+
+	existing, err = pgRepo.ExistingIDs(ctx, ids, authorID)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []uuid.UUID{published.BlogID, draft.BlogID}, existing)
+
+	existing, err = pgRepo.ExistingIDs(ctx, ids, uuid.New())
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{published.BlogID}, existing)
+}
+
 func Test_GetByUserID_NoBlogs(t *testing.T) {
-	blogs, err := pgRepo.GetByUserID(context.Background(), uuid.New())
+	blogs, err := pgRepo.GetByUserID(context.Background(), uuid.New(), false, 10, 0)
 	require.NoError(t, err)
 	require.Empty(t, blogs)
 }
 
+func Test_GetByUserID_Pagination(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	for i, status := range []string{model.BlogStatusPublished, model.BlogStatusDraft, model.BlogStatusPublished} {
+		blog := model.Blog{BlogID: uuid.New(), UserID: userID, Title: fmt.Sprintf("paged%d", i), Content: "paged content", Status: status}
+		require.NoError(t, pgRepo.Create(ctx, &blog))
+	}
+
+	count, err := pgRepo.CountByUserID(ctx, userID, false)
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+	count, err = pgRepo.CountByUserID(ctx, userID, true)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	first, err := pgRepo.GetByUserID(ctx, userID, false, 2, 0)
+	require.NoError(t, err)
+	require.Len(t, first, 2)
+	second, err := pgRepo.GetByUserID(ctx, userID, false, 2, 2)
+	require.NoError(t, err)
+	require.Len(t, second, 1)
+	require.NotContains(t, []uuid.UUID{first[0].BlogID, first[1].BlogID}, second[0].BlogID)
+
+	published, err := pgRepo.GetByUserID(ctx, userID, true, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, published, 2)
+	for _, blog := range published {
+		require.Equal(t, model.BlogStatusPublished, blog.Status)
+	}
+}
+
+func Test_IsBlogOwner(t *testing.T) {
+	ctx := context.Background()
+	blog := model.Blog{BlogID: uuid.New(), UserID: uuid.New(), Title: "owned", Content: "owned content", Status: model.BlogStatusDraft}
+	require.NoError(t, pgRepo.Create(ctx, &blog))
+
+	owner, err := pgRepo.IsBlogOwner(ctx, blog.BlogID, blog.UserID)
+	require.NoError(t, err)
+	require.True(t, owner)
+
+	owner, err = pgRepo.IsBlogOwner(ctx, blog.BlogID, uuid.New())
+	require.NoError(t, err)
+	require.False(t, owner)
+}
+
 func Test_SignUp(t *testing.T) {
 	ctx := context.Background()
 	testUser.Username = "testusername"
@@ -267,39 +698,122 @@ func Test_GetDataByUsername_NotFound(t *testing.T) {
 	require.Error(t, err)
 }
 
-func Test_GetRefreshTokenByID(t *testing.T) {
+func Test_GetRefreshTokensByUserID(t *testing.T) {
 	ctx := context.Background()
 	testUser.Username = "testusername3"
 	testUser.ID = uuid.New()
 
 	_ = pgRepo.SignUp(ctx, &testUser)
-	testUser.RefreshToken = "test_refresh_token"
-	_ = pgRepo.AddRefreshToken(ctx, &testUser)
+	token := &model.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    testUser.ID,
+		Token:     "test_refresh_token",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	_ = pgRepo.AddRefreshToken(ctx, token)
 
-	storedToken, err := pgRepo.GetRefreshTokenByID(ctx, testUser.ID)
+	storedTokens, err := pgRepo.GetRefreshTokensByUserID(ctx, testUser.ID)
 	require.NoError(t, err)
-	require.Equal(t, "test_refresh_token", storedToken)
+	require.Len(t, storedTokens, 1)
+	require.Equal(t, "test_refresh_token", storedTokens[0].Token)
 }
 
-func Test_GetRefreshTokenByID_NotFound(t *testing.T) {
-	_, err := pgRepo.GetRefreshTokenByID(context.Background(), uuid.New())
-	require.Error(t, err)
+func Test_GetRefreshTokensByUserID_NotFound(t *testing.T) {
+	storedTokens, err := pgRepo.GetRefreshTokensByUserID(context.Background(), uuid.New())
+	require.NoError(t, err)
+	require.Empty(t, storedTokens)
 }
 
-func Test_AddRefreshToken(t *testing.T) {
+func Test_GetRefreshTokensByUserID_SkipsExpired(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	err := pgRepo.AddRefreshToken(ctx, &model.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Token:     "expired_refresh_token",
+		ExpiresAt: time.Now().Add(-time.Hour),
+	})
+	require.NoError(t, err)
+
+	storedTokens, err := pgRepo.GetRefreshTokensByUserID(ctx, userID)
+	require.NoError(t, err)
+	require.Empty(t, storedTokens)
+}
+
+func Test_GetRefreshToken(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	token := &model.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Token:     "device_refresh_token",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	require.NoError(t, pgRepo.AddRefreshToken(ctx, token))
+	expired := &model.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Token:     "expired_device_refresh_token",
+		ExpiresAt: time.Now().Add(-time.Hour),
+	}
+	require.NoError(t, pgRepo.AddRefreshToken(ctx, expired))
+
+	stored, err := pgRepo.GetRefreshToken(ctx, token.ID)
+	require.NoError(t, err)
+	require.Equal(t, userID, stored.UserID)
+	require.Equal(t, "device_refresh_token", stored.Token)
+
+	_, err = pgRepo.GetRefreshToken(ctx, expired.ID)
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = pgRepo.GetRefreshToken(ctx, uuid.New())
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func Test_AddRefreshToken_MultipleDevices(t *testing.T) {
 	ctx := context.Background()
 	testUser.Username = "testusername4"
+	testUser.ID = uuid.New()
 
 	_ = pgRepo.SignUp(ctx, &testUser)
 
-	newToken := "new_refresh_token"
-	testUser.RefreshToken = newToken
-	err := pgRepo.AddRefreshToken(ctx, &testUser)
+	firstDevice := &model.RefreshToken{ID: uuid.New(), UserID: testUser.ID, Token: "first_device_token", ExpiresAt: time.Now().Add(time.Hour)}
+	secondDevice := &model.RefreshToken{ID: uuid.New(), UserID: testUser.ID, Token: "second_device_token", ExpiresAt: time.Now().Add(time.Hour)}
+	require.NoError(t, pgRepo.AddRefreshToken(ctx, firstDevice))
+	require.NoError(t, pgRepo.AddRefreshToken(ctx, secondDevice))
+
+	storedTokens, err := pgRepo.GetRefreshTokensByUserID(ctx, testUser.ID)
+	require.NoError(t, err)
+	require.Len(t, storedTokens, 2)
+
+	secondDevice.Token = "second_device_rotated"
+	require.NoError(t, pgRepo.RotateRefreshToken(ctx, secondDevice))
+
+	storedTokens, err = pgRepo.GetRefreshTokensByUserID(ctx, testUser.ID)
 	require.NoError(t, err)
+	stored := make(map[uuid.UUID]string)
+	for _, token := range storedTokens {
+		stored[token.ID] = token.Token
+	}
+	require.Equal(t, "first_device_token", stored[firstDevice.ID])
+	require.Equal(t, "second_device_rotated", stored[secondDevice.ID])
+
+	require.NoError(t, pgRepo.DeleteRefreshToken(ctx, firstDevice.ID))
+	storedTokens, err = pgRepo.GetRefreshTokensByUserID(ctx, testUser.ID)
+	require.NoError(t, err)
+	require.Len(t, storedTokens, 1)
+	require.Equal(t, secondDevice.ID, storedTokens[0].ID)
 
-	storedToken, err := pgRepo.GetRefreshTokenByID(ctx, testUser.ID)
+	require.NoError(t, pgRepo.AddRefreshToken(ctx, firstDevice))
+	require.NoError(t, pgRepo.DeleteRefreshTokensByUserID(ctx, testUser.ID))
+	storedTokens, err = pgRepo.GetRefreshTokensByUserID(ctx, testUser.ID)
 	require.NoError(t, err)
-	require.Equal(t, newToken, storedToken)
+	require.Empty(t, storedTokens)
+}
+
+func Test_RotateRefreshToken_NotFound(t *testing.T) {
+	err := pgRepo.RotateRefreshToken(context.Background(), &model.RefreshToken{ID: uuid.New(), ExpiresAt: time.Now()})
+	require.Error(t, err)
 }
 
 func Test_DeleteUserByID(t *testing.T) {
@@ -328,7 +842,7 @@ func Test_DeleteUserByID_AdminUser(t *testing.T) {
 	require.NoError(t, err)
 
 	err = pgRepo.DeleteUserByID(ctx, testUser.ID)
-	require.Error(t, err)
+	require.ErrorIs(t, err, ErrAdminUser)
 
 	id, _, _, err := pgRepo.GetDataByUsername(ctx, testUser.Username)
 	require.NoError(t, err)
@@ -337,5 +851,876 @@ func Test_DeleteUserByID_AdminUser(t *testing.T) {
 
 func Test_DeleteUserByID_UserNotFound(t *testing.T) {
 	err := pgRepo.DeleteUserByID(context.Background(), uuid.New())
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func Test_DeleteUserWithBlogs(t *testing.T) {
+	ctx := context.Background()
+
+	admin := model.User{ID: uuid.New(), Username: "txadmin", Password: []byte("password"), Admin: true}
+	require.NoError(t, pgRepo.SignUp(ctx, &admin))
+	adminBlog := model.Blog{BlogID: uuid.New(), UserID: admin.ID, Title: "Admin blog", Content: "Admin content", Status: model.BlogStatusPublished}
+	require.NoError(t, pgRepo.Create(ctx, &adminBlog))
+	require.ErrorIs(t, pgRepo.DeleteUserWithBlogs(ctx, admin.ID), ErrAdminUser)
+	_, err := pgRepo.Get(ctx, adminBlog.BlogID)
+	require.NoError(t, err, "the blogs of the admin must survive the rolled back transaction")
+	_, err = pgRepo.GetUserByID(ctx, admin.ID)
+	require.NoError(t, err)
+
+	user := model.User{ID: uuid.New(), Username: "txuser", Password: []byte("password")}
+	require.NoError(t, pgRepo.SignUp(ctx, &user))
+	userBlog := model.Blog{BlogID: uuid.New(), UserID: user.ID, Title: "User blog", Content: "User content", Status: model.BlogStatusPublished}
+	require.NoError(t, pgRepo.Create(ctx, &userBlog))
+	require.NoError(t, pgRepo.DeleteUserWithBlogs(ctx, user.ID))
+	_, err = pgRepo.Get(ctx, userBlog.BlogID)
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = pgRepo.GetUserByID(ctx, user.ID)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func Test_Tags(t *testing.T) {
+	ctx := context.Background()
+	blog := model.Blog{
+		BlogID:  uuid.New(),
+		UserID:  uuid.New(),
+		Title:   "Tagged Blog",
+		Content: "Content of tagged blog",
+	}
+	require.NoError(t, pgRepo.Create(ctx, &blog))
+
+	require.NoError(t, pgRepo.AddTags(ctx, blog.BlogID, []string{"go", "postgres"}))
+	require.NoError(t, pgRepo.AddTags(ctx, blog.BlogID, []string{"go"}))
+
+	tags, err := pgRepo.GetTags(ctx, blog.BlogID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"go", "postgres"}, tags)
+
+	require.NoError(t, pgRepo.ReplaceTags(ctx, blog.BlogID, []string{"echo"}))
+	tags, err = pgRepo.GetTags(ctx, blog.BlogID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"echo"}, tags)
+}
+
+func Test_GetByTag(t *testing.T) {
+	ctx := context.Background()
+	tag := "tag-" + uuid.NewString()[:8]
+	for i := 0; i < 3; i++ {
+		blog := model.Blog{
+			BlogID:  uuid.New(),
+			UserID:  uuid.New(),
+			Title:   fmt.Sprintf("Blog %d", i),
+			Content: "Content",
+		}
+		require.NoError(t, pgRepo.Create(ctx, &blog))
+		require.NoError(t, pgRepo.AddTags(ctx, blog.BlogID, []string{tag}))
+		require.NoError(t, pgRepo.Publish(ctx, blog.BlogID))
+	}
+
+	count, err := pgRepo.CountByTag(ctx, tag)
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+
+	blogs, err := pgRepo.GetByTag(ctx, tag, 2, 0)
+	require.NoError(t, err)
+	require.Len(t, blogs, 2)
+
+	blogs, err = pgRepo.GetByTag(ctx, tag, 2, 2)
+	require.NoError(t, err)
+	require.Len(t, blogs, 1)
+}
+
+func Test_GetTopTagsByUserID(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	suffix := "-" + uuid.NewString()[:8]
+	golang, sql, docker, draft := "go"+suffix, "sql"+suffix, "docker"+suffix, "draft"+suffix
+	create := func(userID uuid.UUID, publish bool, tags ...string) uuid.UUID {
+		blog := model.Blog{BlogID: uuid.New(), UserID: userID, Title: "Tagged", Content: "Tagged content"}
+		require.NoError(t, pgRepo.Create(ctx, &blog))
+		require.NoError(t, pgRepo.AddTags(ctx, blog.BlogID, tags))
+		if publish {
+			require.NoError(t, pgRepo.Publish(ctx, blog.BlogID))
+		}
+		return blog.BlogID
+	}
+	create(userID, true, golang, sql)
+	create(userID, true, golang, docker)
+	create(userID, true, golang, sql, docker)
+	create(userID, true, sql)
+	create(userID, false, draft, docker)
+	require.NoError(t, pgRepo.Delete(ctx, create(userID, true, docker)))
+	create(uuid.New(), true, docker, docker+"-other")
+
+	tags, err := pgRepo.GetTopTagsByUserID(ctx, userID, 10)
+	require.NoError(t, err)
+	require.Equal(t, []*model.TagCount{{Tag: golang, Count: 3}, {Tag: sql, Count: 3}, {Tag: docker, Count: 2}}, tags)
+
+	tags, err = pgRepo.GetTopTagsByUserID(ctx, userID, 1)
+	require.NoError(t, err)
+	require.Equal(t, []*model.TagCount{{Tag: golang, Count: 3}}, tags)
+
+	tags, err = pgRepo.GetTopTagsByUserID(ctx, uuid.New(), 10)
+	require.NoError(t, err)
+	require.Empty(t, tags)
+}
+
+func Test_StreamByUserID(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	draft := model.Blog{BlogID: uuid.New(), UserID: userID, Title: "Export draft", Content: "Draft content"}
+	published := model.Blog{BlogID: uuid.New(), UserID: userID, Title: "Export published", Content: "Published content"}
+	deleted := model.Blog{BlogID: uuid.New(), UserID: userID, Title: "Export deleted", Content: "Deleted content"}
+	for _, blog := range []*model.Blog{&draft, &published, &deleted} {
+		require.NoError(t, pgRepo.Create(ctx, blog))
+	}
+	require.NoError(t, pgRepo.AddTags(ctx, published.BlogID, []string{"zeta", "alpha"}))
+	require.NoError(t, pgRepo.Publish(ctx, published.BlogID))
+	require.NoError(t, pgRepo.Delete(ctx, deleted.BlogID))
+	comment := model.Comment{CommentID: uuid.New(), BlogID: published.BlogID, UserID: userID, Body: "Exported comment"}
+	require.NoError(t, pgRepo.CreateComment(ctx, &comment))
+
+	streamed := make(map[uuid.UUID]*model.Blog)
+	require.NoError(t, pgRepo.StreamByUserID(ctx, userID, func(blog *model.Blog) error {
+		streamed[blog.BlogID] = blog
+		return nil
+	}))
+	require.Len(t, streamed, 2)
+	require.Equal(t, model.BlogStatusDraft, streamed[draft.BlogID].Status)
+	require.Empty(t, streamed[draft.BlogID].Tags)
+	require.Equal(t, []string{"alpha", "zeta"}, streamed[published.BlogID].Tags)
+	require.Equal(t, "Published content", streamed[published.BlogID].Content)
+
+	var comments []*model.Comment
+	require.NoError(t, pgRepo.StreamCommentsByUserID(ctx, userID, func(comment *model.Comment) error {
+		comments = append(comments, comment)
+		return nil
+	}))
+	require.Len(t, comments, 1)
+	require.Equal(t, "Exported comment", comments[0].Body)
+}
+
+func Test_Comments(t *testing.T) {
+	ctx := context.Background()
+	blog := model.Blog{
+		BlogID:  uuid.New(),
+		UserID:  uuid.New(),
+		Title:   "Commented Blog",
+		Content: "Content of commented blog",
+	}
+	require.NoError(t, pgRepo.Create(ctx, &blog))
+
+	first := model.Comment{CommentID: uuid.New(), BlogID: blog.BlogID, UserID: uuid.New(), Body: "first"}
+	second := model.Comment{CommentID: uuid.New(), BlogID: blog.BlogID, UserID: uuid.New(), Body: "second"}
+	require.NoError(t, pgRepo.CreateComment(ctx, &first))
+	require.NoError(t, pgRepo.CreateComment(ctx, &second))
+
+	count, err := pgRepo.CountCommentsByBlogID(ctx, blog.BlogID)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	comments, err := pgRepo.GetCommentsByBlogID(ctx, blog.BlogID, 1, 0)
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	require.Equal(t, "first", comments[0].Body)
+
+	fetched, err := pgRepo.GetComment(ctx, second.CommentID)
+	require.NoError(t, err)
+	require.Equal(t, second.UserID, fetched.UserID)
+
+	require.NoError(t, pgRepo.DeleteComment(ctx, first.CommentID))
+	_, err = pgRepo.GetComment(ctx, first.CommentID)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func Test_CreateComment_BlogNotFound(t *testing.T) {
+	comment := model.Comment{CommentID: uuid.New(), BlogID: uuid.New(), UserID: uuid.New(), Body: "orphan"}
+	err := pgRepo.CreateComment(context.Background(), &comment)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func Test_DeleteComment_NotFound(t *testing.T) {
+	err := pgRepo.DeleteComment(context.Background(), uuid.New())
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func Test_DraftHiddenFromGetAll(t *testing.T) {
+	ctx := context.Background()
+	draft := model.Blog{
+		BlogID:  uuid.New(),
+		UserID:  uuid.New(),
+		Title:   "Draft Blog",
+		Content: "Content of draft blog",
+		Status:  model.BlogStatusDraft,
+	}
+	require.NoError(t, pgRepo.Create(ctx, &draft))
+
+	countBefore, err := pgRepo.Count(ctx, model.BlogFilter{})
+	require.NoError(t, err)
+	blogs, err := pgRepo.GetAll(ctx, model.BlogFilter{}, countBefore+1, 0)
+	require.NoError(t, err)
+	for _, blog := range blogs {
+		require.NotEqual(t, draft.BlogID, blog.BlogID)
+	}
+
+	ownBlogs, err := pgRepo.GetByUserID(ctx, draft.UserID, false, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, ownBlogs, 1)
+	require.Equal(t, model.BlogStatusDraft, ownBlogs[0].Status)
+
+	require.NoError(t, pgRepo.Publish(ctx, draft.BlogID))
+	countAfter, err := pgRepo.Count(ctx, model.BlogFilter{})
+	require.NoError(t, err)
+	require.Equal(t, countBefore+1, countAfter)
+
+	published, err := pgRepo.Get(ctx, draft.BlogID)
+	require.NoError(t, err)
+	require.Equal(t, model.BlogStatusPublished, published.Status)
+	require.False(t, published.ReleaseTime.IsZero())
+}
+
+func Test_Publish_NotFound(t *testing.T) {
+	err := pgRepo.Publish(context.Background(), uuid.New())
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func Test_Publish_AlreadyPublished(t *testing.T) {
+	ctx := context.Background()
+	blog := model.Blog{BlogID: uuid.New(), UserID: uuid.New(), Title: "Published once", Content: "Content", Status: model.BlogStatusDraft}
+	require.NoError(t, pgRepo.Create(ctx, &blog))
+	require.NoError(t, pgRepo.Publish(ctx, blog.BlogID))
+	published, err := pgRepo.Get(ctx, blog.BlogID)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, pgRepo.Publish(ctx, blog.BlogID), ErrNotFound)
+	again, err := pgRepo.Get(ctx, blog.BlogID)
+	require.NoError(t, err)
+	require.Equal(t, published.ReleaseTime, again.ReleaseTime)
+}
+
+func Test_IncrementViews(t *testing.T) {
+	ctx := context.Background()
+	blog := model.Blog{
+		BlogID:  uuid.New(),
+		UserID:  uuid.New(),
+		Title:   "Viewed Blog",
+		Content: "Content of viewed blog",
+		Status:  model.BlogStatusPublished,
+	}
+	require.NoError(t, pgRepo.Create(ctx, &blog))
+
+	for i := 1; i <= 3; i++ {
+		views, err := pgRepo.IncrementViews(ctx, blog.BlogID)
+		require.NoError(t, err)
+		require.Equal(t, i, views)
+	}
+
+	stored, err := pgRepo.Get(ctx, blog.BlogID)
+	require.NoError(t, err)
+	require.Equal(t, 3, stored.Views)
+
+	_, err = pgRepo.IncrementViews(ctx, uuid.New())
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func Test_GetPopular(t *testing.T) {
+	ctx := context.Background()
+	popular := model.Blog{BlogID: uuid.New(), UserID: uuid.New(), Title: "Popular", Content: "Popular content", Status: model.BlogStatusPublished}
+	require.NoError(t, pgRepo.Create(ctx, &popular))
+	for i := 0; i < 1000; i++ {
+		_, err := pgRepo.IncrementViews(ctx, popular.BlogID)
+		require.NoError(t, err)
+	}
+
+	blogs, err := pgRepo.GetPopular(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, blogs, 1)
+	require.Equal(t, popular.BlogID, blogs[0].BlogID)
+	require.Equal(t, 1000, blogs[0].Views)
+}
+
+func Test_RecentViews(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	keep := 3
+	blogIDs := make([]uuid.UUID, 0, keep+1)
+	for i := 0; i <= keep; i++ {
+		blog := model.Blog{BlogID: uuid.New(), UserID: uuid.New(), Title: "Recent", Content: "Recent content", Status: model.BlogStatusPublished}
+		require.NoError(t, pgRepo.Create(ctx, &blog))
+		require.NoError(t, pgRepo.AddRecentView(ctx, userID, blog.BlogID, keep))
+		blogIDs = append(blogIDs, blog.BlogID)
+	}
+
+	blogs, err := pgRepo.GetRecentViews(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, blogs, keep)
+	require.Equal(t, blogIDs[keep], blogs[0].BlogID)
+	for _, blog := range blogs {
+		require.NotEqual(t, blogIDs[0], blog.BlogID)
+	}
+
+	require.NoError(t, pgRepo.AddRecentView(ctx, userID, blogIDs[1], keep))
+	blogs, err = pgRepo.GetRecentViews(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, blogs, keep)
+	require.Equal(t, blogIDs[1], blogs[0].BlogID)
+}
+
+func Test_GetSiblings(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	blogs := make([]*model.Blog, 3)
+	for i := range blogs {
+		blog := &model.Blog{BlogID: uuid.New(), UserID: userID, Title: "Archive", Content: "Archive content", Status: model.BlogStatusDraft}
+		require.NoError(t, pgRepo.Create(ctx, blog))
+		require.NoError(t, pgRepo.Publish(ctx, blog.BlogID))
+		stored, err := pgRepo.Get(ctx, blog.BlogID)
+		require.NoError(t, err)
+		blogs[i] = stored
+	}
+
+	middle, err := pgRepo.GetSiblings(ctx, blogs[1], true)
+	require.NoError(t, err)
+	require.Equal(t, blogs[0].BlogID, middle.Previous.BlogID)
+	require.Equal(t, blogs[2].BlogID, middle.Next.BlogID)
+
+	first, err := pgRepo.GetSiblings(ctx, blogs[0], true)
+	require.NoError(t, err)
+	require.Nil(t, first.Previous)
+	require.Equal(t, blogs[1].BlogID, first.Next.BlogID)
+
+	last, err := pgRepo.GetSiblings(ctx, blogs[2], true)
+	require.NoError(t, err)
+	require.Equal(t, blogs[1].BlogID, last.Previous.BlogID)
+	require.Nil(t, last.Next)
+}
+
+func Test_GetByTag_StablePagination(t *testing.T) {
+	ctx := context.Background()
+	tag := "stable-" + uuid.NewString()[:8]
+	releaseTime := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	total := 7
+	for i := 0; i < total; i++ {
+		blog := model.Blog{BlogID: uuid.New(), UserID: uuid.New(), Title: "Same time", Content: "Same time content", Status: model.BlogStatusPublished}
+		require.NoError(t, pgRepo.Create(ctx, &blog))
+		require.NoError(t, pgRepo.AddTags(ctx, blog.BlogID, []string{tag}))
+		_, err := pgRepo.pool.Exec(ctx, "UPDATE blog SET releasetime = $1 WHERE blogid = $2", releaseTime, blog.BlogID)
+		require.NoError(t, err)
+	}
+
+	seen := make(map[uuid.UUID]struct{}, total)
+	for offset := 0; offset < total; offset += 2 {
+		page, err := pgRepo.GetByTag(ctx, tag, 2, offset)
+		require.NoError(t, err)
+		for _, blog := range page {
+			_, duplicate := seen[blog.BlogID]
+			require.False(t, duplicate)
+			seen[blog.BlogID] = struct{}{}
+		}
+	}
+	require.Len(t, seen, total)
+}
+
+func Test_SetDefaultSort(t *testing.T) {
+	repo := NewPgRepository(nil)
+	require.NoError(t, repo.SetDefaultSort(""))
+	require.Equal(t, blogSortOrders[SortNewest], repo.blogOrder)
+	require.NoError(t, repo.SetDefaultSort(SortOldest))
+	require.Equal(t, blogSortOrders[SortOldest], repo.blogOrder)
+	require.Error(t, repo.SetDefaultSort("random"))
+}
+
+func Test_BlogListOrder(t *testing.T) {
+	repo := NewPgRepository(nil)
+	require.NoError(t, repo.SetDefaultSort(SortOldest))
+	require.Equal(t, blogSortOrders[SortOldest], repo.blogListOrder(nil))
+	require.Equal(t, "title ASC, blogid ASC", repo.blogListOrder(&model.BlogSort{Field: model.SortFieldTitle}))
+	require.Equal(t, "releasetime DESC, blogid DESC", repo.blogListOrder(&model.BlogSort{Field: model.SortFieldReleaseTime, Desc: true}))
+	require.Equal(t, blogSortOrders[SortOldest], repo.blogListOrder(&model.BlogSort{Field: "title; DROP TABLE blog"}))
+}
+
+func Test_RecordFailedLogin(t *testing.T) {
+	ctx := context.Background()
+	user := model.User{ID: uuid.New(), Username: "lockeduser", Password: []byte("password")}
+	require.NoError(t, pgRepo.SignUp(ctx, &user))
+
+	for i := 1; i < 3; i++ {
+		lockedUntil, err := pgRepo.RecordFailedLogin(ctx, user.Username, 3, time.Hour)
+		require.NoError(t, err)
+		require.True(t, lockedUntil.IsZero())
+	}
+	lockedUntil, err := pgRepo.RecordFailedLogin(ctx, user.Username, 3, time.Hour)
+	require.NoError(t, err)
+	require.True(t, lockedUntil.After(time.Now()))
+
+	stored, err := pgRepo.GetLockedUntil(ctx, user.Username)
+	require.NoError(t, err)
+	require.Equal(t, lockedUntil, stored)
+
+	require.NoError(t, pgRepo.ResetFailedLogins(ctx, user.ID))
+	stored, err = pgRepo.GetLockedUntil(ctx, user.Username)
+	require.NoError(t, err)
+	require.True(t, stored.IsZero())
+}
+
+func Test_SignUp_DuplicateEmail(t *testing.T) {
+	ctx := context.Background()
+	first := model.User{ID: uuid.New(), Username: "emailuser1", Email: "same@example.com", Password: []byte("password")}
+	require.NoError(t, pgRepo.SignUp(ctx, &first))
+
+	second := model.User{ID: uuid.New(), Username: "emailuser2", Email: "same@example.com", Password: []byte("password")}
+	require.ErrorIs(t, pgRepo.SignUp(ctx, &second), ErrExist)
+}
+
+func Test_SignUp_CaseDuplicateUsername(t *testing.T) {
+	ctx := context.Background()
+	first := model.User{ID: uuid.New(), Username: "alice", Password: []byte("password")}
+	require.NoError(t, pgRepo.SignUp(ctx, &first))
+
+	second := model.User{ID: uuid.New(), Username: "Alice", Password: []byte("password")}
+	require.ErrorIs(t, pgRepo.SignUp(ctx, &second), ErrExist)
+}
+
+func Test_GetDataByUsername_IgnoresCase(t *testing.T) {
+	ctx := context.Background()
+	user := model.User{ID: uuid.New(), Username: "casefolded", Password: []byte("password")}
+	require.NoError(t, pgRepo.SignUp(ctx, &user))
+
+	id, password, _, err := pgRepo.GetDataByUsername(ctx, "CaseFolded")
+	require.NoError(t, err)
+	require.Equal(t, user.ID, id)
+	require.Equal(t, user.Password, password)
+
+	lockedUntil, err := pgRepo.GetLockedUntil(ctx, "CASEFOLDED")
+	require.NoError(t, err)
+	require.True(t, lockedUntil.IsZero())
+}
+
+func Test_GetDataByEmail(t *testing.T) {
+	ctx := context.Background()
+	user := model.User{ID: uuid.New(), Username: "emaillookup", Email: "lookup@example.com", Password: []byte("password"), Admin: true}
+	require.NoError(t, pgRepo.SignUp(ctx, &user))
+
+	found, err := pgRepo.GetDataByEmail(ctx, user.Email)
+	require.NoError(t, err)
+	require.Equal(t, user.ID, found.ID)
+	require.Equal(t, user.Username, found.Username)
+	require.Equal(t, user.Password, found.Password)
+	require.True(t, found.Admin)
+
+	_, err = pgRepo.GetDataByEmail(ctx, "missing@example.com")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func Test_Moderation(t *testing.T) {
+	ctx := context.Background()
+	blog := model.Blog{BlogID: uuid.New(), UserID: uuid.New(), Title: "Moderated", Content: "Moderated content", Status: model.BlogStatusDraft}
+	require.NoError(t, pgRepo.Create(ctx, &blog))
+
+	require.ErrorIs(t, pgRepo.Approve(ctx, blog.BlogID), ErrNotFound)
+
+	require.NoError(t, pgRepo.SubmitForReview(ctx, blog.BlogID))
+	pending, err := pgRepo.GetPendingReview(ctx, 1000, 0)
+	require.NoError(t, err)
+	found := false
+	for _, p := range pending {
+		found = found || p.BlogID == blog.BlogID
+	}
+	require.True(t, found)
+
+	require.NoError(t, pgRepo.Reject(ctx, blog.BlogID, "off-topic"))
+	rejected, err := pgRepo.Get(ctx, blog.BlogID)
+	require.NoError(t, err)
+	require.Equal(t, model.BlogStatusRejected, rejected.Status)
+	require.Equal(t, "off-topic", rejected.ModerationReason)
+
+	require.NoError(t, pgRepo.SubmitForReview(ctx, blog.BlogID))
+	require.NoError(t, pgRepo.Approve(ctx, blog.BlogID))
+	approved, err := pgRepo.Get(ctx, blog.BlogID)
+	require.NoError(t, err)
+	require.Equal(t, model.BlogStatusPublished, approved.Status)
+	require.Empty(t, approved.ModerationReason)
+}
+
+func Test_PasswordReset(t *testing.T) {
+	ctx := context.Background()
+	user := model.User{ID: uuid.New(), Username: "resetuser", Email: "reset@example.com", Password: []byte("password")}
+	require.NoError(t, pgRepo.SignUp(ctx, &user))
+
+	reset := model.PasswordReset{ID: uuid.New(), UserID: user.ID, Token: "hashedtoken", ExpiresAt: time.Now().Add(time.Hour)}
+	require.NoError(t, pgRepo.AddPasswordReset(ctx, &reset))
+
+	stored, err := pgRepo.GetPasswordReset(ctx, reset.ID)
+	require.NoError(t, err)
+	require.Equal(t, reset.Token, stored.Token)
+	require.Nil(t, stored.UsedAt)
+
+	require.NoError(t, pgRepo.UsePasswordReset(ctx, reset.ID))
+	require.ErrorIs(t, pgRepo.UsePasswordReset(ctx, reset.ID), ErrNotFound)
+
+	stored, err = pgRepo.GetPasswordReset(ctx, reset.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored.UsedAt)
+}
+
+func Test_AddAuditEntry(t *testing.T) {
+	ctx := context.Background()
+	entry := model.AuditEntry{ID: uuid.New(), Action: model.AuditActionImpersonate, ActorID: uuid.New(), TargetID: uuid.New()}
+	require.NoError(t, pgRepo.AddAuditEntry(ctx, &entry))
+	require.ErrorIs(t, pgRepo.AddAuditEntry(ctx, nil), ErrNil)
+
+	var stored model.AuditEntry
+	err := pgRepo.pool.QueryRow(ctx, "SELECT action, actorid, targetid, createdat FROM audit_log WHERE id = $1", entry.ID).
+		Scan(&stored.Action, &stored.ActorID, &stored.TargetID, &stored.CreatedAt)
+	require.NoError(t, err)
+	require.Equal(t, entry.Action, stored.Action)
+	require.Equal(t, entry.ActorID, stored.ActorID)
+	require.Equal(t, entry.TargetID, stored.TargetID)
+	require.False(t, stored.CreatedAt.IsZero())
+}
+
+func Test_Activity(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	blogID := uuid.New()
+	for _, action := range []string{model.ActivityBlogCreated, model.ActivityBlogUpdated, model.ActivityBlogDeleted} {
+		require.NoError(t, pgRepo.AddActivity(ctx, &model.Activity{ID: uuid.New(), UserID: userID, Action: action, TargetID: blogID}))
+	}
+	require.NoError(t, pgRepo.AddActivity(ctx, &model.Activity{ID: uuid.New(), UserID: uuid.New(), Action: model.ActivityBlogCreated, TargetID: uuid.New()}))
+	require.ErrorIs(t, pgRepo.AddActivity(ctx, nil), ErrNil)
+
+	count, err := pgRepo.CountActivity(ctx, userID)
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+
+	activities, err := pgRepo.GetActivity(ctx, userID, 2, 0)
+	require.NoError(t, err)
+	require.Len(t, activities, 2)
+	require.Equal(t, model.ActivityBlogDeleted, activities[0].Action)
+	require.Equal(t, model.ActivityBlogUpdated, activities[1].Action)
+	require.Equal(t, blogID, activities[0].TargetID)
+	require.True(t, activities[0].CreatedAt.After(activities[1].CreatedAt))
+
+	activities, err = pgRepo.GetActivity(ctx, userID, 2, 2)
+	require.NoError(t, err)
+	require.Len(t, activities, 1)
+	require.Equal(t, model.ActivityBlogCreated, activities[0].Action)
+}
+
+func Test_Invite(t *testing.T) {
+	ctx := context.Background()
+	invite := model.Invite{ID: uuid.New(), Code: "hashedinvite", CreatedBy: uuid.New()}
+	require.NoError(t, pgRepo.AddInvite(ctx, &invite))
+
+	first := model.User{ID: uuid.New(), Username: "inviteduser", Password: []byte("password")}
+	err := pgRepo.InTx(ctx, func(ctx context.Context) error {
+		if err := pgRepo.SignUp(ctx, &first); err != nil {
+			return err
+		}
+		return pgRepo.UseInvite(ctx, invite.Code, first.ID)
+	})
+	require.NoError(t, err)
+
+	second := model.User{ID: uuid.New(), Username: "secondinvited", Password: []byte("password")}
+	err = pgRepo.InTx(ctx, func(ctx context.Context) error {
+		if err := pgRepo.SignUp(ctx, &second); err != nil {
+			return err
+		}
+		return pgRepo.UseInvite(ctx, invite.Code, second.ID)
+	})
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = pgRepo.GetUserByID(ctx, second.ID)
+	require.ErrorIs(t, err, ErrNotFound, "the signup with a used invite must be rolled back")
+}
+
+func Test_CreateWithTagsInTx(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	failed := model.Blog{BlogID: uuid.New(), UserID: userID, Title: "Tagged in tx", Content: "content", Status: model.BlogStatusDraft}
+	errTags := errors.New("tags failed")
+	err := pgRepo.InTx(ctx, func(ctx context.Context) error {
+		if err := pgRepo.Create(ctx, &failed); err != nil {
+			return err
+		}
+		return errTags
+	})
+	require.ErrorIs(t, err, errTags)
+	_, err = pgRepo.Get(ctx, failed.BlogID)
+	require.ErrorIs(t, err, ErrNotFound, "the blog must be rolled back with its tags")
+
+	// a slug taken earlier in the same transaction is skipped rather than aborting it
+	first := model.Blog{BlogID: uuid.New(), UserID: userID, Title: "Tagged in tx", Content: "content", Status: model.BlogStatusDraft}
+	second := model.Blog{BlogID: uuid.New(), UserID: userID, Title: "Tagged in tx", Content: "content", Status: model.BlogStatusDraft}
+	err = pgRepo.InTx(ctx, func(ctx context.Context) error {
+		for _, blog := range []*model.Blog{&first, &second} {
+			if err := pgRepo.Create(ctx, blog); err != nil {
+				return err
+			}
+			if err := pgRepo.AddTags(ctx, blog.BlogID, []string{"go"}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, first.Slug, second.Slug)
+	tags, err := pgRepo.GetTags(ctx, second.BlogID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"go"}, tags)
+}
+
+func Test_UpdateAndSubmitForReviewInTx(t *testing.T) {
+	ctx := context.Background()
+	blog := model.Blog{BlogID: uuid.New(), UserID: uuid.New(), Title: "Moderated", Content: "Reviewed content", Status: model.BlogStatusDraft}
+	require.NoError(t, pgRepo.Create(ctx, &blog))
+	require.NoError(t, pgRepo.Publish(ctx, blog.BlogID))
+
+	errReview := errors.New("review failed")
+	edited := blog
+	edited.Content = "Unreviewed content"
+	err := pgRepo.InTx(ctx, func(ctx context.Context) error {
+		if err := pgRepo.Update(ctx, &edited); err != nil {
+			return err
+		}
+		if err := pgRepo.ReplaceTags(ctx, blog.BlogID, []string{"edited"}); err != nil {
+			return err
+		}
+		return errReview
+	})
+	require.ErrorIs(t, err, errReview)
+	stored, err := pgRepo.Get(ctx, blog.BlogID)
+	require.NoError(t, err)
+	require.Equal(t, "Reviewed content", stored.Content, "the unreviewed edit must be rolled back")
+	require.Equal(t, model.BlogStatusPublished, stored.Status)
+
+	err = pgRepo.InTx(ctx, func(ctx context.Context) error {
+		if err := pgRepo.Update(ctx, &edited); err != nil {
+			return err
+		}
+		return pgRepo.SubmitForReview(ctx, blog.BlogID)
+	})
+	require.NoError(t, err)
+	stored, err = pgRepo.Get(ctx, blog.BlogID)
+	require.NoError(t, err)
+	require.Equal(t, "Unreviewed content", stored.Content)
+	require.Equal(t, model.BlogStatusPendingReview, stored.Status)
+}
+
+func Test_SoftDeleteAndRestore(t *testing.T) {
+	ctx := context.Background()
+	blog := model.Blog{BlogID: uuid.New(), UserID: uuid.New(), Title: "Deleted", Content: "Deleted content", Status: model.BlogStatusPublished}
+	require.NoError(t, pgRepo.Create(ctx, &blog))
+
+	require.NoError(t, pgRepo.Delete(ctx, blog.BlogID))
+	_, err := pgRepo.Get(ctx, blog.BlogID)
+	require.Error(t, err)
+	blogs, err := pgRepo.GetByUserID(ctx, blog.UserID, false, 10, 0)
+	require.NoError(t, err)
+	require.Empty(t, blogs)
+
+	require.NoError(t, pgRepo.Restore(ctx, blog.BlogID))
+	restored, err := pgRepo.Get(ctx, blog.BlogID)
+	require.NoError(t, err)
+	require.Equal(t, blog.Title, restored.Title)
+	require.ErrorIs(t, pgRepo.Restore(ctx, blog.BlogID), ErrNotFound)
+
+	require.NoError(t, pgRepo.HardDelete(ctx, blog.BlogID))
+	require.ErrorIs(t, pgRepo.Restore(ctx, blog.BlogID), ErrNotFound)
+	require.ErrorIs(t, pgRepo.HardDelete(ctx, blog.BlogID), ErrNotFound)
+}
+
+func Test_GetProfiles(t *testing.T) {
+	ctx := context.Background()
+	first := model.User{ID: uuid.New(), Username: "profileone", Email: "profileone@example.com", Password: []byte("password")}
+	second := model.User{ID: uuid.New(), Username: "profiletwo", Email: "profiletwo@example.com", Password: []byte("password")}
+	require.NoError(t, pgRepo.SignUp(ctx, &first))
+	require.NoError(t, pgRepo.SignUp(ctx, &second))
+
+	profiles, err := pgRepo.GetProfiles(ctx, []uuid.UUID{first.ID, second.ID, uuid.New()})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []*model.UserProfile{
+		{ID: first.ID, Username: first.Username},
+		{ID: second.ID, Username: second.Username},
+	}, profiles)
+}
+
+func Test_Migrate_Twice(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, Migrate(ctx, pgRepo.pool))
+	require.NoError(t, Migrate(ctx, pgRepo.pool))
+
+	for _, table := range []string{"blog", "users", "refresh_tokens", "blog_tags", "comments", "password_resets", "audit_log", "invites", "user_activity"} {
+		var exists bool
+		require.NoError(t, pgRepo.pool.QueryRow(ctx, "SELECT to_regclass($1) IS NOT NULL", table).Scan(&exists))
+		require.True(t, exists, table)
+	}
+	migrations, err := loadMigrations(migrationFiles)
+	require.NoError(t, err)
+	var applied int
+	require.NoError(t, pgRepo.pool.QueryRow(ctx, "SELECT COUNT(*) FROM schema_migrations").Scan(&applied))
+	require.Equal(t, len(migrations), applied)
+}
+
+func Test_LoadMigrations_SortsByVersion(t *testing.T) {
+	migrations, err := loadMigrations(fstest.MapFS{
+		"migrations/V10__later.sql":  {Data: []byte("SELECT 10")},
+		"migrations/V2__earlier.sql": {Data: []byte("SELECT 2")},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []migration{{2, "earlier", "SELECT 2"}, {10, "later", "SELECT 10"}}, migrations)
+
+	_, err = loadMigrations(fstest.MapFS{"migrations/create.sql": {Data: []byte("SELECT 1")}})
 	require.Error(t, err)
 }
+
+func Test_GetUserByID(t *testing.T) {
+	ctx := context.Background()
+	user := model.User{ID: uuid.New(), Username: "profileuser", Email: "profile@example.com", Password: []byte("password")}
+	require.NoError(t, pgRepo.SignUp(ctx, &user))
+
+	stored, err := pgRepo.GetUserByID(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, user.Username, stored.Username)
+	require.Equal(t, user.Email, stored.Email)
+	require.False(t, stored.Admin)
+	require.False(t, stored.CreatedAt.IsZero())
+	require.Empty(t, stored.Password)
+
+	_, err = pgRepo.GetUserByID(ctx, uuid.New())
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func Test_Diagnostics(t *testing.T) {
+	ctx := context.Background()
+	version, err := pgRepo.ServerVersion(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, version)
+
+	migration, err := pgRepo.MigrationVersion(ctx)
+	require.NoError(t, err)
+	require.Positive(t, migration)
+
+	require.Positive(t, pgRepo.PoolStats().MaxConns)
+}
+
+func Test_SetAdmin(t *testing.T) {
+	ctx := context.Background()
+	user := model.User{ID: uuid.New(), Username: "roleuser", Email: "role@example.com", Password: []byte("password")}
+	require.NoError(t, pgRepo.SignUp(ctx, &user))
+	// another admin, so demoting user never hits the last admin guard
+	keeper := model.User{ID: uuid.New(), Username: "rolekeeper", Password: []byte("password"), Admin: true}
+	require.NoError(t, pgRepo.SignUp(ctx, &keeper))
+	before, err := pgRepo.CountAdmins(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, pgRepo.SetAdmin(ctx, user.ID, true))
+	after, err := pgRepo.CountAdmins(ctx)
+	require.NoError(t, err)
+	require.Equal(t, before+1, after)
+
+	require.NoError(t, pgRepo.SetAdmin(ctx, user.ID, false))
+	stored, err := pgRepo.GetUserByID(ctx, user.ID)
+	require.NoError(t, err)
+	require.False(t, stored.Admin)
+
+	require.ErrorIs(t, pgRepo.SetAdmin(ctx, uuid.New(), true), ErrNotFound)
+	require.ErrorIs(t, pgRepo.SetAdmin(ctx, uuid.New(), false), ErrNotFound)
+}
+
+func Test_TokenVersion(t *testing.T) {
+	ctx := context.Background()
+	user := model.User{ID: uuid.New(), Username: "versionuser", Password: []byte("password")}
+	require.NoError(t, pgRepo.SignUp(ctx, &user))
+
+	version, err := pgRepo.GetTokenVersion(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, 0, version)
+
+	require.NoError(t, pgRepo.BumpTokenVersion(ctx, user.ID))
+	version, err = pgRepo.GetTokenVersion(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, version)
+	stored, err := pgRepo.GetUserByID(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, stored.TokenVersion)
+
+	_, err = pgRepo.GetTokenVersion(ctx, uuid.New())
+	require.ErrorIs(t, err, ErrNotFound)
+	require.ErrorIs(t, pgRepo.BumpTokenVersion(ctx, uuid.New()), ErrNotFound)
+}
+
+func Test_SetAdmin_LastAdmin(t *testing.T) {
+	ctx := context.Background()
+	user := model.User{ID: uuid.New(), Username: "lastadmin", Email: "lastadmin@example.com", Password: []byte("password")}
+	require.NoError(t, pgRepo.SignUp(ctx, &user))
+	require.NoError(t, pgRepo.SetAdmin(ctx, user.ID, true))
+
+	errRollback := errors.New("rollback")
+	err := pgRepo.InTx(ctx, func(ctx context.Context) error {
+		// leave user as the only admin for the rest of the transaction
+		_, err := pgRepo.writer(ctx).Exec(ctx, "UPDATE users SET admin = false WHERE id <> $1", user.ID)
+		require.NoError(t, err)
+		require.ErrorIs(t, pgRepo.SetAdmin(ctx, user.ID, false), ErrLastAdmin)
+		stored, err := pgRepo.GetUserByID(ctx, user.ID)
+		require.NoError(t, err)
+		require.True(t, stored.Admin)
+		return errRollback
+	})
+	require.ErrorIs(t, err, errRollback)
+}
+
+func newBatch(n int) []*model.Blog {
+	userID := uuid.New()
+	blogs := make([]*model.Blog, n)
+	for i := range blogs {
+		blogs[i] = &model.Blog{BlogID: uuid.New(), UserID: userID, Title: fmt.Sprintf("batch%d", i), Content: "batch content", Status: model.BlogStatusPublished}
+	}
+	return blogs
+}
+
+func Test_CreateBatchCopy(t *testing.T) {
+	ctx := context.Background()
+	blogs := newBatch(50)
+
+	require.NoError(t, pgRepo.CreateBatchCopy(ctx, blogs))
+
+	count, err := pgRepo.CountByUserID(ctx, blogs[0].UserID, false)
+	require.NoError(t, err)
+	require.Equal(t, len(blogs), count)
+	stored, err := pgRepo.Get(ctx, blogs[49].BlogID)
+	require.NoError(t, err)
+	require.Equal(t, blogs[49].Title, stored.Title)
+}
+
+func Test_CreateBatchCopy_NamesFailingRow(t *testing.T) {
+	ctx := context.Background()
+	blogs := newBatch(5)
+	blogs[3].BlogID = blogs[1].BlogID
+
+	err := pgRepo.CreateBatchCopy(ctx, blogs)
+	require.ErrorIs(t, err, ErrExist)
+	require.Contains(t, err.Error(), "blog 3")
+
+	count, err := pgRepo.CountByUserID(ctx, blogs[0].UserID, false)
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func Benchmark_CreateBatchCopy(b *testing.B) {
+	ctx := context.Background()
+	for i := 0; i < b.N; i++ {
+		if err := pgRepo.CreateBatchCopy(ctx, newBatch(500)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func Benchmark_CreateLoop(b *testing.B) {
+	ctx := context.Background()
+	for i := 0; i < b.N; i++ {
+		for _, blog := range newBatch(500) {
+			if err := pgRepo.Create(ctx, blog); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
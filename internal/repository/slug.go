@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/artnikel/blogapi/internal/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// slugMaxLen caps the number of characters of a slug taken from the title, before any suffix
+	slugMaxLen = 80
+	// slugFallback is the slug of titles without a single letter or digit
+	slugFallback = "blog"
+	// slugConstraint is the unique index on blog slugs
+	slugConstraint = "blog_slug_idx"
+	// slugAttempts is how many times a write retries when a concurrent write took the chosen slug
+	slugAttempts = 3
+)
+
+// slugify turns a title into a URL friendly slug: lowercased, accents of latin letters stripped and every run of
+// characters other than letters and digits replaced by a single hyphen. Letters of other scripts are kept
+// as they are, so "Привет, мир" becomes "привет-мир".
+func slugify(title string) string {
+	var b strings.Builder
+	pendingHyphen := false
+	length := 0
+	var letter rune
+	for _, r := range norm.NFD.String(title) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// accents of latin letters are dropped, in other scripts the mark is part of the letter, e.g. й or ブ
+			if letter != 0 && !unicode.Is(unicode.Latin, letter) {
+				b.WriteRune(r)
+			}
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if length >= slugMaxLen {
+				return finishSlug(b.String())
+			}
+			if pendingHyphen && length > 0 {
+				b.WriteByte('-')
+				length++
+			}
+			pendingHyphen = false
+			letter = r
+			b.WriteRune(unicode.ToLower(r))
+			length++
+		default:
+			pendingHyphen = true
+			letter = 0
+		}
+	}
+	return finishSlug(b.String())
+}
+
+// finishSlug recomposes the slug and falls back to slugFallback if nothing is left of the title
+func finishSlug(slug string) string {
+	slug = strings.TrimRight(slug, "-")
+	if slug == "" {
+		return slugFallback
+	}
+	return norm.NFC.String(slug)
+}
+
+// freeSlug returns base if no other blog uses it, otherwise base with the smallest free numeric suffix, e.g. "hello-2".
+// A blog whose current slug already belongs to base keeps it, so editing the content does not change its URL.
+func (p *PgRepository) freeSlug(ctx context.Context, base string, blogID uuid.UUID) (string, error) {
+	rows, err := p.writer(ctx).Query(ctx, "SELECT slug, blogid FROM blog WHERE slug = $1 OR slug LIKE $2", base, base+"-%")
+	if err != nil {
+		return "", fmt.Errorf("error in method p.pool.Query(): %w", classify(err))
+	}
+	defer rows.Close()
+	taken := make(map[string]struct{})
+	for rows.Next() {
+		var slug string
+		var id uuid.UUID
+		if err := rows.Scan(&slug, &id); err != nil {
+			return "", fmt.Errorf("error in method rows.Scan(): %w", err)
+		}
+		if id == blogID && slugOf(slug, base) {
+			return slug, nil
+		}
+		taken[slug] = struct{}{}
+	}
+	if err := rows.Err(); err != nil {
+		return "", fmt.Errorf("error in method rows.Err(): %w", classify(err))
+	}
+	return nextSlug(base, taken), nil
+}
+
+// slugOf reports whether slug is base or base with a numeric suffix
+func slugOf(slug, base string) bool {
+	if slug == base {
+		return true
+	}
+	suffix, ok := strings.CutPrefix(slug, base+"-")
+	if !ok {
+		return false
+	}
+	_, err := strconv.Atoi(suffix)
+	return err == nil
+}
+
+// nextSlug picks base or the first base-N, starting from 2, that is not taken
+func nextSlug(base string, taken map[string]struct{}) string {
+	if _, ok := taken[base]; !ok {
+		return base
+	}
+	for n := 2; ; n++ {
+		slug := base + "-" + strconv.Itoa(n)
+		if _, ok := taken[slug]; !ok {
+			return slug
+		}
+	}
+}
+
+// batchSlug is the slug of a blog inserted in bulk, the blog id prefix keeps it unique without looking at existing slugs
+func batchSlug(blog *model.Blog) string {
+	return slugify(blog.Title) + "-" + blog.BlogID.String()[:8]
+}
+
+// isSlugConflict reports whether err is a unique violation of the slug index
+func isSlugConflict(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation && pgErr.ConstraintName == slugConstraint
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StickyPrimary remembers the users who wrote recently, so their reads go to the primary for a while
+// and they see their own writes even when the read replica lags behind
+type StickyPrimary struct {
+	window time.Duration
+	now    func() time.Time
+
+	mu    sync.Mutex
+	until map[uuid.UUID]time.Time
+}
+
+// NewStickyPrimary creates a StickyPrimary that keeps the reads of a user on the primary for window after a write
+func NewStickyPrimary(window time.Duration) *StickyPrimary {
+	return &StickyPrimary{window: window, now: time.Now, until: make(map[uuid.UUID]time.Time)}
+}
+
+// MarkWrite starts or extends the window of the user, it also drops the windows that have run out
+func (s *StickyPrimary) MarkWrite(userID uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	for id, until := range s.until {
+		if !now.Before(until) {
+			delete(s.until, id)
+		}
+	}
+	s.until[userID] = now.Add(s.window)
+}
+
+// Active reports whether the reads of the user must go to the primary
+func (s *StickyPrimary) Active(userID uuid.UUID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	until, ok := s.until[userID]
+	return ok && s.now().Before(until)
+}
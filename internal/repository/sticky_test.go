@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_StickyPrimary_Window(t *testing.T) {
+	sticky := NewStickyPrimary(time.Second)
+	clock := time.Now()
+	sticky.now = func() time.Time { return clock }
+	writer, other := uuid.New(), uuid.New()
+
+	require.False(t, sticky.Active(writer))
+	sticky.MarkWrite(writer)
+	require.True(t, sticky.Active(writer))
+	require.False(t, sticky.Active(other))
+
+	clock = clock.Add(time.Second)
+	require.False(t, sticky.Active(writer))
+
+	sticky.MarkWrite(other)
+	require.NotContains(t, sticky.until, writer)
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/artnikel/blogapi/internal/model"
+	"github.com/artnikel/blogapi/internal/requestid"
+	"github.com/jackc/pgx/v5"
+	log "github.com/sirupsen/logrus"
+)
+
+// queryNameMaxLen caps the length of the SQL text written to the slow-query log
+const queryNameMaxLen = 200
+
+// queryDurationBuckets are the upper bounds of the query duration histogram buckets
+var queryDurationBuckets = []time.Duration{
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	5 * time.Second,
+}
+
+// queryTable matches the table a statement reads from or writes to
+var queryTable = regexp.MustCompile(`(?i)\b(?:from|into|update)\s+([a-z_][a-z0-9_]*)`)
+
+type queryStartKey struct{}
+
+// queryStart is what TraceQueryStart hands over to TraceQueryEnd through the context
+type queryStart struct {
+	sql   string
+	start time.Time
+}
+
+// QueryTracer is a pgx tracer that records a duration histogram per operation and logs queries
+// running longer than the slow-query threshold.
+// Only the SQL text is logged, never the argument values, so no blog content or credentials end up in logs.
+type QueryTracer struct {
+	slowThreshold time.Duration
+	now           func() time.Time
+
+	mu    sync.Mutex
+	stats map[string]*model.QueryStats
+}
+
+// NewQueryTracer creates a tracer that logs queries slower than slowThreshold, a non-positive threshold disables the log
+func NewQueryTracer(slowThreshold time.Duration) *QueryTracer {
+	return &QueryTracer{slowThreshold: slowThreshold, now: time.Now, stats: make(map[string]*model.QueryStats)}
+}
+
+// Stats returns a copy of the duration histograms recorded so far, keyed by operation such as "SELECT blog"
+func (t *QueryTracer) Stats() map[string]model.QueryStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	stats := make(map[string]model.QueryStats, len(t.stats))
+	for operation, s := range t.stats {
+		snapshot := *s
+		snapshot.Buckets = append([]model.DurationBucket(nil), s.Buckets...)
+		stats[operation] = snapshot
+	}
+	return stats
+}
+
+// record adds a query duration to the histogram of its operation
+func (t *QueryTracer) record(operation string, duration time.Duration, failed bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s, ok := t.stats[operation]
+	if !ok {
+		s = &model.QueryStats{Buckets: make([]model.DurationBucket, len(queryDurationBuckets))}
+		for i, bound := range queryDurationBuckets {
+			s.Buckets[i].LessOrEqualMs = bound.Milliseconds()
+		}
+		t.stats[operation] = s
+	}
+	s.Count++
+	s.TotalMs += float64(duration) / float64(time.Millisecond)
+	if failed {
+		s.Errors++
+	}
+	for i, bound := range queryDurationBuckets {
+		if duration <= bound {
+			s.Buckets[i].Count++
+		}
+	}
+}
+
+// TraceQueryStart remembers the query and its start time
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, queryStartKey{}, queryStart{sql: data.SQL, start: t.now()})
+}
+
+// TraceQueryEnd records the query duration and logs the query if it took longer than the slow-query threshold
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	started, ok := ctx.Value(queryStartKey{}).(queryStart)
+	if !ok {
+		return
+	}
+	duration := t.now().Sub(started.start)
+	t.record(queryOperation(started.sql), duration, data.Err != nil)
+	if t.slowThreshold <= 0 || duration < t.slowThreshold {
+		return
+	}
+	fields := log.Fields{
+		"sql":         queryName(started.sql),
+		"duration_ms": duration.Milliseconds(),
+		"request_id":  requestid.FromContext(ctx),
+	}
+	if data.Err != nil {
+		fields["error"] = data.Err.Error()
+	}
+	log.WithFields(fields).Warn("slow query")
+}
+
+// queryName collapses the whitespace of the SQL text and truncates it to a loggable size
+func queryName(sql string) string {
+	name := strings.Join(strings.Fields(sql), " ")
+	if len(name) > queryNameMaxLen {
+		name = name[:queryNameMaxLen] + "..."
+	}
+	return name
+}
+
+// queryOperation names the statement by its command and the first table it touches, e.g. "UPDATE blog"
+func queryOperation(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "UNKNOWN"
+	}
+	command := strings.ToUpper(fields[0])
+	if match := queryTable.FindStringSubmatch(sql); match != nil {
+		return command + " " + strings.ToLower(match[1])
+	}
+	return command
+}
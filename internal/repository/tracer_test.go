@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/artnikel/blogapi/internal/model"
+	"github.com/artnikel/blogapi/internal/requestid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_QueryTracer_LogsSlowQuery(t *testing.T) {
+	hook := test.NewGlobal()
+	tracer := NewQueryTracer(100 * time.Millisecond)
+	clock := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	tracer.now = func() time.Time { return clock }
+
+	ctx := requestid.WithID(context.Background(), "req-1")
+	ctx = tracer.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{
+		SQL:  "SELECT title\n\t\tFROM blog WHERE content = $1",
+		Args: []any{"secret content"},
+	})
+	clock = clock.Add(250 * time.Millisecond)
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+
+	entry := hook.LastEntry()
+	require.NotNil(t, entry)
+	require.Equal(t, "slow query", entry.Message)
+	require.Equal(t, "SELECT title FROM blog WHERE content = $1", entry.Data["sql"])
+	require.Equal(t, int64(250), entry.Data["duration_ms"])
+	require.Equal(t, "req-1", entry.Data["request_id"])
+	line, err := entry.String()
+	require.NoError(t, err)
+	require.NotContains(t, line, "secret content")
+}
+
+func Test_QueryTracer_IgnoresFastQuery(t *testing.T) {
+	hook := test.NewGlobal()
+	tracer := NewQueryTracer(100 * time.Millisecond)
+	clock := time.Now()
+	tracer.now = func() time.Time { return clock }
+
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "SELECT 1"})
+	clock = clock.Add(10 * time.Millisecond)
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+
+	require.Empty(t, hook.AllEntries())
+}
+
+func Test_QueryTracer_RecordsHistogram(t *testing.T) {
+	tracer := NewQueryTracer(0)
+	clock := time.Now()
+	tracer.now = func() time.Time { return clock }
+
+	for _, took := range []time.Duration{3 * time.Millisecond, 40 * time.Millisecond} {
+		ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "UPDATE blog SET title = $1 WHERE blogid = $2"})
+		clock = clock.Add(took)
+		tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+	}
+
+	stats := tracer.Stats()["UPDATE blog"]
+	require.Equal(t, int64(2), stats.Count)
+	require.Equal(t, int64(0), stats.Errors)
+	require.InDelta(t, 43.0, stats.TotalMs, 0.001)
+	require.Equal(t, int64(0), stats.Buckets[0].Count)
+	require.Equal(t, int64(1), stats.Buckets[1].Count)
+	require.Equal(t, int64(2), stats.Buckets[4].Count)
+}
+
+func Test_QueryTracer_RecordsQueryAgainstDatabase(t *testing.T) {
+	tracer := NewQueryTracer(0)
+	conf := pgRepo.pool.Config()
+	conf.ConnConfig.Tracer = tracer
+	pool, err := pgxpool.NewWithConfig(context.Background(), conf)
+	require.NoError(t, err)
+	defer pool.Close()
+	repo := NewPgRepository(pool)
+
+	_, err = repo.Count(context.Background(), model.BlogFilter{})
+	require.NoError(t, err)
+
+	stats := repo.QueryStats()
+	require.Contains(t, stats, "SELECT blog")
+	require.Equal(t, int64(1), stats["SELECT blog"].Count)
+	require.Len(t, stats["SELECT blog"].Buckets, len(queryDurationBuckets))
+}
+
+func Test_QueryOperation(t *testing.T) {
+	require.Equal(t, "SELECT blog", queryOperation("select title FROM Blog WHERE blogid = $1"))
+	require.Equal(t, "INSERT users", queryOperation("INSERT INTO users (id) VALUES ($1)"))
+	require.Equal(t, "SHOW", queryOperation("SHOW server_version"))
+	require.Equal(t, "UNKNOWN", queryOperation("  "))
+}
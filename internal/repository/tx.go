@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type txKey struct{}
+
+type afterCommitKey struct{}
+
+// execer is the part of a pool or a transaction that writes need
+type execer interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// InTx runs fn in a transaction, committed when fn returns nil and rolled back otherwise.
+// Writes made with the context given to fn join the transaction when they support it, which is noted on the method.
+func (p *PgRepository) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error in method p.pool.Begin(): %w", classify(err))
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	txCtx, runAfterCommit := WithAfterCommit(context.WithValue(ctx, txKey{}, tx))
+	if err := fn(txCtx); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error in method tx.Commit(): %w", classify(err))
+	}
+	runAfterCommit()
+	return nil
+}
+
+// AfterCommit runs fn once the transaction of InTx for the context commits, or right away outside of one.
+// fn is dropped if the transaction rolls back. Caches drop entries with it, dropping them before the commit would
+// let a read in between cache the old row again.
+func AfterCommit(ctx context.Context, fn func()) {
+	if hooks, ok := ctx.Value(afterCommitKey{}).(*[]func()); ok {
+		*hooks = append(*hooks, fn)
+		return
+	}
+	fn()
+}
+
+// WithAfterCommit returns a context collecting the functions given to AfterCommit instead of running them and
+// a function running the collected ones in order. InTx runs them after its commit, mocked transactions can do the same.
+// Within a context that already collects them the functions are left to the outer one, like nested InTx calls.
+func WithAfterCommit(ctx context.Context) (context.Context, func()) {
+	if _, ok := ctx.Value(afterCommitKey{}).(*[]func()); ok {
+		return ctx, func() {}
+	}
+	hooks := new([]func())
+	return context.WithValue(ctx, afterCommitKey{}, hooks), func() {
+		for _, fn := range *hooks {
+			fn()
+		}
+	}
+}
+
+// writer returns the transaction started by InTx for the context, or the pool outside of one
+func (p *PgRepository) writer(ctx context.Context) execer {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return p.pool
+}
+
+// savepoint runs fn in a savepoint of the transaction started by InTx for the context, so a failed statement can be
+// retried without aborting the whole transaction. Outside of a transaction fn runs on the pool.
+func (p *PgRepository) savepoint(ctx context.Context, fn func(db execer) error) error {
+	tx, ok := ctx.Value(txKey{}).(pgx.Tx)
+	if !ok {
+		return fn(p.pool)
+	}
+	sp, err := tx.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	if err := fn(sp); err != nil {
+		_ = sp.Rollback(ctx)
+		return err
+	}
+	return sp.Commit(ctx)
+}
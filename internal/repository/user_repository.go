@@ -2,72 +2,339 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/artnikel/blogapi/internal/model"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
-// SignUp creates a new user record in the db
+// SignUp creates a new user record in the db, usernames differing only in case count as the same user.
+// It joins the transaction of InTx.
 func (p *PgRepository) SignUp(ctx context.Context, user *model.User) error {
 	if user == nil {
 		return ErrNil
 	}
+	db := p.writer(ctx)
 	var numberUsers int
-	err := p.pool.QueryRow(context.Background(), "SELECT COUNT(id) FROM users WHERE username = $1", user.Username).Scan(&numberUsers)
+	err := db.QueryRow(ctx, "SELECT COUNT(id) FROM users WHERE LOWER(username) = LOWER($1) OR email = NULLIF($2, '')",
+		user.Username, user.Email).Scan(&numberUsers)
 	if err != nil {
-		return fmt.Errorf("error in method p.pool.QueryRow(): %w", err)
+		return fmt.Errorf("error in method p.pool.QueryRow(): %w", classify(err))
 	}
 	if numberUsers != 0 {
 		return ErrExist
 	}
-	_, err = p.pool.Exec(ctx, "INSERT INTO users(id, username, password, admin) VALUES($1, $2, $3, $4)",
-		user.ID, user.Username, user.Password, user.Admin)
+	_, err = db.Exec(ctx, "INSERT INTO users(id, username, email, password, admin) VALUES($1, $2, NULLIF($3, ''), $4, $5)",
+		user.ID, user.Username, user.Email, user.Password, user.Admin)
 	if err != nil {
-		return fmt.Errorf("error in method p.pool.Exec(): %w", err)
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return ErrExist
+		}
+		return fmt.Errorf("error in method p.pool.Exec(): %w", classify(err))
 	}
 	return nil
 }
 
-// GetDataByUsername returns data of user by username
+// GetDataByUsername returns data of user by username, ignoring case
 func (p *PgRepository) GetDataByUsername(ctx context.Context, username string) (id uuid.UUID, password []byte, admin bool, e error) {
 	var user model.User
 	user.Username = username
-	err := p.pool.QueryRow(ctx, "SELECT id, password, admin FROM users WHERE username = $1", user.Username).
+	err := p.pool.QueryRow(ctx, "SELECT id, password, admin FROM users WHERE LOWER(username) = LOWER($1)", user.Username).
 		Scan(&user.ID, &user.Password, &user.Admin)
 	if err != nil {
-		return uuid.UUID{}, nil, false, fmt.Errorf("error in method p.pool.QueryRow(): %w", err)
+		return uuid.UUID{}, nil, false, fmt.Errorf("error in method p.pool.QueryRow(): %w", classify(err))
 	}
 	return user.ID, user.Password, user.Admin, nil
 }
 
-// GetRefreshTokenByID returns refreshToken from users table by id
-func (p *PgRepository) GetRefreshTokenByID(ctx context.Context, id uuid.UUID) (string, error) {
-	var hash string
-	err := p.pool.QueryRow(ctx, "SELECT refreshToken FROM users WHERE id = $1", id).Scan(&hash)
+// GetDataByEmail returns data of user by email
+func (p *PgRepository) GetDataByEmail(ctx context.Context, email string) (*model.User, error) {
+	var user model.User
+	err := p.pool.QueryRow(ctx, "SELECT id, username, email, password, admin FROM users WHERE email = $1", email).
+		Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.Admin)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error in method p.pool.QueryRow(): %w", classify(err))
+	}
+	return &user, nil
+}
+
+// GetUserByID returns the profile of the user without the password hash and refresh token
+func (p *PgRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*model.User, error) {
+	var user model.User
+	err := p.pool.QueryRow(ctx, `SELECT id, username, COALESCE(email, ''), COALESCE(admin, false), token_version, createdat
+		FROM users WHERE id = $1`, id).
+		Scan(&user.ID, &user.Username, &user.Email, &user.Admin, &user.TokenVersion, &user.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error in method p.pool.QueryRow(): %w", classify(err))
+	}
+	return &user, nil
+}
+
+// SetAdmin grants or revokes the admin role of the user, it joins the transaction of InTx. Demoting the only
+// admin returns ErrLastAdmin and a missing user returns ErrNotFound. The admins are locked before they are counted,
+// so two concurrent demotions cannot both see the other admin and leave none.
+func (p *PgRepository) SetAdmin(ctx context.Context, id uuid.UUID, admin bool) error {
+	db := p.writer(ctx)
+	result, err := db.Exec(ctx, `WITH admins AS (SELECT id FROM users WHERE admin FOR UPDATE)
+		UPDATE users SET admin = $1 WHERE id = $2
+		AND ($1 OR NOT admin OR EXISTS (SELECT 1 FROM admins WHERE id <> $2))`, admin, id)
+	if err != nil {
+		return fmt.Errorf("error in method p.pool.Exec(): %w", classify(err))
+	}
+	if result.RowsAffected() > 0 {
+		return nil
+	}
+	var exists bool
+	err = db.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM users WHERE id = $1)", id).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("error in method p.pool.QueryRow(): %w", classify(err))
+	}
+	if !exists {
+		return ErrNotFound
+	}
+	return ErrLastAdmin
+}
+
+// CountAdmins returns the number of users with the admin role
+func (p *PgRepository) CountAdmins(ctx context.Context) (int, error) {
+	var count int
+	err := p.pool.QueryRow(ctx, "SELECT COUNT(*) FROM users WHERE admin").Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("error in method p.pool.QueryRow(): %w", classify(err))
+	}
+	return count, nil
+}
+
+// GetPasswordByID returns the password hash of the user
+func (p *PgRepository) GetPasswordByID(ctx context.Context, id uuid.UUID) ([]byte, error) {
+	var password []byte
+	err := p.pool.QueryRow(ctx, "SELECT password FROM users WHERE id = $1", id).Scan(&password)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
 	if err != nil {
-		return "", fmt.Errorf("error in method p.pool.QueryRow(): %w", err)
+		return nil, fmt.Errorf("error in method p.pool.QueryRow(): %w", classify(err))
 	}
-	return hash, nil
+	return password, nil
 }
 
-// AddRefreshToken adds refreshToken to users table by id
-func (p *PgRepository) AddRefreshToken(ctx context.Context, user *model.User) error {
-	_, err := p.pool.Exec(ctx, "UPDATE users SET refreshtoken = $1 WHERE id = $2", user.RefreshToken, user.ID)
+// UpdatePassword replaces the password hash of the user, it joins the transaction of InTx
+func (p *PgRepository) UpdatePassword(ctx context.Context, id uuid.UUID, hash []byte) error {
+	result, err := p.writer(ctx).Exec(ctx, "UPDATE users SET password = $1 WHERE id = $2", hash, id)
 	if err != nil {
-		return fmt.Errorf("error in method p.pool.Exec(): %w", err)
+		return fmt.Errorf("error in method p.pool.Exec(): %w", classify(err))
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
 	}
 	return nil
 }
 
-// DeleteUserByID delete user record in the db by its ID
-func (p *PgRepository) DeleteUserByID(ctx context.Context, id uuid.UUID) error {
-	result, err := p.pool.Exec(ctx, "DELETE FROM users WHERE id = $1 AND admin = false", id)
+// GetLockedUntil returns the time the user is locked until, or zero time if the user isn't locked
+func (p *PgRepository) GetLockedUntil(ctx context.Context, username string) (time.Time, error) {
+	var lockedUntil *time.Time
+	err := p.pool.QueryRow(ctx, "SELECT locked_until FROM users WHERE LOWER(username) = LOWER($1)", username).Scan(&lockedUntil)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error in method p.pool.QueryRow(): %w", classify(err))
+	}
+	if lockedUntil == nil {
+		return time.Time{}, nil
+	}
+	return *lockedUntil, nil
+}
+
+// RecordFailedLogin counts a failed login of the user and locks the user for lockout once maxAttempts is reached.
+// Locking starts counting from zero again, so the user gets maxAttempts more tries after the lock expires.
+// It returns the time the user is locked until, or zero time if the user isn't locked.
+func (p *PgRepository) RecordFailedLogin(ctx context.Context, username string, maxAttempts int, lockout time.Duration) (time.Time, error) {
+	var lockedUntil *time.Time
+	err := p.pool.QueryRow(ctx, `UPDATE users SET
+		locked_until = CASE WHEN failed_logins + 1 >= $2 THEN NOW() + $3::interval ELSE locked_until END,
+		failed_logins = CASE WHEN failed_logins + 1 >= $2 THEN 0 ELSE failed_logins + 1 END
+		WHERE LOWER(username) = LOWER($1) RETURNING locked_until`, username, maxAttempts, lockout).Scan(&lockedUntil)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error in method p.pool.QueryRow(): %w", classify(err))
+	}
+	if lockedUntil == nil {
+		return time.Time{}, nil
+	}
+	return *lockedUntil, nil
+}
+
+// ResetFailedLogins clears the failed logins counter and the lock of the user
+func (p *PgRepository) ResetFailedLogins(ctx context.Context, id uuid.UUID) error {
+	_, err := p.pool.Exec(ctx, "UPDATE users SET failed_logins = 0, locked_until = NULL WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("error in method p.pool.Exec(): %w", classify(err))
+	}
+	return nil
+}
+
+// GetRefreshTokensByUserID returns all not expired refresh tokens of the user
+func (p *PgRepository) GetRefreshTokensByUserID(ctx context.Context, id uuid.UUID) ([]*model.RefreshToken, error) {
+	rows, err := p.pool.Query(ctx, "SELECT id, userid, token, expiresat, createdat FROM refresh_tokens WHERE userid = $1 AND expiresat > NOW()", id)
+	if err != nil {
+		return nil, fmt.Errorf("error in method p.pool.Query(): %w", classify(err))
+	}
+	defer rows.Close()
+	var tokens []*model.RefreshToken
+	for rows.Next() {
+		var token model.RefreshToken
+		if err := rows.Scan(&token.ID, &token.UserID, &token.Token, &token.ExpiresAt, &token.CreatedAt); err != nil {
+			return nil, fmt.Errorf("error in method rows.Scan(): %w", err)
+		}
+		tokens = append(tokens, &token)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", classify(err))
+	}
+	return tokens, nil
+}
+
+// GetRefreshToken returns the not expired refresh token row with the given ID, or ErrNotFound if there is none
+func (p *PgRepository) GetRefreshToken(ctx context.Context, id uuid.UUID) (*model.RefreshToken, error) {
+	var token model.RefreshToken
+	err := p.pool.QueryRow(ctx, "SELECT id, userid, token, expiresat, createdat FROM refresh_tokens WHERE id = $1 AND expiresat > NOW()", id).
+		Scan(&token.ID, &token.UserID, &token.Token, &token.ExpiresAt, &token.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
 	if err != nil {
-		return fmt.Errorf("error in method p.pool.Exec(): %w", err)
+		return nil, fmt.Errorf("error in method p.pool.QueryRow(): %w", classify(err))
+	}
+	return &token, nil
+}
+
+// GetProfiles retrieves the public profiles of the given users in a single query, unknown ids are skipped
+func (p *PgRepository) GetProfiles(ctx context.Context, ids []uuid.UUID) ([]*model.UserProfile, error) {
+	rows, err := p.reader(ctx).Query(ctx, "SELECT id, username FROM users WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, fmt.Errorf("error in method p.pool.Query(): %w", classify(err))
+	}
+	defer rows.Close()
+	profiles := make([]*model.UserProfile, 0, len(ids))
+	for rows.Next() {
+		var profile model.UserProfile
+		if err := rows.Scan(&profile.ID, &profile.Username); err != nil {
+			return nil, fmt.Errorf("error in method rows.Scan(): %w", err)
+		}
+		profiles = append(profiles, &profile)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", classify(err))
+	}
+	return profiles, nil
+}
+
+// AddRefreshToken inserts a new refresh token row for one of the user's devices
+func (p *PgRepository) AddRefreshToken(ctx context.Context, token *model.RefreshToken) error {
+	_, err := p.pool.Exec(ctx, "INSERT INTO refresh_tokens (id, userid, token, expiresat) VALUES ($1, $2, $3, $4)",
+		token.ID, token.UserID, token.Token, token.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("error in method p.pool.Exec(): %w", classify(err))
+	}
+	return nil
+}
+
+// RotateRefreshToken replaces the hash and expiry of the refresh token row with the given ID
+func (p *PgRepository) RotateRefreshToken(ctx context.Context, token *model.RefreshToken) error {
+	result, err := p.pool.Exec(ctx, "UPDATE refresh_tokens SET token = $1, expiresat = $2 WHERE id = $3",
+		token.Token, token.ExpiresAt, token.ID)
+	if err != nil {
+		return fmt.Errorf("error in method p.pool.Exec(): %w", classify(err))
 	}
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("no user found with the given ID")
+		return fmt.Errorf("no refresh token found with the given ID")
+	}
+	return nil
+}
+
+// DeleteRefreshToken removes a single refresh token row by its ID
+func (p *PgRepository) DeleteRefreshToken(ctx context.Context, id uuid.UUID) error {
+	_, err := p.pool.Exec(ctx, "DELETE FROM refresh_tokens WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("error in method p.pool.Exec(): %w", classify(err))
 	}
 	return nil
 }
+
+// GetTokenVersion returns the token version of the user, access tokens of an older version are revoked.
+// It reads the primary, so a revoke is seen by the next request, and returns ErrNotFound if there is no such user.
+func (p *PgRepository) GetTokenVersion(ctx context.Context, id uuid.UUID) (int, error) {
+	var version int
+	err := p.pool.QueryRow(ctx, "SELECT token_version FROM users WHERE id = $1", id).Scan(&version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ErrNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("error in method p.pool.QueryRow(): %w", classify(err))
+	}
+	return version, nil
+}
+
+// BumpTokenVersion increments the token version of the user, revoking every access token issued before,
+// it joins the transaction of InTx. It returns ErrNotFound if there is no such user.
+func (p *PgRepository) BumpTokenVersion(ctx context.Context, id uuid.UUID) error {
+	result, err := p.writer(ctx).Exec(ctx, "UPDATE users SET token_version = token_version + 1 WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("error in method p.pool.Exec(): %w", classify(err))
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// DeleteRefreshTokensByUserID removes every refresh token row of the user, it joins the transaction of InTx
+func (p *PgRepository) DeleteRefreshTokensByUserID(ctx context.Context, id uuid.UUID) error {
+	_, err := p.writer(ctx).Exec(ctx, "DELETE FROM refresh_tokens WHERE userid = $1", id)
+	if err != nil {
+		return fmt.Errorf("error in method p.pool.Exec(): %w", classify(err))
+	}
+	return nil
+}
+
+// DeleteUserWithBlogs soft-deletes the blogs of the user and deletes the user in one transaction, so a failure in
+// between never leaves the blogs of a deleted user behind. Admins are protected like in DeleteUserByID, deleting one
+// returns ErrAdminUser and changes nothing.
+func (p *PgRepository) DeleteUserWithBlogs(ctx context.Context, id uuid.UUID) error {
+	return p.InTx(ctx, func(ctx context.Context) error {
+		if _, err := p.DeleteBlogsByUserID(ctx, id); err != nil {
+			return err
+		}
+		return p.DeleteUserByID(ctx, id)
+	})
+}
+
+// DeleteUserByID delete user record in the db by its ID, it joins the transaction of InTx.
+// Admins are never deleted, deleting one returns ErrAdminUser and a missing user returns ErrNotFound.
+func (p *PgRepository) DeleteUserByID(ctx context.Context, id uuid.UUID) error {
+	db := p.writer(ctx)
+	result, err := db.Exec(ctx, "DELETE FROM users WHERE id = $1 AND admin = false", id)
+	if err != nil {
+		return fmt.Errorf("error in method p.pool.Exec(): %w", classify(err))
+	}
+	if result.RowsAffected() > 0 {
+		return nil
+	}
+	var admin bool
+	err = db.QueryRow(ctx, "SELECT admin FROM users WHERE id = $1", id).Scan(&admin)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("error in method p.pool.QueryRow(): %w", classify(err))
+	}
+	return ErrAdminUser
+}
@@ -0,0 +1,18 @@
+// Package requestid carries the id of the current HTTP request through context.Context,
+// so layers below the handlers can tag their logs with it.
+package requestid
+
+import "context"
+
+type contextKey struct{}
+
+// WithID returns a copy of ctx carrying the request id
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request id carried by ctx, or an empty string if there is none
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
@@ -0,0 +1,22 @@
+// Package requestuser carries the id of the authenticated user of the current HTTP request through context.Context,
+// so layers below the handlers can make per-user decisions such as routing reads.
+package requestuser
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type contextKey struct{}
+
+// WithID returns a copy of ctx carrying the user id
+func WithID(ctx context.Context, id uuid.UUID) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the user id carried by ctx and whether there is one
+func FromContext(ctx context.Context) (uuid.UUID, bool) {
+	id, ok := ctx.Value(contextKey{}).(uuid.UUID)
+	return id, ok
+}
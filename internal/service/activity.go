@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/artnikel/blogapi/internal/model"
+	"github.com/artnikel/blogapi/internal/requestuser"
+	"github.com/google/uuid"
+)
+
+// activityRecorder is the part of the repositories that writes the activity log
+type activityRecorder interface {
+	AddActivity(ctx context.Context, activity *model.Activity) error
+}
+
+// recordActivity adds the action on target to the activity log of the user of the request.
+// Writes made outside of an authenticated request, without a user in ctx, are not recorded.
+func recordActivity(ctx context.Context, rps activityRecorder, action string, targetID uuid.UUID) error {
+	userID, ok := requestuser.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	err := rps.AddActivity(ctx, &model.Activity{ID: uuid.New(), UserID: userID, Action: action, TargetID: targetID})
+	if err != nil {
+		return fmt.Errorf("AddActivity - %w", err)
+	}
+	return nil
+}
@@ -3,9 +3,18 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/artnikel/blogapi/internal/constants"
 	"github.com/artnikel/blogapi/internal/model"
+	"github.com/artnikel/blogapi/internal/render"
+	"github.com/artnikel/blogapi/internal/repository"
 	"github.com/google/uuid"
 )
 
@@ -13,92 +22,699 @@ import (
 type BlogRepository interface {
 	Create(ctx context.Context, blog *model.Blog) error
 	Get(ctx context.Context, id uuid.UUID) (*model.Blog, error)
+	GetBySlug(ctx context.Context, slug string) (*model.Blog, error)
+	GetWithAuthor(ctx context.Context, id uuid.UUID) (*model.BlogWithAuthor, error)
 	Delete(ctx context.Context, id uuid.UUID) error
-	DeleteBlogsByUserID(ctx context.Context, id uuid.UUID) error
+	DeleteBlogsByUserID(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)
+	DeleteIfUnmodifiedSince(ctx context.Context, id uuid.UUID, since time.Time) error
+	DeleteMany(ctx context.Context, ids []uuid.UUID, ownerID uuid.UUID) ([]uuid.UUID, error)
+	ExistingIDs(ctx context.Context, ids []uuid.UUID, viewerID uuid.UUID) ([]uuid.UUID, error)
+	Restore(ctx context.Context, id uuid.UUID) error
+	HardDelete(ctx context.Context, id uuid.UUID) error
 	Update(ctx context.Context, blog *model.Blog) error
-	Count(ctx context.Context) (int, error)
-	GetAll(ctx context.Context, limit, offset int) ([]*model.Blog, error)
-	GetByUserID(ctx context.Context, id uuid.UUID) ([]*model.Blog, error)
+	Publish(ctx context.Context, id uuid.UUID) error
+	SubmitForReview(ctx context.Context, id uuid.UUID) error
+	Approve(ctx context.Context, id uuid.UUID) error
+	Reject(ctx context.Context, id uuid.UUID, reason string) error
+	GetPendingReview(ctx context.Context, limit, offset int) ([]*model.Blog, error)
+	IncrementViews(ctx context.Context, id uuid.UUID) (int, error)
+	GetPopular(ctx context.Context, limit int) ([]*model.Blog, error)
+	AddRecentView(ctx context.Context, userID, blogID uuid.UUID, keep int) error
+	GetRecentViews(ctx context.Context, userID uuid.UUID) ([]*model.Blog, error)
+	GetSiblings(ctx context.Context, blog *model.Blog, authorOnly bool) (*model.BlogSiblings, error)
+	Count(ctx context.Context, filter model.BlogFilter) (int, error)
+	EstimateCount(ctx context.Context) (int, error)
+	GetAll(ctx context.Context, filter model.BlogFilter, limit, offset int) ([]*model.Blog, error)
+	StreamAll(ctx context.Context, filter model.BlogFilter, fn func(*model.Blog) error) error
+	StreamByUserID(ctx context.Context, id uuid.UUID, fn func(*model.Blog) error) error
+	CountByUserID(ctx context.Context, id uuid.UUID, publishedOnly bool) (int, error)
+	CountDraftsByUserID(ctx context.Context, id uuid.UUID) (int, error)
+	GetByUserID(ctx context.Context, id uuid.UUID, publishedOnly bool, limit, offset int) ([]*model.Blog, error)
+	IsBlogOwner(ctx context.Context, blogID, userID uuid.UUID) (bool, error)
+	AddTags(ctx context.Context, blogID uuid.UUID, tags []string) error
+	ReplaceTags(ctx context.Context, blogID uuid.UUID, tags []string) error
+	GetTags(ctx context.Context, blogID uuid.UUID) ([]string, error)
+	CountByTag(ctx context.Context, tag string) (int, error)
+	GetByTag(ctx context.Context, tag string, limit, offset int) ([]*model.Blog, error)
+	GetTopTagsByUserID(ctx context.Context, id uuid.UUID, limit int) ([]*model.TagCount, error)
+	ClaimIdempotencyKey(ctx context.Context, key *model.IdempotencyKey, ttl time.Duration) (*model.IdempotencyKey, error)
+	DeleteIdempotencyKey(ctx context.Context, userID uuid.UUID, key string) error
+	AddActivity(ctx context.Context, activity *model.Activity) error
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 // BlogService contains Repository interface
 type BlogService struct {
-	blogRps BlogRepository
+	blogRps    BlogRepository
+	moderation bool
+	media      mediaPolicy
+	// estimateAbove is the table size from which the total of GetAll is estimated instead of counted, 0 always counts
+	estimateAbove int
+	totalCount    *countCache
+	// draftLimit is the number of drafts a user may keep, 0 does not limit them
+	draftLimit int
 }
 
 // NewBlogService accepts Repository object and returns an object of type *BlogService
 func NewBlogService(blogRps BlogRepository) *BlogService {
-	return &BlogService{blogRps: blogRps}
+	return &BlogService{blogRps: blogRps, totalCount: newCountCache(constants.CountCacheTTL)}
 }
 
-// Create is a method of BlogService that calls Create method of Repository
-func (s *BlogService) Create(ctx context.Context, blog *model.Blog) error {
-	err := s.blogRps.Create(ctx, blog)
+// SetCountCacheTTL sets how long GetAll reuses the total number of published blogs.
+// Zero keeps constants.CountCacheTTL, a negative ttl counts on every call.
+func (s *BlogService) SetCountCacheTTL(ttl time.Duration) {
+	if ttl != 0 {
+		s.totalCount = newCountCache(ttl)
+	}
+}
+
+// SetModeration turns the moderation queue on or off.
+// With moderation on, blogs of untrusted users go to review instead of being published right away.
+func (s *BlogService) SetModeration(enabled bool) {
+	s.moderation = enabled
+}
+
+// SetCountEstimate makes GetAll estimate the total number of blogs from the table statistics once the table
+// holds more than threshold rows, an exact COUNT gets slow on huge tables. A non-positive threshold always counts.
+func (s *BlogService) SetCountEstimate(threshold int) {
+	s.estimateAbove = threshold
+}
+
+// SetDraftLimit caps the number of drafts a user may keep, independently of how many blogs they published.
+// Creating a blog beyond the limit fails with ErrDraftLimitExceeded, a non-positive limit does not limit drafts.
+func (s *BlogService) SetDraftLimit(limit int) {
+	s.draftLimit = max(limit, 0)
+}
+
+// SetMediaCheck configures how image and link URLs in blog content are checked.
+// Mode is one of MediaCheckOff, MediaCheckWarn or MediaCheckReject, an empty mode keeps the check off.
+// With allowed hosts set, absolute URLs to any other host are disallowed as well.
+func (s *BlogService) SetMediaCheck(mode string, allowedHosts []string) error {
+	switch mode {
+	case "", MediaCheckOff, MediaCheckWarn, MediaCheckReject:
+	default:
+		return fmt.Errorf("unknown media check mode %q", mode)
+	}
+	hosts := make(map[string]struct{}, len(allowedHosts))
+	for _, host := range allowedHosts {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			hosts[host] = struct{}{}
+		}
+	}
+	s.media = mediaPolicy{mode: mode, allowedHosts: hosts}
+	return nil
+}
+
+// checkMedia rejects the blog with a MediaError or attaches the issues as warnings, depending on the media check mode
+func (s *BlogService) checkMedia(blog *model.Blog) error {
+	issues := s.media.check(blog)
+	if len(issues) == 0 {
+		return nil
+	}
+	if s.media.mode == MediaCheckReject {
+		return &MediaError{Issues: issues}
+	}
+	blog.Warnings = issues
+	return nil
+}
+
+// Preview is a method of BlogService that renders the content to sanitized HTML with its excerpt, word count and
+// reading time, and reports the media references the media check disallows. Nothing is saved.
+func (s *BlogService) Preview(content string) (*model.ContentPreview, error) {
+	preview, err := render.Preview(content)
+	if err != nil {
+		return nil, fmt.Errorf("render.Preview - %w", err)
+	}
+	preview.Warnings = s.media.check(&model.Blog{Content: content})
+	return preview, nil
+}
+
+// Create is a method of BlogService that saves a new blog as a draft and attaches the normalized tags.
+// Drafts of untrusted users are capped by the draft limit, see SetDraftLimit.
+// Create, Update and Delete add the write to the activity log of the user of the request.
+func (s *BlogService) Create(ctx context.Context, blog *model.Blog, trusted bool) error {
+	blog.Status = model.BlogStatusDraft
+	blog.Tags = NormalizeTags(blog.Tags)
+	if err := s.checkMedia(blog); err != nil {
+		return err
+	}
+	if !trusted {
+		if err := s.checkDraftLimit(ctx, blog.UserID); err != nil {
+			return err
+		}
+	}
+	// the blog is saved with its tags or not at all, so a failed create can be retried without leaving a duplicate
+	return s.blogRps.InTx(ctx, func(ctx context.Context) error {
+		err := s.blogRps.Create(ctx, blog)
+		if err != nil {
+			return fmt.Errorf("blogRps.Create - %w", err)
+		}
+		if len(blog.Tags) > 0 {
+			err = s.blogRps.AddTags(ctx, blog.BlogID, blog.Tags)
+			if err != nil {
+				return fmt.Errorf("blogRps.AddTags - %w", err)
+			}
+		}
+		return recordActivity(ctx, s.blogRps, model.ActivityBlogCreated, blog.BlogID)
+	})
+}
+
+// checkDraftLimit fails with ErrDraftLimitExceeded when the user already keeps as many drafts as the limit allows.
+// Concurrent creates of the same user may both pass the check, the limit bounds hoarding rather than being exact.
+func (s *BlogService) checkDraftLimit(ctx context.Context, userID uuid.UUID) error {
+	if s.draftLimit == 0 {
+		return nil
+	}
+	drafts, err := s.blogRps.CountDraftsByUserID(ctx, userID)
 	if err != nil {
-		return fmt.Errorf("blogRps.Create - %w", err)
+		return fmt.Errorf("blogRps.CountDraftsByUserID - %w", err)
+	}
+	if drafts >= s.draftLimit {
+		return ErrDraftLimitExceeded
 	}
 	return nil
 }
 
-// Get is a method of BlogService that calls Get method of Repository
+// CreateIdempotent creates the blog unless the user already sent a request with the same idempotency key
+// within constants.IdempotencyKeyTTL. A repeated request gets the blog created by the first one and true,
+// the same key with a different blog gets ErrIdempotencyKeyReused.
+func (s *BlogService) CreateIdempotent(ctx context.Context, blog *model.Blog, key string, trusted bool) (*model.Blog, bool, error) {
+	hash, err := requestHash(blog)
+	if err != nil {
+		return nil, false, err
+	}
+	claim := &model.IdempotencyKey{UserID: blog.UserID, Key: key, RequestHash: hash, BlogID: blog.BlogID}
+	stored, err := s.blogRps.ClaimIdempotencyKey(ctx, claim, constants.IdempotencyKeyTTL)
+	if err != nil {
+		return nil, false, fmt.Errorf("blogRps.ClaimIdempotencyKey - %w", err)
+	}
+	if stored != nil {
+		if stored.RequestHash != hash {
+			return nil, false, ErrIdempotencyKeyReused
+		}
+		original, err := s.Get(repository.WithPrimary(ctx), stored.BlogID)
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, false, ErrIdempotencyKeyPending
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		return original, true, nil
+	}
+	if err := s.Create(ctx, blog, trusted); err != nil {
+		// the key is released so that the client can retry the failed request with it
+		if releaseErr := s.blogRps.DeleteIdempotencyKey(ctx, blog.UserID, key); releaseErr != nil {
+			return nil, false, fmt.Errorf("%w (blogRps.DeleteIdempotencyKey - %v)", err, releaseErr)
+		}
+		return nil, false, err
+	}
+	return blog, false, nil
+}
+
+// requestHash fingerprints the fields of a blog a client sends, so a reused idempotency key can be told from a retry
+func requestHash(blog *model.Blog) (string, error) {
+	body, err := json.Marshal(struct {
+		Title   string   `json:"title"`
+		Content string   `json:"content"`
+		Tags    []string `json:"tags"`
+	}{blog.Title, blog.Content, NormalizeTags(blog.Tags)})
+	if err != nil {
+		return "", fmt.Errorf("json.Marshal - %w", err)
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Get is a method of BlogService that calls Get method of Repository and hydrates the blog tags
 func (s *BlogService) Get(ctx context.Context, id uuid.UUID) (*model.Blog, error) {
 	blog, err := s.blogRps.Get(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("blogRps.Get - %w", err)
 	}
+	blog.Tags, err = s.blogRps.GetTags(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("blogRps.GetTags - %w", err)
+	}
+	return blog, nil
+}
+
+// GetWithAuthor is a method of BlogService that calls GetWithAuthor method of Repository
+func (s *BlogService) GetWithAuthor(ctx context.Context, id uuid.UUID) (*model.BlogWithAuthor, error) {
+	blog, err := s.blogRps.GetWithAuthor(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("blogRps.GetWithAuthor - %w", err)
+	}
+	blog.Tags, err = s.blogRps.GetTags(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("blogRps.GetTags - %w", err)
+	}
+	return blog, nil
+}
+
+// GetBySlug is a method of BlogService that calls GetBySlug method of Repository
+func (s *BlogService) GetBySlug(ctx context.Context, slug string) (*model.Blog, error) {
+	blog, err := s.blogRps.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, fmt.Errorf("blogRps.GetBySlug - %w", err)
+	}
+	blog.Tags, err = s.blogRps.GetTags(ctx, blog.BlogID)
+	if err != nil {
+		return nil, fmt.Errorf("blogRps.GetTags - %w", err)
+	}
 	return blog, nil
 }
 
 // Delete is a method of BlogService that calls Delete method of Repository
 func (s *BlogService) Delete(ctx context.Context, id uuid.UUID) error {
-	err := s.blogRps.Delete(ctx, id)
+	return s.blogRps.InTx(ctx, func(ctx context.Context) error {
+		err := s.blogRps.Delete(ctx, id)
+		if err != nil {
+			return fmt.Errorf("blogRps.Delete - %w", err)
+		}
+		repository.AfterCommit(ctx, s.totalCount.invalidate)
+		return recordActivity(ctx, s.blogRps, model.ActivityBlogDeleted, id)
+	})
+}
+
+// DeleteIfUnmodifiedSince is a method of BlogService that soft-deletes the blog unless it was modified after since,
+// returning repository.ErrModified if it was
+func (s *BlogService) DeleteIfUnmodifiedSince(ctx context.Context, id uuid.UUID, since time.Time) error {
+	return s.blogRps.InTx(ctx, func(ctx context.Context) error {
+		err := s.blogRps.DeleteIfUnmodifiedSince(ctx, id, since)
+		if err != nil {
+			return fmt.Errorf("blogRps.DeleteIfUnmodifiedSince - %w", err)
+		}
+		repository.AfterCommit(ctx, s.totalCount.invalidate)
+		return recordActivity(ctx, s.blogRps, model.ActivityBlogDeleted, id)
+	})
+}
+
+// DeleteBlogsByUserID is a method of BlogService that soft-deletes every blog of the user and adds each of them to the
+// activity log of the user of the request
+func (s *BlogService) DeleteBlogsByUserID(ctx context.Context, id uuid.UUID) error {
+	return s.blogRps.InTx(ctx, func(ctx context.Context) error {
+		deleted, err := s.blogRps.DeleteBlogsByUserID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("blogRps.DeleteBlogsByUserID - %w", err)
+		}
+		return s.recordDeleted(ctx, deleted)
+	})
+}
+
+// DeleteMany is a method of BlogService that soft-deletes the blogs with the given ids and returns how many were deleted.
+// Unless ownerID is uuid.Nil only the blogs of that author are deleted. Each deleted blog is added to the activity log.
+func (s *BlogService) DeleteMany(ctx context.Context, ids []uuid.UUID, ownerID uuid.UUID) (int, error) {
+	var deleted []uuid.UUID
+	err := s.blogRps.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		deleted, err = s.blogRps.DeleteMany(ctx, ids, ownerID)
+		if err != nil {
+			return fmt.Errorf("blogRps.DeleteMany - %w", err)
+		}
+		return s.recordDeleted(ctx, deleted)
+	})
 	if err != nil {
-		return fmt.Errorf("blogRps.Delete - %w", err)
+		return 0, err
+	}
+	return len(deleted), nil
+}
+
+// recordDeleted adds the deleted blogs to the activity log and drops the cached count once the deletion commits
+func (s *BlogService) recordDeleted(ctx context.Context, deleted []uuid.UUID) error {
+	if len(deleted) == 0 {
+		return nil
+	}
+	repository.AfterCommit(ctx, s.totalCount.invalidate)
+	for _, id := range deleted {
+		if err := recordActivity(ctx, s.blogRps, model.ActivityBlogDeleted, id); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
-// DeleteBlogsByUserID is a method of BlogService that calls DeleteBlogsByUserID method of Repository
-func (s *BlogService) DeleteBlogsByUserID(ctx context.Context, id uuid.UUID) error {
-	err := s.blogRps.DeleteBlogsByUserID(ctx, id)
+// Exists is a method of BlogService that reports for each of the given ids whether a blog with it exists.
+// Unless viewerID is uuid.Nil, unpublished blogs of other authors are reported as missing.
+func (s *BlogService) Exists(ctx context.Context, ids []uuid.UUID, viewerID uuid.UUID) (map[uuid.UUID]bool, error) {
+	exists := make(map[uuid.UUID]bool, len(ids))
+	if len(ids) == 0 {
+		return exists, nil
+	}
+	existing, err := s.blogRps.ExistingIDs(ctx, ids, viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("blogRps.ExistingIDs - %w", err)
+	}
+	for _, id := range ids {
+		exists[id] = false
+	}
+	for _, id := range existing {
+		exists[id] = true
+	}
+	return exists, nil
+}
+
+// Update is a method of BlogService that calls Update method of Repository.
+// Tags are replaced only when they were sent, so an update without tags keeps the existing ones.
+// With moderation on, an untrusted edit of a published or rejected blog sends it back to review. The edit, its tags and
+// the status change are saved in one transaction, so an unreviewed edit is never served as published.
+func (s *BlogService) Update(ctx context.Context, blog *model.Blog, trusted bool) error {
+	blog.Tags = NormalizeTags(blog.Tags)
+	if err := s.checkMedia(blog); err != nil {
+		return err
+	}
+	submitted := false
+	err := s.blogRps.InTx(ctx, func(ctx context.Context) error {
+		err := s.blogRps.Update(ctx, blog)
+		if err != nil {
+			return fmt.Errorf("blogRps.Update - %w", err)
+		}
+		if blog.Tags != nil {
+			err = s.blogRps.ReplaceTags(ctx, blog.BlogID, blog.Tags)
+			if err != nil {
+				return fmt.Errorf("blogRps.ReplaceTags - %w", err)
+			}
+		}
+		if err := recordActivity(ctx, s.blogRps, model.ActivityBlogUpdated, blog.BlogID); err != nil {
+			return err
+		}
+		if !s.moderation || trusted {
+			return nil
+		}
+		// the status is read from the primary, a lagging replica could miss a recent publish
+		current, err := s.blogRps.Get(repository.WithPrimary(ctx), blog.BlogID)
+		if err != nil {
+			return fmt.Errorf("blogRps.Get - %w", err)
+		}
+		if current.Status == model.BlogStatusPublished || current.Status == model.BlogStatusRejected {
+			err = s.blogRps.SubmitForReview(ctx, blog.BlogID)
+			if err != nil {
+				return fmt.Errorf("blogRps.SubmitForReview - %w", err)
+			}
+			submitted = true
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("blogRps.DeleteBlogsByUserID - %w", err)
+		return err
+	}
+	if submitted {
+		repository.AfterCommit(ctx, s.totalCount.invalidate)
+		blog.Status = model.BlogStatusPendingReview
 	}
 	return nil
 }
 
-// Update is a method of BlogService that calls Update method of Repository
-func (s *BlogService) Update(ctx context.Context, blog *model.Blog) error {
-	err := s.blogRps.Update(ctx, blog)
+// Publish is a method of BlogService that publishes a blog and returns its new status.
+// With moderation on, a blog of an untrusted user is submitted for review instead.
+func (s *BlogService) Publish(ctx context.Context, id uuid.UUID, trusted bool) (string, error) {
+	if s.moderation && !trusted {
+		err := s.blogRps.SubmitForReview(ctx, id)
+		if err != nil {
+			return "", fmt.Errorf("blogRps.SubmitForReview - %w", err)
+		}
+		return model.BlogStatusPendingReview, nil
+	}
+	err := s.blogRps.InTx(ctx, func(ctx context.Context) error {
+		err := s.blogRps.Publish(ctx, id)
+		if err != nil {
+			return fmt.Errorf("blogRps.Publish - %w", err)
+		}
+		repository.AfterCommit(ctx, s.totalCount.invalidate)
+		return recordActivity(ctx, s.blogRps, model.ActivityBlogPublished, id)
+	})
 	if err != nil {
-		return fmt.Errorf("blogRps.Update - %w", err)
+		return "", err
 	}
+	return model.BlogStatusPublished, nil
+}
+
+// Restore is a method of BlogService that brings back a soft-deleted blog and adds it to the activity log
+func (s *BlogService) Restore(ctx context.Context, id uuid.UUID) error {
+	return s.blogRps.InTx(ctx, func(ctx context.Context) error {
+		err := s.blogRps.Restore(ctx, id)
+		if err != nil {
+			return fmt.Errorf("blogRps.Restore - %w", err)
+		}
+		repository.AfterCommit(ctx, s.totalCount.invalidate)
+		return recordActivity(ctx, s.blogRps, model.ActivityBlogRestored, id)
+	})
+}
+
+// HardDelete is a method of BlogService that permanently removes a blog
+func (s *BlogService) HardDelete(ctx context.Context, id uuid.UUID) error {
+	err := s.blogRps.HardDelete(ctx, id)
+	if err != nil {
+		return fmt.Errorf("blogRps.HardDelete - %w", err)
+	}
+	repository.AfterCommit(ctx, s.totalCount.invalidate)
 	return nil
 }
 
-// GetAll is a method of BlogService that calls GetAll method of Repository
-func (s *BlogService) GetAll(ctx context.Context, limit, offset int) (*model.BlogListResponse, error) {
-	count, err := s.blogRps.Count(ctx)
+// Approve is a method of BlogService that publishes a blog waiting for review
+func (s *BlogService) Approve(ctx context.Context, id uuid.UUID) error {
+	err := s.blogRps.Approve(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("blogRps.Count - %w", err)
+		return fmt.Errorf("blogRps.Approve - %w", err)
 	}
+	repository.AfterCommit(ctx, s.totalCount.invalidate)
+	return nil
+}
 
-	blogs, err := s.blogRps.GetAll(ctx, limit, offset)
+// Reject is a method of BlogService that refuses a blog waiting for review.
+// The reason is stored on the blog, so its author sees it next to the rejected status.
+func (s *BlogService) Reject(ctx context.Context, id uuid.UUID, reason string) error {
+	err := s.blogRps.Reject(ctx, id, reason)
+	if err != nil {
+		return fmt.Errorf("blogRps.Reject - %w", err)
+	}
+	return nil
+}
+
+// GetPendingReview is a method of BlogService that calls GetPendingReview method of Repository
+func (s *BlogService) GetPendingReview(ctx context.Context, limit, offset int) ([]*model.Blog, error) {
+	blogs, err := s.blogRps.GetPendingReview(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("blogRps.GetPendingReview - %w", err)
+	}
+	return blogs, nil
+}
+
+// IncrementViews is a method of BlogService that calls IncrementViews method of Repository
+func (s *BlogService) IncrementViews(ctx context.Context, id uuid.UUID) (int, error) {
+	views, err := s.blogRps.IncrementViews(ctx, id)
+	if err != nil {
+		return 0, fmt.Errorf("blogRps.IncrementViews - %w", err)
+	}
+	return views, nil
+}
+
+// GetPopular is a method of BlogService that calls GetPopular method of Repository
+func (s *BlogService) GetPopular(ctx context.Context, limit int) ([]*model.Blog, error) {
+	blogs, err := s.blogRps.GetPopular(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("blogRps.GetPopular - %w", err)
+	}
+	return blogs, nil
+}
+
+// AddRecentView is a method of BlogService that records a blog in the user's recently viewed list
+func (s *BlogService) AddRecentView(ctx context.Context, userID, blogID uuid.UUID) error {
+	err := s.blogRps.AddRecentView(ctx, userID, blogID, constants.RecentViewsLimit)
+	if err != nil {
+		return fmt.Errorf("blogRps.AddRecentView - %w", err)
+	}
+	return nil
+}
+
+// GetRecentViews is a method of BlogService that calls GetRecentViews method of Repository
+func (s *BlogService) GetRecentViews(ctx context.Context, userID uuid.UUID) ([]*model.Blog, error) {
+	blogs, err := s.blogRps.GetRecentViews(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("blogRps.GetRecentViews - %w", err)
+	}
+	return blogs, nil
+}
+
+// GetSiblings is a method of BlogService that calls GetSiblings method of Repository
+func (s *BlogService) GetSiblings(ctx context.Context, blog *model.Blog, authorOnly bool) (*model.BlogSiblings, error) {
+	siblings, err := s.blogRps.GetSiblings(ctx, blog, authorOnly)
+	if err != nil {
+		return nil, fmt.Errorf("blogRps.GetSiblings - %w", err)
+	}
+	return siblings, nil
+}
+
+// GetAll is a method of BlogService that calls GetAll method of Repository.
+// Only the total of the unfiltered list is cached and estimated, a filtered total is always counted exactly.
+func (s *BlogService) GetAll(ctx context.Context, filter model.BlogFilter, limit, offset int) (*model.BlogListResponse, error) {
+	var count int
+	var estimated bool
+	var err error
+	if filter.IsZero() {
+		count, estimated, err = s.countAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		count, err = s.blogRps.Count(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("blogRps.Count - %w", err)
+		}
+	}
+
+	blogs, err := s.blogRps.GetAll(ctx, filter, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("blogRps.GetAll - %w", err)
 	}
 
-	return &model.BlogListResponse{
-		Blogs: blogs,
-		Count: count,
-	}, nil
+	resp := newBlogListResponse(blogs, count, limit, offset)
+	resp.CountIsEstimate = estimated
+	return resp, nil
+}
+
+// StreamAll is a method of BlogService that calls StreamAll method of Repository, passing every published blog to fn
+func (s *BlogService) StreamAll(ctx context.Context, filter model.BlogFilter, fn func(*model.Blog) error) error {
+	if err := s.blogRps.StreamAll(ctx, filter, fn); err != nil {
+		return fmt.Errorf("blogRps.StreamAll - %w", err)
+	}
+	return nil
 }
 
-// GetByUserID is a method of BlogService that calls GetByUserID method of Repository
-func (s *BlogService) GetByUserID(ctx context.Context, id uuid.UUID) ([]*model.Blog, error) {
-	blogs, err := s.blogRps.GetByUserID(ctx, id)
+// StreamByUserID is a method of BlogService that calls StreamByUserID method of Repository, passing every blog of the
+// user, drafts included, to fn
+func (s *BlogService) StreamByUserID(ctx context.Context, id uuid.UUID, fn func(*model.Blog) error) error {
+	if err := s.blogRps.StreamByUserID(ctx, id, fn); err != nil {
+		return fmt.Errorf("blogRps.StreamByUserID - %w", err)
+	}
+	return nil
+}
+
+// Count is a method of BlogService that returns the number of published blogs and whether the number is an estimate
+func (s *BlogService) Count(ctx context.Context) (*model.BlogCountResponse, error) {
+	count, estimated, err := s.countAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &model.BlogCountResponse{Count: count, CountIsEstimate: estimated}, nil
+}
+
+// CountByUserID is a method of BlogService that calls CountByUserID method of Repository
+func (s *BlogService) CountByUserID(ctx context.Context, id uuid.UUID, publishedOnly bool) (*model.BlogCountResponse, error) {
+	count, err := s.blogRps.CountByUserID(ctx, id, publishedOnly)
+	if err != nil {
+		return nil, fmt.Errorf("blogRps.CountByUserID - %w", err)
+	}
+	return &model.BlogCountResponse{Count: count}, nil
+}
+
+// countAll returns the number of published blogs, estimated when the count estimate is on and the table is large enough
+// The total is cached for a short time, see SetCountCacheTTL.
+func (s *BlogService) countAll(ctx context.Context) (count int, estimated bool, err error) {
+	if count, estimated, ok := s.totalCount.get(); ok {
+		return count, estimated, nil
+	}
+	if s.estimateAbove > 0 {
+		estimate, err := s.blogRps.EstimateCount(ctx)
+		if err != nil {
+			return 0, false, fmt.Errorf("blogRps.EstimateCount - %w", err)
+		}
+		if estimate > s.estimateAbove {
+			s.totalCount.set(estimate, true)
+			return estimate, true, nil
+		}
+	}
+	count, err = s.blogRps.Count(ctx, model.BlogFilter{})
+	if err != nil {
+		return 0, false, fmt.Errorf("blogRps.Count - %w", err)
+	}
+	s.totalCount.set(count, false)
+	return count, false, nil
+}
+
+// GetByUserID is a method of BlogService that calls CountByUserID and GetByUserID methods of Repository
+func (s *BlogService) GetByUserID(ctx context.Context, id uuid.UUID, publishedOnly bool, limit, offset int) (*model.BlogListResponse, error) {
+	count, err := s.blogRps.CountByUserID(ctx, id, publishedOnly)
+	if err != nil {
+		return nil, fmt.Errorf("blogRps.CountByUserID - %w", err)
+	}
+
+	blogs, err := s.blogRps.GetByUserID(ctx, id, publishedOnly, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("blogRps.GetByUserID - %w", err)
 	}
-	return blogs, nil
+
+	return newBlogListResponse(blogs, count, limit, offset), nil
+}
+
+// IsBlogOwner is a method of BlogService that calls IsBlogOwner method of Repository
+func (s *BlogService) IsBlogOwner(ctx context.Context, blogID, userID uuid.UUID) (bool, error) {
+	owner, err := s.blogRps.IsBlogOwner(ctx, blogID, userID)
+	if err != nil {
+		return false, fmt.Errorf("blogRps.IsBlogOwner - %w", err)
+	}
+	return owner, nil
+}
+
+// GetTopTags is a method of BlogService that returns the tags the user uses most across their published blogs
+func (s *BlogService) GetTopTags(ctx context.Context, userID uuid.UUID, limit int) ([]*model.TagCount, error) {
+	tags, err := s.blogRps.GetTopTagsByUserID(ctx, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("blogRps.GetTopTagsByUserID - %w", err)
+	}
+	return tags, nil
+}
+
+// GetByTag is a method of BlogService that calls GetByTag method of Repository
+func (s *BlogService) GetByTag(ctx context.Context, tag string, limit, offset int) (*model.BlogListResponse, error) {
+	tag = normalizeTag(tag)
+	count, err := s.blogRps.CountByTag(ctx, tag)
+	if err != nil {
+		return nil, fmt.Errorf("blogRps.CountByTag - %w", err)
+	}
+
+	blogs, err := s.blogRps.GetByTag(ctx, tag, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("blogRps.GetByTag - %w", err)
+	}
+
+	return newBlogListResponse(blogs, count, limit, offset), nil
+}
+
+// newBlogListResponse wraps a page of blogs with the paging metadata computed from the total count.
+// An empty page is an empty slice, so it is sent as [] rather than null.
+func newBlogListResponse(blogs []*model.Blog, count, limit, offset int) *model.BlogListResponse {
+	if blogs == nil {
+		blogs = []*model.Blog{}
+	}
+	resp := &model.BlogListResponse{Blogs: blogs, Count: count, Limit: limit, Offset: offset}
+	if limit > 0 {
+		resp.Page = offset/limit + 1
+		resp.TotalPages = (count + limit - 1) / limit
+	}
+	return resp
+}
+
+// NormalizeTags lowercases and trims tags, dropping empty and duplicated ones while keeping their order
+func NormalizeTags(tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+	seen := make(map[string]struct{}, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = normalizeTag(tag)
+		if tag == "" {
+			continue
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
 }
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/artnikel/blogapi/internal/model"
+	"github.com/google/uuid"
+)
+
+// CommentRepository is an interface that contains methods on comments
+type CommentRepository interface {
+	CreateComment(ctx context.Context, comment *model.Comment) error
+	GetComment(ctx context.Context, id uuid.UUID) (*model.Comment, error)
+	CountCommentsByBlogID(ctx context.Context, blogID uuid.UUID) (int, error)
+	GetCommentsByBlogID(ctx context.Context, blogID uuid.UUID, limit, offset int) ([]*model.Comment, error)
+	StreamCommentsByUserID(ctx context.Context, userID uuid.UUID, fn func(*model.Comment) error) error
+	DeleteComment(ctx context.Context, id uuid.UUID) error
+	AddActivity(ctx context.Context, activity *model.Activity) error
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// CommentService contains CommentRepository interface
+type CommentService struct {
+	commentRps CommentRepository
+}
+
+// NewCommentService accepts CommentRepository object and returns an object of type *CommentService
+func NewCommentService(commentRps CommentRepository) *CommentService {
+	return &CommentService{commentRps: commentRps}
+}
+
+// Create is a method of CommentService that calls CreateComment method of Repository and adds the comment to the
+// activity log of the user of the request
+func (s *CommentService) Create(ctx context.Context, comment *model.Comment) error {
+	return s.commentRps.InTx(ctx, func(ctx context.Context) error {
+		err := s.commentRps.CreateComment(ctx, comment)
+		if err != nil {
+			return fmt.Errorf("commentRps.CreateComment - %w", err)
+		}
+		return recordActivity(ctx, s.commentRps, model.ActivityCommentCreated, comment.CommentID)
+	})
+}
+
+// Get is a method of CommentService that calls GetComment method of Repository
+func (s *CommentService) Get(ctx context.Context, id uuid.UUID) (*model.Comment, error) {
+	comment, err := s.commentRps.GetComment(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("commentRps.GetComment - %w", err)
+	}
+	return comment, nil
+}
+
+// GetByBlogID is a method of CommentService that returns a page of comments of a certain blog
+func (s *CommentService) GetByBlogID(ctx context.Context, blogID uuid.UUID, limit, offset int) (*model.CommentListResponse, error) {
+	count, err := s.commentRps.CountCommentsByBlogID(ctx, blogID)
+	if err != nil {
+		return nil, fmt.Errorf("commentRps.CountCommentsByBlogID - %w", err)
+	}
+
+	comments, err := s.commentRps.GetCommentsByBlogID(ctx, blogID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("commentRps.GetCommentsByBlogID - %w", err)
+	}
+
+	return &model.CommentListResponse{
+		Comments: comments,
+		Count:    count,
+	}, nil
+}
+
+// StreamByUserID is a method of CommentService that calls StreamCommentsByUserID method of Repository, passing every
+// comment of the user to fn
+func (s *CommentService) StreamByUserID(ctx context.Context, userID uuid.UUID, fn func(*model.Comment) error) error {
+	if err := s.commentRps.StreamCommentsByUserID(ctx, userID, fn); err != nil {
+		return fmt.Errorf("commentRps.StreamCommentsByUserID - %w", err)
+	}
+	return nil
+}
+
+// Delete is a method of CommentService that calls DeleteComment method of Repository and adds the deletion to the
+// activity log of the user of the request
+func (s *CommentService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.commentRps.InTx(ctx, func(ctx context.Context) error {
+		err := s.commentRps.DeleteComment(ctx, id)
+		if err != nil {
+			return fmt.Errorf("commentRps.DeleteComment - %w", err)
+		}
+		return recordActivity(ctx, s.commentRps, model.ActivityCommentDeleted, id)
+	})
+}
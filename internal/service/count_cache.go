@@ -0,0 +1,50 @@
+package service
+
+import (
+	"sync"
+	"time"
+)
+
+// countCache keeps the total of published blogs for a short time, so listing pages doesn't run a COUNT per request.
+// Writes of this instance invalidate it right away, writes of other instances show up once the ttl expires.
+type countCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu        sync.Mutex
+	count     int
+	estimated bool
+	expires   time.Time
+}
+
+func newCountCache(ttl time.Duration) *countCache {
+	return &countCache{ttl: ttl, now: time.Now}
+}
+
+// get returns the cached total and whether it is an estimate, ok is false when nothing fresh is cached
+func (c *countCache) get() (count int, estimated, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.now().Before(c.expires) {
+		return 0, false, false
+	}
+	return c.count, c.estimated, true
+}
+
+// set caches the total for the ttl, a non-positive ttl caches nothing
+func (c *countCache) set(count int, estimated bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ttl <= 0 {
+		return
+	}
+	c.count, c.estimated = count, estimated
+	c.expires = c.now().Add(c.ttl)
+}
+
+// invalidate drops the cached total after a write that may have changed it
+func (c *countCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.expires = time.Time{}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+
+	"github.com/artnikel/blogapi/internal/model"
+)
+
+// DiagnosticsRepository is an interface that contains methods describing the database
+type DiagnosticsRepository interface {
+	ServerVersion(ctx context.Context) (string, error)
+	MigrationVersion(ctx context.Context) (int, error)
+	PoolStats() model.PoolStats
+	QueryStats() map[string]model.QueryStats
+}
+
+// CacheStatsReader is an interface of a blog cache reporting its counters
+type CacheStatsReader interface {
+	Stats() model.CacheStats
+}
+
+// DiagnosticsService contains DiagnosticsRepository interface
+type DiagnosticsService struct {
+	diagRps DiagnosticsRepository
+	cache   CacheStatsReader
+}
+
+// NewDiagnosticsService accepts DiagnosticsRepository object and returns an object of type *DiagnosticsService
+func NewDiagnosticsService(diagRps DiagnosticsRepository) *DiagnosticsService {
+	return &DiagnosticsService{diagRps: diagRps}
+}
+
+// SetCache adds the counters of the blog cache to the diagnostics
+func (s *DiagnosticsService) SetCache(cache CacheStatsReader) {
+	s.cache = cache
+}
+
+// Diagnostics is a method of DiagnosticsService that assembles the database state and the app build info
+func (s *DiagnosticsService) Diagnostics(ctx context.Context) (*model.Diagnostics, error) {
+	version, err := s.diagRps.ServerVersion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("diagRps.ServerVersion - %w", err)
+	}
+	migration, err := s.diagRps.MigrationVersion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("diagRps.MigrationVersion - %w", err)
+	}
+	diagnostics := &model.Diagnostics{
+		Database: model.DatabaseDiagnostics{
+			ServerVersion:    version,
+			MigrationVersion: migration,
+			Pool:             s.diagRps.PoolStats(),
+			Queries:          s.diagRps.QueryStats(),
+		},
+		Build: buildInfo(),
+	}
+	if s.cache != nil {
+		stats := s.cache.Stats()
+		diagnostics.Cache = &stats
+	}
+	return diagnostics, nil
+}
+
+// buildInfo reads the module version and VCS details stamped into the binary by the go tool
+func buildInfo() model.BuildInfo {
+	build := model.BuildInfo{GoVersion: runtime.Version()}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return build
+	}
+	build.Version = info.Main.Version
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			build.Revision = setting.Value
+		case "vcs.time":
+			build.BuildTime = setting.Value
+		case "vcs.modified":
+			build.Modified = setting.Value == "true"
+		}
+	}
+	return build
+}
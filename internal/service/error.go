@@ -0,0 +1,41 @@
+// Package service error.go contains custom errors
+package service
+
+import "fmt"
+
+// ErrAccountLocked means that the account is temporarily locked after too many failed logins
+var ErrAccountLocked = fmt.Errorf("account is temporarily locked")
+
+// ErrInvalidResetToken means that the password reset token is malformed, expired or unknown
+var ErrInvalidResetToken = fmt.Errorf("invalid password reset token")
+
+// ErrResetTokenUsed means that the password reset token was already used
+var ErrResetTokenUsed = fmt.Errorf("password reset token already used")
+
+// ErrPasswordResetDisabled means that no mailer is configured to send password reset tokens
+var ErrPasswordResetDisabled = fmt.Errorf("password reset is not configured")
+
+// ErrLastAdmin means that the change would leave the service without any admin
+var ErrLastAdmin = fmt.Errorf("cannot demote the last admin")
+
+// ErrImpersonateAdmin means that an admin tried to impersonate another admin
+var ErrImpersonateAdmin = fmt.Errorf("cannot impersonate an admin")
+
+// ErrSignupDisabled means that the public signup is turned off
+var ErrSignupDisabled = fmt.Errorf("signups are disabled")
+
+// ErrInvalidInvite means that the invite code is missing, unknown or already used
+var ErrInvalidInvite = fmt.Errorf("invalid invite code")
+
+// ErrWrongPassword means that the given current password doesn't match the stored one
+var ErrWrongPassword = fmt.Errorf("wrong password")
+
+// ErrIdempotencyKeyReused means that the idempotency key was already used for a request with a different body
+var ErrIdempotencyKeyReused = fmt.Errorf("idempotency key was used for a different request")
+
+// ErrIdempotencyKeyPending means that the blog of the first request with the idempotency key is not available,
+// the request may still be running
+var ErrIdempotencyKeyPending = fmt.Errorf("request with this idempotency key has not completed")
+
+// ErrDraftLimitExceeded means that the user already keeps as many drafts as the draft limit allows
+var ErrDraftLimitExceeded = fmt.Errorf("draft limit exceeded")
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/artnikel/blogapi/internal/model"
+)
+
+// Media check modes of blog content
+const (
+	// MediaCheckOff skips checking media references
+	MediaCheckOff = "off"
+	// MediaCheckWarn saves the blog and reports the disallowed references as warnings
+	MediaCheckWarn = "warn"
+	// MediaCheckReject refuses to save a blog with disallowed references
+	MediaCheckReject = "reject"
+)
+
+// disallowedSchemes can run code or smuggle content in the reader's browser and are never allowed
+var disallowedSchemes = map[string]struct{}{
+	"javascript": {},
+	"data":       {},
+	"vbscript":   {},
+}
+
+// mediaReference matches Markdown links and images, Markdown autolinks and HTML src and href attributes
+var mediaReference = regexp.MustCompile(`(?i)\]\(\s*<?([^)\s>]+)|<([a-z][a-z0-9+.-]*:[^>\s]+)>|\b(?:src|href)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+))`)
+
+// urlNoise are characters browsers drop from URLs, so "java\tscript:" must be treated as "javascript:"
+var urlNoise = regexp.MustCompile(`[\x00-\x20\x7f]+`)
+
+// mediaPolicy decides which image and link URLs a blog may reference
+type mediaPolicy struct {
+	mode         string
+	allowedHosts map[string]struct{}
+}
+
+// check returns an issue for every disallowed reference found in the content of the blog
+func (p mediaPolicy) check(blog *model.Blog) []model.MediaIssue {
+	if p.mode == "" || p.mode == MediaCheckOff {
+		return nil
+	}
+	var issues []model.MediaIssue
+	for _, match := range mediaReference.FindAllStringSubmatch(blog.Content, -1) {
+		ref := firstNonEmpty(match[1:])
+		if reason := p.verify(ref); reason != "" {
+			issues = append(issues, model.MediaIssue{Field: "content", URL: ref, Reason: reason})
+		}
+	}
+	return issues
+}
+
+// verify returns why the reference is disallowed, or an empty string if it is fine
+func (p mediaPolicy) verify(ref string) string {
+	cleaned := urlNoise.ReplaceAllString(ref, "")
+	if i := strings.Index(cleaned, ":"); i > 0 {
+		if _, ok := disallowedSchemes[strings.ToLower(cleaned[:i])]; ok {
+			return "disallowed scheme " + strings.ToLower(cleaned[:i])
+		}
+	}
+	if len(p.allowedHosts) == 0 {
+		return ""
+	}
+	parsed, err := url.Parse(cleaned)
+	if err != nil {
+		return "malformed url"
+	}
+	if parsed.Host == "" {
+		return ""
+	}
+	if _, ok := p.allowedHosts[strings.ToLower(parsed.Hostname())]; !ok {
+		return "host " + parsed.Hostname() + " is not allowed"
+	}
+	return ""
+}
+
+func firstNonEmpty(values []string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// MediaError is returned when a blog references disallowed media and the check mode is MediaCheckReject
+type MediaError struct {
+	Issues []model.MediaIssue
+}
+
+func (e *MediaError) Error() string {
+	return fmt.Sprintf("blog content has %d disallowed media references", len(e.Issues))
+}
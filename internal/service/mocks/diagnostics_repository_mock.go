@@ -0,0 +1,237 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/artnikel/blogapi/internal/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockDiagnosticsRepository creates a new instance of MockDiagnosticsRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDiagnosticsRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDiagnosticsRepository {
+	mock := &MockDiagnosticsRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDiagnosticsRepository is an autogenerated mock type for the DiagnosticsRepository type
+type MockDiagnosticsRepository struct {
+	mock.Mock
+}
+
+type MockDiagnosticsRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDiagnosticsRepository) EXPECT() *MockDiagnosticsRepository_Expecter {
+	return &MockDiagnosticsRepository_Expecter{mock: &_m.Mock}
+}
+
+// MigrationVersion provides a mock function for the type MockDiagnosticsRepository
+func (_mock *MockDiagnosticsRepository) MigrationVersion(ctx context.Context) (int, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MigrationVersion")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDiagnosticsRepository_MigrationVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MigrationVersion'
+type MockDiagnosticsRepository_MigrationVersion_Call struct {
+	*mock.Call
+}
+
+// MigrationVersion is a helper method to define mock.On call
+//   - ctx
+func (_e *MockDiagnosticsRepository_Expecter) MigrationVersion(ctx interface{}) *MockDiagnosticsRepository_MigrationVersion_Call {
+	return &MockDiagnosticsRepository_MigrationVersion_Call{Call: _e.mock.On("MigrationVersion", ctx)}
+}
+
+func (_c *MockDiagnosticsRepository_MigrationVersion_Call) Run(run func(ctx context.Context)) *MockDiagnosticsRepository_MigrationVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockDiagnosticsRepository_MigrationVersion_Call) Return(n int, err error) *MockDiagnosticsRepository_MigrationVersion_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockDiagnosticsRepository_MigrationVersion_Call) RunAndReturn(run func(ctx context.Context) (int, error)) *MockDiagnosticsRepository_MigrationVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PoolStats provides a mock function for the type MockDiagnosticsRepository
+func (_mock *MockDiagnosticsRepository) PoolStats() model.PoolStats {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PoolStats")
+	}
+
+	var r0 model.PoolStats
+	if returnFunc, ok := ret.Get(0).(func() model.PoolStats); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(model.PoolStats)
+	}
+	return r0
+}
+
+// MockDiagnosticsRepository_PoolStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PoolStats'
+type MockDiagnosticsRepository_PoolStats_Call struct {
+	*mock.Call
+}
+
+// PoolStats is a helper method to define mock.On call
+func (_e *MockDiagnosticsRepository_Expecter) PoolStats() *MockDiagnosticsRepository_PoolStats_Call {
+	return &MockDiagnosticsRepository_PoolStats_Call{Call: _e.mock.On("PoolStats")}
+}
+
+func (_c *MockDiagnosticsRepository_PoolStats_Call) Run(run func()) *MockDiagnosticsRepository_PoolStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockDiagnosticsRepository_PoolStats_Call) Return(poolStats model.PoolStats) *MockDiagnosticsRepository_PoolStats_Call {
+	_c.Call.Return(poolStats)
+	return _c
+}
+
+func (_c *MockDiagnosticsRepository_PoolStats_Call) RunAndReturn(run func() model.PoolStats) *MockDiagnosticsRepository_PoolStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QueryStats provides a mock function for the type MockDiagnosticsRepository
+func (_mock *MockDiagnosticsRepository) QueryStats() map[string]model.QueryStats {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryStats")
+	}
+
+	var r0 map[string]model.QueryStats
+	if returnFunc, ok := ret.Get(0).(func() map[string]model.QueryStats); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]model.QueryStats)
+		}
+	}
+	return r0
+}
+
+// MockDiagnosticsRepository_QueryStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryStats'
+type MockDiagnosticsRepository_QueryStats_Call struct {
+	*mock.Call
+}
+
+// QueryStats is a helper method to define mock.On call
+func (_e *MockDiagnosticsRepository_Expecter) QueryStats() *MockDiagnosticsRepository_QueryStats_Call {
+	return &MockDiagnosticsRepository_QueryStats_Call{Call: _e.mock.On("QueryStats")}
+}
+
+func (_c *MockDiagnosticsRepository_QueryStats_Call) Run(run func()) *MockDiagnosticsRepository_QueryStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockDiagnosticsRepository_QueryStats_Call) Return(m map[string]model.QueryStats) *MockDiagnosticsRepository_QueryStats_Call {
+	_c.Call.Return(m)
+	return _c
+}
+
+func (_c *MockDiagnosticsRepository_QueryStats_Call) RunAndReturn(run func() map[string]model.QueryStats) *MockDiagnosticsRepository_QueryStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ServerVersion provides a mock function for the type MockDiagnosticsRepository
+func (_mock *MockDiagnosticsRepository) ServerVersion(ctx context.Context) (string, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ServerVersion")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (string, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDiagnosticsRepository_ServerVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ServerVersion'
+type MockDiagnosticsRepository_ServerVersion_Call struct {
+	*mock.Call
+}
+
+// ServerVersion is a helper method to define mock.On call
+//   - ctx
+func (_e *MockDiagnosticsRepository_Expecter) ServerVersion(ctx interface{}) *MockDiagnosticsRepository_ServerVersion_Call {
+	return &MockDiagnosticsRepository_ServerVersion_Call{Call: _e.mock.On("ServerVersion", ctx)}
+}
+
+func (_c *MockDiagnosticsRepository_ServerVersion_Call) Run(run func(ctx context.Context)) *MockDiagnosticsRepository_ServerVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockDiagnosticsRepository_ServerVersion_Call) Return(s string, err error) *MockDiagnosticsRepository_ServerVersion_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockDiagnosticsRepository_ServerVersion_Call) RunAndReturn(run func(ctx context.Context) (string, error)) *MockDiagnosticsRepository_ServerVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -6,6 +6,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/artnikel/blogapi/internal/model"
 	"github.com/google/uuid"
@@ -39,17 +40,201 @@ func (_m *MockUserRepository) EXPECT() *MockUserRepository_Expecter {
 	return &MockUserRepository_Expecter{mock: &_m.Mock}
 }
 
+// AddActivity provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) AddActivity(ctx context.Context, activity *model.Activity) error {
+	ret := _mock.Called(ctx, activity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddActivity")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Activity) error); ok {
+		r0 = returnFunc(ctx, activity)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_AddActivity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddActivity'
+type MockUserRepository_AddActivity_Call struct {
+	*mock.Call
+}
+
+// AddActivity is a helper method to define mock.On call
+//   - ctx
+//   - activity
+func (_e *MockUserRepository_Expecter) AddActivity(ctx interface{}, activity interface{}) *MockUserRepository_AddActivity_Call {
+	return &MockUserRepository_AddActivity_Call{Call: _e.mock.On("AddActivity", ctx, activity)}
+}
+
+func (_c *MockUserRepository_AddActivity_Call) Run(run func(ctx context.Context, activity *model.Activity)) *MockUserRepository_AddActivity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Activity))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_AddActivity_Call) Return(err error) *MockUserRepository_AddActivity_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_AddActivity_Call) RunAndReturn(run func(ctx context.Context, activity *model.Activity) error) *MockUserRepository_AddActivity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddAuditEntry provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) AddAuditEntry(ctx context.Context, entry *model.AuditEntry) error {
+	ret := _mock.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddAuditEntry")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.AuditEntry) error); ok {
+		r0 = returnFunc(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_AddAuditEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddAuditEntry'
+type MockUserRepository_AddAuditEntry_Call struct {
+	*mock.Call
+}
+
+// AddAuditEntry is a helper method to define mock.On call
+//   - ctx
+//   - entry
+func (_e *MockUserRepository_Expecter) AddAuditEntry(ctx interface{}, entry interface{}) *MockUserRepository_AddAuditEntry_Call {
+	return &MockUserRepository_AddAuditEntry_Call{Call: _e.mock.On("AddAuditEntry", ctx, entry)}
+}
+
+func (_c *MockUserRepository_AddAuditEntry_Call) Run(run func(ctx context.Context, entry *model.AuditEntry)) *MockUserRepository_AddAuditEntry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.AuditEntry))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_AddAuditEntry_Call) Return(err error) *MockUserRepository_AddAuditEntry_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_AddAuditEntry_Call) RunAndReturn(run func(ctx context.Context, entry *model.AuditEntry) error) *MockUserRepository_AddAuditEntry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddInvite provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) AddInvite(ctx context.Context, invite *model.Invite) error {
+	ret := _mock.Called(ctx, invite)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddInvite")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Invite) error); ok {
+		r0 = returnFunc(ctx, invite)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_AddInvite_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddInvite'
+type MockUserRepository_AddInvite_Call struct {
+	*mock.Call
+}
+
+// AddInvite is a helper method to define mock.On call
+//   - ctx
+//   - invite
+func (_e *MockUserRepository_Expecter) AddInvite(ctx interface{}, invite interface{}) *MockUserRepository_AddInvite_Call {
+	return &MockUserRepository_AddInvite_Call{Call: _e.mock.On("AddInvite", ctx, invite)}
+}
+
+func (_c *MockUserRepository_AddInvite_Call) Run(run func(ctx context.Context, invite *model.Invite)) *MockUserRepository_AddInvite_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Invite))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_AddInvite_Call) Return(err error) *MockUserRepository_AddInvite_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_AddInvite_Call) RunAndReturn(run func(ctx context.Context, invite *model.Invite) error) *MockUserRepository_AddInvite_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddPasswordReset provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) AddPasswordReset(ctx context.Context, reset *model.PasswordReset) error {
+	ret := _mock.Called(ctx, reset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddPasswordReset")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.PasswordReset) error); ok {
+		r0 = returnFunc(ctx, reset)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_AddPasswordReset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddPasswordReset'
+type MockUserRepository_AddPasswordReset_Call struct {
+	*mock.Call
+}
+
+// AddPasswordReset is a helper method to define mock.On call
+//   - ctx
+//   - reset
+func (_e *MockUserRepository_Expecter) AddPasswordReset(ctx interface{}, reset interface{}) *MockUserRepository_AddPasswordReset_Call {
+	return &MockUserRepository_AddPasswordReset_Call{Call: _e.mock.On("AddPasswordReset", ctx, reset)}
+}
+
+func (_c *MockUserRepository_AddPasswordReset_Call) Run(run func(ctx context.Context, reset *model.PasswordReset)) *MockUserRepository_AddPasswordReset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.PasswordReset))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_AddPasswordReset_Call) Return(err error) *MockUserRepository_AddPasswordReset_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_AddPasswordReset_Call) RunAndReturn(run func(ctx context.Context, reset *model.PasswordReset) error) *MockUserRepository_AddPasswordReset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddRefreshToken provides a mock function for the type MockUserRepository
-func (_mock *MockUserRepository) AddRefreshToken(ctx context.Context, user *model.User) error {
-	ret := _mock.Called(ctx, user)
+func (_mock *MockUserRepository) AddRefreshToken(ctx context.Context, token *model.RefreshToken) error {
+	ret := _mock.Called(ctx, token)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AddRefreshToken")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.User) error); ok {
-		r0 = returnFunc(ctx, user)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.RefreshToken) error); ok {
+		r0 = returnFunc(ctx, token)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -63,14 +248,14 @@ type MockUserRepository_AddRefreshToken_Call struct {
 
 // AddRefreshToken is a helper method to define mock.On call
 //   - ctx
-//   - user
-func (_e *MockUserRepository_Expecter) AddRefreshToken(ctx interface{}, user interface{}) *MockUserRepository_AddRefreshToken_Call {
-	return &MockUserRepository_AddRefreshToken_Call{Call: _e.mock.On("AddRefreshToken", ctx, user)}
+//   - token
+func (_e *MockUserRepository_Expecter) AddRefreshToken(ctx interface{}, token interface{}) *MockUserRepository_AddRefreshToken_Call {
+	return &MockUserRepository_AddRefreshToken_Call{Call: _e.mock.On("AddRefreshToken", ctx, token)}
 }
 
-func (_c *MockUserRepository_AddRefreshToken_Call) Run(run func(ctx context.Context, user *model.User)) *MockUserRepository_AddRefreshToken_Call {
+func (_c *MockUserRepository_AddRefreshToken_Call) Run(run func(ctx context.Context, token *model.RefreshToken)) *MockUserRepository_AddRefreshToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*model.User))
+		run(args[0].(context.Context), args[1].(*model.RefreshToken))
 	})
 	return _c
 }
@@ -80,17 +265,17 @@ func (_c *MockUserRepository_AddRefreshToken_Call) Return(err error) *MockUserRe
 	return _c
 }
 
-func (_c *MockUserRepository_AddRefreshToken_Call) RunAndReturn(run func(ctx context.Context, user *model.User) error) *MockUserRepository_AddRefreshToken_Call {
+func (_c *MockUserRepository_AddRefreshToken_Call) RunAndReturn(run func(ctx context.Context, token *model.RefreshToken) error) *MockUserRepository_AddRefreshToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteUserByID provides a mock function for the type MockUserRepository
-func (_mock *MockUserRepository) DeleteUserByID(ctx context.Context, id uuid.UUID) error {
+// BumpTokenVersion provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) BumpTokenVersion(ctx context.Context, id uuid.UUID) error {
 	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteUserByID")
+		panic("no return value specified for BumpTokenVersion")
 	}
 
 	var r0 error
@@ -102,123 +287,109 @@ func (_mock *MockUserRepository) DeleteUserByID(ctx context.Context, id uuid.UUI
 	return r0
 }
 
-// MockUserRepository_DeleteUserByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUserByID'
-type MockUserRepository_DeleteUserByID_Call struct {
+// MockUserRepository_BumpTokenVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BumpTokenVersion'
+type MockUserRepository_BumpTokenVersion_Call struct {
 	*mock.Call
 }
 
-// DeleteUserByID is a helper method to define mock.On call
+// BumpTokenVersion is a helper method to define mock.On call
 //   - ctx
 //   - id
-func (_e *MockUserRepository_Expecter) DeleteUserByID(ctx interface{}, id interface{}) *MockUserRepository_DeleteUserByID_Call {
-	return &MockUserRepository_DeleteUserByID_Call{Call: _e.mock.On("DeleteUserByID", ctx, id)}
+func (_e *MockUserRepository_Expecter) BumpTokenVersion(ctx interface{}, id interface{}) *MockUserRepository_BumpTokenVersion_Call {
+	return &MockUserRepository_BumpTokenVersion_Call{Call: _e.mock.On("BumpTokenVersion", ctx, id)}
 }
 
-func (_c *MockUserRepository_DeleteUserByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockUserRepository_DeleteUserByID_Call {
+func (_c *MockUserRepository_BumpTokenVersion_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockUserRepository_BumpTokenVersion_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockUserRepository_DeleteUserByID_Call) Return(err error) *MockUserRepository_DeleteUserByID_Call {
+func (_c *MockUserRepository_BumpTokenVersion_Call) Return(err error) *MockUserRepository_BumpTokenVersion_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockUserRepository_DeleteUserByID_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *MockUserRepository_DeleteUserByID_Call {
+func (_c *MockUserRepository_BumpTokenVersion_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *MockUserRepository_BumpTokenVersion_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetDataByUsername provides a mock function for the type MockUserRepository
-func (_mock *MockUserRepository) GetDataByUsername(ctx context.Context, username string) (uuid.UUID, []byte, bool, error) {
-	ret := _mock.Called(ctx, username)
+// CountActivity provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) CountActivity(ctx context.Context, userID uuid.UUID) (int, error) {
+	ret := _mock.Called(ctx, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetDataByUsername")
+		panic("no return value specified for CountActivity")
 	}
 
-	var r0 uuid.UUID
-	var r1 []byte
-	var r2 bool
-	var r3 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (uuid.UUID, []byte, bool, error)); ok {
-		return returnFunc(ctx, username)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) uuid.UUID); ok {
-		r0 = returnFunc(ctx, username)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(uuid.UUID)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) []byte); ok {
-		r1 = returnFunc(ctx, username)
-	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).([]byte)
-		}
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int, error)); ok {
+		return returnFunc(ctx, userID)
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, string) bool); ok {
-		r2 = returnFunc(ctx, username)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) int); ok {
+		r0 = returnFunc(ctx, userID)
 	} else {
-		r2 = ret.Get(2).(bool)
+		r0 = ret.Get(0).(int)
 	}
-	if returnFunc, ok := ret.Get(3).(func(context.Context, string) error); ok {
-		r3 = returnFunc(ctx, username)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
 	} else {
-		r3 = ret.Error(3)
+		r1 = ret.Error(1)
 	}
-	return r0, r1, r2, r3
+	return r0, r1
 }
 
-// MockUserRepository_GetDataByUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDataByUsername'
-type MockUserRepository_GetDataByUsername_Call struct {
+// MockUserRepository_CountActivity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountActivity'
+type MockUserRepository_CountActivity_Call struct {
 	*mock.Call
 }
 
-// GetDataByUsername is a helper method to define mock.On call
+// CountActivity is a helper method to define mock.On call
 //   - ctx
-//   - username
-func (_e *MockUserRepository_Expecter) GetDataByUsername(ctx interface{}, username interface{}) *MockUserRepository_GetDataByUsername_Call {
-	return &MockUserRepository_GetDataByUsername_Call{Call: _e.mock.On("GetDataByUsername", ctx, username)}
+//   - userID
+func (_e *MockUserRepository_Expecter) CountActivity(ctx interface{}, userID interface{}) *MockUserRepository_CountActivity_Call {
+	return &MockUserRepository_CountActivity_Call{Call: _e.mock.On("CountActivity", ctx, userID)}
 }
 
-func (_c *MockUserRepository_GetDataByUsername_Call) Run(run func(ctx context.Context, username string)) *MockUserRepository_GetDataByUsername_Call {
+func (_c *MockUserRepository_CountActivity_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockUserRepository_CountActivity_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockUserRepository_GetDataByUsername_Call) Return(uUID uuid.UUID, bytes []byte, b bool, err error) *MockUserRepository_GetDataByUsername_Call {
-	_c.Call.Return(uUID, bytes, b, err)
+func (_c *MockUserRepository_CountActivity_Call) Return(n int, err error) *MockUserRepository_CountActivity_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockUserRepository_GetDataByUsername_Call) RunAndReturn(run func(ctx context.Context, username string) (uuid.UUID, []byte, bool, error)) *MockUserRepository_GetDataByUsername_Call {
+func (_c *MockUserRepository_CountActivity_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) (int, error)) *MockUserRepository_CountActivity_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetRefreshTokenByID provides a mock function for the type MockUserRepository
-func (_mock *MockUserRepository) GetRefreshTokenByID(ctx context.Context, id uuid.UUID) (string, error) {
+// DeleteBlogsByUserID provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) DeleteBlogsByUserID(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
 	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetRefreshTokenByID")
+		panic("no return value specified for DeleteBlogsByUserID")
 	}
 
-	var r0 string
+	var r0 []uuid.UUID
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (string, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]uuid.UUID, error)); ok {
 		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) string); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []uuid.UUID); ok {
 		r0 = returnFunc(ctx, id)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
 		r1 = returnFunc(ctx, id)
@@ -228,77 +399,1179 @@ func (_mock *MockUserRepository) GetRefreshTokenByID(ctx context.Context, id uui
 	return r0, r1
 }
 
-// MockUserRepository_GetRefreshTokenByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRefreshTokenByID'
-type MockUserRepository_GetRefreshTokenByID_Call struct {
+// MockUserRepository_DeleteBlogsByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBlogsByUserID'
+type MockUserRepository_DeleteBlogsByUserID_Call struct {
 	*mock.Call
 }
 
-// GetRefreshTokenByID is a helper method to define mock.On call
+// DeleteBlogsByUserID is a helper method to define mock.On call
 //   - ctx
 //   - id
-func (_e *MockUserRepository_Expecter) GetRefreshTokenByID(ctx interface{}, id interface{}) *MockUserRepository_GetRefreshTokenByID_Call {
-	return &MockUserRepository_GetRefreshTokenByID_Call{Call: _e.mock.On("GetRefreshTokenByID", ctx, id)}
+func (_e *MockUserRepository_Expecter) DeleteBlogsByUserID(ctx interface{}, id interface{}) *MockUserRepository_DeleteBlogsByUserID_Call {
+	return &MockUserRepository_DeleteBlogsByUserID_Call{Call: _e.mock.On("DeleteBlogsByUserID", ctx, id)}
 }
 
-func (_c *MockUserRepository_GetRefreshTokenByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockUserRepository_GetRefreshTokenByID_Call {
+func (_c *MockUserRepository_DeleteBlogsByUserID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockUserRepository_DeleteBlogsByUserID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockUserRepository_GetRefreshTokenByID_Call) Return(s string, err error) *MockUserRepository_GetRefreshTokenByID_Call {
-	_c.Call.Return(s, err)
+func (_c *MockUserRepository_DeleteBlogsByUserID_Call) Return(uUIDs []uuid.UUID, err error) *MockUserRepository_DeleteBlogsByUserID_Call {
+	_c.Call.Return(uUIDs, err)
 	return _c
 }
 
-func (_c *MockUserRepository_GetRefreshTokenByID_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (string, error)) *MockUserRepository_GetRefreshTokenByID_Call {
+func (_c *MockUserRepository_DeleteBlogsByUserID_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)) *MockUserRepository_DeleteBlogsByUserID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SignUp provides a mock function for the type MockUserRepository
-func (_mock *MockUserRepository) SignUp(ctx context.Context, user *model.User) error {
-	ret := _mock.Called(ctx, user)
+// DeleteRefreshToken provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) DeleteRefreshToken(ctx context.Context, id uuid.UUID) error {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SignUp")
+		panic("no return value specified for DeleteRefreshToken")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.User) error); ok {
-		r0 = returnFunc(ctx, user)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockUserRepository_SignUp_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SignUp'
-type MockUserRepository_SignUp_Call struct {
+// MockUserRepository_DeleteRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRefreshToken'
+type MockUserRepository_DeleteRefreshToken_Call struct {
 	*mock.Call
 }
 
-// SignUp is a helper method to define mock.On call
+// DeleteRefreshToken is a helper method to define mock.On call
 //   - ctx
-//   - user
-func (_e *MockUserRepository_Expecter) SignUp(ctx interface{}, user interface{}) *MockUserRepository_SignUp_Call {
-	return &MockUserRepository_SignUp_Call{Call: _e.mock.On("SignUp", ctx, user)}
+//   - id
+func (_e *MockUserRepository_Expecter) DeleteRefreshToken(ctx interface{}, id interface{}) *MockUserRepository_DeleteRefreshToken_Call {
+	return &MockUserRepository_DeleteRefreshToken_Call{Call: _e.mock.On("DeleteRefreshToken", ctx, id)}
 }
 
-func (_c *MockUserRepository_SignUp_Call) Run(run func(ctx context.Context, user *model.User)) *MockUserRepository_SignUp_Call {
+func (_c *MockUserRepository_DeleteRefreshToken_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockUserRepository_DeleteRefreshToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*model.User))
+		run(args[0].(context.Context), args[1].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockUserRepository_SignUp_Call) Return(err error) *MockUserRepository_SignUp_Call {
+func (_c *MockUserRepository_DeleteRefreshToken_Call) Return(err error) *MockUserRepository_DeleteRefreshToken_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockUserRepository_SignUp_Call) RunAndReturn(run func(ctx context.Context, user *model.User) error) *MockUserRepository_SignUp_Call {
+func (_c *MockUserRepository_DeleteRefreshToken_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *MockUserRepository_DeleteRefreshToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteRefreshTokensByUserID provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) DeleteRefreshTokensByUserID(ctx context.Context, id uuid.UUID) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRefreshTokensByUserID")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_DeleteRefreshTokensByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRefreshTokensByUserID'
+type MockUserRepository_DeleteRefreshTokensByUserID_Call struct {
+	*mock.Call
+}
+
+// DeleteRefreshTokensByUserID is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockUserRepository_Expecter) DeleteRefreshTokensByUserID(ctx interface{}, id interface{}) *MockUserRepository_DeleteRefreshTokensByUserID_Call {
+	return &MockUserRepository_DeleteRefreshTokensByUserID_Call{Call: _e.mock.On("DeleteRefreshTokensByUserID", ctx, id)}
+}
+
+func (_c *MockUserRepository_DeleteRefreshTokensByUserID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockUserRepository_DeleteRefreshTokensByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_DeleteRefreshTokensByUserID_Call) Return(err error) *MockUserRepository_DeleteRefreshTokensByUserID_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_DeleteRefreshTokensByUserID_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *MockUserRepository_DeleteRefreshTokensByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUserByID provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) DeleteUserByID(ctx context.Context, id uuid.UUID) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUserByID")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_DeleteUserByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUserByID'
+type MockUserRepository_DeleteUserByID_Call struct {
+	*mock.Call
+}
+
+// DeleteUserByID is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockUserRepository_Expecter) DeleteUserByID(ctx interface{}, id interface{}) *MockUserRepository_DeleteUserByID_Call {
+	return &MockUserRepository_DeleteUserByID_Call{Call: _e.mock.On("DeleteUserByID", ctx, id)}
+}
+
+func (_c *MockUserRepository_DeleteUserByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockUserRepository_DeleteUserByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_DeleteUserByID_Call) Return(err error) *MockUserRepository_DeleteUserByID_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_DeleteUserByID_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *MockUserRepository_DeleteUserByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetActivity provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetActivity(ctx context.Context, userID uuid.UUID, limit int, offset int) ([]*model.Activity, error) {
+	ret := _mock.Called(ctx, userID, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActivity")
+	}
+
+	var r0 []*model.Activity
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, int) ([]*model.Activity, error)); ok {
+		return returnFunc(ctx, userID, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, int) []*model.Activity); ok {
+		r0 = returnFunc(ctx, userID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Activity)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, int, int) error); ok {
+		r1 = returnFunc(ctx, userID, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_GetActivity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActivity'
+type MockUserRepository_GetActivity_Call struct {
+	*mock.Call
+}
+
+// GetActivity is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - limit
+//   - offset
+func (_e *MockUserRepository_Expecter) GetActivity(ctx interface{}, userID interface{}, limit interface{}, offset interface{}) *MockUserRepository_GetActivity_Call {
+	return &MockUserRepository_GetActivity_Call{Call: _e.mock.On("GetActivity", ctx, userID, limit, offset)}
+}
+
+func (_c *MockUserRepository_GetActivity_Call) Run(run func(ctx context.Context, userID uuid.UUID, limit int, offset int)) *MockUserRepository_GetActivity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetActivity_Call) Return(activitys []*model.Activity, err error) *MockUserRepository_GetActivity_Call {
+	_c.Call.Return(activitys, err)
+	return _c
+}
+
+func (_c *MockUserRepository_GetActivity_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, limit int, offset int) ([]*model.Activity, error)) *MockUserRepository_GetActivity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDataByEmail provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetDataByEmail(ctx context.Context, email string) (*model.User, error) {
+	ret := _mock.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDataByEmail")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.User, error)); ok {
+		return returnFunc(ctx, email)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = returnFunc(ctx, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_GetDataByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDataByEmail'
+type MockUserRepository_GetDataByEmail_Call struct {
+	*mock.Call
+}
+
+// GetDataByEmail is a helper method to define mock.On call
+//   - ctx
+//   - email
+func (_e *MockUserRepository_Expecter) GetDataByEmail(ctx interface{}, email interface{}) *MockUserRepository_GetDataByEmail_Call {
+	return &MockUserRepository_GetDataByEmail_Call{Call: _e.mock.On("GetDataByEmail", ctx, email)}
+}
+
+func (_c *MockUserRepository_GetDataByEmail_Call) Run(run func(ctx context.Context, email string)) *MockUserRepository_GetDataByEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetDataByEmail_Call) Return(user *model.User, err error) *MockUserRepository_GetDataByEmail_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserRepository_GetDataByEmail_Call) RunAndReturn(run func(ctx context.Context, email string) (*model.User, error)) *MockUserRepository_GetDataByEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDataByUsername provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetDataByUsername(ctx context.Context, username string) (uuid.UUID, []byte, bool, error) {
+	ret := _mock.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDataByUsername")
+	}
+
+	var r0 uuid.UUID
+	var r1 []byte
+	var r2 bool
+	var r3 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (uuid.UUID, []byte, bool, error)); ok {
+		return returnFunc(ctx, username)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) uuid.UUID); ok {
+		r0 = returnFunc(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(uuid.UUID)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) []byte); ok {
+		r1 = returnFunc(ctx, username)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string) bool); ok {
+		r2 = returnFunc(ctx, username)
+	} else {
+		r2 = ret.Get(2).(bool)
+	}
+	if returnFunc, ok := ret.Get(3).(func(context.Context, string) error); ok {
+		r3 = returnFunc(ctx, username)
+	} else {
+		r3 = ret.Error(3)
+	}
+	return r0, r1, r2, r3
+}
+
+// MockUserRepository_GetDataByUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDataByUsername'
+type MockUserRepository_GetDataByUsername_Call struct {
+	*mock.Call
+}
+
+// GetDataByUsername is a helper method to define mock.On call
+//   - ctx
+//   - username
+func (_e *MockUserRepository_Expecter) GetDataByUsername(ctx interface{}, username interface{}) *MockUserRepository_GetDataByUsername_Call {
+	return &MockUserRepository_GetDataByUsername_Call{Call: _e.mock.On("GetDataByUsername", ctx, username)}
+}
+
+func (_c *MockUserRepository_GetDataByUsername_Call) Run(run func(ctx context.Context, username string)) *MockUserRepository_GetDataByUsername_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetDataByUsername_Call) Return(uUID uuid.UUID, bytes []byte, b bool, err error) *MockUserRepository_GetDataByUsername_Call {
+	_c.Call.Return(uUID, bytes, b, err)
+	return _c
+}
+
+func (_c *MockUserRepository_GetDataByUsername_Call) RunAndReturn(run func(ctx context.Context, username string) (uuid.UUID, []byte, bool, error)) *MockUserRepository_GetDataByUsername_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLockedUntil provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetLockedUntil(ctx context.Context, username string) (time.Time, error) {
+	ret := _mock.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLockedUntil")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (time.Time, error)); ok {
+		return returnFunc(ctx, username)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) time.Time); ok {
+		r0 = returnFunc(ctx, username)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_GetLockedUntil_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLockedUntil'
+type MockUserRepository_GetLockedUntil_Call struct {
+	*mock.Call
+}
+
+// GetLockedUntil is a helper method to define mock.On call
+//   - ctx
+//   - username
+func (_e *MockUserRepository_Expecter) GetLockedUntil(ctx interface{}, username interface{}) *MockUserRepository_GetLockedUntil_Call {
+	return &MockUserRepository_GetLockedUntil_Call{Call: _e.mock.On("GetLockedUntil", ctx, username)}
+}
+
+func (_c *MockUserRepository_GetLockedUntil_Call) Run(run func(ctx context.Context, username string)) *MockUserRepository_GetLockedUntil_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetLockedUntil_Call) Return(time time.Time, err error) *MockUserRepository_GetLockedUntil_Call {
+	_c.Call.Return(time, err)
+	return _c
+}
+
+func (_c *MockUserRepository_GetLockedUntil_Call) RunAndReturn(run func(ctx context.Context, username string) (time.Time, error)) *MockUserRepository_GetLockedUntil_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPasswordByID provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetPasswordByID(ctx context.Context, id uuid.UUID) ([]byte, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPasswordByID")
+	}
+
+	var r0 []byte
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]byte, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []byte); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_GetPasswordByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPasswordByID'
+type MockUserRepository_GetPasswordByID_Call struct {
+	*mock.Call
+}
+
+// GetPasswordByID is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockUserRepository_Expecter) GetPasswordByID(ctx interface{}, id interface{}) *MockUserRepository_GetPasswordByID_Call {
+	return &MockUserRepository_GetPasswordByID_Call{Call: _e.mock.On("GetPasswordByID", ctx, id)}
+}
+
+func (_c *MockUserRepository_GetPasswordByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockUserRepository_GetPasswordByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetPasswordByID_Call) Return(bytes []byte, err error) *MockUserRepository_GetPasswordByID_Call {
+	_c.Call.Return(bytes, err)
+	return _c
+}
+
+func (_c *MockUserRepository_GetPasswordByID_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) ([]byte, error)) *MockUserRepository_GetPasswordByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPasswordReset provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetPasswordReset(ctx context.Context, id uuid.UUID) (*model.PasswordReset, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPasswordReset")
+	}
+
+	var r0 *model.PasswordReset
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.PasswordReset, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.PasswordReset); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PasswordReset)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_GetPasswordReset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPasswordReset'
+type MockUserRepository_GetPasswordReset_Call struct {
+	*mock.Call
+}
+
+// GetPasswordReset is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockUserRepository_Expecter) GetPasswordReset(ctx interface{}, id interface{}) *MockUserRepository_GetPasswordReset_Call {
+	return &MockUserRepository_GetPasswordReset_Call{Call: _e.mock.On("GetPasswordReset", ctx, id)}
+}
+
+func (_c *MockUserRepository_GetPasswordReset_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockUserRepository_GetPasswordReset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetPasswordReset_Call) Return(passwordReset *model.PasswordReset, err error) *MockUserRepository_GetPasswordReset_Call {
+	_c.Call.Return(passwordReset, err)
+	return _c
+}
+
+func (_c *MockUserRepository_GetPasswordReset_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*model.PasswordReset, error)) *MockUserRepository_GetPasswordReset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProfiles provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetProfiles(ctx context.Context, ids []uuid.UUID) ([]*model.UserProfile, error) {
+	ret := _mock.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProfiles")
+	}
+
+	var r0 []*model.UserProfile
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID) ([]*model.UserProfile, error)); ok {
+		return returnFunc(ctx, ids)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []uuid.UUID) []*model.UserProfile); ok {
+		r0 = returnFunc(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.UserProfile)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_GetProfiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProfiles'
+type MockUserRepository_GetProfiles_Call struct {
+	*mock.Call
+}
+
+// GetProfiles is a helper method to define mock.On call
+//   - ctx
+//   - ids
+func (_e *MockUserRepository_Expecter) GetProfiles(ctx interface{}, ids interface{}) *MockUserRepository_GetProfiles_Call {
+	return &MockUserRepository_GetProfiles_Call{Call: _e.mock.On("GetProfiles", ctx, ids)}
+}
+
+func (_c *MockUserRepository_GetProfiles_Call) Run(run func(ctx context.Context, ids []uuid.UUID)) *MockUserRepository_GetProfiles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetProfiles_Call) Return(userProfiles []*model.UserProfile, err error) *MockUserRepository_GetProfiles_Call {
+	_c.Call.Return(userProfiles, err)
+	return _c
+}
+
+func (_c *MockUserRepository_GetProfiles_Call) RunAndReturn(run func(ctx context.Context, ids []uuid.UUID) ([]*model.UserProfile, error)) *MockUserRepository_GetProfiles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRefreshToken provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetRefreshToken(ctx context.Context, id uuid.UUID) (*model.RefreshToken, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRefreshToken")
+	}
+
+	var r0 *model.RefreshToken
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.RefreshToken, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.RefreshToken); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RefreshToken)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_GetRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRefreshToken'
+type MockUserRepository_GetRefreshToken_Call struct {
+	*mock.Call
+}
+
+// GetRefreshToken is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockUserRepository_Expecter) GetRefreshToken(ctx interface{}, id interface{}) *MockUserRepository_GetRefreshToken_Call {
+	return &MockUserRepository_GetRefreshToken_Call{Call: _e.mock.On("GetRefreshToken", ctx, id)}
+}
+
+func (_c *MockUserRepository_GetRefreshToken_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockUserRepository_GetRefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetRefreshToken_Call) Return(refreshToken *model.RefreshToken, err error) *MockUserRepository_GetRefreshToken_Call {
+	_c.Call.Return(refreshToken, err)
+	return _c
+}
+
+func (_c *MockUserRepository_GetRefreshToken_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*model.RefreshToken, error)) *MockUserRepository_GetRefreshToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTokenVersion provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetTokenVersion(ctx context.Context, id uuid.UUID) (int, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTokenVersion")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) int); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_GetTokenVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTokenVersion'
+type MockUserRepository_GetTokenVersion_Call struct {
+	*mock.Call
+}
+
+// GetTokenVersion is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockUserRepository_Expecter) GetTokenVersion(ctx interface{}, id interface{}) *MockUserRepository_GetTokenVersion_Call {
+	return &MockUserRepository_GetTokenVersion_Call{Call: _e.mock.On("GetTokenVersion", ctx, id)}
+}
+
+func (_c *MockUserRepository_GetTokenVersion_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockUserRepository_GetTokenVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetTokenVersion_Call) Return(n int, err error) *MockUserRepository_GetTokenVersion_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockUserRepository_GetTokenVersion_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (int, error)) *MockUserRepository_GetTokenVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByID provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*model.User, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByID")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.User, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.User); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_GetUserByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByID'
+type MockUserRepository_GetUserByID_Call struct {
+	*mock.Call
+}
+
+// GetUserByID is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockUserRepository_Expecter) GetUserByID(ctx interface{}, id interface{}) *MockUserRepository_GetUserByID_Call {
+	return &MockUserRepository_GetUserByID_Call{Call: _e.mock.On("GetUserByID", ctx, id)}
+}
+
+func (_c *MockUserRepository_GetUserByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockUserRepository_GetUserByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetUserByID_Call) Return(user *model.User, err error) *MockUserRepository_GetUserByID_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserRepository_GetUserByID_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*model.User, error)) *MockUserRepository_GetUserByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InTx provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	ret := _mock.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InTx")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, func(ctx context.Context) error) error); ok {
+		r0 = returnFunc(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_InTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InTx'
+type MockUserRepository_InTx_Call struct {
+	*mock.Call
+}
+
+// InTx is a helper method to define mock.On call
+//   - ctx
+//   - fn
+func (_e *MockUserRepository_Expecter) InTx(ctx interface{}, fn interface{}) *MockUserRepository_InTx_Call {
+	return &MockUserRepository_InTx_Call{Call: _e.mock.On("InTx", ctx, fn)}
+}
+
+func (_c *MockUserRepository_InTx_Call) Run(run func(ctx context.Context, fn func(ctx context.Context) error)) *MockUserRepository_InTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(ctx context.Context) error))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_InTx_Call) Return(err error) *MockUserRepository_InTx_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_InTx_Call) RunAndReturn(run func(ctx context.Context, fn func(ctx context.Context) error) error) *MockUserRepository_InTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordFailedLogin provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) RecordFailedLogin(ctx context.Context, username string, maxAttempts int, lockout time.Duration) (time.Time, error) {
+	ret := _mock.Called(ctx, username, maxAttempts, lockout)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordFailedLogin")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, time.Duration) (time.Time, error)); ok {
+		return returnFunc(ctx, username, maxAttempts, lockout)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, time.Duration) time.Time); ok {
+		r0 = returnFunc(ctx, username, maxAttempts, lockout)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int, time.Duration) error); ok {
+		r1 = returnFunc(ctx, username, maxAttempts, lockout)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_RecordFailedLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordFailedLogin'
+type MockUserRepository_RecordFailedLogin_Call struct {
+	*mock.Call
+}
+
+// RecordFailedLogin is a helper method to define mock.On call
+//   - ctx
+//   - username
+//   - maxAttempts
+//   - lockout
+func (_e *MockUserRepository_Expecter) RecordFailedLogin(ctx interface{}, username interface{}, maxAttempts interface{}, lockout interface{}) *MockUserRepository_RecordFailedLogin_Call {
+	return &MockUserRepository_RecordFailedLogin_Call{Call: _e.mock.On("RecordFailedLogin", ctx, username, maxAttempts, lockout)}
+}
+
+func (_c *MockUserRepository_RecordFailedLogin_Call) Run(run func(ctx context.Context, username string, maxAttempts int, lockout time.Duration)) *MockUserRepository_RecordFailedLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_RecordFailedLogin_Call) Return(time time.Time, err error) *MockUserRepository_RecordFailedLogin_Call {
+	_c.Call.Return(time, err)
+	return _c
+}
+
+func (_c *MockUserRepository_RecordFailedLogin_Call) RunAndReturn(run func(ctx context.Context, username string, maxAttempts int, lockout time.Duration) (time.Time, error)) *MockUserRepository_RecordFailedLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetFailedLogins provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) ResetFailedLogins(ctx context.Context, id uuid.UUID) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetFailedLogins")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_ResetFailedLogins_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetFailedLogins'
+type MockUserRepository_ResetFailedLogins_Call struct {
+	*mock.Call
+}
+
+// ResetFailedLogins is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockUserRepository_Expecter) ResetFailedLogins(ctx interface{}, id interface{}) *MockUserRepository_ResetFailedLogins_Call {
+	return &MockUserRepository_ResetFailedLogins_Call{Call: _e.mock.On("ResetFailedLogins", ctx, id)}
+}
+
+func (_c *MockUserRepository_ResetFailedLogins_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockUserRepository_ResetFailedLogins_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_ResetFailedLogins_Call) Return(err error) *MockUserRepository_ResetFailedLogins_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_ResetFailedLogins_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *MockUserRepository_ResetFailedLogins_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RotateRefreshToken provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) RotateRefreshToken(ctx context.Context, token *model.RefreshToken) error {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotateRefreshToken")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.RefreshToken) error); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_RotateRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotateRefreshToken'
+type MockUserRepository_RotateRefreshToken_Call struct {
+	*mock.Call
+}
+
+// RotateRefreshToken is a helper method to define mock.On call
+//   - ctx
+//   - token
+func (_e *MockUserRepository_Expecter) RotateRefreshToken(ctx interface{}, token interface{}) *MockUserRepository_RotateRefreshToken_Call {
+	return &MockUserRepository_RotateRefreshToken_Call{Call: _e.mock.On("RotateRefreshToken", ctx, token)}
+}
+
+func (_c *MockUserRepository_RotateRefreshToken_Call) Run(run func(ctx context.Context, token *model.RefreshToken)) *MockUserRepository_RotateRefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.RefreshToken))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_RotateRefreshToken_Call) Return(err error) *MockUserRepository_RotateRefreshToken_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_RotateRefreshToken_Call) RunAndReturn(run func(ctx context.Context, token *model.RefreshToken) error) *MockUserRepository_RotateRefreshToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetAdmin provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) SetAdmin(ctx context.Context, id uuid.UUID, admin bool) error {
+	ret := _mock.Called(ctx, id, admin)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetAdmin")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool) error); ok {
+		r0 = returnFunc(ctx, id, admin)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_SetAdmin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetAdmin'
+type MockUserRepository_SetAdmin_Call struct {
+	*mock.Call
+}
+
+// SetAdmin is a helper method to define mock.On call
+//   - ctx
+//   - id
+//   - admin
+func (_e *MockUserRepository_Expecter) SetAdmin(ctx interface{}, id interface{}, admin interface{}) *MockUserRepository_SetAdmin_Call {
+	return &MockUserRepository_SetAdmin_Call{Call: _e.mock.On("SetAdmin", ctx, id, admin)}
+}
+
+func (_c *MockUserRepository_SetAdmin_Call) Run(run func(ctx context.Context, id uuid.UUID, admin bool)) *MockUserRepository_SetAdmin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_SetAdmin_Call) Return(err error) *MockUserRepository_SetAdmin_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_SetAdmin_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, admin bool) error) *MockUserRepository_SetAdmin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SignUp provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) SignUp(ctx context.Context, user *model.User) error {
+	ret := _mock.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignUp")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.User) error); ok {
+		r0 = returnFunc(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_SignUp_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SignUp'
+type MockUserRepository_SignUp_Call struct {
+	*mock.Call
+}
+
+// SignUp is a helper method to define mock.On call
+//   - ctx
+//   - user
+func (_e *MockUserRepository_Expecter) SignUp(ctx interface{}, user interface{}) *MockUserRepository_SignUp_Call {
+	return &MockUserRepository_SignUp_Call{Call: _e.mock.On("SignUp", ctx, user)}
+}
+
+func (_c *MockUserRepository_SignUp_Call) Run(run func(ctx context.Context, user *model.User)) *MockUserRepository_SignUp_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.User))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_SignUp_Call) Return(err error) *MockUserRepository_SignUp_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_SignUp_Call) RunAndReturn(run func(ctx context.Context, user *model.User) error) *MockUserRepository_SignUp_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePassword provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) UpdatePassword(ctx context.Context, id uuid.UUID, hash []byte) error {
+	ret := _mock.Called(ctx, id, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePassword")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, []byte) error); ok {
+		r0 = returnFunc(ctx, id, hash)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_UpdatePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePassword'
+type MockUserRepository_UpdatePassword_Call struct {
+	*mock.Call
+}
+
+// UpdatePassword is a helper method to define mock.On call
+//   - ctx
+//   - id
+//   - hash
+func (_e *MockUserRepository_Expecter) UpdatePassword(ctx interface{}, id interface{}, hash interface{}) *MockUserRepository_UpdatePassword_Call {
+	return &MockUserRepository_UpdatePassword_Call{Call: _e.mock.On("UpdatePassword", ctx, id, hash)}
+}
+
+func (_c *MockUserRepository_UpdatePassword_Call) Run(run func(ctx context.Context, id uuid.UUID, hash []byte)) *MockUserRepository_UpdatePassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_UpdatePassword_Call) Return(err error) *MockUserRepository_UpdatePassword_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_UpdatePassword_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, hash []byte) error) *MockUserRepository_UpdatePassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UseInvite provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) UseInvite(ctx context.Context, code string, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, code, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseInvite")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, code, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_UseInvite_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseInvite'
+type MockUserRepository_UseInvite_Call struct {
+	*mock.Call
+}
+
+// UseInvite is a helper method to define mock.On call
+//   - ctx
+//   - code
+//   - userID
+func (_e *MockUserRepository_Expecter) UseInvite(ctx interface{}, code interface{}, userID interface{}) *MockUserRepository_UseInvite_Call {
+	return &MockUserRepository_UseInvite_Call{Call: _e.mock.On("UseInvite", ctx, code, userID)}
+}
+
+func (_c *MockUserRepository_UseInvite_Call) Run(run func(ctx context.Context, code string, userID uuid.UUID)) *MockUserRepository_UseInvite_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_UseInvite_Call) Return(err error) *MockUserRepository_UseInvite_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_UseInvite_Call) RunAndReturn(run func(ctx context.Context, code string, userID uuid.UUID) error) *MockUserRepository_UseInvite_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UsePasswordReset provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) UsePasswordReset(ctx context.Context, id uuid.UUID) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UsePasswordReset")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_UsePasswordReset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsePasswordReset'
+type MockUserRepository_UsePasswordReset_Call struct {
+	*mock.Call
+}
+
+// UsePasswordReset is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockUserRepository_Expecter) UsePasswordReset(ctx interface{}, id interface{}) *MockUserRepository_UsePasswordReset_Call {
+	return &MockUserRepository_UsePasswordReset_Call{Call: _e.mock.On("UsePasswordReset", ctx, id)}
+}
+
+func (_c *MockUserRepository_UsePasswordReset_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockUserRepository_UsePasswordReset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_UsePasswordReset_Call) Return(err error) *MockUserRepository_UsePasswordReset_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_UsePasswordReset_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *MockUserRepository_UsePasswordReset_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -3,14 +3,28 @@ package service
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/artnikel/blogapi/internal/config"
+	"github.com/artnikel/blogapi/internal/constants"
+	"github.com/artnikel/blogapi/internal/middleware"
 	"github.com/artnikel/blogapi/internal/model"
+	"github.com/artnikel/blogapi/internal/repository"
+	"github.com/artnikel/blogapi/internal/requestuser"
 	"github.com/artnikel/blogapi/internal/service/mocks"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestUserService_SignUp(t *testing.T) {
@@ -19,7 +33,8 @@ func TestUserService_SignUp(t *testing.T) {
 	svc := NewUserService(mockRepo, cfg)
 
 	user := &model.User{
-		Username: "testuser",
+		Username: "TestUser",
+		Email:    "TestUser@Example.com",
 		Password: []byte("password123"),
 	}
 
@@ -28,6 +43,8 @@ func TestUserService_SignUp(t *testing.T) {
 		Return(nil).
 		Run(func(_ context.Context, u *model.User) {
 			require.NotEqual(t, []byte("password123"), u.Password)
+			require.Equal(t, "testuser", u.Username)
+			require.Equal(t, "testuser@example.com", u.Email)
 		})
 
 	err := svc.SignUp(context.Background(), user)
@@ -48,15 +65,29 @@ func TestUserService_Login(t *testing.T) {
 		Password: password,
 	}
 
+	mockRepo.EXPECT().
+		GetLockedUntil(mock.Anything, user.Username).
+		Return(time.Time{}, nil)
+
 	mockRepo.EXPECT().
 		GetDataByUsername(mock.Anything, user.Username).
 		Return(userID, hashedPass, true, nil)
 
 	mockRepo.EXPECT().
-		AddRefreshToken(mock.Anything, mock.AnythingOfType("*model.User")).
+		ResetFailedLogins(mock.Anything, userID).
+		Return(nil)
+
+	mockRepo.EXPECT().
+		GetTokenVersion(mock.Anything, userID).
+		Return(3, nil)
+
+	mockRepo.EXPECT().
+		AddRefreshToken(mock.Anything, mock.AnythingOfType("*model.RefreshToken")).
 		Return(nil).
-		Run(func(_ context.Context, u *model.User) {
-			require.NotEmpty(t, u.RefreshToken)
+		Run(func(_ context.Context, token *model.RefreshToken) {
+			require.NotEmpty(t, token.Token)
+			require.Equal(t, userID, token.UserID)
+			require.NotEqual(t, uuid.Nil, token.ID)
 		})
 
 	tokens, err := svc.Login(context.Background(), user)
@@ -65,6 +96,11 @@ func TestUserService_Login(t *testing.T) {
 	require.NotEmpty(t, tokens.RefreshToken)
 	require.Equal(t, userID, user.ID)
 	require.True(t, user.Admin)
+	token, err := middleware.ValidateToken(tokens.AccessToken, cfg)
+	require.NoError(t, err)
+	version, err := middleware.TokenVersion(token)
+	require.NoError(t, err)
+	require.Equal(t, 3, version)
 }
 
 func TestUserService_Login_WrongPassword(t *testing.T) {
@@ -81,16 +117,94 @@ func TestUserService_Login_WrongPassword(t *testing.T) {
 		Password: []byte("wrong_password"),
 	}
 
+	mockRepo.EXPECT().
+		GetLockedUntil(mock.Anything, user.Username).
+		Return(time.Time{}, nil)
+
 	mockRepo.EXPECT().
 		GetDataByUsername(mock.Anything, user.Username).
 		Return(userID, hashedPass, false, nil)
 
+	mockRepo.EXPECT().
+		RecordFailedLogin(mock.Anything, user.Username, constants.MaxFailedLogins, constants.LoginLockoutDuration).
+		Return(time.Time{}, nil)
+
 	tokens, err := svc.Login(context.Background(), user)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "CheckPasswordHash")
+	require.NotErrorIs(t, err, ErrAccountLocked)
 	require.Empty(t, tokens.AccessToken)
 }
 
+func TestUserService_Login_LocksAfterFailedAttempts(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	cfg := &config.Config{BlogTokenSignature: "secret", BlogLoginLockout: time.Hour}
+	svc := NewUserService(mockRepo, cfg)
+
+	userID := uuid.New()
+	hashedPass, _ := svc.HashPassword([]byte("correct_password"))
+	user := &model.User{
+		Username: "testuser",
+		Password: []byte("wrong_password"),
+	}
+
+	mockRepo.EXPECT().
+		GetLockedUntil(mock.Anything, user.Username).
+		Return(time.Time{}, nil).Once()
+	mockRepo.EXPECT().
+		GetDataByUsername(mock.Anything, user.Username).
+		Return(userID, hashedPass, false, nil).Once()
+	mockRepo.EXPECT().
+		RecordFailedLogin(mock.Anything, user.Username, constants.MaxFailedLogins, time.Hour).
+		Return(time.Now().Add(time.Hour), nil).Once()
+
+	_, err := svc.Login(context.Background(), user)
+	require.ErrorIs(t, err, ErrAccountLocked)
+
+	// while locked even the correct password is rejected without checking it
+	mockRepo.EXPECT().
+		GetLockedUntil(mock.Anything, user.Username).
+		Return(time.Now().Add(time.Hour), nil).Once()
+
+	user.Password = []byte("correct_password")
+	_, err = svc.Login(context.Background(), user)
+	require.ErrorIs(t, err, ErrAccountLocked)
+}
+
+func TestUserService_Login_AutoUnlock(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	cfg := &config.Config{BlogTokenSignature: "secret"}
+	svc := NewUserService(mockRepo, cfg)
+
+	userID := uuid.New()
+	password := []byte("password123")
+	hashedPass, _ := svc.HashPassword(password)
+	user := &model.User{
+		Username: "testuser",
+		Password: password,
+	}
+
+	mockRepo.EXPECT().
+		GetLockedUntil(mock.Anything, user.Username).
+		Return(time.Now().Add(-time.Minute), nil)
+	mockRepo.EXPECT().
+		GetDataByUsername(mock.Anything, user.Username).
+		Return(userID, hashedPass, false, nil)
+	mockRepo.EXPECT().
+		ResetFailedLogins(mock.Anything, userID).
+		Return(nil)
+	mockRepo.EXPECT().
+		GetTokenVersion(mock.Anything, userID).
+		Return(0, nil)
+	mockRepo.EXPECT().
+		AddRefreshToken(mock.Anything, mock.AnythingOfType("*model.RefreshToken")).
+		Return(nil)
+
+	tokens, err := svc.Login(context.Background(), user)
+	require.NoError(t, err)
+	require.NotEmpty(t, tokens.AccessToken)
+}
+
 func TestUserService_Refresh(t *testing.T) {
 	mockRepo := mocks.NewMockUserRepository(t)
 	cfg := &config.Config{BlogTokenSignature: "secret"}
@@ -98,29 +212,70 @@ func TestUserService_Refresh(t *testing.T) {
 
 	userID := uuid.New()
 	isAdmin := true
+	currentDevice := &model.RefreshToken{ID: uuid.New(), UserID: userID}
 
-	tokenPair, err := svc.GenerateTokenPair(userID, isAdmin)
+	tokenPair, err := svc.GenerateTokenPair(userID, isAdmin, 0, currentDevice.ID)
 	require.NoError(t, err)
 
 	sum := sha256.Sum256([]byte(tokenPair.RefreshToken))
 	hashedRefreshToken, err := svc.HashPassword(sum[:])
 	require.NoError(t, err)
+	currentDevice.Token = string(hashedRefreshToken)
 
 	mockRepo.EXPECT().
-		GetRefreshTokenByID(mock.Anything, userID).
-		Return(string(hashedRefreshToken), nil)
+		GetRefreshToken(mock.Anything, currentDevice.ID).
+		Return(currentDevice, nil).
+		Once()
+	mockRepo.EXPECT().GetUserByID(mock.Anything, userID).Return(&model.User{ID: userID, Admin: true}, nil)
 
 	mockRepo.EXPECT().
-		AddRefreshToken(mock.Anything, mock.AnythingOfType("*model.User")).
+		RotateRefreshToken(mock.Anything, mock.AnythingOfType("*model.RefreshToken")).
 		Return(nil).
-		Run(func(_ context.Context, u *model.User) {
-			require.NotEmpty(t, u.RefreshToken)
+		Run(func(_ context.Context, token *model.RefreshToken) {
+			require.Equal(t, currentDevice.ID, token.ID)
+			require.NotEqual(t, string(hashedRefreshToken), token.Token)
 		})
 
 	newTokenPair, err := svc.Refresh(context.Background(), tokenPair)
 	require.NoError(t, err)
 	require.NotEmpty(t, newTokenPair.AccessToken)
 	require.NotEmpty(t, newTokenPair.RefreshToken)
+	sessionID, err := svc.refreshSessionID(newTokenPair.RefreshToken)
+	require.NoError(t, err)
+	require.Equal(t, currentDevice.ID, sessionID, "the rotated token keeps the row of its device")
+}
+
+func TestUserService_Refresh_AfterDemotion(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	cfg := &config.Config{BlogTokenSignature: "secret"}
+	svc := NewUserService(mockRepo, cfg)
+
+	userID := uuid.New()
+	session := &model.RefreshToken{ID: uuid.New(), UserID: userID}
+	tokenPair, err := svc.GenerateTokenPair(userID, true, 0, session.ID)
+	require.NoError(t, err)
+	sum := sha256.Sum256([]byte(tokenPair.RefreshToken))
+	hashedRefreshToken, err := svc.HashPassword(sum[:])
+	require.NoError(t, err)
+	session.Token = string(hashedRefreshToken)
+
+	runInTx(mockRepo)
+	mockRepo.EXPECT().SetAdmin(mock.Anything, userID, false).Return(nil)
+	mockRepo.EXPECT().BumpTokenVersion(mock.Anything, userID).Return(nil)
+	mockRepo.EXPECT().DeleteRefreshTokensByUserID(mock.Anything, userID).Return(nil)
+	require.NoError(t, svc.SetRole(context.Background(), userID, false))
+
+	// the session outlived the demotion, e.g. it was stored by a login racing with it
+	mockRepo.EXPECT().GetRefreshToken(mock.Anything, session.ID).Return(session, nil)
+	mockRepo.EXPECT().GetUserByID(mock.Anything, userID).Return(&model.User{ID: userID, Admin: false}, nil)
+	mockRepo.EXPECT().RotateRefreshToken(mock.Anything, mock.AnythingOfType("*model.RefreshToken")).Return(nil)
+
+	newTokenPair, err := svc.Refresh(context.Background(), tokenPair)
+	require.NoError(t, err)
+	id, isAdmin, err := svc.TokensIDCompare(newTokenPair)
+	require.NoError(t, err)
+	require.Equal(t, userID, id)
+	require.False(t, isAdmin, "the refreshed token must carry the role from the users table")
 }
 
 func TestUserService_Refresh_InvalidToken(t *testing.T) {
@@ -130,29 +285,1473 @@ func TestUserService_Refresh_InvalidToken(t *testing.T) {
 
 	userID := uuid.New()
 	isAdmin := true
+	sessionID := uuid.New()
 
-	tokenPair, err := svc.GenerateTokenPair(userID, isAdmin)
+	tokenPair, err := svc.GenerateTokenPair(userID, isAdmin, 0, sessionID)
 	require.NoError(t, err)
 
 	mockRepo.EXPECT().
-		GetRefreshTokenByID(mock.Anything, userID).
-		Return("some_invalid_hash", nil)
+		GetRefreshToken(mock.Anything, sessionID).
+		Return(&model.RefreshToken{ID: sessionID, UserID: userID, Token: "some_invalid_hash"}, nil)
 
 	_, err = svc.Refresh(context.Background(), tokenPair)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "CheckPasswordHash error")
 }
 
+func TestUserService_Refresh_OtherUsersSession(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	svc := NewUserService(mockRepo, &config.Config{BlogTokenSignature: "secret"})
+
+	sessionID := uuid.New()
+	tokenPair, err := svc.GenerateTokenPair(uuid.New(), false, 0, sessionID)
+	require.NoError(t, err)
+	hashed, err := svc.hashRefreshToken(tokenPair.RefreshToken)
+	require.NoError(t, err)
+
+	mockRepo.EXPECT().
+		GetRefreshToken(mock.Anything, sessionID).
+		Return(&model.RefreshToken{ID: sessionID, UserID: uuid.New(), Token: hashed}, nil)
+
+	_, err = svc.Refresh(context.Background(), tokenPair)
+	require.Error(t, err)
+}
+
+func TestUserService_Refresh_WithoutJTI(t *testing.T) {
+	svc := NewUserService(mocks.NewMockUserRepository(t), &config.Config{BlogTokenSignature: "secret"})
+
+	userID := uuid.New()
+	accessToken, err := svc.GenerateJWTToken(time.Minute, userID, false, 0)
+	require.NoError(t, err)
+	refreshToken, err := svc.GenerateJWTToken(time.Hour, userID, false, 0)
+	require.NoError(t, err)
+
+	_, err = svc.Refresh(context.Background(), TokenPair{AccessToken: accessToken, RefreshToken: refreshToken})
+	require.ErrorIs(t, err, middleware.ErrInvalidClaims)
+}
+
+func TestUserService_GenerateTokenPair_ConfiguredTTL(t *testing.T) {
+	cfg := &config.Config{BlogTokenSignature: "secret", BlogAccessTokenTTL: 2 * time.Minute, BlogRefreshTokenTTL: time.Hour}
+	svc := NewUserService(mocks.NewMockUserRepository(t), cfg)
+
+	before := time.Now().Truncate(time.Second)
+	tokenPair, err := svc.GenerateTokenPair(uuid.New(), false, 0, uuid.New())
+	require.NoError(t, err)
+	after := time.Now()
+
+	for token, ttl := range map[string]time.Duration{tokenPair.AccessToken: 2 * time.Minute, tokenPair.RefreshToken: time.Hour} {
+		claims := jwt.MapClaims{}
+		_, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (any, error) {
+			return []byte(cfg.BlogTokenSignature), nil
+		})
+		require.NoError(t, err)
+		exp, err := claims.GetExpirationTime()
+		require.NoError(t, err)
+		require.False(t, exp.Before(before.Add(ttl)))
+		require.False(t, exp.After(after.Add(ttl)))
+	}
+}
+
+func TestUserService_Refresh_OtherAudience(t *testing.T) {
+	staging := NewUserService(mocks.NewMockUserRepository(t), &config.Config{BlogTokenSignature: "secret", BlogTokenAudience: "blogapi-staging"})
+	prod := NewUserService(mocks.NewMockUserRepository(t), &config.Config{BlogTokenSignature: "secret", BlogTokenAudience: "blogapi-prod"})
+
+	tokenPair, err := staging.GenerateTokenPair(uuid.New(), false, 0, uuid.New())
+	require.NoError(t, err)
+	_, _, err = staging.TokensIDCompare(tokenPair)
+	require.NoError(t, err)
+
+	_, err = prod.Refresh(context.Background(), tokenPair)
+	require.ErrorIs(t, err, middleware.ErrInvalidClaims)
+}
+
+func TestUserService_Logout(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	cfg := &config.Config{BlogTokenSignature: "secret"}
+	svc := NewUserService(mockRepo, cfg)
+
+	userID := uuid.New()
+	currentDevice := &model.RefreshToken{ID: uuid.New(), UserID: userID}
+	tokenPair, err := svc.GenerateTokenPair(userID, false, 0, currentDevice.ID)
+	require.NoError(t, err)
+
+	sum := sha256.Sum256([]byte(tokenPair.RefreshToken))
+	hashedRefreshToken, err := svc.HashPassword(sum[:])
+	require.NoError(t, err)
+	currentDevice.Token = string(hashedRefreshToken)
+
+	mockRepo.EXPECT().
+		GetRefreshToken(mock.Anything, currentDevice.ID).
+		Return(currentDevice, nil)
+	mockRepo.EXPECT().
+		DeleteRefreshToken(mock.Anything, currentDevice.ID).
+		Return(nil)
+
+	err = svc.Logout(context.Background(), userID, tokenPair.RefreshToken)
+	require.NoError(t, err)
+}
+
+func TestUserService_LogoutAll(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	cfg := &config.Config{BlogTokenSignature: "secret"}
+	svc := NewUserService(mockRepo, cfg)
+	userID := uuid.New()
+
+	runInTx(mockRepo)
+	mockRepo.EXPECT().
+		BumpTokenVersion(mock.Anything, userID).
+		Return(nil)
+	mockRepo.EXPECT().
+		DeleteRefreshTokensByUserID(mock.Anything, userID).
+		Return(nil)
+
+	err := svc.LogoutAll(context.Background(), userID)
+	require.NoError(t, err)
+}
+
 func TestUserService_DeleteUserByID(t *testing.T) {
 	mockRepo := mocks.NewMockUserRepository(t)
 	cfg := &config.Config{BlogTokenSignature: "secret"}
 	svc := NewUserService(mockRepo, cfg)
 	userID := uuid.New()
 
+	runInTx(mockRepo)
+	mockRepo.EXPECT().
+		DeleteBlogsByUserID(mock.Anything, userID).
+		Return(nil, nil)
 	mockRepo.EXPECT().
 		DeleteUserByID(mock.Anything, userID).
 		Return(nil)
+	adminID := uuid.New()
+	mockRepo.EXPECT().
+		AddActivity(mock.Anything, mock.MatchedBy(func(a *model.Activity) bool {
+			return a.UserID == adminID && a.Action == model.ActivityUserDeleted && a.TargetID == userID
+		})).
+		Return(nil)
+
+	err := svc.DeleteUserByID(requestuser.WithID(context.Background(), adminID), userID)
+	require.NoError(t, err)
+}
+
+func TestUserService_DeleteUserByID_DropsBlogCount(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	blogRepo := mocks.NewMockBlogRepository(t)
+	blogSvc := NewBlogService(blogRepo)
+	svc := NewUserService(mockRepo, &config.Config{})
+	svc.SetBlogDeleter(blogSvc)
+	adminID, userID := uuid.New(), uuid.New()
+	blogSvc.totalCount.set(5, false)
+
+	runInTx(mockRepo)
+	runBlogInTx(blogRepo)
+	blogRepo.EXPECT().DeleteBlogsByUserID(mock.Anything, mock.Anything).Return([]uuid.UUID{uuid.New()}, nil)
+	mockRepo.EXPECT().DeleteUserByID(mock.Anything, adminID).Return(repository.ErrAdminUser)
+	mockRepo.EXPECT().DeleteUserByID(mock.Anything, userID).Return(nil)
+
+	// the failed delete rolls back, the count stays cached
+	err := svc.DeleteUserByID(context.Background(), adminID)
+	require.ErrorIs(t, err, repository.ErrAdminUser)
+	_, _, ok := blogSvc.totalCount.get()
+	require.True(t, ok)
+
+	err = svc.DeleteUserByID(context.Background(), userID)
+	require.NoError(t, err)
+	_, _, ok = blogSvc.totalCount.get()
+	require.False(t, ok)
+}
+
+func TestNormalizeTags(t *testing.T) {
+	require.Nil(t, NormalizeTags(nil))
+	require.Equal(t, []string{}, NormalizeTags([]string{" ", ""}))
+	require.Equal(t, []string{"go", "postgres"}, NormalizeTags([]string{"Go", " go ", "POSTGRES", "", "postgres"}))
+}
+
+func TestBlogService_Create_WithTags(t *testing.T) {
+	mockRepo := mocks.NewMockBlogRepository(t)
+	svc := NewBlogService(mockRepo)
+	runBlogInTx(mockRepo)
+
+	blog := &model.Blog{
+		BlogID:  uuid.New(),
+		Title:   "testtitle",
+		Content: "testcontent",
+		Tags:    []string{"Go", "go", " Echo "},
+	}
+
+	mockRepo.EXPECT().Create(mock.Anything, blog).Return(nil)
+	mockRepo.EXPECT().AddTags(mock.Anything, blog.BlogID, []string{"go", "echo"}).Return(nil)
+
+	err := svc.Create(context.Background(), blog, false)
+	require.NoError(t, err)
+	require.Equal(t, []string{"go", "echo"}, blog.Tags)
+	require.Equal(t, model.BlogStatusDraft, blog.Status)
+}
+
+func TestBlogService_Create_WithoutTags(t *testing.T) {
+	mockRepo := mocks.NewMockBlogRepository(t)
+	svc := NewBlogService(mockRepo)
+	runBlogInTx(mockRepo)
+
+	blog := &model.Blog{BlogID: uuid.New(), Title: "testtitle", Content: "testcontent"}
+
+	mockRepo.EXPECT().Create(mock.Anything, blog).Return(nil)
+
+	err := svc.Create(context.Background(), blog, false)
+	require.NoError(t, err)
+}
+
+func TestBlogService_Create_TagsFailure(t *testing.T) {
+	mockRepo := mocks.NewMockBlogRepository(t)
+	svc := NewBlogService(mockRepo)
+	blog := &model.Blog{BlogID: uuid.New(), Title: "testtitle", Content: "testcontent", Tags: []string{"go"}}
+
+	// the error of the transaction is what rolls the created blog back
+	mockRepo.EXPECT().InTx(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			err := fn(ctx)
+			require.ErrorIs(t, err, repository.ErrUnavailable)
+			return err
+		}).Once()
+	mockRepo.EXPECT().Create(mock.Anything, blog).Return(nil)
+	mockRepo.EXPECT().AddTags(mock.Anything, blog.BlogID, []string{"go"}).Return(repository.ErrUnavailable)
+
+	require.ErrorIs(t, svc.Create(context.Background(), blog, false), repository.ErrUnavailable)
+}
+
+func TestBlogService_Create_DraftLimit(t *testing.T) {
+	mockRepo := mocks.NewMockBlogRepository(t)
+	svc := NewBlogService(mockRepo)
+	runBlogInTx(mockRepo)
+	svc.SetDraftLimit(50)
+
+	userID := uuid.New()
+	mockRepo.EXPECT().CountDraftsByUserID(mock.Anything, userID).Return(50, nil).Once()
+	blog := &model.Blog{BlogID: uuid.New(), UserID: userID, Title: "testtitle", Content: "testcontent"}
+	require.ErrorIs(t, svc.Create(context.Background(), blog, false), ErrDraftLimitExceeded)
+
+	// the existing drafts can still be published, which makes room for new ones
+	draftID := uuid.New()
+	mockRepo.EXPECT().Publish(mock.Anything, draftID).Return(nil)
+	status, err := svc.Publish(context.Background(), draftID, false)
+	require.NoError(t, err)
+	require.Equal(t, model.BlogStatusPublished, status)
+
+	mockRepo.EXPECT().CountDraftsByUserID(mock.Anything, userID).Return(49, nil).Once()
+	mockRepo.EXPECT().Create(mock.Anything, blog).Return(nil).Once()
+	require.NoError(t, svc.Create(context.Background(), blog, false))
+
+	// admins are not limited, their drafts are not even counted
+	mockRepo.EXPECT().Create(mock.Anything, blog).Return(nil).Once()
+	require.NoError(t, svc.Create(context.Background(), blog, true))
+}
+
+func TestBlogService_Get_HydratesTags(t *testing.T) {
+	mockRepo := mocks.NewMockBlogRepository(t)
+	svc := NewBlogService(mockRepo)
+
+	id := uuid.New()
+	mockRepo.EXPECT().Get(mock.Anything, id).Return(&model.Blog{BlogID: id, Title: "testtitle"}, nil)
+	mockRepo.EXPECT().GetTags(mock.Anything, id).Return([]string{"echo", "go"}, nil)
+
+	blog, err := svc.Get(context.Background(), id)
+	require.NoError(t, err)
+	require.Equal(t, []string{"echo", "go"}, blog.Tags)
+}
+
+func TestBlogService_RecordsActivity(t *testing.T) {
+	mockRepo := mocks.NewMockBlogRepository(t)
+	svc := NewBlogService(mockRepo)
+	runBlogInTx(mockRepo)
+	userID := uuid.New()
+	ctx := requestuser.WithID(context.Background(), userID)
+	blog := &model.Blog{BlogID: uuid.New(), UserID: userID, Title: "testtitle", Content: "testcontent"}
+
+	var actions []string
+	mockRepo.EXPECT().Create(mock.Anything, blog).Return(nil)
+	mockRepo.EXPECT().Update(mock.Anything, blog).Return(nil)
+	mockRepo.EXPECT().Delete(mock.Anything, blog.BlogID).Return(nil)
+	mockRepo.EXPECT().AddActivity(mock.Anything, mock.MatchedBy(func(a *model.Activity) bool {
+		return a.UserID == userID && a.TargetID == blog.BlogID && a.ID != uuid.Nil
+	})).RunAndReturn(func(_ context.Context, a *model.Activity) error {
+		actions = append(actions, a.Action)
+		return nil
+	}).Times(3)
+
+	require.NoError(t, svc.Create(ctx, blog, false))
+	require.NoError(t, svc.Update(ctx, blog, false))
+	require.NoError(t, svc.Delete(ctx, blog.BlogID))
+	require.Equal(t, []string{model.ActivityBlogCreated, model.ActivityBlogUpdated, model.ActivityBlogDeleted}, actions)
+
+	// writes outside of a request have no user to record them for
+	mockRepo.EXPECT().Create(mock.Anything, blog).Return(nil)
+	require.NoError(t, svc.Create(context.Background(), blog, false))
+}
+
+func TestBlogService_RecordsActivity_BulkWrites(t *testing.T) {
+	mockRepo := mocks.NewMockBlogRepository(t)
+	svc := NewBlogService(mockRepo)
+	runBlogInTx(mockRepo)
+	userID := uuid.New()
+	ctx := requestuser.WithID(context.Background(), userID)
+	first, second, third := uuid.New(), uuid.New(), uuid.New()
+
+	var recorded []model.Activity
+	mockRepo.EXPECT().DeleteMany(mock.Anything, []uuid.UUID{first, second, uuid.Nil}, uuid.Nil).Return([]uuid.UUID{first, second}, nil)
+	mockRepo.EXPECT().DeleteBlogsByUserID(mock.Anything, userID).Return([]uuid.UUID{third}, nil)
+	mockRepo.EXPECT().Restore(mock.Anything, first).Return(nil)
+	mockRepo.EXPECT().Publish(mock.Anything, first).Return(nil)
+	mockRepo.EXPECT().AddActivity(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, a *model.Activity) error {
+		recorded = append(recorded, model.Activity{Action: a.Action, TargetID: a.TargetID})
+		return nil
+	})
+
+	deleted, err := svc.DeleteMany(ctx, []uuid.UUID{first, second, uuid.Nil}, uuid.Nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, deleted)
+	require.NoError(t, svc.DeleteBlogsByUserID(ctx, userID))
+	require.NoError(t, svc.Restore(ctx, first))
+	_, err = svc.Publish(ctx, first, true)
+	require.NoError(t, err)
+	require.Equal(t, []model.Activity{
+		{Action: model.ActivityBlogDeleted, TargetID: first},
+		{Action: model.ActivityBlogDeleted, TargetID: second},
+		{Action: model.ActivityBlogDeleted, TargetID: third},
+		{Action: model.ActivityBlogRestored, TargetID: first},
+		{Action: model.ActivityBlogPublished, TargetID: first},
+	}, recorded)
+}
+
+func TestBlogService_RecordsActivity_Failure(t *testing.T) {
+	mockRepo := mocks.NewMockBlogRepository(t)
+	svc := NewBlogService(mockRepo)
+	runBlogInTx(mockRepo)
+	ctx := requestuser.WithID(context.Background(), uuid.New())
+	blog := &model.Blog{BlogID: uuid.New(), Title: "testtitle", Content: "testcontent"}
+
+	mockRepo.EXPECT().Create(mock.Anything, blog).Return(nil)
+	mockRepo.EXPECT().AddActivity(mock.Anything, mock.Anything).Return(repository.ErrUnavailable)
+
+	require.ErrorIs(t, svc.Create(ctx, blog, false), repository.ErrUnavailable)
+}
+
+func TestBlogService_CreateIdempotent(t *testing.T) {
+	userID := uuid.New()
+	newBlog := func() *model.Blog {
+		return &model.Blog{BlogID: uuid.New(), UserID: userID, Title: "testtitle", Content: "testcontent", Tags: []string{"Go"}}
+	}
+	hash, err := requestHash(newBlog())
+	require.NoError(t, err)
+
+	t.Run("first request", func(t *testing.T) {
+		mockRepo := mocks.NewMockBlogRepository(t)
+		svc := NewBlogService(mockRepo)
+		runBlogInTx(mockRepo)
+		blog := newBlog()
+		mockRepo.EXPECT().ClaimIdempotencyKey(mock.Anything, &model.IdempotencyKey{UserID: userID, Key: "k", RequestHash: hash, BlogID: blog.BlogID},
+			constants.IdempotencyKeyTTL).Return(nil, nil)
+		mockRepo.EXPECT().Create(mock.Anything, blog).Return(nil)
+		mockRepo.EXPECT().AddTags(mock.Anything, blog.BlogID, []string{"go"}).Return(nil)
+
+		created, replayed, err := svc.CreateIdempotent(context.Background(), blog, "k", false)
+		require.NoError(t, err)
+		require.False(t, replayed)
+		require.Same(t, blog, created)
+	})
+	t.Run("replay", func(t *testing.T) {
+		mockRepo := mocks.NewMockBlogRepository(t)
+		svc := NewBlogService(mockRepo)
+		originalID := uuid.New()
+		mockRepo.EXPECT().ClaimIdempotencyKey(mock.Anything, mock.Anything, constants.IdempotencyKeyTTL).
+			Return(&model.IdempotencyKey{UserID: userID, Key: "k", RequestHash: hash, BlogID: originalID}, nil)
+		mockRepo.EXPECT().Get(mock.Anything, originalID).Return(&model.Blog{BlogID: originalID, Title: "testtitle"}, nil)
+		mockRepo.EXPECT().GetTags(mock.Anything, originalID).Return([]string{"go"}, nil)
+
+		original, replayed, err := svc.CreateIdempotent(context.Background(), newBlog(), "k", false)
+		require.NoError(t, err)
+		require.True(t, replayed)
+		require.Equal(t, originalID, original.BlogID)
+	})
+	t.Run("different body", func(t *testing.T) {
+		mockRepo := mocks.NewMockBlogRepository(t)
+		svc := NewBlogService(mockRepo)
+		mockRepo.EXPECT().ClaimIdempotencyKey(mock.Anything, mock.Anything, constants.IdempotencyKeyTTL).
+			Return(&model.IdempotencyKey{UserID: userID, Key: "k", RequestHash: "other", BlogID: uuid.New()}, nil)
+
+		_, _, err := svc.CreateIdempotent(context.Background(), newBlog(), "k", false)
+		require.ErrorIs(t, err, ErrIdempotencyKeyReused)
+	})
+	t.Run("failed create releases the key", func(t *testing.T) {
+		mockRepo := mocks.NewMockBlogRepository(t)
+		svc := NewBlogService(mockRepo)
+		runBlogInTx(mockRepo)
+		blog := newBlog()
+		mockRepo.EXPECT().ClaimIdempotencyKey(mock.Anything, mock.Anything, constants.IdempotencyKeyTTL).Return(nil, nil)
+		mockRepo.EXPECT().Create(mock.Anything, blog).Return(errors.New("connection refused"))
+		mockRepo.EXPECT().DeleteIdempotencyKey(mock.Anything, userID, "k").Return(nil)
+
+		_, _, err := svc.CreateIdempotent(context.Background(), blog, "k", false)
+		require.Error(t, err)
+	})
+}
+
+func TestBlogService_GetBySlug(t *testing.T) {
+	mockRepo := mocks.NewMockBlogRepository(t)
+	svc := NewBlogService(mockRepo)
+
+	id := uuid.New()
+	mockRepo.EXPECT().GetBySlug(mock.Anything, "testtitle").Return(&model.Blog{BlogID: id, Title: "testtitle", Slug: "testtitle"}, nil)
+	mockRepo.EXPECT().GetTags(mock.Anything, id).Return([]string{"go"}, nil)
+
+	blog, err := svc.GetBySlug(context.Background(), "testtitle")
+	require.NoError(t, err)
+	require.Equal(t, id, blog.BlogID)
+	require.Equal(t, []string{"go"}, blog.Tags)
+}
+
+func TestBlogService_Update_ReplacesTags(t *testing.T) {
+	mockRepo := mocks.NewMockBlogRepository(t)
+	svc := NewBlogService(mockRepo)
+	runBlogInTx(mockRepo)
+
+	blog := &model.Blog{BlogID: uuid.New(), Title: "testtitle", Content: "testcontent", Tags: []string{"GO"}}
+
+	mockRepo.EXPECT().Update(mock.Anything, blog).Return(nil)
+	mockRepo.EXPECT().ReplaceTags(mock.Anything, blog.BlogID, []string{"go"}).Return(nil)
+
+	err := svc.Update(context.Background(), blog, false)
+	require.NoError(t, err)
+}
+
+func TestBlogService_Update_KeepsTagsWhenOmitted(t *testing.T) {
+	mockRepo := mocks.NewMockBlogRepository(t)
+	svc := NewBlogService(mockRepo)
+	runBlogInTx(mockRepo)
+
+	blog := &model.Blog{BlogID: uuid.New(), Title: "testtitle", Content: "testcontent"}
+
+	mockRepo.EXPECT().Update(mock.Anything, blog).Return(nil)
+
+	err := svc.Update(context.Background(), blog, false)
+	require.NoError(t, err)
+}
+
+func TestBlogService_GetByTag(t *testing.T) {
+	mockRepo := mocks.NewMockBlogRepository(t)
+	svc := NewBlogService(mockRepo)
+
+	blogs := []*model.Blog{{BlogID: uuid.New(), Title: "testtitle"}}
+	mockRepo.EXPECT().CountByTag(mock.Anything, "go").Return(1, nil)
+	mockRepo.EXPECT().GetByTag(mock.Anything, "go", 10, 0).Return(blogs, nil)
+
+	resp, err := svc.GetByTag(context.Background(), " Go ", 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, resp.Count)
+	require.Equal(t, blogs, resp.Blogs)
+}
+
+func TestBlogService_GetAll_PagingMetadata(t *testing.T) {
+	for _, tc := range []struct {
+		count, limit, offset int
+		page, totalPages     int
+	}{
+		{count: 0, limit: 20, offset: 0, page: 1, totalPages: 0},
+		{count: 40, limit: 20, offset: 20, page: 2, totalPages: 2},
+		{count: 41, limit: 20, offset: 40, page: 3, totalPages: 3},
+		{count: 5, limit: 2, offset: 3, page: 2, totalPages: 3},
+	} {
+		mockRepo := mocks.NewMockBlogRepository(t)
+		svc := NewBlogService(mockRepo)
+		mockRepo.EXPECT().Count(mock.Anything, model.BlogFilter{}).Return(tc.count, nil)
+		mockRepo.EXPECT().GetAll(mock.Anything, model.BlogFilter{}, tc.limit, tc.offset).Return([]*model.Blog{}, nil)
+
+		resp, err := svc.GetAll(context.Background(), model.BlogFilter{}, tc.limit, tc.offset)
+		require.NoError(t, err)
+		require.Equal(t, tc.count, resp.Count)
+		require.Equal(t, tc.limit, resp.Limit)
+		require.Equal(t, tc.offset, resp.Offset)
+		require.Equal(t, tc.page, resp.Page, "count %d offset %d", tc.count, tc.offset)
+		require.Equal(t, tc.totalPages, resp.TotalPages, "count %d limit %d", tc.count, tc.limit)
+	}
+}
 
-	err := svc.DeleteUserByID(context.Background(), userID)
+func TestBlogService_GetAll_CachesCount(t *testing.T) {
+	mockRepo := mocks.NewMockBlogRepository(t)
+	svc := NewBlogService(mockRepo)
+	now := time.Now()
+	svc.totalCount.now = func() time.Time { return now }
+	mockRepo.EXPECT().GetAll(mock.Anything, model.BlogFilter{}, 20, 0).Return([]*model.Blog{}, nil)
+
+	mockRepo.EXPECT().Count(mock.Anything, model.BlogFilter{}).Return(7, nil).Once()
+	for i := 0; i < 3; i++ {
+		resp, err := svc.GetAll(context.Background(), model.BlogFilter{}, 20, 0)
+		require.NoError(t, err)
+		require.Equal(t, 7, resp.Count)
+	}
+
+	now = now.Add(constants.CountCacheTTL)
+	mockRepo.EXPECT().Count(mock.Anything, model.BlogFilter{}).Return(8, nil).Once()
+	resp, err := svc.GetAll(context.Background(), model.BlogFilter{}, 20, 0)
+	require.NoError(t, err)
+	require.Equal(t, 8, resp.Count)
+
+	id := uuid.New()
+	runBlogInTx(mockRepo)
+	mockRepo.EXPECT().Delete(mock.Anything, id).Return(nil)
+	require.NoError(t, svc.Delete(context.Background(), id))
+	mockRepo.EXPECT().Count(mock.Anything, model.BlogFilter{}).Return(7, nil).Once()
+	resp, err = svc.GetAll(context.Background(), model.BlogFilter{}, 20, 0)
 	require.NoError(t, err)
+	require.Equal(t, 7, resp.Count)
+
+	mockRepo.AssertNumberOfCalls(t, "Count", 3)
+}
+
+func TestBlogService_GetAll_CountEstimate(t *testing.T) {
+	t.Run("above threshold", func(t *testing.T) {
+		mockRepo := mocks.NewMockBlogRepository(t)
+		svc := NewBlogService(mockRepo)
+		svc.SetCountEstimate(1000000)
+		mockRepo.EXPECT().EstimateCount(mock.Anything).Return(5000000, nil)
+		mockRepo.EXPECT().GetAll(mock.Anything, model.BlogFilter{}, 20, 0).Return([]*model.Blog{}, nil)
+
+		resp, err := svc.GetAll(context.Background(), model.BlogFilter{}, 20, 0)
+		require.NoError(t, err)
+		require.Equal(t, 5000000, resp.Count)
+		require.True(t, resp.CountIsEstimate)
+		mockRepo.AssertNotCalled(t, "Count", mock.Anything)
+	})
+	t.Run("below threshold", func(t *testing.T) {
+		mockRepo := mocks.NewMockBlogRepository(t)
+		svc := NewBlogService(mockRepo)
+		svc.SetCountEstimate(1000000)
+		mockRepo.EXPECT().EstimateCount(mock.Anything).Return(900, nil)
+		mockRepo.EXPECT().Count(mock.Anything, model.BlogFilter{}).Return(850, nil)
+		mockRepo.EXPECT().GetAll(mock.Anything, model.BlogFilter{}, 20, 0).Return([]*model.Blog{}, nil)
+
+		resp, err := svc.GetAll(context.Background(), model.BlogFilter{}, 20, 0)
+		require.NoError(t, err)
+		require.Equal(t, 850, resp.Count)
+		require.False(t, resp.CountIsEstimate)
+	})
+	t.Run("never analyzed", func(t *testing.T) {
+		mockRepo := mocks.NewMockBlogRepository(t)
+		svc := NewBlogService(mockRepo)
+		svc.SetCountEstimate(1000000)
+		mockRepo.EXPECT().EstimateCount(mock.Anything).Return(-1, nil)
+		mockRepo.EXPECT().Count(mock.Anything, model.BlogFilter{}).Return(3, nil)
+		mockRepo.EXPECT().GetAll(mock.Anything, model.BlogFilter{}, 20, 0).Return([]*model.Blog{}, nil)
+
+		resp, err := svc.GetAll(context.Background(), model.BlogFilter{}, 20, 0)
+		require.NoError(t, err)
+		require.Equal(t, 3, resp.Count)
+		require.False(t, resp.CountIsEstimate)
+	})
+}
+
+func TestBlogService_GetAll_FilteredCount(t *testing.T) {
+	mockRepo := mocks.NewMockBlogRepository(t)
+	svc := NewBlogService(mockRepo)
+	svc.SetCountEstimate(1000)
+
+	userID := uuid.New()
+	filter := model.BlogFilter{UserID: &userID}
+	mockRepo.EXPECT().Count(mock.Anything, filter).Return(4, nil).Twice()
+	mockRepo.EXPECT().GetAll(mock.Anything, filter, 10, 0).Return([]*model.Blog{}, nil).Twice()
+
+	for i := 0; i < 2; i++ {
+		resp, err := svc.GetAll(context.Background(), filter, 10, 0)
+		require.NoError(t, err)
+		require.Equal(t, 4, resp.Count)
+		require.False(t, resp.CountIsEstimate)
+	}
+}
+
+func TestBlogService_GetByUserID(t *testing.T) {
+	mockRepo := mocks.NewMockBlogRepository(t)
+	svc := NewBlogService(mockRepo)
+
+	userID := uuid.New()
+	blogs := []*model.Blog{{BlogID: uuid.New(), UserID: userID, Title: "testtitle"}}
+	mockRepo.EXPECT().CountByUserID(mock.Anything, userID, true).Return(11, nil)
+	mockRepo.EXPECT().GetByUserID(mock.Anything, userID, true, 10, 10).Return(blogs, nil)
+
+	resp, err := svc.GetByUserID(context.Background(), userID, true, 10, 10)
+	require.NoError(t, err)
+	require.Equal(t, 11, resp.Count)
+	require.Equal(t, blogs, resp.Blogs)
+}
+
+func TestBlogService_EmptyPageSerializesAsArray(t *testing.T) {
+	mockRepo := mocks.NewMockBlogRepository(t)
+	svc := NewBlogService(mockRepo)
+
+	userID := uuid.New()
+	mockRepo.EXPECT().Count(mock.Anything, model.BlogFilter{}).Return(0, nil)
+	mockRepo.EXPECT().GetAll(mock.Anything, model.BlogFilter{}, 10, 0).Return(nil, nil)
+	mockRepo.EXPECT().CountByUserID(mock.Anything, userID, false).Return(0, nil)
+	mockRepo.EXPECT().GetByUserID(mock.Anything, userID, false, 10, 0).Return(nil, nil)
+
+	all, err := svc.GetAll(context.Background(), model.BlogFilter{}, 10, 0)
+	require.NoError(t, err)
+	byUser, err := svc.GetByUserID(context.Background(), userID, false, 10, 0)
+	require.NoError(t, err)
+
+	for _, resp := range []*model.BlogListResponse{all, byUser} {
+		body, err := json.Marshal(resp)
+		require.NoError(t, err)
+		require.Contains(t, string(body), `"blogs":[]`)
+	}
+}
+
+func TestBlogService_IsBlogOwner(t *testing.T) {
+	mockRepo := mocks.NewMockBlogRepository(t)
+	svc := NewBlogService(mockRepo)
+
+	blogID, userID := uuid.New(), uuid.New()
+	mockRepo.EXPECT().IsBlogOwner(mock.Anything, blogID, userID).Return(true, nil)
+
+	owner, err := svc.IsBlogOwner(context.Background(), blogID, userID)
+	require.NoError(t, err)
+	require.True(t, owner)
+}
+
+func TestUserService_ChangePassword(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	cfg := &config.Config{BlogTokenSignature: "secret"}
+	svc := NewUserService(mockRepo, cfg)
+
+	userID := uuid.New()
+	hashedPass, _ := svc.HashPassword([]byte("old_password"))
+
+	mockRepo.EXPECT().
+		GetPasswordByID(mock.Anything, userID).
+		Return(hashedPass, nil)
+	runInTx(mockRepo)
+	mockRepo.EXPECT().
+		UpdatePassword(mock.Anything, userID, mock.AnythingOfType("[]uint8")).
+		Return(nil).
+		Run(func(_ context.Context, _ uuid.UUID, hash []byte) {
+			verified, err := svc.CheckPasswordHash(hash, []byte("new_password"))
+			require.NoError(t, err)
+			require.True(t, verified)
+		})
+	mockRepo.EXPECT().
+		BumpTokenVersion(mock.Anything, userID).
+		Return(nil)
+	mockRepo.EXPECT().
+		DeleteRefreshTokensByUserID(mock.Anything, userID).
+		Return(nil)
+
+	err := svc.ChangePassword(context.Background(), userID, []byte("old_password"), []byte("new_password"))
+	require.NoError(t, err)
+}
+
+func TestUserService_ChangePassword_WrongOldPassword(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	cfg := &config.Config{BlogTokenSignature: "secret"}
+	svc := NewUserService(mockRepo, cfg)
+
+	userID := uuid.New()
+	hashedPass, _ := svc.HashPassword([]byte("old_password"))
+
+	mockRepo.EXPECT().
+		GetPasswordByID(mock.Anything, userID).
+		Return(hashedPass, nil)
+
+	err := svc.ChangePassword(context.Background(), userID, []byte("wrong_password"), []byte("new_password"))
+	require.ErrorIs(t, err, ErrWrongPassword)
+}
+
+func TestUserService_Login_ByEmail(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	cfg := &config.Config{BlogTokenSignature: "secret"}
+	svc := NewUserService(mockRepo, cfg)
+
+	userID := uuid.New()
+	password := []byte("password123")
+	hashedPass, _ := svc.HashPassword(password)
+	user := &model.User{
+		Username: " TestUser@Example.com ",
+		Password: password,
+	}
+
+	mockRepo.EXPECT().
+		GetDataByEmail(mock.Anything, "testuser@example.com").
+		Return(&model.User{ID: userID, Username: "testuser", Email: "testuser@example.com", Password: hashedPass}, nil)
+	mockRepo.EXPECT().
+		GetLockedUntil(mock.Anything, "testuser").
+		Return(time.Time{}, nil)
+	mockRepo.EXPECT().
+		GetDataByUsername(mock.Anything, "testuser").
+		Return(userID, hashedPass, false, nil)
+	mockRepo.EXPECT().
+		ResetFailedLogins(mock.Anything, userID).
+		Return(nil)
+	mockRepo.EXPECT().
+		GetTokenVersion(mock.Anything, userID).
+		Return(0, nil)
+	mockRepo.EXPECT().
+		AddRefreshToken(mock.Anything, mock.AnythingOfType("*model.RefreshToken")).
+		Return(nil)
+
+	tokens, err := svc.Login(context.Background(), user)
+	require.NoError(t, err)
+	require.NotEmpty(t, tokens.AccessToken)
+	require.Equal(t, userID, user.ID)
+}
+
+func TestBlogService_Publish_WithModeration(t *testing.T) {
+	mockRepo := mocks.NewMockBlogRepository(t)
+	svc := NewBlogService(mockRepo)
+	svc.SetModeration(true)
+
+	id := uuid.New()
+	mockRepo.EXPECT().SubmitForReview(mock.Anything, id).Return(nil)
+
+	status, err := svc.Publish(context.Background(), id, false)
+	require.NoError(t, err)
+	require.Equal(t, model.BlogStatusPendingReview, status)
+}
+
+func TestBlogService_Publish_TrustedSkipsModeration(t *testing.T) {
+	mockRepo := mocks.NewMockBlogRepository(t)
+	svc := NewBlogService(mockRepo)
+	svc.SetModeration(true)
+
+	id := uuid.New()
+	runBlogInTx(mockRepo)
+	mockRepo.EXPECT().Publish(mock.Anything, id).Return(nil)
+
+	status, err := svc.Publish(context.Background(), id, true)
+	require.NoError(t, err)
+	require.Equal(t, model.BlogStatusPublished, status)
+}
+
+func TestBlogService_Update_WithModerationSendsBackToReview(t *testing.T) {
+	mockRepo := mocks.NewMockBlogRepository(t)
+	svc := NewBlogService(mockRepo)
+	svc.SetModeration(true)
+	runBlogInTx(mockRepo)
+
+	blog := &model.Blog{BlogID: uuid.New(), Title: "testtitle", Content: "testcontent"}
+
+	mockRepo.EXPECT().Update(mock.Anything, blog).Return(nil)
+	mockRepo.EXPECT().Get(mock.Anything, blog.BlogID).Return(&model.Blog{BlogID: blog.BlogID, Status: model.BlogStatusPublished}, nil)
+	mockRepo.EXPECT().SubmitForReview(mock.Anything, blog.BlogID).Return(nil)
+
+	err := svc.Update(context.Background(), blog, false)
+	require.NoError(t, err)
+	require.Equal(t, model.BlogStatusPendingReview, blog.Status)
+}
+
+func TestBlogService_Update_WithModerationSubmitFailure(t *testing.T) {
+	mockRepo := mocks.NewMockBlogRepository(t)
+	svc := NewBlogService(mockRepo)
+	svc.SetModeration(true)
+
+	blog := &model.Blog{BlogID: uuid.New(), Title: "testtitle", Content: "testcontent"}
+
+	// the error of the transaction is what rolls the edit back, so it never goes live unreviewed
+	mockRepo.EXPECT().InTx(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			err := fn(ctx)
+			require.ErrorIs(t, err, repository.ErrUnavailable)
+			return err
+		}).Once()
+	mockRepo.EXPECT().Update(mock.Anything, blog).Return(nil)
+	mockRepo.EXPECT().Get(mock.Anything, blog.BlogID).Return(&model.Blog{BlogID: blog.BlogID, Status: model.BlogStatusPublished}, nil)
+	mockRepo.EXPECT().SubmitForReview(mock.Anything, blog.BlogID).Return(repository.ErrUnavailable)
+
+	err := svc.Update(context.Background(), blog, false)
+	require.ErrorIs(t, err, repository.ErrUnavailable)
+	require.Empty(t, blog.Status)
+}
+
+func TestBlogService_Reject(t *testing.T) {
+	mockRepo := mocks.NewMockBlogRepository(t)
+	svc := NewBlogService(mockRepo)
+
+	id := uuid.New()
+	mockRepo.EXPECT().Reject(mock.Anything, id, "off-topic").Return(nil)
+
+	err := svc.Reject(context.Background(), id, "off-topic")
+	require.NoError(t, err)
+}
+
+func TestBlogService_Restore_NotFound(t *testing.T) {
+	mockRepo := mocks.NewMockBlogRepository(t)
+	svc := NewBlogService(mockRepo)
+
+	id := uuid.New()
+	runBlogInTx(mockRepo)
+	mockRepo.EXPECT().Restore(mock.Anything, id).Return(repository.ErrNotFound)
+
+	err := svc.Restore(context.Background(), id)
+	require.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+// capturingMailer records the last email instead of sending it
+type capturingMailer struct {
+	to, body string
+}
+
+func (m *capturingMailer) Send(_ context.Context, to, _, body string) error {
+	m.to, m.body = to, body
+	return nil
+}
+
+func TestUserService_RequestPasswordReset_UnknownEmail(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	cfg := &config.Config{BlogTokenSignature: "secret"}
+	svc := NewUserService(mockRepo, cfg)
+	m := &capturingMailer{}
+	svc.SetMailer(m)
+
+	mockRepo.EXPECT().
+		GetDataByEmail(mock.Anything, "missing@example.com").
+		Return(nil, repository.ErrNotFound)
+
+	err := svc.RequestPasswordReset(context.Background(), "missing@example.com")
+	require.NoError(t, err)
+	require.Empty(t, m.to)
+}
+
+func TestUserService_RequestPasswordReset_NoMailer(t *testing.T) {
+	svc := NewUserService(mocks.NewMockUserRepository(t), &config.Config{BlogTokenSignature: "secret"})
+
+	err := svc.RequestPasswordReset(context.Background(), "testuser@example.com")
+	require.ErrorIs(t, err, ErrPasswordResetDisabled)
+}
+
+// requestResetToken runs a password reset request and returns the emailed token and the stored reset row
+func requestResetToken(t *testing.T, svc *UserService, mockRepo *mocks.MockUserRepository, userID uuid.UUID) (string, *model.PasswordReset) {
+	t.Helper()
+	m := &capturingMailer{}
+	svc.SetMailer(m)
+	var stored *model.PasswordReset
+
+	mockRepo.EXPECT().
+		GetDataByEmail(mock.Anything, "testuser@example.com").
+		Return(&model.User{ID: userID, Username: "testuser", Email: "testuser@example.com"}, nil).Once()
+	mockRepo.EXPECT().
+		AddPasswordReset(mock.Anything, mock.AnythingOfType("*model.PasswordReset")).
+		Return(nil).
+		Run(func(_ context.Context, reset *model.PasswordReset) {
+			stored = reset
+		}).Once()
+
+	require.NoError(t, svc.RequestPasswordReset(context.Background(), "testuser@example.com"))
+	require.Equal(t, "testuser@example.com", m.to)
+	token := m.body[strings.LastIndex(m.body, " ")+1:]
+	require.NotEqual(t, token, stored.Token)
+	return token, stored
+}
+
+func TestUserService_ConfirmPasswordReset(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	cfg := &config.Config{BlogTokenSignature: "secret"}
+	svc := NewUserService(mockRepo, cfg)
+
+	userID := uuid.New()
+	token, stored := requestResetToken(t, svc, mockRepo, userID)
+
+	mockRepo.EXPECT().GetPasswordReset(mock.Anything, stored.ID).Return(stored, nil)
+	mockRepo.EXPECT().UsePasswordReset(mock.Anything, stored.ID).Return(nil)
+	runInTx(mockRepo)
+	mockRepo.EXPECT().UpdatePassword(mock.Anything, userID, mock.AnythingOfType("[]uint8")).Return(nil)
+	mockRepo.EXPECT().BumpTokenVersion(mock.Anything, userID).Return(nil)
+	mockRepo.EXPECT().DeleteRefreshTokensByUserID(mock.Anything, userID).Return(nil)
+
+	err := svc.ConfirmPasswordReset(context.Background(), token, []byte("new_password"))
+	require.NoError(t, err)
+}
+
+func TestUserService_ConfirmPasswordReset_AlreadyUsed(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	cfg := &config.Config{BlogTokenSignature: "secret"}
+	svc := NewUserService(mockRepo, cfg)
+
+	userID := uuid.New()
+	token, stored := requestResetToken(t, svc, mockRepo, userID)
+	usedAt := time.Now()
+	stored.UsedAt = &usedAt
+
+	mockRepo.EXPECT().GetPasswordReset(mock.Anything, stored.ID).Return(stored, nil)
+
+	err := svc.ConfirmPasswordReset(context.Background(), token, []byte("new_password"))
+	require.ErrorIs(t, err, ErrResetTokenUsed)
+}
+
+func TestUserService_ConfirmPasswordReset_Expired(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	cfg := &config.Config{BlogTokenSignature: "secret"}
+	svc := NewUserService(mockRepo, cfg)
+
+	token, err := svc.signToken(jwt.MapClaims{
+		"exp":     time.Now().Add(-time.Minute).Unix(),
+		"id":      uuid.New(),
+		"isAdmin": false,
+		"jti":     uuid.NewString(),
+		"purpose": resetTokenPurpose,
+	})
+	require.NoError(t, err)
+
+	err = svc.ConfirmPasswordReset(context.Background(), token, []byte("new_password"))
+	require.ErrorIs(t, err, ErrInvalidResetToken)
+}
+
+func TestUserService_ConfirmPasswordReset_AccessTokenRejected(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	cfg := &config.Config{BlogTokenSignature: "secret"}
+	svc := NewUserService(mockRepo, cfg)
+
+	token, err := svc.GenerateJWTToken(time.Minute, uuid.New(), false, 0)
+	require.NoError(t, err)
+
+	err = svc.ConfirmPasswordReset(context.Background(), token, []byte("new_password"))
+	require.ErrorIs(t, err, ErrInvalidResetToken)
+}
+
+func TestBlogService_Get_NotFound(t *testing.T) {
+	mockRepo := mocks.NewMockBlogRepository(t)
+	svc := NewBlogService(mockRepo)
+
+	id := uuid.New()
+	mockRepo.EXPECT().Get(mock.Anything, id).Return(nil, repository.ErrNotFound)
+
+	_, err := svc.Get(context.Background(), id)
+	require.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestUserService_GetProfiles_Batched(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	svc := NewUserService(mockRepo, &config.Config{})
+
+	first, second, missing := uuid.New(), uuid.New(), uuid.New()
+	mockRepo.EXPECT().
+		GetProfiles(mock.Anything, []uuid.UUID{second, missing, first}).
+		Return([]*model.UserProfile{{ID: first, Username: "first"}, {ID: second, Username: "second"}}, nil).
+		Once()
+
+	profiles, err := svc.GetProfiles(context.Background(), []uuid.UUID{second, missing, first, second})
+	require.NoError(t, err)
+	require.Equal(t, []*model.UserProfile{{ID: second, Username: "second"}, {ID: first, Username: "first"}}, profiles)
+
+	profiles, err = svc.GetProfiles(context.Background(), nil)
+	require.NoError(t, err)
+	require.Empty(t, profiles)
+}
+
+func TestBlogService_Create_RejectsJavascriptLink(t *testing.T) {
+	mockRepo := mocks.NewMockBlogRepository(t)
+	svc := NewBlogService(mockRepo)
+	require.NoError(t, svc.SetMediaCheck(MediaCheckReject, nil))
+
+	blog := &model.Blog{
+		BlogID:  uuid.New(),
+		Title:   "testtitle",
+		Content: `Click [here](javascript:alert(1)), <a href="java	script:alert(1)">here</a> or <img src="data:image/png;base64,AAAA">`,
+	}
+
+	err := svc.Create(context.Background(), blog, false)
+	var mediaErr *MediaError
+	require.ErrorAs(t, err, &mediaErr)
+	require.Len(t, mediaErr.Issues, 3)
+	require.Equal(t, "content", mediaErr.Issues[0].Field)
+	require.Equal(t, "disallowed scheme javascript", mediaErr.Issues[0].Reason)
+	require.Equal(t, "disallowed scheme javascript", mediaErr.Issues[1].Reason)
+	require.Equal(t, "disallowed scheme data", mediaErr.Issues[2].Reason)
+}
+
+func TestBlogService_Create_AllowlistedImagePasses(t *testing.T) {
+	mockRepo := mocks.NewMockBlogRepository(t)
+	svc := NewBlogService(mockRepo)
+	runBlogInTx(mockRepo)
+	require.NoError(t, svc.SetMediaCheck(MediaCheckReject, []string{"images.example.com"}))
+
+	blog := &model.Blog{
+		BlogID:  uuid.New(),
+		Title:   "testtitle",
+		Content: "![cat](https://images.example.com/cat.png) and [more](/blog/about)",
+	}
+	mockRepo.EXPECT().Create(mock.Anything, blog).Return(nil)
+
+	require.NoError(t, svc.Create(context.Background(), blog, false))
+	require.Empty(t, blog.Warnings)
+}
+
+func TestBlogService_Update_WarnsOnUnknownHost(t *testing.T) {
+	mockRepo := mocks.NewMockBlogRepository(t)
+	svc := NewBlogService(mockRepo)
+	runBlogInTx(mockRepo)
+	require.NoError(t, svc.SetMediaCheck(MediaCheckWarn, []string{"images.example.com"}))
+
+	blog := &model.Blog{
+		BlogID:  uuid.New(),
+		Title:   "testtitle",
+		Content: `<a href='https://tracker.example.net/pixel'>link</a>`,
+	}
+	mockRepo.EXPECT().Update(mock.Anything, blog).Return(nil)
+
+	require.NoError(t, svc.Update(context.Background(), blog, true))
+	require.Equal(t, []model.MediaIssue{{
+		Field:  "content",
+		URL:    "https://tracker.example.net/pixel",
+		Reason: "host tracker.example.net is not allowed",
+	}}, blog.Warnings)
+}
+
+func TestBlogService_Preview(t *testing.T) {
+	svc := NewBlogService(mocks.NewMockBlogRepository(t))
+	require.NoError(t, svc.SetMediaCheck(MediaCheckWarn, []string{"images.example.com"}))
+
+	preview, err := svc.Preview("![cat](https://images.example.com/cat.png) and ![pixel](https://tracker.example.net/p.gif)")
+	require.NoError(t, err)
+	require.Contains(t, preview.HTML, `<img src="https://images.example.com/cat.png"`)
+	require.Equal(t, 1, preview.WordCount)
+	require.Equal(t, []model.MediaIssue{{
+		Field:  "content",
+		URL:    "https://tracker.example.net/p.gif",
+		Reason: "host tracker.example.net is not allowed",
+	}}, preview.Warnings)
+}
+
+func TestBlogService_Exists(t *testing.T) {
+	mockRepo := mocks.NewMockBlogRepository(t)
+	svc := NewBlogService(mockRepo)
+	present, absent, viewerID := uuid.New(), uuid.New(), uuid.New()
+	mockRepo.EXPECT().ExistingIDs(mock.Anything, []uuid.UUID{present, absent}, viewerID).Return([]uuid.UUID{present}, nil)
+
+	exists, err := svc.Exists(context.Background(), []uuid.UUID{present, absent}, viewerID)
+	require.NoError(t, err)
+	require.Equal(t, map[uuid.UUID]bool{present: true, absent: false}, exists)
+
+	exists, err = svc.Exists(context.Background(), []uuid.UUID{}, viewerID)
+	require.NoError(t, err)
+	require.Empty(t, exists)
+}
+
+func TestBlogService_SetMediaCheck_UnknownMode(t *testing.T) {
+	svc := NewBlogService(mocks.NewMockBlogRepository(t))
+	require.Error(t, svc.SetMediaCheck("strict", nil))
+}
+
+func TestDiagnosticsService_Diagnostics(t *testing.T) {
+	mockRepo := mocks.NewMockDiagnosticsRepository(t)
+	svc := NewDiagnosticsService(mockRepo)
+
+	stats := model.PoolStats{TotalConns: 4, AcquiredConns: 1, IdleConns: 3, MaxConns: 10, AcquireCount: 42, AcquireDuration: "15ms"}
+	mockRepo.EXPECT().ServerVersion(mock.Anything).Return("16.2", nil)
+	mockRepo.EXPECT().MigrationVersion(mock.Anything).Return(13, nil)
+	queries := map[string]model.QueryStats{"SELECT blog": {Count: 7, TotalMs: 12.5}}
+	mockRepo.EXPECT().PoolStats().Return(stats)
+	mockRepo.EXPECT().QueryStats().Return(queries)
+
+	diagnostics, err := svc.Diagnostics(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, model.DatabaseDiagnostics{ServerVersion: "16.2", MigrationVersion: 13, Pool: stats, Queries: queries}, diagnostics.Database)
+	require.Equal(t, runtime.Version(), diagnostics.Build.GoVersion)
+}
+
+type fixedCacheStats model.CacheStats
+
+func (s fixedCacheStats) Stats() model.CacheStats {
+	return model.CacheStats(s)
+}
+
+func TestDiagnosticsService_Diagnostics_Cache(t *testing.T) {
+	mockRepo := mocks.NewMockDiagnosticsRepository(t)
+	svc := NewDiagnosticsService(mockRepo)
+	svc.SetCache(fixedCacheStats{Hits: 9, Misses: 3, Evictions: 1, Size: 2})
+
+	mockRepo.EXPECT().ServerVersion(mock.Anything).Return("16.2", nil)
+	mockRepo.EXPECT().MigrationVersion(mock.Anything).Return(13, nil)
+	mockRepo.EXPECT().PoolStats().Return(model.PoolStats{})
+	mockRepo.EXPECT().QueryStats().Return(nil)
+
+	diagnostics, err := svc.Diagnostics(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, &model.CacheStats{Hits: 9, Misses: 3, Evictions: 1, Size: 2}, diagnostics.Cache)
+}
+
+func TestDiagnosticsService_Diagnostics_VersionError(t *testing.T) {
+	mockRepo := mocks.NewMockDiagnosticsRepository(t)
+	svc := NewDiagnosticsService(mockRepo)
+
+	errDown := errors.New("connection refused")
+	mockRepo.EXPECT().ServerVersion(mock.Anything).Return("", errDown)
+
+	_, err := svc.Diagnostics(context.Background())
+	require.ErrorIs(t, err, errDown)
+}
+
+func TestUserService_SetRole_Promote(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	svc := NewUserService(mockRepo, &config.Config{})
+
+	id := uuid.New()
+	runInTx(mockRepo)
+	mockRepo.EXPECT().SetAdmin(mock.Anything, id, true).Return(nil)
+	mockRepo.EXPECT().BumpTokenVersion(mock.Anything, id).Return(nil)
+	mockRepo.EXPECT().DeleteRefreshTokensByUserID(mock.Anything, id).Return(nil)
+
+	require.NoError(t, svc.SetRole(context.Background(), id, true))
+}
+
+func TestUserService_SetRole_Demote(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	svc := NewUserService(mockRepo, &config.Config{})
+
+	id := uuid.New()
+	runInTx(mockRepo)
+	mockRepo.EXPECT().SetAdmin(mock.Anything, id, false).Return(nil)
+	mockRepo.EXPECT().BumpTokenVersion(mock.Anything, id).Return(nil)
+	mockRepo.EXPECT().DeleteRefreshTokensByUserID(mock.Anything, id).Return(nil)
+
+	require.NoError(t, svc.SetRole(context.Background(), id, false))
+}
+
+func TestUserService_SetRole_DemoteRevokesAccessTokens(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	cfg := &config.Config{BlogTokenSignature: "secret"}
+	svc := NewUserService(mockRepo, cfg)
+
+	id := uuid.New()
+	version := 0
+	tokenPair, err := svc.GenerateTokenPair(id, true, version, uuid.New())
+	require.NoError(t, err)
+	mockRepo.EXPECT().GetTokenVersion(mock.Anything, id).RunAndReturn(
+		func(context.Context, uuid.UUID) (int, error) {
+			return version, nil
+		})
+	runInTx(mockRepo)
+	mockRepo.EXPECT().SetAdmin(mock.Anything, id, false).Return(nil)
+	mockRepo.EXPECT().BumpTokenVersion(mock.Anything, id).Run(func(context.Context, uuid.UUID) {
+		version++
+	}).Return(nil).Once()
+	mockRepo.EXPECT().DeleteRefreshTokensByUserID(mock.Anything, id).Return(nil)
+
+	e := echo.New()
+	e.GET("/", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, middleware.JWTMiddleware(cfg, svc))
+	authorized := func() int {
+		req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+tokenPair.AccessToken)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	require.Equal(t, http.StatusOK, authorized(), "the admin token works before the demotion")
+	require.NoError(t, svc.SetRole(context.Background(), id, false))
+	require.Equal(t, http.StatusUnauthorized, authorized(), "the admin token issued before the demotion is rejected")
+}
+
+func TestUserService_SetRole_LastAdmin(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	svc := NewUserService(mockRepo, &config.Config{})
+
+	id := uuid.New()
+	runInTx(mockRepo)
+	mockRepo.EXPECT().SetAdmin(mock.Anything, id, false).Return(repository.ErrLastAdmin)
+
+	err := svc.SetRole(context.Background(), id, false)
+	require.ErrorIs(t, err, ErrLastAdmin)
+}
+
+func TestUserService_SetRole_NotFound(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	svc := NewUserService(mockRepo, &config.Config{})
+
+	id := uuid.New()
+	runInTx(mockRepo)
+	mockRepo.EXPECT().SetAdmin(mock.Anything, id, true).Return(repository.ErrNotFound)
+
+	err := svc.SetRole(context.Background(), id, true)
+	require.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestUserService_Impersonate(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	cfg := &config.Config{BlogTokenSignature: "secret"}
+	svc := NewUserService(mockRepo, cfg)
+
+	adminID, id := uuid.New(), uuid.New()
+	mockRepo.EXPECT().GetUserByID(mock.Anything, id).Return(&model.User{ID: id}, nil)
+	mockRepo.EXPECT().AddAuditEntry(mock.Anything, mock.MatchedBy(func(entry *model.AuditEntry) bool {
+		return entry.ID != uuid.Nil && entry.Action == model.AuditActionImpersonate &&
+			entry.ActorID == adminID && entry.TargetID == id
+	})).Return(nil).Once()
+
+	tokenString, err := svc.Impersonate(context.Background(), adminID, id)
+	require.NoError(t, err)
+
+	token, err := jwt.Parse(tokenString, func(*jwt.Token) (interface{}, error) {
+		return []byte(cfg.BlogTokenSignature), nil
+	})
+	require.NoError(t, err)
+	claims := token.Claims.(jwt.MapClaims)
+	require.Equal(t, id.String(), claims["id"])
+	require.Equal(t, adminID.String(), claims["impersonated_by"])
+	require.Equal(t, false, claims["isAdmin"])
+	exp, err := claims.GetExpirationTime()
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now().Add(constants.ImpersonationTokenExpiration), exp.Time, 5*time.Second)
+}
+
+func TestUserService_Impersonate_Admin(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	svc := NewUserService(mockRepo, &config.Config{BlogTokenSignature: "secret"})
+
+	id := uuid.New()
+	mockRepo.EXPECT().GetUserByID(mock.Anything, id).Return(&model.User{ID: id, Admin: true}, nil)
+
+	_, err := svc.Impersonate(context.Background(), uuid.New(), id)
+	require.ErrorIs(t, err, ErrImpersonateAdmin)
+}
+
+func TestUserService_Impersonate_AuditFailure(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	svc := NewUserService(mockRepo, &config.Config{BlogTokenSignature: "secret"})
+
+	id := uuid.New()
+	errDown := errors.New("database is down")
+	mockRepo.EXPECT().GetUserByID(mock.Anything, id).Return(&model.User{ID: id}, nil)
+	mockRepo.EXPECT().AddAuditEntry(mock.Anything, mock.Anything).Return(errDown)
+
+	token, err := svc.Impersonate(context.Background(), uuid.New(), id)
+	require.ErrorIs(t, err, errDown)
+	require.Empty(t, token)
+}
+
+// runInTx makes the mocked InTx call fn, like the repository does inside its transaction
+func runInTx(mockRepo *mocks.MockUserRepository) {
+	mockRepo.EXPECT().InTx(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return commitTx(ctx, fn)
+		})
+}
+
+// commitTx calls fn and then what it left for repository.AfterCommit, unless fn failed
+func commitTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	txCtx, commit := repository.WithAfterCommit(ctx)
+	if err := fn(txCtx); err != nil {
+		return err
+	}
+	commit()
+	return nil
+}
+
+// runBlogInTx makes the mocked InTx of the blog repository call fn, like the repository does inside its transaction
+func runBlogInTx(mockRepo *mocks.MockBlogRepository) {
+	mockRepo.EXPECT().InTx(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return commitTx(ctx, fn)
+		})
+}
+
+func TestUserService_SetSignupMode(t *testing.T) {
+	svc := NewUserService(mocks.NewMockUserRepository(t), &config.Config{})
+	for _, mode := range []string{"", SignupOpen, SignupInvite, SignupDisabled} {
+		require.NoError(t, svc.SetSignupMode(mode), mode)
+	}
+	require.Error(t, svc.SetSignupMode("closed"))
+}
+
+func TestUserService_SignUpUser_Disabled(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	svc := NewUserService(mockRepo, &config.Config{BlogBcryptCost: bcrypt.MinCost})
+	require.NoError(t, svc.SetSignupMode(SignupDisabled))
+
+	err := svc.SignUpUser(context.Background(), &model.User{Username: "testuser", Password: []byte("password123")}, "")
+	require.ErrorIs(t, err, ErrSignupDisabled)
+}
+
+func TestUserService_SignUpUser_Invite(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	svc := NewUserService(mockRepo, &config.Config{BlogBcryptCost: bcrypt.MinCost})
+	require.NoError(t, svc.SetSignupMode(SignupInvite))
+
+	user := &model.User{ID: uuid.New(), Username: "testuser", Password: []byte("password123")}
+	runInTx(mockRepo)
+	mockRepo.EXPECT().SignUp(mock.Anything, user).Return(nil)
+	mockRepo.EXPECT().UseInvite(mock.Anything, hashToken("INVITECODE"), user.ID).Return(nil)
+
+	require.NoError(t, svc.SignUpUser(context.Background(), user, "INVITECODE"))
+}
+
+func TestUserService_SignUpUser_UsedInvite(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	svc := NewUserService(mockRepo, &config.Config{BlogBcryptCost: bcrypt.MinCost})
+	require.NoError(t, svc.SetSignupMode(SignupInvite))
+
+	user := &model.User{ID: uuid.New(), Username: "testuser", Password: []byte("password123")}
+	runInTx(mockRepo)
+	mockRepo.EXPECT().SignUp(mock.Anything, user).Return(nil)
+	mockRepo.EXPECT().UseInvite(mock.Anything, hashToken("USEDCODE"), user.ID).Return(repository.ErrNotFound)
+
+	err := svc.SignUpUser(context.Background(), user, "USEDCODE")
+	require.ErrorIs(t, err, ErrInvalidInvite)
+
+	err = svc.SignUpUser(context.Background(), &model.User{Username: "otheruser", Password: []byte("password123")}, "")
+	require.ErrorIs(t, err, ErrInvalidInvite)
+}
+
+func TestUserService_CreateInvites(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	svc := NewUserService(mockRepo, &config.Config{})
+
+	adminID := uuid.New()
+	var stored []string
+	mockRepo.EXPECT().AddInvite(mock.Anything, mock.MatchedBy(func(invite *model.Invite) bool {
+		return invite.CreatedBy == adminID
+	})).Run(func(_ context.Context, invite *model.Invite) {
+		stored = append(stored, invite.Code)
+	}).Return(nil).Times(3)
+
+	codes, err := svc.CreateInvites(context.Background(), adminID, 3)
+	require.NoError(t, err)
+	require.Len(t, codes, 3)
+	for i, code := range codes {
+		require.NotEmpty(t, code)
+		require.Equal(t, hashToken(code), stored[i], "only the hash of the code is stored")
+	}
+}
+
+func TestUserService_RevokeTokens(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	cfg := &config.Config{BlogTokenSignature: "secret", BlogBcryptCost: bcrypt.MinCost}
+	svc := NewUserService(mockRepo, cfg)
+	ctx := context.Background()
+
+	adminID, id, sessionID := uuid.New(), uuid.New(), uuid.New()
+	version := 2
+	tokenPair, err := svc.GenerateTokenPair(id, false, version, sessionID)
+	require.NoError(t, err)
+	hashed, err := svc.hashRefreshToken(tokenPair.RefreshToken)
+	require.NoError(t, err)
+	// the repository keeps the refresh tokens of the user until the revoke deletes them
+	stored := &model.RefreshToken{ID: sessionID, UserID: id, Token: hashed}
+	mockRepo.EXPECT().GetRefreshToken(mock.Anything, sessionID).RunAndReturn(
+		func(context.Context, uuid.UUID) (*model.RefreshToken, error) {
+			if stored == nil {
+				return nil, repository.ErrNotFound
+			}
+			return stored, nil
+		})
+	mockRepo.EXPECT().GetTokenVersion(mock.Anything, id).RunAndReturn(
+		func(context.Context, uuid.UUID) (int, error) {
+			return version, nil
+		})
+	runInTx(mockRepo)
+	mockRepo.EXPECT().BumpTokenVersion(mock.Anything, id).Run(func(context.Context, uuid.UUID) {
+		version++
+	}).Return(nil).Once()
+	mockRepo.EXPECT().DeleteRefreshTokensByUserID(mock.Anything, id).Run(func(context.Context, uuid.UUID) {
+		stored = nil
+	}).Return(nil).Once()
+	mockRepo.EXPECT().AddAuditEntry(mock.Anything, mock.MatchedBy(func(entry *model.AuditEntry) bool {
+		return entry.Action == model.AuditActionRevokeTokens && entry.ActorID == adminID && entry.TargetID == id
+	})).Return(nil).Once()
+
+	// the access token is accepted while its ver claim matches the token version of the user
+	e := echo.New()
+	e.GET("/", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, middleware.JWTMiddleware(cfg, svc))
+	authorized := func() int {
+		req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+tokenPair.AccessToken)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	_, err = svc.findRefreshToken(ctx, id, tokenPair.RefreshToken)
+	require.NoError(t, err, "the session works before the revoke")
+	require.Equal(t, http.StatusOK, authorized(), "the access token works before the revoke")
+
+	require.NoError(t, svc.RevokeTokens(ctx, adminID, id))
+
+	require.Equal(t, http.StatusUnauthorized, authorized(), "the access token stops working with the revoke")
+	_, err = svc.Refresh(ctx, tokenPair)
+	require.Error(t, err)
+	require.Error(t, svc.Logout(ctx, id, tokenPair.RefreshToken))
+}
+
+func TestUserService_RefreshTokenIsNotAnAccessToken(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	cfg := &config.Config{BlogTokenSignature: "secret"}
+	svc := NewUserService(mockRepo, cfg)
+
+	tokenPair, err := svc.GenerateTokenPair(uuid.New(), true, 0, uuid.New())
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.GET("/", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, middleware.JWTMiddleware(cfg, svc))
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+tokenPair.RefreshToken)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestUserService_RevokeTokens_NotFound(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	svc := NewUserService(mockRepo, &config.Config{})
+
+	id := uuid.New()
+	runInTx(mockRepo)
+	mockRepo.EXPECT().BumpTokenVersion(mock.Anything, id).Return(repository.ErrNotFound)
+
+	err := svc.RevokeTokens(context.Background(), uuid.New(), id)
+	require.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestUserService_TokenVersion_MissingUser(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	svc := NewUserService(mockRepo, &config.Config{})
+
+	id := uuid.New()
+	mockRepo.EXPECT().GetTokenVersion(mock.Anything, id).Return(0, repository.ErrNotFound)
+
+	_, err := svc.TokenVersion(context.Background(), id)
+	require.ErrorIs(t, err, middleware.ErrTokenRevoked)
+}
+
+func TestUserService_GetActivity(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository(t)
+	svc := NewUserService(mockRepo, &config.Config{})
+
+	id := uuid.New()
+	activities := []*model.Activity{
+		{ID: uuid.New(), UserID: id, Action: model.ActivityBlogUpdated, TargetID: uuid.New()},
+		{ID: uuid.New(), UserID: id, Action: model.ActivityBlogCreated, TargetID: uuid.New()},
+	}
+	mockRepo.EXPECT().CountActivity(mock.Anything, id).Return(5, nil)
+	mockRepo.EXPECT().GetActivity(mock.Anything, id, 2, 0).Return(activities, nil)
+
+	resp, err := svc.GetActivity(context.Background(), id, 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, &model.ActivityListResponse{Activities: activities, Count: 5}, resp)
+}
+
+func TestUserService_HashPassword_ConfiguredCost(t *testing.T) {
+	svc := NewUserService(mocks.NewMockUserRepository(t), &config.Config{BlogBcryptCost: bcrypt.MinCost})
+
+	hash, err := svc.HashPassword([]byte("password123"))
+	require.NoError(t, err)
+	cost, err := bcrypt.Cost(hash)
+	require.NoError(t, err)
+	require.Equal(t, bcrypt.MinCost, cost)
+}
+
+func TestUserService_BcryptCost_OutOfRange(t *testing.T) {
+	for _, configured := range []int{0, 3, 32} {
+		svc := NewUserService(mocks.NewMockUserRepository(t), &config.Config{BlogBcryptCost: configured})
+		require.Equal(t, constants.BcryptCost, svc.bcryptCost())
+	}
 }
@@ -3,15 +3,21 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
 	"crypto/sha256"
-	"time"
-
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/artnikel/blogapi/internal/config"
 	"github.com/artnikel/blogapi/internal/constants"
+	"github.com/artnikel/blogapi/internal/mailer"
 	"github.com/artnikel/blogapi/internal/middleware"
 	"github.com/artnikel/blogapi/internal/model"
+	"github.com/artnikel/blogapi/internal/repository"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
@@ -21,20 +27,101 @@ import (
 type UserRepository interface {
 	SignUp(ctx context.Context, user *model.User) error
 	GetDataByUsername(ctx context.Context, username string) (uuid.UUID, []byte, bool, error)
-	AddRefreshToken(ctx context.Context, user *model.User) error
-	GetRefreshTokenByID(ctx context.Context, id uuid.UUID) (string, error)
+	GetDataByEmail(ctx context.Context, email string) (*model.User, error)
+	GetUserByID(ctx context.Context, id uuid.UUID) (*model.User, error)
+	SetAdmin(ctx context.Context, id uuid.UUID, admin bool) error
+	GetLockedUntil(ctx context.Context, username string) (time.Time, error)
+	RecordFailedLogin(ctx context.Context, username string, maxAttempts int, lockout time.Duration) (time.Time, error)
+	ResetFailedLogins(ctx context.Context, id uuid.UUID) error
+	GetPasswordByID(ctx context.Context, id uuid.UUID) ([]byte, error)
+	UpdatePassword(ctx context.Context, id uuid.UUID, hash []byte) error
+	AddPasswordReset(ctx context.Context, reset *model.PasswordReset) error
+	GetPasswordReset(ctx context.Context, id uuid.UUID) (*model.PasswordReset, error)
+	UsePasswordReset(ctx context.Context, id uuid.UUID) error
+	AddRefreshToken(ctx context.Context, token *model.RefreshToken) error
+	GetRefreshToken(ctx context.Context, id uuid.UUID) (*model.RefreshToken, error)
+	RotateRefreshToken(ctx context.Context, token *model.RefreshToken) error
+	DeleteRefreshToken(ctx context.Context, id uuid.UUID) error
+	DeleteRefreshTokensByUserID(ctx context.Context, id uuid.UUID) error
+	GetTokenVersion(ctx context.Context, id uuid.UUID) (int, error)
+	BumpTokenVersion(ctx context.Context, id uuid.UUID) error
+	DeleteBlogsByUserID(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)
 	DeleteUserByID(ctx context.Context, id uuid.UUID) error
+	GetProfiles(ctx context.Context, ids []uuid.UUID) ([]*model.UserProfile, error)
+	AddAuditEntry(ctx context.Context, entry *model.AuditEntry) error
+	AddInvite(ctx context.Context, invite *model.Invite) error
+	UseInvite(ctx context.Context, code string, userID uuid.UUID) error
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+	AddActivity(ctx context.Context, activity *model.Activity) error
+	CountActivity(ctx context.Context, userID uuid.UUID) (int, error)
+	GetActivity(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*model.Activity, error)
+}
+
+// BlogDeleter soft-deletes the blogs of a user
+type BlogDeleter interface {
+	DeleteBlogsByUserID(ctx context.Context, id uuid.UUID) error
 }
 
+// Signup modes of the public signup endpoint
+const (
+	// SignupOpen lets anyone sign up
+	SignupOpen = "open"
+	// SignupInvite requires a single-use invite code generated by an admin
+	SignupInvite = "invite"
+	// SignupDisabled refuses every signup, admins can still create accounts
+	SignupDisabled = "disabled"
+)
+
+// resetTokenPurpose is the purpose claim of password reset tokens, which keeps them from being used as access tokens
+const resetTokenPurpose = "reset"
+
 // UserService contains UserRepository interface
 type UserService struct {
-	rpsUser UserRepository
-	cfg     *config.Config
+	rpsUser    UserRepository
+	cfg        *config.Config
+	mailer     mailer.Mailer
+	blogs      BlogDeleter
+	signupMode string
 }
 
 // NewUserService accepts UserRepository object and returnes an object of type *UserService
 func NewUserService(rpsUser UserRepository, cfg *config.Config) *UserService {
-	return &UserService{rpsUser: rpsUser, cfg: cfg}
+	return &UserService{rpsUser: rpsUser, cfg: cfg, blogs: repositoryBlogs{rpsUser}, signupMode: SignupOpen}
+}
+
+// SetSignupMode configures who may sign up through SignUpUser.
+// Mode is one of SignupOpen, SignupInvite or SignupDisabled, an empty mode keeps signups open.
+func (s *UserService) SetSignupMode(mode string) error {
+	switch mode {
+	case "":
+		mode = SignupOpen
+	case SignupOpen, SignupInvite, SignupDisabled:
+	default:
+		return fmt.Errorf("unknown signup mode %q", mode)
+	}
+	s.signupMode = mode
+	return nil
+}
+
+// SetMailer sets the mailer used to email users, without one password resets are disabled
+func (s *UserService) SetMailer(m mailer.Mailer) {
+	s.mailer = m
+}
+
+// repositoryBlogs deletes the blogs of a user straight in the repository, without the caches or the activity log
+type repositoryBlogs struct {
+	rps UserRepository
+}
+
+func (r repositoryBlogs) DeleteBlogsByUserID(ctx context.Context, id uuid.UUID) error {
+	_, err := r.rps.DeleteBlogsByUserID(ctx, id)
+	return err
+}
+
+// SetBlogDeleter sets what deletes the blogs of deleted users, by default the repository.
+// Passing the BlogService drops the blogs from the caches and the blog count and records them in the activity log as well.
+func (s *UserService) SetBlogDeleter(blogs BlogDeleter) {
+	s.blogs = blogs
 }
 
 // TokenPair contains an Access and a Refresh tokens
@@ -45,10 +132,9 @@ type TokenPair struct {
 
 // SignUp is a method of UserService that calls  method of Repository
 func (s *UserService) SignUp(ctx context.Context, user *model.User) error {
-	var err error
-	user.Password, err = s.HashPassword(user.Password)
+	err := s.prepareUser(user)
 	if err != nil {
-		return fmt.Errorf("HashPassword - %w", err)
+		return fmt.Errorf("prepareUser - %w", err)
 	}
 	err = s.rpsUser.SignUp(ctx, user)
 	if err != nil {
@@ -57,8 +143,86 @@ func (s *UserService) SignUp(ctx context.Context, user *model.User) error {
 	return nil
 }
 
-// Login is a method of UserService that calls method of Repository
+// SignUpUser is a method of UserService that signs up a user through the public signup, following the signup mode.
+// With signups disabled it returns ErrSignupDisabled. Invite-only signups need an unused invite code, the user is
+// created and the invite used up in one transaction, a missing, unknown or used code returns ErrInvalidInvite.
+func (s *UserService) SignUpUser(ctx context.Context, user *model.User, invite string) error {
+	switch s.signupMode {
+	case SignupDisabled:
+		return ErrSignupDisabled
+	case SignupInvite:
+		if invite == "" {
+			return ErrInvalidInvite
+		}
+	default:
+		return s.SignUp(ctx, user)
+	}
+	err := s.prepareUser(user)
+	if err != nil {
+		return fmt.Errorf("prepareUser - %w", err)
+	}
+	return s.rpsUser.InTx(ctx, func(ctx context.Context) error {
+		err := s.rpsUser.SignUp(ctx, user)
+		if err != nil {
+			return fmt.Errorf("rpsUser.SignUp - %w", err)
+		}
+		err = s.rpsUser.UseInvite(ctx, hashToken(invite), user.ID)
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrInvalidInvite
+		}
+		if err != nil {
+			return fmt.Errorf("rpsUser.UseInvite - %w", err)
+		}
+		return nil
+	})
+}
+
+// prepareUser normalizes the username and the email of a new user and hashes the password
+func (s *UserService) prepareUser(user *model.User) error {
+	var err error
+	user.Username = normalizeUsername(user.Username)
+	user.Email = normalizeEmail(user.Email)
+	user.Password, err = s.HashPassword(user.Password)
+	if err != nil {
+		return fmt.Errorf("HashPassword - %w", err)
+	}
+	return nil
+}
+
+// CreateInvites is a method of UserService that generates count single-use invite codes on behalf of an admin.
+// Only hashes of the codes are stored, the codes themselves are returned once.
+func (s *UserService) CreateInvites(ctx context.Context, adminID uuid.UUID, count int) ([]string, error) {
+	codes := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		code := rand.Text()
+		err := s.rpsUser.AddInvite(ctx, &model.Invite{ID: uuid.New(), Code: hashToken(code), CreatedBy: adminID})
+		if err != nil {
+			return nil, fmt.Errorf("rpsUser.AddInvite - %w", err)
+		}
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
+
+// Login is a method of UserService that calls method of Repository.
+// The user may log in with either the username or the email in the Username field.
+// After constants.MaxFailedLogins wrong passwords in a row the account is locked and ErrAccountLocked is returned until the lock expires.
 func (s *UserService) Login(ctx context.Context, user *model.User) (*TokenPair, error) {
+	if strings.Contains(user.Username, "@") {
+		byEmail, err := s.rpsUser.GetDataByEmail(ctx, normalizeEmail(user.Username))
+		if err != nil {
+			return &TokenPair{}, fmt.Errorf("rpsUser.GetDataByEmail - %w", err)
+		}
+		user.Username = byEmail.Username
+		user.Email = byEmail.Email
+	}
+	lockedUntil, err := s.rpsUser.GetLockedUntil(ctx, user.Username)
+	if err != nil {
+		return &TokenPair{}, fmt.Errorf("rpsUser.GetLockedUntil - %w", err)
+	}
+	if time.Now().Before(lockedUntil) {
+		return &TokenPair{}, fmt.Errorf("locked until %s: %w", lockedUntil.Format(time.RFC3339), ErrAccountLocked)
+	}
 	id, hash, admin, err := s.rpsUser.GetDataByUsername(ctx, user.Username)
 	user.ID = id
 	user.Admin = admin
@@ -67,100 +231,437 @@ func (s *UserService) Login(ctx context.Context, user *model.User) (*TokenPair,
 	}
 	verified, err := s.CheckPasswordHash(hash, user.Password)
 	if err != nil || !verified {
+		lockedUntil, lockErr := s.rpsUser.RecordFailedLogin(ctx, user.Username, constants.MaxFailedLogins, s.loginLockout())
+		if lockErr != nil {
+			return &TokenPair{}, fmt.Errorf("rpsUser.RecordFailedLogin - %w", lockErr)
+		}
+		if time.Now().Before(lockedUntil) {
+			return &TokenPair{}, fmt.Errorf("locked until %s: %w", lockedUntil.Format(time.RFC3339), ErrAccountLocked)
+		}
 		return &TokenPair{}, fmt.Errorf("CheckPasswordHash - %w", err)
 	}
-	tokenPair, err := s.GenerateTokenPair(user.ID, user.Admin)
+	err = s.rpsUser.ResetFailedLogins(ctx, user.ID)
+	if err != nil {
+		return &TokenPair{}, fmt.Errorf("rpsUser.ResetFailedLogins - %w", err)
+	}
+	version, err := s.rpsUser.GetTokenVersion(ctx, user.ID)
+	if err != nil {
+		return &TokenPair{}, fmt.Errorf("rpsUser.GetTokenVersion - %w", err)
+	}
+	sessionID := uuid.New()
+	tokenPair, err := s.GenerateTokenPair(user.ID, user.Admin, version, sessionID)
 	if err != nil {
 		return &TokenPair{}, fmt.Errorf("GenerateTokenPair - %w", err)
 	}
-	sum := sha256.Sum256([]byte(tokenPair.RefreshToken))
-	hashedRefreshToken, err := s.HashPassword(sum[:])
+	hashedRefreshToken, err := s.hashRefreshToken(tokenPair.RefreshToken)
 	if err != nil {
-		return &TokenPair{}, fmt.Errorf("HashPassword - %w", err)
+		return &TokenPair{}, fmt.Errorf("hashRefreshToken - %w", err)
 	}
-	user.RefreshToken = string(hashedRefreshToken)
-	err = s.rpsUser.AddRefreshToken(context.Background(), user)
+	err = s.rpsUser.AddRefreshToken(ctx, &model.RefreshToken{
+		ID:        sessionID,
+		UserID:    user.ID,
+		Token:     hashedRefreshToken,
+		ExpiresAt: time.Now().Add(s.cfg.RefreshTokenTTL()),
+	})
 	if err != nil {
 		return &TokenPair{}, fmt.Errorf("rpsUser.AddRefreshToken - %w", err)
 	}
 	return &tokenPair, nil
 }
 
-// Refresh is a method of ServiceUser that refreshes access and refresh tokens
+// Refresh is a method of ServiceUser that refreshes access and refresh tokens.
+// Only the refresh token row matching the presented token is rotated, so sessions on other devices stay valid.
+// The role comes from the users table rather than the old access token, so a changed role takes effect.
 func (s *UserService) Refresh(ctx context.Context, tokenPair TokenPair) (TokenPair, error) {
-	id, isAdmin, err := s.TokensIDCompare(tokenPair)
+	id, _, err := s.TokensIDCompare(tokenPair)
 	if err != nil {
 		return TokenPair{}, fmt.Errorf("TokensIDCompare - %w", err)
 	}
-	hash, err := s.rpsUser.GetRefreshTokenByID(ctx, id)
+	stored, err := s.findRefreshToken(ctx, id, tokenPair.RefreshToken)
 	if err != nil {
-		return TokenPair{}, fmt.Errorf("rpsUser.GetRefreshTokenByID - %w", err)
+		return TokenPair{}, fmt.Errorf("findRefreshToken - %w", err)
 	}
-	sum := sha256.Sum256([]byte(tokenPair.RefreshToken))
-	verified, err := s.CheckPasswordHash([]byte(hash), sum[:])
-	if err != nil || !verified {
-		return TokenPair{}, fmt.Errorf("CheckPasswordHash error: refreshToken invalid")
+	user, err := s.rpsUser.GetUserByID(ctx, id)
+	if err != nil {
+		return TokenPair{}, fmt.Errorf("rpsUser.GetUserByID - %w", err)
 	}
-	tokenPair, err = s.GenerateTokenPair(id, isAdmin)
+	tokenPair, err = s.GenerateTokenPair(id, user.Admin, user.TokenVersion, stored.ID)
 	if err != nil {
 		return TokenPair{}, fmt.Errorf("GenerateTokenPair - %w", err)
 	}
-	sum = sha256.Sum256([]byte(tokenPair.RefreshToken))
-	hashedRefreshToken, err := s.HashPassword(sum[:])
+	hashedRefreshToken, err := s.hashRefreshToken(tokenPair.RefreshToken)
 	if err != nil {
-		return TokenPair{}, fmt.Errorf("HashPassword - %w", err)
+		return TokenPair{}, fmt.Errorf("hashRefreshToken - %w", err)
 	}
-	var user model.User
-	user.RefreshToken = string(hashedRefreshToken)
-	user.ID = id
-	err = s.rpsUser.AddRefreshToken(context.Background(), &user)
+	stored.Token = hashedRefreshToken
+	stored.ExpiresAt = time.Now().Add(s.cfg.RefreshTokenTTL())
+	err = s.rpsUser.RotateRefreshToken(ctx, stored)
 	if err != nil {
-		return TokenPair{}, fmt.Errorf("rpsUser.AddRefreshToken - %w", err)
+		return TokenPair{}, fmt.Errorf("rpsUser.RotateRefreshToken - %w", err)
 	}
 	return tokenPair, nil
 }
 
-// DeleteUserByID is a method of UserService that calls  method of Repository
-func (s *UserService) DeleteUserByID(ctx context.Context, id uuid.UUID) error {
-	err := s.rpsUser.DeleteUserByID(ctx, id)
+// Logout is a method of UserService that revokes the given refresh token of the user
+func (s *UserService) Logout(ctx context.Context, id uuid.UUID, refreshToken string) error {
+	stored, err := s.findRefreshToken(ctx, id, refreshToken)
+	if err != nil {
+		return fmt.Errorf("findRefreshToken - %w", err)
+	}
+	err = s.rpsUser.DeleteRefreshToken(ctx, stored.ID)
 	if err != nil {
-		return fmt.Errorf("rpsUser.DeleteUserByID - %w", err)
+		return fmt.Errorf("rpsUser.DeleteRefreshToken - %w", err)
 	}
 	return nil
 }
 
-// TokensIDCompare compares IDs from refresh and access token for being equal
-func (s *UserService) TokensIDCompare(tokenPair TokenPair) (uuid.UUID, bool, error) {
-	accessToken, err := middleware.ValidateToken(tokenPair.AccessToken, s.cfg.BlogTokenSignature)
+// LogoutAll is a method of UserService that revokes access and refresh tokens of the user on every device
+func (s *UserService) LogoutAll(ctx context.Context, id uuid.UUID) error {
+	return s.rpsUser.InTx(ctx, func(ctx context.Context) error {
+		return s.revokeSessions(ctx, id)
+	})
+}
+
+// revokeSessions bumps the token version of the user and deletes their refresh tokens, so every access and refresh
+// token issued before stops working. It is meant to run in the transaction of the change it comes with.
+func (s *UserService) revokeSessions(ctx context.Context, id uuid.UUID) error {
+	err := s.rpsUser.BumpTokenVersion(ctx, id)
 	if err != nil {
-		return uuid.Nil, false, fmt.Errorf("middleware.validateToken - %w", err)
+		return fmt.Errorf("rpsUser.BumpTokenVersion - %w", err)
+	}
+	err = s.rpsUser.DeleteRefreshTokensByUserID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("rpsUser.DeleteRefreshTokensByUserID - %w", err)
 	}
-	var accessID uuid.UUID
-	var uuidID uuid.UUID
-	var isAdmin bool
-	if claims, ok := accessToken.Claims.(jwt.MapClaims); ok && accessToken.Valid {
-		uuidID, err = uuid.Parse(claims["id"].(string))
+	return nil
+}
+
+// RevokeTokens is a method of UserService that lets an admin end every session of a user. The token version of the
+// user is bumped, so access tokens already issued stop working, and all their refresh tokens are deleted in the same
+// transaction as the audit log entry of the revocation.
+func (s *UserService) RevokeTokens(ctx context.Context, adminID, id uuid.UUID) error {
+	return s.rpsUser.InTx(ctx, func(ctx context.Context) error {
+		err := s.revokeSessions(ctx, id)
+		if err != nil {
+			return fmt.Errorf("revokeSessions - %w", err)
+		}
+		err = s.rpsUser.AddAuditEntry(ctx, &model.AuditEntry{
+			ID:       uuid.New(),
+			Action:   model.AuditActionRevokeTokens,
+			ActorID:  adminID,
+			TargetID: id,
+		})
 		if err != nil {
-			return uuid.Nil, false, fmt.Errorf("uuid.Parse - %w", err)
+			return fmt.Errorf("rpsUser.AddAuditEntry - %w", err)
 		}
-		isAdmin = claims["isAdmin"].(bool)
-		accessID = uuidID
+		return nil
+	})
+}
+
+// TokenVersion is a method of UserService that returns the token version access tokens of the user must carry,
+// a missing user returns middleware.ErrTokenRevoked
+func (s *UserService) TokenVersion(ctx context.Context, id uuid.UUID) (int, error) {
+	version, err := s.rpsUser.GetTokenVersion(ctx, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return 0, middleware.ErrTokenRevoked
 	}
-	refreshToken, err := middleware.ValidateToken(tokenPair.RefreshToken, s.cfg.BlogTokenSignature)
 	if err != nil {
-		return uuid.Nil, false, fmt.Errorf("middleware.validateToken - %w", err)
+		return 0, fmt.Errorf("rpsUser.GetTokenVersion - %w", err)
+	}
+	return version, nil
+}
+
+// ChangePassword is a method of UserService that replaces the user's password after verifying the old one.
+// Access and refresh tokens on every device are revoked, so every session has to log in with the new password.
+func (s *UserService) ChangePassword(ctx context.Context, id uuid.UUID, oldPassword, newPassword []byte) error {
+	hash, err := s.rpsUser.GetPasswordByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("rpsUser.GetPasswordByID - %w", err)
+	}
+	verified, err := s.CheckPasswordHash(hash, oldPassword)
+	if err != nil || !verified {
+		return fmt.Errorf("CheckPasswordHash - %w", ErrWrongPassword)
+	}
+	newHash, err := s.HashPassword(newPassword)
+	if err != nil {
+		return fmt.Errorf("HashPassword - %w", err)
+	}
+	return s.rpsUser.InTx(ctx, func(ctx context.Context) error {
+		err := s.rpsUser.UpdatePassword(ctx, id, newHash)
+		if err != nil {
+			return fmt.Errorf("rpsUser.UpdatePassword - %w", err)
+		}
+		err = s.revokeSessions(ctx, id)
+		if err != nil {
+			return fmt.Errorf("revokeSessions - %w", err)
+		}
+		return nil
+	})
+}
+
+// RequestPasswordReset is a method of UserService that emails a short-lived password reset token to the user.
+// An unknown email is not an error, so callers can't tell which emails are registered.
+// Without a mailer it returns ErrPasswordResetDisabled, the token could not reach the user.
+func (s *UserService) RequestPasswordReset(ctx context.Context, email string) error {
+	if s.mailer == nil {
+		return ErrPasswordResetDisabled
+	}
+	user, err := s.rpsUser.GetDataByEmail(ctx, normalizeEmail(email))
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("rpsUser.GetDataByEmail - %w", err)
+	}
+	resetID := uuid.New()
+	expiresAt := time.Now().Add(constants.PasswordResetExpiration)
+	token, err := s.signToken(jwt.MapClaims{
+		"exp":     expiresAt.Unix(),
+		"id":      user.ID,
+		"isAdmin": false,
+		"jti":     resetID.String(),
+		"purpose": resetTokenPurpose,
+	})
+	if err != nil {
+		return fmt.Errorf("signToken - %w", err)
+	}
+	err = s.rpsUser.AddPasswordReset(ctx, &model.PasswordReset{
+		ID:        resetID,
+		UserID:    user.ID,
+		Token:     hashToken(token),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return fmt.Errorf("rpsUser.AddPasswordReset - %w", err)
+	}
+	err = s.mailer.Send(ctx, user.Email, "Password reset",
+		"Use this token to reset your password within "+constants.PasswordResetExpiration.String()+": "+token)
+	if err != nil {
+		return fmt.Errorf("mailer.Send - %w", err)
+	}
+	return nil
+}
+
+// ConfirmPasswordReset is a method of UserService that sets a new password using a password reset token.
+// Each token works once, and access and refresh tokens on every device are revoked afterwards.
+func (s *UserService) ConfirmPasswordReset(ctx context.Context, token string, newPassword []byte) error {
+	resetID, userID, err := s.parseResetToken(token)
+	if err != nil {
+		return fmt.Errorf("parseResetToken - %w", err)
+	}
+	reset, err := s.rpsUser.GetPasswordReset(ctx, resetID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("rpsUser.GetPasswordReset - %w", ErrInvalidResetToken)
 	}
-	var refreshID uuid.UUID
-	if claims, ok := refreshToken.Claims.(jwt.MapClaims); ok && refreshToken.Valid {
-		exp := claims["exp"].(float64)
-		uuidID, err = uuid.Parse(claims["id"].(string))
+	if err != nil {
+		return fmt.Errorf("rpsUser.GetPasswordReset - %w", err)
+	}
+	if reset.UserID != userID || subtle.ConstantTimeCompare([]byte(reset.Token), []byte(hashToken(token))) != 1 {
+		return fmt.Errorf("password reset %s: %w", resetID, ErrInvalidResetToken)
+	}
+	if reset.UsedAt != nil {
+		return fmt.Errorf("password reset %s: %w", resetID, ErrResetTokenUsed)
+	}
+	if time.Now().After(reset.ExpiresAt) {
+		return fmt.Errorf("password reset %s: %w", resetID, ErrInvalidResetToken)
+	}
+	// marking the token used first makes concurrent confirmations of the same token fail
+	err = s.rpsUser.UsePasswordReset(ctx, resetID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("rpsUser.UsePasswordReset - %w", ErrResetTokenUsed)
+	}
+	if err != nil {
+		return fmt.Errorf("rpsUser.UsePasswordReset - %w", err)
+	}
+	hash, err := s.HashPassword(newPassword)
+	if err != nil {
+		return fmt.Errorf("HashPassword - %w", err)
+	}
+	return s.rpsUser.InTx(ctx, func(ctx context.Context) error {
+		err := s.rpsUser.UpdatePassword(ctx, userID, hash)
 		if err != nil {
-			return uuid.Nil, false, fmt.Errorf("uuid.Parse - %w", err)
+			return fmt.Errorf("rpsUser.UpdatePassword - %w", err)
 		}
-		refreshID = uuidID
-		if exp < float64(time.Now().Unix()) {
-			return uuid.Nil, false, fmt.Errorf("validateToken - %w", err)
+		err = s.revokeSessions(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("revokeSessions - %w", err)
 		}
+		return nil
+	})
+}
+
+// parseResetToken validates a password reset token and returns its reset and user IDs
+func (s *UserService) parseResetToken(token string) (resetID, userID uuid.UUID, err error) {
+	parsed, err := middleware.ValidateToken(token, s.cfg)
+	if err != nil || !parsed.Valid {
+		return uuid.Nil, uuid.Nil, ErrInvalidResetToken
+	}
+	claims, ok := parsed.Claims.(jwt.MapClaims)
+	if !ok || claims["purpose"] != resetTokenPurpose {
+		return uuid.Nil, uuid.Nil, ErrInvalidResetToken
+	}
+	jti, _ := claims["jti"].(string)
+	id, _ := claims["id"].(string)
+	resetID, err = uuid.Parse(jti)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, ErrInvalidResetToken
+	}
+	userID, err = uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, ErrInvalidResetToken
+	}
+	return resetID, userID, nil
+}
+
+// hashToken hashes a password reset token or an invite code for storage, a fast hash is enough for a random single-use token
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// DeleteUserByID is a method of UserService that deletes the user together with their blogs in one transaction.
+// Admins cannot be deleted, see repository.ErrAdminUser, their blogs are kept then.
+func (s *UserService) DeleteUserByID(ctx context.Context, id uuid.UUID) error {
+	return s.rpsUser.InTx(ctx, func(ctx context.Context) error {
+		err := s.blogs.DeleteBlogsByUserID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("blogs.DeleteBlogsByUserID - %w", err)
+		}
+		err = s.rpsUser.DeleteUserByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("rpsUser.DeleteUserByID - %w", err)
+		}
+		return recordActivity(ctx, s.rpsUser, model.ActivityUserDeleted, id)
+	})
+}
+
+// GetProfile is a method of UserService that returns the profile of the current user
+func (s *UserService) GetProfile(ctx context.Context, id uuid.UUID) (*model.User, error) {
+	user, err := s.rpsUser.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("rpsUser.GetUserByID - %w", err)
+	}
+	return user, nil
+}
+
+// SetRole is a method of UserService that promotes a user to admin or demotes them.
+// Demoting the only remaining admin returns ErrLastAdmin, so there is always someone able to manage roles.
+// The token version of the user is bumped and their refresh tokens are revoked with the change, so tokens issued
+// with the old role stop working and the next login carries the new one.
+func (s *UserService) SetRole(ctx context.Context, id uuid.UUID, admin bool) error {
+	return s.rpsUser.InTx(ctx, func(ctx context.Context) error {
+		err := s.rpsUser.SetAdmin(ctx, id, admin)
+		if errors.Is(err, repository.ErrLastAdmin) {
+			return ErrLastAdmin
+		}
+		if err != nil {
+			return fmt.Errorf("rpsUser.SetAdmin - %w", err)
+		}
+		err = s.revokeSessions(ctx, id)
+		if err != nil {
+			return fmt.Errorf("revokeSessions - %w", err)
+		}
+		return nil
+	})
+}
+
+// Impersonate is a method of UserService that gives an admin a short-lived access token acting as another user,
+// so support can see what the user sees. The token carries the id of the admin in the impersonated_by claim, never
+// the admin role, and comes without a refresh token. Every impersonation is recorded in the audit log before the
+// token is returned, impersonating an admin returns ErrImpersonateAdmin.
+func (s *UserService) Impersonate(ctx context.Context, adminID, id uuid.UUID) (string, error) {
+	user, err := s.rpsUser.GetUserByID(ctx, id)
+	if err != nil {
+		return "", fmt.Errorf("rpsUser.GetUserByID - %w", err)
+	}
+	if user.Admin {
+		return "", ErrImpersonateAdmin
+	}
+	now := time.Now()
+	token, err := s.signToken(jwt.MapClaims{
+		"exp":             now.Add(constants.ImpersonationTokenExpiration).Unix(),
+		"iat":             now.Unix(),
+		"id":              id,
+		"isAdmin":         false,
+		"ver":             user.TokenVersion,
+		"impersonated_by": adminID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("signToken - %w", err)
+	}
+	err = s.rpsUser.AddAuditEntry(ctx, &model.AuditEntry{
+		ID:       uuid.New(),
+		Action:   model.AuditActionImpersonate,
+		ActorID:  adminID,
+		TargetID: id,
+	})
+	if err != nil {
+		return "", fmt.Errorf("rpsUser.AddAuditEntry - %w", err)
+	}
+	return token, nil
+}
+
+// GetActivity is a method of UserService that returns a page of the activity log of a user, newest first
+func (s *UserService) GetActivity(ctx context.Context, id uuid.UUID, limit, offset int) (*model.ActivityListResponse, error) {
+	count, err := s.rpsUser.CountActivity(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("rpsUser.CountActivity - %w", err)
+	}
+	activities, err := s.rpsUser.GetActivity(ctx, id, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("rpsUser.GetActivity - %w", err)
+	}
+	return &model.ActivityListResponse{Activities: activities, Count: count}, nil
+}
+
+// GetProfiles is a method of UserService that fetches the public profiles of many users at once.
+// Duplicate ids are queried once and the profiles keep the order of the first occurrence of each id.
+func (s *UserService) GetProfiles(ctx context.Context, ids []uuid.UUID) ([]*model.UserProfile, error) {
+	unique := make([]uuid.UUID, 0, len(ids))
+	seen := make(map[uuid.UUID]struct{}, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) == 0 {
+		return []*model.UserProfile{}, nil
+	}
+	profiles, err := s.rpsUser.GetProfiles(ctx, unique)
+	if err != nil {
+		return nil, fmt.Errorf("rpsUser.GetProfiles - %w", err)
+	}
+	byID := make(map[uuid.UUID]*model.UserProfile, len(profiles))
+	for _, profile := range profiles {
+		byID[profile.ID] = profile
+	}
+	ordered := make([]*model.UserProfile, 0, len(profiles))
+	for _, id := range unique {
+		if profile, ok := byID[id]; ok {
+			ordered = append(ordered, profile)
+		}
+	}
+	return ordered, nil
+}
+
+// TokensIDCompare compares IDs from refresh and access token for being equal
+func (s *UserService) TokensIDCompare(tokenPair TokenPair) (uuid.UUID, bool, error) {
+	accessToken, err := middleware.ValidateToken(tokenPair.AccessToken, s.cfg)
+	if err != nil {
+		return uuid.Nil, false, fmt.Errorf("middleware.validateToken - %w", err)
+	}
+	accessID, isAdmin, err := middleware.TokenUser(accessToken)
+	if err != nil {
+		return uuid.Nil, false, fmt.Errorf("middleware.TokenUser - %w", err)
+	}
+	refreshToken, err := middleware.ValidateToken(tokenPair.RefreshToken, s.cfg)
+	if err != nil {
+		return uuid.Nil, false, fmt.Errorf("middleware.validateToken - %w", err)
+	}
+	refreshID, _, err := middleware.TokenUser(refreshToken)
+	if err != nil {
+		return uuid.Nil, false, fmt.Errorf("middleware.TokenUser - %w", err)
 	}
 	if accessID != refreshID {
 		return uuid.Nil, false, fmt.Errorf("user ID in acess token doesn't equal user ID in refresh token")
@@ -168,9 +669,80 @@ func (s *UserService) TokensIDCompare(tokenPair TokenPair) (uuid.UUID, bool, err
 	return accessID, isAdmin, nil
 }
 
+// normalizeUsername lowercases a username, so "Alice" and "alice" are stored as the same user
+func normalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
+// normalizeEmail trims and lowercases an email, so lookups don't depend on how it was typed
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// loginLockout returns how long an account stays locked after too many failed logins
+func (s *UserService) loginLockout() time.Duration {
+	if s.cfg.BlogLoginLockout > 0 {
+		return s.cfg.BlogLoginLockout
+	}
+	return constants.LoginLockoutDuration
+}
+
+// bcryptCost returns the configured bcrypt cost, or the default one if it is unset or out of the bcrypt range
+func (s *UserService) bcryptCost() int {
+	if s.cfg.BlogBcryptCost >= bcrypt.MinCost && s.cfg.BlogBcryptCost <= bcrypt.MaxCost {
+		return s.cfg.BlogBcryptCost
+	}
+	return constants.BcryptCost
+}
+
+// findRefreshToken returns the active refresh token row of the user that matches the given token.
+// The row is looked up by the jti claim of the token, so only a single hash is compared however many devices the user has.
+func (s *UserService) findRefreshToken(ctx context.Context, id uuid.UUID, refreshToken string) (*model.RefreshToken, error) {
+	sessionID, err := s.refreshSessionID(refreshToken)
+	if err != nil {
+		return nil, fmt.Errorf("refreshSessionID - %w", err)
+	}
+	token, err := s.rpsUser.GetRefreshToken(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("rpsUser.GetRefreshToken - %w", err)
+	}
+	if token.UserID != id {
+		return nil, fmt.Errorf("CheckPasswordHash error: refreshToken invalid")
+	}
+	sum := sha256.Sum256([]byte(refreshToken))
+	if verified, err := s.CheckPasswordHash([]byte(token.Token), sum[:]); err != nil || !verified {
+		return nil, fmt.Errorf("CheckPasswordHash error: refreshToken invalid")
+	}
+	return token, nil
+}
+
+// refreshSessionID returns the jti claim of a refresh token, the ID of its refresh token row
+func (s *UserService) refreshSessionID(refreshToken string) (uuid.UUID, error) {
+	parsed, err := middleware.ValidateToken(refreshToken, s.cfg)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("middleware.ValidateToken - %w", err)
+	}
+	jti, _ := parsed.Claims.(jwt.MapClaims)["jti"].(string)
+	sessionID, err := uuid.Parse(jti)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: jti is not a UUID", middleware.ErrInvalidClaims)
+	}
+	return sessionID, nil
+}
+
+// hashRefreshToken hashes the sha256 sum of the refresh token, since bcrypt only accepts 72 bytes
+func (s *UserService) hashRefreshToken(refreshToken string) (string, error) {
+	sum := sha256.Sum256([]byte(refreshToken))
+	hashedRefreshToken, err := s.HashPassword(sum[:])
+	if err != nil {
+		return "", fmt.Errorf("HashPassword - %w", err)
+	}
+	return string(hashedRefreshToken), nil
+}
+
 // HashPassword is a method of ServiceUser that makes from bytes hashed value
 func (s *UserService) HashPassword(password []byte) ([]byte, error) {
-	bytes, err := bcrypt.GenerateFromPassword(password, constants.BcryptCost)
+	bytes, err := bcrypt.GenerateFromPassword(password, s.bcryptCost())
 	if err != nil {
 		return bytes, fmt.Errorf("bcrypt.GenerateFromPassword - %w", err)
 	}
@@ -186,15 +758,24 @@ func (s *UserService) CheckPasswordHash(hash, password []byte) (bool, error) {
 	return true, nil
 }
 
-// GenerateTokenPair generates pair of access and refresh tokens
-func (s *UserService) GenerateTokenPair(id uuid.UUID, isAdmin bool) (TokenPair, error) {
-	accessToken, err := s.GenerateJWTToken(constants.AccessTokenExpiration, id, isAdmin)
+// GenerateTokenPair generates pair of access and refresh tokens with the lifetimes of the config.
+// The refresh token carries sessionID, the ID of its refresh token row, in the jti claim,
+// the access token carries the token version of the user in the ver claim.
+func (s *UserService) GenerateTokenPair(id uuid.UUID, isAdmin bool, version int, sessionID uuid.UUID) (TokenPair, error) {
+	accessToken, err := s.GenerateJWTToken(s.cfg.AccessTokenTTL(), id, isAdmin, version)
 	if err != nil {
 		return TokenPair{}, fmt.Errorf("GenerateJWTToken - %w", err)
 	}
-	refreshToken, err := s.GenerateJWTToken(constants.RefreshTokenExpiration, id, isAdmin)
+	now := time.Now()
+	refreshToken, err := s.signToken(jwt.MapClaims{
+		"exp":     now.Add(s.cfg.RefreshTokenTTL()).Unix(),
+		"iat":     now.Unix(),
+		"id":      id,
+		"isAdmin": isAdmin,
+		"jti":     sessionID.String(),
+	})
 	if err != nil {
-		return TokenPair{}, fmt.Errorf("GenerateJWTToken - %w", err)
+		return TokenPair{}, fmt.Errorf("signToken - %w", err)
 	}
 	return TokenPair{
 		AccessToken:  accessToken,
@@ -202,13 +783,22 @@ func (s *UserService) GenerateTokenPair(id uuid.UUID, isAdmin bool) (TokenPair,
 	}, nil
 }
 
-// GenerateJWTToken is a method of ServiceUser that generate JWT token with given expiration with user id
-func (s *UserService) GenerateJWTToken(expiration time.Duration, id uuid.UUID, isAdmin bool) (string, error) {
-	claims := &jwt.MapClaims{
-		"exp":     time.Now().Add(expiration).Unix(),
+// GenerateJWTToken is a method of ServiceUser that generate JWT token with given expiration with user id and token version
+func (s *UserService) GenerateJWTToken(expiration time.Duration, id uuid.UUID, isAdmin bool, version int) (string, error) {
+	now := time.Now()
+	return s.signToken(jwt.MapClaims{
+		"exp":     now.Add(expiration).Unix(),
+		"iat":     now.Unix(),
 		"id":      id,
 		"isAdmin": isAdmin,
-	}
+		"ver":     version,
+	})
+}
+
+// signToken adds the issuer and audience of the config to the claims and signs them with the token signature
+func (s *UserService) signToken(claims jwt.MapClaims) (string, error) {
+	claims["iss"] = s.cfg.TokenIssuer()
+	claims["aud"] = s.cfg.TokenAudience()
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString([]byte(s.cfg.BlogTokenSignature))
 	if err != nil {
@@ -3,6 +3,7 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"log"
@@ -12,33 +13,38 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/artnikel/blogapi/internal/cache"
 	"github.com/artnikel/blogapi/internal/config"
 	"github.com/artnikel/blogapi/internal/constants"
 	"github.com/artnikel/blogapi/internal/handler"
+	"github.com/artnikel/blogapi/internal/mailer"
 	customMiddleware "github.com/artnikel/blogapi/internal/middleware"
 	"github.com/artnikel/blogapi/internal/repository"
 	"github.com/artnikel/blogapi/internal/service"
 	"github.com/caarlos0/env"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/redis/go-redis/v9"
 	"gopkg.in/go-playground/validator.v9"
 )
 
-func connectPostgres() (*pgxpool.Pool, error) {
-	cfg := config.Config{}
-	if err := env.Parse(&cfg); err != nil {
-		log.Fatalf("Failed to parse config: %v", err)
+// startServer serves plain HTTP, or TLS with the configured version and cipher suites when a certificate is set
+func startServer(e *echo.Echo, cfg *config.Config) error {
+	if !cfg.TLSEnabled() {
+		return e.Start(":" + cfg.BlogServerPort)
 	}
-	conf, err := pgxpool.ParseConfig(cfg.BlogPostgresPath)
+	tlsConfig, err := cfg.TLSConfig()
 	if err != nil {
-		return nil, fmt.Errorf("error in method pgxpool.ParseConfig: %v", err)
+		return fmt.Errorf("cfg.TLSConfig: %w", err)
 	}
-	pool, err := pgxpool.NewWithConfig(context.Background(), conf)
+	cert, err := tls.LoadX509KeyPair(cfg.BlogTLSCertFile, cfg.BlogTLSKeyFile)
 	if err != nil {
-		return nil, fmt.Errorf("error in method pgxpool.NewWithConfig: %v", err)
+		return fmt.Errorf("tls.LoadX509KeyPair: %w", err)
 	}
-	return pool, nil
+	tlsConfig.Certificates = []tls.Certificate{cert}
+	e.TLSServer.Addr = ":" + cfg.BlogServerPort
+	e.TLSServer.TLSConfig = tlsConfig
+	return e.StartServer(e.TLSServer)
 }
 
 func main() {
@@ -48,42 +54,137 @@ func main() {
 	if err := env.Parse(&cfg); err != nil {
 		log.Fatalf("Failed to parse config: %v", err)
 	}
+	if err := cfg.ValidateTokenTTLs(); err != nil {
+		log.Fatalf("Failed to configure token lifetimes: %v", err)
+	}
+	if err := cfg.ValidateTLS(); err != nil {
+		log.Fatalf("Failed to configure TLS: %v", err)
+	}
 
-	pool, err := connectPostgres()
+	poolOptions := repository.PoolOptions{
+		Tracer:             repository.NewQueryTracer(cfg.BlogSlowQuery),
+		StatementCacheMode: cfg.BlogPgStatementCacheMode,
+		StatementTimeout:   cfg.BlogStatementTimeout,
+	}
+	dsn, err := cfg.PostgresDSN()
+	if err != nil {
+		log.Fatalf("Failed to configure Postgres: %v", err)
+	}
+	pool, err := repository.Connect(context.Background(), dsn, constants.DBConnectAttempts, constants.DBConnectBackoff, poolOptions)
 	if err != nil {
-		fmt.Printf("Failed to connect to Postgres: %v", err)
+		log.Fatalf("Failed to connect to Postgres: %v", err)
 	}
 	defer pool.Close()
+	if err := repository.Migrate(context.Background(), pool); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
 
 	repoPostgres := repository.NewPgRepository(pool)
-	blogService := service.NewBlogService(repoPostgres)
+	var sticky *repository.StickyPrimary
+	if cfg.BlogPostgresReplicaPath != "" {
+		replica, err := repository.Open(context.Background(), cfg.BlogPostgresReplicaPath, poolOptions)
+		if err != nil {
+			log.Fatalf("Failed to open the Postgres replica pool: %v", err)
+		}
+		defer replica.Close()
+		repoPostgres.SetReplica(replica)
+		window := cfg.BlogReadYourWrites
+		if window <= 0 {
+			window = constants.ReadYourWritesWindow
+		}
+		sticky = repository.NewStickyPrimary(window)
+		repoPostgres.SetStickyPrimary(sticky)
+		go repoPostgres.CheckReplica(context.Background(), constants.ReplicaCheckInterval)
+	}
+	if err := repoPostgres.SetDefaultSort(cfg.BlogDefaultSort); err != nil {
+		log.Fatalf("Failed to set default sort: %v", err)
+	}
+	var blogRepo service.BlogRepository = repoPostgres
+	var memoryCache *cache.MemoryCacheRepository
+	cacheTTL := cfg.BlogCacheTTL
+	if cacheTTL <= 0 {
+		cacheTTL = constants.BlogCacheTTL
+	}
+	switch {
+	case cfg.BlogRedisURL != "":
+		redisOptions, err := redis.ParseURL(cfg.BlogRedisURL)
+		if err != nil {
+			log.Fatalf("Failed to parse the Redis URL: %v", err)
+		}
+		redisClient := redis.NewClient(redisOptions)
+		defer redisClient.Close()
+		blogRepo = cache.NewCachingBlogRepository(repoPostgres, redisClient, cacheTTL)
+	case cfg.BlogCacheSize > 0:
+		memoryCache = cache.NewMemoryCacheRepository(repoPostgres, cfg.BlogCacheSize, cacheTTL)
+		blogRepo = memoryCache
+	}
+	blogService := service.NewBlogService(blogRepo)
+	blogService.SetModeration(cfg.BlogModeration)
+	blogService.SetDraftLimit(cfg.BlogDraftLimit)
+	blogService.SetCountEstimate(cfg.BlogCountEstimateAbove)
+	blogService.SetCountCacheTTL(cfg.BlogCountCacheTTL)
+	if err := blogService.SetMediaCheck(cfg.BlogMediaCheck, cfg.BlogMediaHosts); err != nil {
+		log.Fatalf("Failed to set media check: %v", err)
+	}
 	userService := service.NewUserService(repoPostgres, &cfg)
-	handlers := handler.NewHandler(blogService, userService, v)
+	userService.SetBlogDeleter(blogService)
+	if err := userService.SetSignupMode(cfg.BlogSignupMode); err != nil {
+		log.Fatalf("Failed to set signup mode: %v", err)
+	}
+	if cfg.BlogSMTPAddr != "" {
+		smtpMailer, err := mailer.NewSMTPMailer(cfg.BlogSMTPAddr, cfg.BlogSMTPFrom, cfg.BlogSMTPUsername, cfg.BlogSMTPPassword)
+		if err != nil {
+			log.Fatalf("Failed to configure SMTP: %v", err)
+		}
+		userService.SetMailer(smtpMailer)
+	}
+	commentService := service.NewCommentService(repoPostgres)
+	handlers := handler.NewHandler(blogService, userService, commentService, v)
+	handlers.SetBulkMaxItems(cfg.BlogBulkMaxItems)
+	handlers.SetPublicDraftCounts(cfg.BlogPublicDraftCounts)
+	if cfg.BlogRefreshCookie {
+		handlers.SetRefreshCookie(cfg.RefreshTokenTTL())
+	}
+	diagnosticsService := service.NewDiagnosticsService(repoPostgres)
+	if memoryCache != nil {
+		diagnosticsService.SetCache(memoryCache)
+	}
+	handlers.SetDiagnosticsService(diagnosticsService)
 
 	e := echo.New()
+	e.HTTPErrorHandler = customMiddleware.ErrorHandler(e)
+	ipExtractor, err := customMiddleware.IPExtractor(cfg.BlogTrustedProxies)
+	if err != nil {
+		log.Fatalf("Failed to set trusted proxies: %v", err)
+	}
+	e.IPExtractor = ipExtractor
+
+	e.Pre(customMiddleware.RequestID(cfg.BlogRequestIDHeader))
 
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
+	bodyLimit, err := customMiddleware.BodyLimit(cfg.BlogBodyLimit)
+	if err != nil {
+		log.Fatalf("Failed to set body limit: %v", err)
+	}
+	e.Use(bodyLimit)
+	if len(cfg.BlogAllowedOrigins) > 0 {
+		e.Use(customMiddleware.CORS(cfg.BlogAllowedOrigins))
+	}
+	if cfg.BlogDebugBodyLog {
+		e.Use(customMiddleware.BodyLogger(cfg.BlogBodyLogMaxBytes))
+	}
+	if sticky != nil {
+		e.Use(customMiddleware.ReadYourWrites(sticky))
+	}
 
-	e.POST("/blog", handlers.Create, customMiddleware.JWTMiddleware(&cfg))
-	e.GET("/blog/:id", handlers.Get, customMiddleware.JWTMiddleware(&cfg))
-	e.DELETE("/blog/:id", handlers.Delete, customMiddleware.JWTMiddleware(&cfg))
-	e.DELETE("/blogs/user/:id", handlers.DeleteBlogsByUserID, customMiddleware.JWTMiddleware(&cfg))
-	e.PUT("/blog", handlers.Update, customMiddleware.JWTMiddleware(&cfg))
-	e.GET("/blogs", handlers.GetAll, customMiddleware.JWTMiddleware(&cfg))
-	e.GET("/blogs/user/:id", handlers.GetByUserID, customMiddleware.JWTMiddleware(&cfg))
-
-	e.POST("/signup", handlers.SignUpUser)
-	e.POST("/signupadmin", handlers.SignUpAdmin, customMiddleware.JWTMiddleware(&cfg))
-	e.POST("/login", handlers.Login)
-	e.POST("/refresh", handlers.Refresh)
-	e.DELETE("/user/:id", handlers.DeleteUserByID, customMiddleware.JWTMiddleware(&cfg))
+	handler.RegisterRoutes(e, handlers, &cfg)
 
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
 	go func() {
-		if err := e.Start(":" + cfg.BlogServerPort); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		if err := startServer(e, &cfg); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			slog.Error("failed to start server", "error", err)
 		}
 	}()